@@ -4,11 +4,21 @@
 package gotrade
 
 import (
+	"context"
+	"errors"
 	"math"
 	"sync"
 	"time"
 )
 
+var (
+	ErrReplaySpeedMustBeGreaterThanZero  = errors.New("Replay speed must be greater than 0")
+	ErrBarIndexStepMustBeGreaterThanZero = errors.New("Bar index step must be greater than 0")
+
+	// the interval used between replayed bars when the bar timestamps do not provide one
+	ReplayFallbackInterval time.Duration = time.Second
+)
+
 type DOHLCVStreamTickReceiver interface {
 	ReceiveTick(tickData DOHLCV)
 }
@@ -17,6 +27,23 @@ type DOHLCVStreamSubscriber interface {
 	AddTickSubscription(subscriber DOHLCVTickReceiver)
 }
 
+// LookbackPeriodHolder is implemented by the indicators, giving the number of source bars consumed before the first result
+type LookbackPeriodHolder interface {
+	GetLookbackPeriod() int
+}
+
+// BarsUntilValidHolder is implemented by the indicators, giving the number of source bars consumed before the first
+// result, including any bars for which results are withheld beyond the lookback period
+type BarsUntilValidHolder interface {
+	BarsUntilValid() int
+}
+
+// BarIndexStepSetter is implemented by the indicators, which are given the step between the stream bar indices of
+// consecutive bars so that a result displaced by a number of bars is displaced by that number of steps
+type BarIndexStepSetter interface {
+	SetBarIndexStep(step int)
+}
+
 type DataStreamHolder interface {
 	MinValue() float64
 	MaxValue() float64
@@ -38,12 +65,20 @@ const (
 	MonthlyBar
 )
 
+// An InvalidBarAction is notified of each bar dropped by a stream filtering invalid bars, with the reason it is invalid
+type InvalidBarAction func(tickData DOHLCV, err error)
+
 type DOHLCVStream struct {
-	Data           []DOHLCV
-	subscribers    []DOHLCVTickReceiver
-	streamBarIndex int
-	minValue       float64
-	maxValue       float64
+	Data              []DOHLCV
+	subscribers       []DOHLCVTickReceiver
+	subscriberNames   []string
+	streamBarIndex    int
+	barIndexSkip      int // the step less 1, so that the zero value steps by 1
+	ticksReceived     int
+	minValue          float64
+	maxValue          float64
+	filterInvalidBars bool
+	invalidBarAction  InvalidBarAction
 }
 
 type InterDayDOHLCVStream struct {
@@ -71,8 +106,52 @@ func NewMonthlyDOHLCVStream() *InterDayDOHLCVStream {
 	return NewInterDayDOHLCVStream(MonthlyBar)
 }
 
+// FilterInvalidBars drops each bar failing ValidateDOHLCV before it is added to the stream or dispatched to the
+// subscribers, notifying the invalidBarAction, if not nil, of the dropped bar so that it can be flagged
+func (p *DOHLCVStream) FilterInvalidBars(invalidBarAction InvalidBarAction) {
+	p.filterInvalidBars = true
+	p.invalidBarAction = invalidBarAction
+}
+
+// SetBarIndexing sets the stream bar indices given to the subscribers to follow the sequence start, start + step,
+// start + 2 * step, ... rather than 1, 2, 3, ... so that the bars of several sources can be merged into a single
+// index space. The bar indices the indicators report, e.g. ValidFromBar, are in the same index space, though the bar
+// ranges of ValuesInRange assume a step of 1. The step is given to each subscriber implementing BarIndexStepSetter,
+// so that the results an indicator displaces by a number of bars, e.g. by an offset, a Delay or the warm-up fill,
+// are displaced by that number of steps. It should be set before the first bar is received
+func (p *DOHLCVStream) SetBarIndexing(start int, step int) error {
+	if step <= 0 {
+		return ErrBarIndexStepMustBeGreaterThanZero
+	}
+
+	p.streamBarIndex = start - step
+	p.barIndexSkip = step - 1
+
+	for subscriberIndex := range p.subscribers {
+		p.setSubscriberBarIndexStep(p.subscribers[subscriberIndex])
+	}
+	return nil
+}
+
+// setSubscriberBarIndexStep gives the step of the bar indices to the subscriber, if it implements BarIndexStepSetter
+func (p *DOHLCVStream) setSubscriberBarIndexStep(subscriber DOHLCVTickReceiver) {
+	if setter, ok := subscriber.(BarIndexStepSetter); ok {
+		setter.SetBarIndexStep(p.barIndexSkip + 1)
+	}
+}
+
 func (p *DOHLCVStream) ReceiveTick(tickData DOHLCV) {
-	p.streamBarIndex++
+	if p.filterInvalidBars {
+		if err := ValidateDOHLCV(tickData); err != nil {
+			if p.invalidBarAction != nil {
+				p.invalidBarAction(tickData, err)
+			}
+			return
+		}
+	}
+
+	p.streamBarIndex += p.barIndexSkip + 1
+	p.ticksReceived++
 	p.Data = append(p.Data, tickData)
 
 	if p.minValue > tickData.L() {
@@ -99,6 +178,57 @@ func (p *DOHLCVStream) ReceiveTick(tickData DOHLCV) {
 	waitGroup.Wait()
 }
 
+// Replay dispatches the bars to the stream subscribers at an accelerated wall-clock pace, the
+// delay between bars is the difference of the bar timestamps divided by speed. Bars without
+// usable timestamps are spaced by the ReplayFallbackInterval. Replay stops and returns the
+// context error if the context is cancelled before all the bars are dispatched.
+func (p *DOHLCVStream) Replay(bars []DOHLCV, speed float64, ctx context.Context) error {
+	if speed <= 0 {
+		return ErrReplaySpeedMustBeGreaterThanZero
+	}
+
+	for i := range bars {
+		if i > 0 {
+			interval := ReplayFallbackInterval
+			previousDate := bars[i-1].D()
+			currentDate := bars[i].D()
+			if !previousDate.IsZero() && !currentDate.IsZero() && currentDate.After(previousDate) {
+				interval = currentDate.Sub(previousDate)
+			}
+
+			timer := time.NewTimer(time.Duration(float64(interval) / speed))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		p.ReceiveTick(bars[i])
+	}
+
+	return nil
+}
+
+// TicksUntilValid returns how many more ticks the stream must dispatch before an indicator subscribed from the
+// start of the stream emits its first result, including the tick producing that result, 0 once it has been emitted.
+// The BarsUntilValid of an indicator that is a BarsUntilValidHolder is used in preference to its lookback period
+func (p *DOHLCVStream) TicksUntilValid(indicator LookbackPeriodHolder) int {
+	barsUntilValid := indicator.GetLookbackPeriod()
+	if holder, ok := indicator.(BarsUntilValidHolder); ok {
+		barsUntilValid = holder.BarsUntilValid()
+	}
+
+	remaining := barsUntilValid + 1 - p.ticksReceived
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
 func (p *DOHLCVStream) MinDate() time.Time {
 	// do some checks here, return an error object too
 	return p.Data[0].D()
@@ -118,7 +248,15 @@ func (p *DOHLCVStream) MaxValue() float64 {
 }
 
 func (p *DOHLCVStream) AddTickSubscription(subscriber DOHLCVTickReceiver) {
+	p.AddNamedTickSubscription("", subscriber)
+}
+
+// AddNamedTickSubscription adds a subscriber with a name by which it is identified in the snapshots of the
+// stream, a subscriber without a name is not included in the snapshots
+func (p *DOHLCVStream) AddNamedTickSubscription(name string, subscriber DOHLCVTickReceiver) {
 	p.subscribers = append(p.subscribers, subscriber)
+	p.subscriberNames = append(p.subscriberNames, name)
+	p.setSubscriberBarIndexStep(subscriber)
 }
 
 func (p *DOHLCVStream) RemoveTickSubscription(subscriber DOHLCVTickReceiver) {
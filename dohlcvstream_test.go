@@ -1 +1,434 @@
 package gotrade_test
+
+import (
+	"context"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"sync"
+	"time"
+)
+
+type recordingTickReceiver struct {
+	mutex           sync.Mutex
+	receivedTicks   []gotrade.DOHLCV
+	receivedIndexes []int
+}
+
+func (r *recordingTickReceiver) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.receivedTicks = append(r.receivedTicks, tickData)
+	r.receivedIndexes = append(r.receivedIndexes, streamBarIndex)
+}
+
+var _ = Describe("when replaying bars through a DOHLCV stream", func() {
+	var (
+		stream      *gotrade.InterDayDOHLCVStream
+		subscriberA *recordingTickReceiver
+		subscriberB *recordingTickReceiver
+		bars        []gotrade.DOHLCV
+		replayError error
+	)
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+		subscriberA = &recordingTickReceiver{}
+		subscriberB = &recordingTickReceiver{}
+		stream.AddTickSubscription(subscriberA)
+		stream.AddTickSubscription(subscriberB)
+
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		bars = []gotrade.DOHLCV{}
+		for i := 0; i < 5; i++ {
+			bars = append(bars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), 1.0, 2.0, 0.5, float64(i), 100.0))
+		}
+	})
+
+	Context("and the replay is run at a high speed", func() {
+		BeforeEach(func() {
+			// a day between bars replayed at this speed is roughly a millisecond
+			replayError = stream.Replay(bars, float64(24*time.Hour/time.Millisecond), context.Background())
+		})
+
+		It("should not return an error", func() {
+			Expect(replayError).To(BeNil())
+		})
+
+		It("all subscribers should have received the ticks in order", func() {
+			Expect(subscriberA.receivedTicks).To(Equal(bars))
+			Expect(subscriberB.receivedTicks).To(Equal(bars))
+			Expect(subscriberA.receivedIndexes).To(Equal([]int{1, 2, 3, 4, 5}))
+		})
+
+		It("the stream should have stored the replayed bars", func() {
+			Expect(stream.Data).To(Equal(bars))
+		})
+	})
+
+	Context("and the bars have no timestamps", func() {
+		var previousInterval time.Duration
+
+		BeforeEach(func() {
+			previousInterval = gotrade.ReplayFallbackInterval
+			gotrade.ReplayFallbackInterval = time.Millisecond
+			bars = []gotrade.DOHLCV{}
+			for i := 0; i < 3; i++ {
+				bars = append(bars, gotrade.NewDOHLCVDataItem(time.Time{}, 1.0, 2.0, 0.5, float64(i), 100.0))
+			}
+			replayError = stream.Replay(bars, 1.0, context.Background())
+		})
+
+		AfterEach(func() {
+			gotrade.ReplayFallbackInterval = previousInterval
+		})
+
+		It("should fall back to the fixed interval and dispatch all of the ticks", func() {
+			Expect(replayError).To(BeNil())
+			Expect(subscriberA.receivedTicks).To(Equal(bars))
+		})
+	})
+
+	Context("and the context is cancelled", func() {
+		BeforeEach(func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			replayError = stream.Replay(bars, 1.0, ctx)
+		})
+
+		It("should return the context error", func() {
+			Expect(replayError).To(Equal(context.Canceled))
+		})
+
+		It("no ticks should have been dispatched", func() {
+			Expect(subscriberA.receivedTicks).To(BeEmpty())
+		})
+	})
+
+	Context("and the speed is not greater than zero", func() {
+		BeforeEach(func() {
+			replayError = stream.Replay(bars, 0.0, context.Background())
+		})
+
+		It("should return the appropriate error", func() {
+			Expect(replayError).To(Equal(gotrade.ErrReplaySpeedMustBeGreaterThanZero))
+		})
+	})
+})
+
+var _ = Describe("when determining how many ticks an indicator needs before it is valid", func() {
+	var (
+		stream *gotrade.InterDayDOHLCVStream
+	)
+
+	newTick := func(i int) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(time.Time{}, 10.0, 11.0, 9.0, 10.0+float64(i%5), 100.0)
+	}
+
+	// feeds the stream until the indicator emits and returns the ticks dispatched, checking the
+	// reported count of remaining ticks before each tick
+	ticksUntilFirstEmission := func(indicator gotrade.LookbackPeriodHolder, length func() int) int {
+		for i := 1; i <= 100; i++ {
+			Expect(stream.TicksUntilValid(indicator)).To(BeNumerically(">", 0))
+			stream.ReceiveTick(newTick(i))
+			if length() > 0 {
+				Expect(stream.TicksUntilValid(indicator)).To(Equal(0))
+				return i
+			}
+		}
+		return -1
+	}
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+	})
+
+	Context("given a simple moving average", func() {
+		var indicator *indicators.Sma
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewSmaForStream(stream, 10, gotrade.UseClosePrice)
+		})
+
+		It("the reported counts should match the first actual emission", func() {
+			Expect(indicator.BarsUntilValid()).To(Equal(9))
+			Expect(stream.TicksUntilValid(indicator)).To(Equal(10))
+			Expect(ticksUntilFirstEmission(indicator, indicator.Length)).To(Equal(indicator.BarsUntilValid() + 1))
+			Expect(indicator.ValidFromBar()).To(Equal(indicator.BarsUntilValid() + 1))
+		})
+	})
+
+	Context("given an exponential moving average", func() {
+		var indicator *indicators.Ema
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewEmaForStream(stream, 10, gotrade.UseClosePrice)
+		})
+
+		It("the reported counts should match the first actual emission", func() {
+			Expect(indicator.BarsUntilValid()).To(Equal(9))
+			Expect(ticksUntilFirstEmission(indicator, indicator.Length)).To(Equal(indicator.BarsUntilValid() + 1))
+		})
+	})
+
+	Context("given a triple exponential moving average, whose warm-up exceeds its period", func() {
+		var indicator *indicators.Tema
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewTemaForStream(stream, 10, gotrade.UseClosePrice)
+		})
+
+		It("the reported counts should match the first actual emission", func() {
+			Expect(indicator.BarsUntilValid()).To(Equal(27))
+			Expect(ticksUntilFirstEmission(indicator, indicator.Length)).To(Equal(indicator.BarsUntilValid() + 1))
+		})
+	})
+
+	Context("given an exponential moving average with a minimum valid bars beyond its lookback period", func() {
+		var indicator *indicators.Ema
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewEmaForStream(stream, 10, gotrade.UseClosePrice)
+			indicator.SetMinValidBars(20)
+		})
+
+		It("the reported counts should include the withheld results", func() {
+			Expect(indicator.BarsUntilValid()).To(Equal(19))
+			Expect(stream.TicksUntilValid(indicator)).To(Equal(20))
+			Expect(ticksUntilFirstEmission(indicator, indicator.Length)).To(Equal(indicator.BarsUntilValid() + 1))
+			Expect(indicator.ValidFromBar()).To(Equal(indicator.BarsUntilValid() + 1))
+		})
+	})
+
+	Context("given a moving average convergence divergence, a composite of emas", func() {
+		var indicator *indicators.Macd
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewMacdForStream(stream, 12, 26, 9, gotrade.UseClosePrice)
+		})
+
+		It("the reported counts should be the combined warm-up of its emas", func() {
+			Expect(indicator.BarsUntilValid()).To(Equal(33))
+			Expect(ticksUntilFirstEmission(indicator, indicator.Length)).To(Equal(indicator.BarsUntilValid() + 1))
+			Expect(indicator.ValidFromBar()).To(Equal(indicator.BarsUntilValid() + 1))
+		})
+	})
+
+	Context("given a schaff trend cycle with a minimum valid bars beyond its lookback period", func() {
+		var indicator *indicators.Stc
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewDefaultStcForStream(stream)
+			indicator.SetMinValidBars(indicator.GetLookbackPeriod() + 6)
+		})
+
+		It("the reported counts should include the withheld results of the composite", func() {
+			Expect(indicator.BarsUntilValid()).To(Equal(indicator.GetLookbackPeriod() + 5))
+			Expect(ticksUntilFirstEmission(indicator, indicator.Length)).To(Equal(indicator.BarsUntilValid() + 1))
+			Expect(indicator.ValidFromBar()).To(Equal(indicator.BarsUntilValid() + 1))
+		})
+	})
+
+	Context("given the stream has been primed with some ticks", func() {
+		var indicator *indicators.Tema
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewTemaForStream(stream, 10, gotrade.UseClosePrice)
+			for i := 1; i <= 20; i++ {
+				stream.ReceiveTick(newTick(i))
+			}
+		})
+
+		It("should report the remaining ticks before the first emission", func() {
+			Expect(stream.TicksUntilValid(indicator)).To(Equal(8))
+		})
+	})
+})
+
+var _ = Describe("when filtering invalid bars from a DOHLCV stream", func() {
+	var (
+		stream        *gotrade.InterDayDOHLCVStream
+		subscriber    *recordingTickReceiver
+		validBars     []gotrade.DOHLCV
+		invalidBars   []gotrade.DOHLCV
+		flaggedBars   []gotrade.DOHLCV
+		flaggedErrors []error
+	)
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+		subscriber = &recordingTickReceiver{}
+		stream.AddTickSubscription(subscriber)
+		flaggedBars = nil
+		flaggedErrors = nil
+
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		validBars = []gotrade.DOHLCV{
+			gotrade.NewDOHLCVDataItem(start, 1.0, 2.0, 0.5, 1.5, 100.0),
+			gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, 2), 1.0, 2.0, 0.5, 1.5, 100.0),
+		}
+		invalidBars = []gotrade.DOHLCV{
+			gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, 1), 1.0, 0.5, 2.0, 1.5, 100.0),
+			gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, 3), 1.0, 2.0, 0.5, 1.5, -100.0),
+		}
+	})
+
+	receiveAll := func() {
+		stream.ReceiveTick(validBars[0])
+		stream.ReceiveTick(invalidBars[0])
+		stream.ReceiveTick(validBars[1])
+		stream.ReceiveTick(invalidBars[1])
+	}
+
+	Context("and filtering is enabled", func() {
+		BeforeEach(func() {
+			stream.FilterInvalidBars(func(tickData gotrade.DOHLCV, err error) {
+				flaggedBars = append(flaggedBars, tickData)
+				flaggedErrors = append(flaggedErrors, err)
+			})
+			receiveAll()
+		})
+
+		It("the invalid bars should not reach the subscribers", func() {
+			Expect(subscriber.receivedTicks).To(Equal(validBars))
+			Expect(subscriber.receivedIndexes).To(Equal([]int{1, 2}))
+		})
+
+		It("the invalid bars should not be added to the stream", func() {
+			Expect(stream.Data).To(Equal(validBars))
+		})
+
+		It("the invalid bars should be flagged with the reason they are invalid", func() {
+			Expect(flaggedBars).To(Equal(invalidBars))
+			Expect(flaggedErrors).To(Equal([]error{gotrade.ErrDOHLCVLowAboveHigh, gotrade.ErrDOHLCVVolumeIsNegative}))
+		})
+	})
+
+	Context("and filtering is enabled without an invalid bar action", func() {
+		It("the invalid bars should not reach the subscribers", func() {
+			stream.FilterInvalidBars(nil)
+			receiveAll()
+			Expect(subscriber.receivedTicks).To(Equal(validBars))
+		})
+	})
+
+	Context("and filtering is not enabled", func() {
+		It("all the bars should reach the subscribers", func() {
+			receiveAll()
+			Expect(len(subscriber.receivedTicks)).To(Equal(4))
+		})
+	})
+})
+
+var _ = Describe("when setting the bar indexing of a DOHLCV stream", func() {
+	var (
+		stream     *gotrade.InterDayDOHLCVStream
+		subscriber *recordingTickReceiver
+		indicator  *indicators.Sma
+		start      time.Time = time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	receiveAll := func() {
+		for i := 0; i < 10; i++ {
+			stream.ReceiveTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), 1.0, 2.0, 0.5, float64(i), 100.0))
+		}
+	}
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+		subscriber = &recordingTickReceiver{}
+		stream.AddTickSubscription(subscriber)
+		indicator, _ = indicators.NewSmaForStream(stream, 5, gotrade.UseClosePrice)
+	})
+
+	Context("and the bar indexing starts at 1000 with a step of 1", func() {
+		BeforeEach(func() {
+			Expect(stream.SetBarIndexing(1000, 1)).To(Succeed())
+			receiveAll()
+		})
+
+		It("the subscribers should receive bar indices from the start", func() {
+			Expect(subscriber.receivedIndexes).To(Equal([]int{1000, 1001, 1002, 1003, 1004, 1005, 1006, 1007, 1008, 1009}))
+		})
+
+		It("the valid from bar of the indicator should reflect the offset", func() {
+			Expect(indicator.ValidFromBar()).To(Equal(1000 + indicator.GetLookbackPeriod()))
+		})
+
+		It("the ticks until valid should still count the ticks received", func() {
+			Expect(stream.TicksUntilValid(indicator)).To(Equal(0))
+		})
+	})
+
+	Context("and the bar indexing has a step greater than 1", func() {
+		BeforeEach(func() {
+			Expect(stream.SetBarIndexing(10, 5)).To(Succeed())
+			receiveAll()
+		})
+
+		It("the subscribers should receive bar indices in steps", func() {
+			Expect(subscriber.receivedIndexes[:3]).To(Equal([]int{10, 15, 20}))
+			Expect(indicator.ValidFromBar()).To(Equal(10 + 5*indicator.GetLookbackPeriod()))
+		})
+	})
+
+	Context("and the bar indexing has a step greater than 1 with indicators displacing their results", func() {
+		var (
+			delay     *indicators.Delay
+			offsetSma *indicators.Sma
+			warmupSma *indicators.Sma
+		)
+
+		BeforeEach(func() {
+			// subscribed before the bar indexing is set
+			delay, _ = indicators.NewDelayForStream(stream, -3, gotrade.UseClosePrice)
+			Expect(stream.SetBarIndexing(10, 5)).To(Succeed())
+
+			// subscribed after the bar indexing is set
+			offsetSma, _ = indicators.NewSmaWithOffsetForStream(stream, 5, 2, gotrade.UseClosePrice)
+			warmupSma, _ = indicators.NewSmaForStream(stream, 5, gotrade.UseClosePrice)
+			warmupSma.SetWarmupFill(true)
+			receiveAll()
+		})
+
+		It("a forward Delay should shift its results by the number of steps", func() {
+			Expect(delay.ValidFromBar()).To(Equal(10 + 3*5))
+		})
+
+		It("an offset should displace the results by the number of steps", func() {
+			Expect(offsetSma.ValidFromBar()).To(Equal(30 + 2*5))
+		})
+
+		It("the warm-up fill should start from the first bar", func() {
+			Expect(warmupSma.ValidFromBar()).To(Equal(10))
+			Expect(warmupSma.Length()).To(Equal(10))
+		})
+	})
+
+	Context("and the bar indexing is not set", func() {
+		It("the subscribers should receive bar indices from 1", func() {
+			receiveAll()
+			Expect(subscriber.receivedIndexes[:3]).To(Equal([]int{1, 2, 3}))
+			Expect(indicator.ValidFromBar()).To(Equal(5))
+		})
+	})
+
+	Context("and the stream is the zero value", func() {
+		It("the subscribers should receive bar indices from 1", func() {
+			zeroStream := &gotrade.DOHLCVStream{}
+			zeroSubscriber := &recordingTickReceiver{}
+			zeroStream.AddTickSubscription(zeroSubscriber)
+			for i := 0; i < 3; i++ {
+				zeroStream.ReceiveTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), 1.0, 2.0, 0.5, float64(i), 100.0))
+			}
+			Expect(zeroSubscriber.receivedIndexes).To(Equal([]int{1, 2, 3}))
+		})
+	})
+
+	Context("and the step is not greater than zero", func() {
+		It("should return the appropriate error", func() {
+			Expect(stream.SetBarIndexing(1000, 0)).To(Equal(gotrade.ErrBarIndexStepMustBeGreaterThanZero))
+		})
+	})
+})
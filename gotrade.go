@@ -1,9 +1,17 @@
 package gotrade
 
 import (
+	"errors"
 	"time"
 )
 
+var (
+	ErrDOHLCVLowAboveHigh      = errors.New("The low of the DOHLCV bar is above the high")
+	ErrDOHLCVOpenOutsideRange  = errors.New("The open of the DOHLCV bar is outside the low high range")
+	ErrDOHLCVCloseOutsideRange = errors.New("The close of the DOHLCV bar is outside the low high range")
+	ErrDOHLCVVolumeIsNegative  = errors.New("The volume of the DOHLCV bar is negative")
+)
+
 type DOHLCV interface {
 	D() time.Time
 	O() float64
@@ -65,6 +73,29 @@ func (di *DOHLCVDataItem) V() float64 {
 	return di.volumePrice
 }
 
+// ValidateDOHLCV checks the sanity of a bar, returning the error of the first check failed: the low is at or
+// below the high, the open and close are within the low high range and the volume is not negative
+func ValidateDOHLCV(bar DOHLCV) error {
+	// the checks are negated so that a NaN price or volume also fails them
+	if !(bar.L() <= bar.H()) {
+		return ErrDOHLCVLowAboveHigh
+	}
+
+	if !(bar.L() <= bar.O() && bar.O() <= bar.H()) {
+		return ErrDOHLCVOpenOutsideRange
+	}
+
+	if !(bar.L() <= bar.C() && bar.C() <= bar.H()) {
+		return ErrDOHLCVCloseOutsideRange
+	}
+
+	if !(bar.V() >= 0.0) {
+		return ErrDOHLCVVolumeIsNegative
+	}
+
+	return nil
+}
+
 // A function that selects which data property to use from a DOHLCV data structure
 type DOHLCVDataSelectionFunc func(dataItem DOHLCV) float64
 
@@ -0,0 +1,59 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"math"
+	"time"
+)
+
+var _ = Describe("when validating a DOHLCV bar", func() {
+	var date = time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	Context("and the bar is valid", func() {
+		It("should not return an error", func() {
+			Expect(gotrade.ValidateDOHLCV(gotrade.NewDOHLCVDataItem(date, 10.0, 12.0, 9.0, 11.0, 1000.0))).To(BeNil())
+		})
+
+		It("should not return an error for a bar with no range or volume", func() {
+			Expect(gotrade.ValidateDOHLCV(gotrade.NewDOHLCVDataItem(date, 10.0, 10.0, 10.0, 10.0, 0.0))).To(BeNil())
+		})
+	})
+
+	Context("and the low is above the high", func() {
+		It("should return the appropriate error", func() {
+			Expect(gotrade.ValidateDOHLCV(gotrade.NewDOHLCVDataItem(date, 10.0, 9.0, 12.0, 11.0, 1000.0))).To(Equal(gotrade.ErrDOHLCVLowAboveHigh))
+		})
+	})
+
+	Context("and the open is outside the low high range", func() {
+		It("should return the appropriate error for an open above the high", func() {
+			Expect(gotrade.ValidateDOHLCV(gotrade.NewDOHLCVDataItem(date, 13.0, 12.0, 9.0, 11.0, 1000.0))).To(Equal(gotrade.ErrDOHLCVOpenOutsideRange))
+		})
+
+		It("should return the appropriate error for an open below the low", func() {
+			Expect(gotrade.ValidateDOHLCV(gotrade.NewDOHLCVDataItem(date, 8.0, 12.0, 9.0, 11.0, 1000.0))).To(Equal(gotrade.ErrDOHLCVOpenOutsideRange))
+		})
+	})
+
+	Context("and the close is outside the low high range", func() {
+		It("should return the appropriate error for a close above the high", func() {
+			Expect(gotrade.ValidateDOHLCV(gotrade.NewDOHLCVDataItem(date, 10.0, 12.0, 9.0, 12.5, 1000.0))).To(Equal(gotrade.ErrDOHLCVCloseOutsideRange))
+		})
+
+		It("should return the appropriate error for a close below the low", func() {
+			Expect(gotrade.ValidateDOHLCV(gotrade.NewDOHLCVDataItem(date, 10.0, 12.0, 9.0, 8.5, 1000.0))).To(Equal(gotrade.ErrDOHLCVCloseOutsideRange))
+		})
+
+		It("should return the appropriate error for a NaN close", func() {
+			Expect(gotrade.ValidateDOHLCV(gotrade.NewDOHLCVDataItem(date, 10.0, 12.0, 9.0, math.NaN(), 1000.0))).To(Equal(gotrade.ErrDOHLCVCloseOutsideRange))
+		})
+	})
+
+	Context("and the volume is negative", func() {
+		It("should return the appropriate error", func() {
+			Expect(gotrade.ValidateDOHLCV(gotrade.NewDOHLCVDataItem(date, 10.0, 12.0, 9.0, 11.0, -1.0))).To(Equal(gotrade.ErrDOHLCVVolumeIsNegative))
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package indicators
+
+import (
+	"fmt"
+)
+
+// An ActionErrorHandler is notified of a panic recovered from the value available action of an indicator, with
+// the stream bar index of the result that was being made available
+type ActionErrorHandler func(err error, barIndex int)
+
+type baseActionErrorHandler struct {
+	actionErrorHandler ActionErrorHandler
+}
+
+func newBaseActionErrorHandler() *baseActionErrorHandler {
+	return &baseActionErrorHandler{}
+}
+
+// SetActionErrorHandler opts in to recovering a panic in the value available action, such as that of a downstream
+// consumer of the results, the panic is passed to the handler as an error and the indicator continues to process
+// the ticks that follow. The result is counted in the Length and the bounds as if the action had completed. By
+// default a panic is not recovered and passes up to the source of the tick, a nil handler restores the default.
+// It applies to the value available action of the indicators with a single float result
+func (ind *baseActionErrorHandler) SetActionErrorHandler(handler ActionErrorHandler) {
+	ind.actionErrorHandler = handler
+}
+
+// recoverAction passes a panic in the value available action to the action error handler, when one is set, it
+// must be deferred by the caller of the action
+func (ind *baseActionErrorHandler) recoverAction(streamBarIndex int) {
+	if ind.actionErrorHandler == nil {
+		return
+	}
+
+	if r := recover(); r != nil {
+		err, isError := r.(error)
+		if !isError {
+			err = fmt.Errorf("value available action panicked: %v", r)
+		}
+		ind.actionErrorHandler(err, streamBarIndex)
+	}
+}
+
+// notifyValueAvailable makes the result available through the value available action, recovering a panic in the
+// action when an action error handler is set
+func (ind *baseIndicatorWithFloatBounds) notifyValueAvailable(newValue float64, streamBarIndex int) {
+	defer ind.recoverAction(streamBarIndex)
+
+	ind.valueAvailableAction(newValue, streamBarIndex)
+}
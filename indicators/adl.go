@@ -1,30 +1,62 @@
 package indicators
 
 import (
+	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // An Accumulation Distribution Line Indicator (Adl), no storage, for use in other indicators
 type AdlWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
+	*baseGapReset
 
 	// private variables
-	previousAdl float64
+	smoothingEma *EmaWithoutStorage
+	previousAdl  float64
 }
 
 // NewAdlWithoutStorage creates an Accumulation Distribution Line Indicator (Adl) without storage
 func NewAdlWithoutStorage(valueAvailableAction ValueAvailableActionFloat) (indicator *AdlWithoutStorage, err error) {
+	return NewAdlWithSmoothingWithoutStorage(0, valueAvailableAction)
+}
+
+// NewAdlWithSmoothingWithoutStorage creates an Accumulation Distribution Line Indicator (Adl) without storage
+// smoothed by an Ema of smoothingPeriod, a smoothingPeriod of 0 gives the raw line
+func NewAdlWithSmoothingWithoutStorage(smoothingPeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *AdlWithoutStorage, err error) {
 
 	// an indicator without storage MUST have a value available action
 	if valueAvailableAction == nil {
 		return nil, ErrValueAvailableActionIsNil
 	}
+
+	// the minimum smoothingPeriod for the Ema is 2, 0 disables the smoothing
+	if smoothingPeriod < 0 || smoothingPeriod == 1 {
+		return nil, errors.New("smoothingPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum smoothingPeriod
+	if smoothingPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("smoothingPeriod is greater than the maximum (100000)")
+	}
+
 	lookback := 0
+	if smoothingPeriod > 0 {
+		lookback = smoothingPeriod - 1
+	}
+
 	ind := AdlWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		baseGapReset:                 newBaseGapReset(),
 		previousAdl:                  float64(0.0),
 	}
 
+	if smoothingPeriod > 0 {
+		ind.smoothingEma, _ = NewEmaWithoutStorage(smoothingPeriod, func(dataItem float64, streamBarIndex int) {
+			ind.UpdateIndicatorWithNewValue(dataItem, streamBarIndex)
+		})
+	}
+
 	return &ind, nil
 }
 
@@ -38,8 +70,13 @@ type Adl struct {
 
 // NewAdl creates an Accumulation Distribution Line Indicator (Adl) for online usage
 func NewAdl() (indicator *Adl, err error) {
+	return NewAdlWithSmoothing(0)
+}
+
+// NewAdlWithSmoothing creates an Accumulation Distribution Line Indicator (Adl) for online usage smoothed by an Ema of smoothingPeriod
+func NewAdlWithSmoothing(smoothingPeriod int) (indicator *Adl, err error) {
 	ind := Adl{}
-	ind.AdlWithoutStorage, err = NewAdlWithoutStorage(func(dataItem float64, streamBarIndex int) {
+	ind.AdlWithoutStorage, err = NewAdlWithSmoothingWithoutStorage(smoothingPeriod, func(dataItem float64, streamBarIndex int) {
 		ind.Data = append(ind.Data, dataItem)
 	})
 
@@ -60,6 +97,14 @@ func NewAdlForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Adl
 	return ind, err
 }
 
+// NewAdlWithSmoothingForStream creates an Accumulation Distribution Line Indicator (Adl) for online usage with a source data stream
+// smoothed by an Ema of smoothingPeriod
+func NewAdlWithSmoothingForStream(priceStream gotrade.DOHLCVStreamSubscriber, smoothingPeriod int) (indicator *Adl, err error) {
+	ind, err := NewAdlWithSmoothing(smoothingPeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
 // NewAdlForStreamWithSrcLen creates an Accumulation Distribution Line Indicator (Adl) for offline usage with a source data stream
 func NewAdlForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Adl, err error) {
 	ind, err := NewAdlWithSrcLen(sourceLength)
@@ -69,12 +114,38 @@ func NewAdlForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStre
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *AdlWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// a gap beyond the maxGap of the policy starts the line again from 0
+	if ind.gapExceeded(tickData) {
+		ind.previousAdl = 0.0
+	}
 
 	moneyFlowMultiplier := ((tickData.C() - tickData.L()) - (tickData.H() - tickData.C())) / (tickData.H() - tickData.L())
 	moneyFlowVolume := moneyFlowMultiplier * tickData.V()
 	result := ind.previousAdl + moneyFlowVolume
 
-	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	ind.emitResult(result, streamBarIndex)
 
 	ind.previousAdl = result
 }
+
+// emitResult passes the raw line through the smoothing Ema, when there is one
+func (ind *AdlWithoutStorage) emitResult(result float64, streamBarIndex int) {
+	if ind.smoothingEma != nil {
+		ind.smoothingEma.ReceiveTick(result, streamBarIndex)
+		return
+	}
+
+	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+}
+
+// ValuesInRange returns the Adl results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Adl) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Adl results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Adl) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
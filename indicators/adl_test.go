@@ -3,7 +3,9 @@ package indicators_test
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
 	"github.com/thetruetrade/gotrade/indicators"
+	"time"
 )
 
 var _ = Describe("when creating an adlwithoutstorage", func() {
@@ -181,3 +183,91 @@ var _ = Describe("when calculating an accumulation distribution line (adl) with
 		})
 	})
 })
+
+var _ = Describe("when calculating a smoothed adl with DOHLCV source data", func() {
+	var (
+		indicator  *indicators.Adl
+		raw        *indicators.Adl
+		sourceData []gotrade.DOHLCV
+	)
+
+	BeforeEach(func() {
+		// the money flow multiplier is not defined for bars without a range, so each bar has one
+		sourceData = []gotrade.DOHLCV{}
+		for i := 0; i < 40; i++ {
+			closePrice := 10.0 + float64(i%7) - float64(i%3)
+			sourceData = append(sourceData, gotrade.NewDOHLCVDataItem(time.Now(), closePrice, closePrice+1.0+float64(i%4), closePrice-1.0, closePrice, 100.0+float64(i*10)))
+		}
+
+		raw, _ = indicators.NewAdl()
+		for i := 0; i < len(sourceData); i++ {
+			raw.ReceiveDOHLCVTick(sourceData[i], i+1)
+		}
+	})
+
+	Context("and the smoothingPeriod is 0", func() {
+		BeforeEach(func() {
+			indicator, _ = indicators.NewAdlWithSmoothing(0)
+			for i := 0; i < len(sourceData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceData[i], i+1)
+			}
+		})
+
+		It("should reproduce the raw line", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(raw.GetLookbackPeriod()))
+			Expect(indicator.Data).To(Equal(raw.Data))
+		})
+	})
+
+	Context("and the smoothingPeriod is positive", func() {
+		var (
+			period   int = 5
+			expected []float64
+		)
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewAdlWithSmoothing(period)
+			for i := 0; i < len(sourceData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceData[i], i+1)
+			}
+
+			expected = []float64{}
+			ema, _ := indicators.NewEmaWithoutStorage(period, func(dataItem float64, streamBarIndex int) {
+				expected = append(expected, dataItem)
+			})
+			for i, value := range raw.Data {
+				ema.ReceiveTick(value, i+1)
+			}
+		})
+
+		It("should lag the raw line by the smoothing period", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(period - 1))
+			Expect(indicator.ValidFromBar()).To(Equal(raw.ValidFromBar() + period - 1))
+			Expect(len(indicator.Data)).To(Equal(len(raw.Data) - (period - 1)))
+		})
+
+		It("should be the ema of the raw line", func() {
+			Expect(indicator.Data).To(Equal(expected))
+		})
+	})
+
+	Context("and the smoothingPeriod is below the minimum", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			withoutStorage, err := indicators.NewAdlWithSmoothingWithoutStorage(1, fakeFloatValAvailable)
+			Expect(withoutStorage).To(BeNil())
+			Expect(err).NotTo(BeNil())
+
+			withoutStorage, err = indicators.NewAdlWithSmoothingWithoutStorage(-1, fakeFloatValAvailable)
+			Expect(withoutStorage).To(BeNil())
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Context("and the indicator is created for use with a price stream", func() {
+		It("should have requested to be attached to the stream", func() {
+			stream := newFakeDOHLCVStreamSubscriber()
+			streamIndicator, _ := indicators.NewAdlWithSmoothingForStream(stream, 5)
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(streamIndicator))
+		})
+	})
+})
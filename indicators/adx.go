@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // An Average Directional Index (Adx), no storage, for use in other indicators
@@ -158,3 +159,15 @@ func NewDefaultAdxForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOH
 func (ind *AdxWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.dx.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the Adx results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Adx) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Adx results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Adx) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
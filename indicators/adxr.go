@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // An Average Directional Index Rating (Adxr), no storage
@@ -149,3 +150,15 @@ func (ind *AdxrWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, stream
 	ind.periodCounter += 1
 	ind.adx.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the Adxr results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Adxr) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Adxr results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Adxr) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
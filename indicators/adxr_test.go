@@ -393,3 +393,33 @@ var _ = Describe("when calculating an average directional movement rating (Adxr)
 
 	})
 })
+
+var _ = Describe("when calculating an average directional movement rating (Adxr) against a lagged Adx", func() {
+	var (
+		period    int = 14
+		indicator *indicators.Adxr
+		adx       *indicators.Adx
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewAdxr(period)
+		adx, _ = indicators.NewAdx(period)
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			adx.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should have a lookback of the Adx lookback plus the rating lag", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(adx.GetLookbackPeriod() + period - 1))
+		Expect(len(indicator.Data)).To(Equal(len(adx.Data) - (period - 1)))
+	})
+
+	It("should be the average of the Adx and the Adx lagged by timePeriod - 1 bars, as TA-Lib", func() {
+		lag := period - 1
+		for i := range indicator.Data {
+			expected := (adx.Data[i+lag] + adx.Data[i]) / 2.0
+			Expect(indicator.Data[i]).To(BeNumerically("~", expected, 1e-9))
+		}
+	})
+})
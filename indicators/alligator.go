@@ -0,0 +1,285 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionAlligator func(dataItemJaw float64, dataItemTeeth float64, dataItemLips float64, streamBarIndex int)
+
+// An Alligator Indicator (Alligator), no storage, for use in other indicators
+// Bill Williams' three Rma, smoothed moving average, lines of the median price, (high + low) / 2, each shifted forward
+// into the future, the jaw of the jawPeriod by the jawShift, the teeth of the teethPeriod by the teethShift and the
+// lips of the lipsPeriod by the lipsShift. Each tick gives the results for the future bar the smallest shift after it,
+// the lines of the larger shifts being delayed by the difference so that the three results are for the same bar
+type AlligatorWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionAlligator
+	jaw                  *RmaWithoutStorage
+	teeth                *RmaWithoutStorage
+	lips                 *RmaWithoutStorage
+	jawDelay             *DelayWithoutStorage
+	teethDelay           *DelayWithoutStorage
+	lipsDelay            *DelayWithoutStorage
+	minShift             int
+	currentJaw           float64
+	currentTeeth         float64
+	currentLips          float64
+}
+
+// NewAlligatorWithoutStorage creates an Alligator Indicator (Alligator) without storage
+func NewAlligatorWithoutStorage(jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int, valueAvailableAction ValueAvailableActionAlligator) (indicator *AlligatorWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum jawPeriod for this indicator is 2
+	if jawPeriod < 2 {
+		return nil, errors.New("jawPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum jawPeriod
+	if jawPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("jawPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum jawShift for this indicator is 0
+	if jawShift < 0 {
+		return nil, errors.New("jawShift is less than the minimum (0)")
+	}
+
+	// check the maximum jawShift
+	if jawShift > MaximumLookbackPeriod {
+		return nil, errors.New("jawShift is greater than the maximum (100000)")
+	}
+
+	// the minimum teethPeriod for this indicator is 2
+	if teethPeriod < 2 {
+		return nil, errors.New("teethPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum teethPeriod
+	if teethPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("teethPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum teethShift for this indicator is 0
+	if teethShift < 0 {
+		return nil, errors.New("teethShift is less than the minimum (0)")
+	}
+
+	// check the maximum teethShift
+	if teethShift > MaximumLookbackPeriod {
+		return nil, errors.New("teethShift is greater than the maximum (100000)")
+	}
+
+	// the minimum lipsPeriod for this indicator is 2
+	if lipsPeriod < 2 {
+		return nil, errors.New("lipsPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum lipsPeriod
+	if lipsPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("lipsPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum lipsShift for this indicator is 0
+	if lipsShift < 0 {
+		return nil, errors.New("lipsShift is less than the minimum (0)")
+	}
+
+	// check the maximum lipsShift
+	if lipsShift > MaximumLookbackPeriod {
+		return nil, errors.New("lipsShift is greater than the maximum (100000)")
+	}
+
+	minShift := jawShift
+	if teethShift < minShift {
+		minShift = teethShift
+	}
+	if lipsShift < minShift {
+		minShift = lipsShift
+	}
+
+	ind := AlligatorWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		minShift:             minShift,
+	}
+
+	ind.jawDelay, err = NewDelayWithoutStorage(jawShift-ind.minShift, func(dataItem float64, streamBarIndex int) {
+		ind.currentJaw = dataItem
+	})
+
+	ind.teethDelay, err = NewDelayWithoutStorage(teethShift-ind.minShift, func(dataItem float64, streamBarIndex int) {
+		ind.currentTeeth = dataItem
+	})
+
+	ind.lipsDelay, err = NewDelayWithoutStorage(lipsShift-ind.minShift, func(dataItem float64, streamBarIndex int) {
+		ind.currentLips = dataItem
+	})
+
+	ind.jaw, err = NewRmaWithoutStorage(jawPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.jawDelay.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	ind.teeth, err = NewRmaWithoutStorage(teethPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.teethDelay.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	ind.lips, err = NewRmaWithoutStorage(lipsPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.lipsDelay.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	// the first results wait for the slowest of the lines and its delay
+	lookback := ind.jaw.GetLookbackPeriod() + ind.jawDelay.GetLookbackPeriod()
+	if ind.teeth.GetLookbackPeriod()+ind.teethDelay.GetLookbackPeriod() > lookback {
+		lookback = ind.teeth.GetLookbackPeriod() + ind.teethDelay.GetLookbackPeriod()
+	}
+	if ind.lips.GetLookbackPeriod()+ind.lipsDelay.GetLookbackPeriod() > lookback {
+		lookback = ind.lips.GetLookbackPeriod() + ind.lipsDelay.GetLookbackPeriod()
+	}
+	ind.baseIndicator = newBaseIndicator(lookback)
+
+	return &ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *AlligatorWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	medianPrice := (tickData.H() + tickData.L()) / 2.0
+	ind.jaw.ReceiveTick(medianPrice, streamBarIndex)
+	ind.teeth.ReceiveTick(medianPrice, streamBarIndex)
+	ind.lips.ReceiveTick(medianPrice, streamBarIndex)
+
+	// the results are only available once each of the delayed lines has one for the same bar
+	if ind.jawDelay.Length() == 0 || ind.teethDelay.Length() == 0 || ind.lipsDelay.Length() == 0 {
+		return
+	}
+
+	jaw := ind.quantize(ind.currentJaw)
+	teeth := ind.quantize(ind.currentTeeth)
+	lips := ind.quantize(ind.currentLips)
+
+	ind.UpdateMinMax(math.Min(jaw, math.Min(teeth, lips)), math.Max(jaw, math.Max(teeth, lips)))
+
+	ind.IncDataLength()
+
+	// the results are for the future bar the smallest shift after this one
+	shiftedBarIndex := streamBarIndex + ind.barIndexSpan(ind.minShift)
+
+	ind.SetValidFromBar(shiftedBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(jaw, teeth, lips, shiftedBarIndex)
+}
+
+// An Alligator Indicator (Alligator)
+type Alligator struct {
+	*AlligatorWithoutStorage
+
+	// public variables
+	Jaw   []float64
+	Teeth []float64
+	Lips  []float64
+}
+
+// NewAlligator creates an Alligator Indicator (Alligator) for online usage
+func NewAlligator(jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int) (indicator *Alligator, err error) {
+	ind := Alligator{}
+	ind.AlligatorWithoutStorage, err = NewAlligatorWithoutStorage(jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift,
+		func(dataItemJaw float64, dataItemTeeth float64, dataItemLips float64, streamBarIndex int) {
+			ind.Jaw = append(ind.Jaw, dataItemJaw)
+			ind.Teeth = append(ind.Teeth, dataItemTeeth)
+			ind.Lips = append(ind.Lips, dataItemLips)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultAlligator creates an Alligator Indicator (Alligator) for online usage with default parameters
+//	- jawPeriod: 13
+//	- jawShift: 8
+//	- teethPeriod: 8
+//	- teethShift: 5
+//	- lipsPeriod: 5
+//	- lipsShift: 3
+func NewDefaultAlligator() (indicator *Alligator, err error) {
+	jawPeriod := 13
+	jawShift := 8
+	teethPeriod := 8
+	teethShift := 5
+	lipsPeriod := 5
+	lipsShift := 3
+	return NewAlligator(jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift)
+}
+
+// NewAlligatorWithSrcLen creates an Alligator Indicator (Alligator) for offline usage
+func NewAlligatorWithSrcLen(sourceLength uint, jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int) (indicator *Alligator, err error) {
+	ind, err := NewAlligator(jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Jaw = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Teeth = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Lips = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultAlligatorWithSrcLen creates an Alligator Indicator (Alligator) for offline usage with default parameters
+func NewDefaultAlligatorWithSrcLen(sourceLength uint) (indicator *Alligator, err error) {
+	ind, err := NewDefaultAlligator()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Jaw = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Teeth = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Lips = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewAlligatorForStream creates an Alligator Indicator (Alligator) for online usage with a source data stream
+func NewAlligatorForStream(priceStream gotrade.DOHLCVStreamSubscriber, jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int) (indicator *Alligator, err error) {
+	ind, err := NewAlligator(jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultAlligatorForStream creates an Alligator Indicator (Alligator) for online usage with a source data stream
+func NewDefaultAlligatorForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Alligator, err error) {
+	ind, err := NewDefaultAlligator()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewAlligatorForStreamWithSrcLen creates an Alligator Indicator (Alligator) for offline usage with a source data stream
+func NewAlligatorForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int) (indicator *Alligator, err error) {
+	ind, err := NewAlligatorWithSrcLen(sourceLength, jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultAlligatorForStreamWithSrcLen creates an Alligator Indicator (Alligator) for offline usage with a source data stream
+func NewDefaultAlligatorForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Alligator, err error) {
+	ind, err := NewDefaultAlligatorWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the Alligator results as rows after a header of barIndex,jaw,teeth,lips, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *Alligator) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("jaw", ind.Jaw), floatCSVColumn("teeth", ind.Teeth), floatCSVColumn("lips", ind.Lips))
+}
@@ -0,0 +1,118 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when creating an alligatorwithoutstorage", func() {
+	var (
+		indicator      *indicators.AlligatorWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemJaw float64, dataItemTeeth float64, dataItemLips float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAlligatorWithoutStorage(13, 8, 8, 5, 5, 3, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a jawPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAlligatorWithoutStorage(1, 8, 8, 5, 5, 3, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a teethShift below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAlligatorWithoutStorage(13, 8, 8, -1, 5, 3, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a lipsPeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAlligatorWithoutStorage(13, 8, 8, 5, indicators.MaximumLookbackPeriod+1, 3, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating an alligator (alligator) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.Alligator
+	)
+
+	// the Rma of the median price of the timePeriod for each stream bar index
+	rmaByBar := func(timePeriod int) map[int]float64 {
+		results := make(map[int]float64)
+		rma, _ := indicators.NewRmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+			results[streamBarIndex] = dataItem
+		})
+		for i := range sourceDOHLCVData {
+			rma.ReceiveTick((sourceDOHLCVData[i].H()+sourceDOHLCVData[i].L())/2.0, i+1)
+		}
+		return results
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultAlligator()
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should wait for the jaw and its delay behind the lips before the first result", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(12 + 8 - 3))
+		Expect(len(indicator.Jaw)).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+		Expect(len(indicator.Teeth)).To(Equal(len(indicator.Jaw)))
+		Expect(len(indicator.Lips)).To(Equal(len(indicator.Jaw)))
+		Expect(indicator.Length()).To(Equal(len(indicator.Jaw)))
+	})
+
+	It("the results should be for the bars shifted forward by the lips shift", func() {
+		Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1 + 3))
+
+		// the first jaw result is the first Rma of the jawPeriod shifted forward by the jawShift
+		Expect(indicator.ValidFromBar()).To(Equal(13 + 8))
+	})
+
+	It("each line should be the rma of its period shifted forward by its shift", func() {
+		jaw, teeth, lips := rmaByBar(13), rmaByBar(8), rmaByBar(5)
+		for i := range indicator.Jaw {
+			bar := indicator.ValidFromBar() + i
+			Expect(indicator.Jaw[i]).To(Equal(jaw[bar-8]))
+			Expect(indicator.Teeth[i]).To(Equal(teeth[bar-5]))
+			Expect(indicator.Lips[i]).To(Equal(lips[bar-3]))
+		}
+	})
+
+	It("the bounds should be the bounds of the three lines", func() {
+		for i := range indicator.Jaw {
+			for _, line := range []float64{indicator.Jaw[i], indicator.Teeth[i], indicator.Lips[i]} {
+				Expect(line).To(BeNumerically("<=", indicator.MaxValue()))
+				Expect(line).To(BeNumerically(">=", indicator.MinValue()))
+			}
+		}
+	})
+})
@@ -0,0 +1,154 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// An Anchored Volume Weighted Average Price Indicator (AnchoredVwap), no storage, for use in other indicators
+// the ticks before the anchor bar are ignored, from the anchor bar onward the typical price, (high + low + close) / 3,
+// weighted by the volume is accumulated, sum(typicalPrice * volume) / sum(volume). While no volume has traded
+// since the anchor the result is the typical price
+type AnchoredVwapWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	anchorBarIndex   int
+	totalPriceVolume float64
+	totalVolume      float64
+}
+
+// NewAnchoredVwapWithoutStorage creates an Anchored Volume Weighted Average Price Indicator (AnchoredVwap) without storage
+func NewAnchoredVwapWithoutStorage(anchorBarIndex int, valueAvailableAction ValueAvailableActionFloat) (indicator *AnchoredVwapWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum anchorBarIndex for this indicator is 1, the first bar of a stream
+	if anchorBarIndex < 1 {
+		return nil, errors.New("anchorBarIndex is less than the minimum (1)")
+	}
+
+	lookback := 0
+	ind := AnchoredVwapWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		anchorBarIndex:               anchorBarIndex,
+	}
+
+	return &ind, nil
+}
+
+// AnchorBarIndex returns the stream bar index from which the volume weighted average price is accumulated
+func (ind *AnchoredVwapWithoutStorage) AnchorBarIndex() int {
+	return ind.anchorBarIndex
+}
+
+// Reanchor moves the anchor to the stream bar index barIndex and resets the sums, the results restart from the
+// first tick received at or after the new anchor
+func (ind *AnchoredVwapWithoutStorage) Reanchor(barIndex int) error {
+
+	// the minimum barIndex for this indicator is 1, the first bar of a stream
+	if barIndex < 1 {
+		return errors.New("barIndex is less than the minimum (1)")
+	}
+
+	ind.anchorBarIndex = barIndex
+	ind.totalPriceVolume = 0.0
+	ind.totalVolume = 0.0
+
+	ind.validFromBar = -1
+	ind.dataLength = 0
+	*ind.baseFloatBounds = *newBaseFloatBounds()
+	ind.hasPreviousOutput = false
+
+	return nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *AnchoredVwapWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if streamBarIndex < ind.anchorBarIndex {
+		return
+	}
+
+	typicalPrice := (tickData.H() + tickData.L() + tickData.C()) / 3.0
+	ind.totalPriceVolume += typicalPrice * tickData.V()
+	ind.totalVolume += tickData.V()
+
+	result := typicalPrice
+	if ind.totalVolume > 0.0 {
+		result = ind.totalPriceVolume / ind.totalVolume
+	}
+
+	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+}
+
+// An Anchored Volume Weighted Average Price Indicator (AnchoredVwap)
+type AnchoredVwap struct {
+	*AnchoredVwapWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewAnchoredVwap creates an Anchored Volume Weighted Average Price Indicator (AnchoredVwap) for online usage
+func NewAnchoredVwap(anchorBarIndex int) (indicator *AnchoredVwap, err error) {
+	ind := AnchoredVwap{}
+	ind.AnchoredVwapWithoutStorage, err = NewAnchoredVwapWithoutStorage(anchorBarIndex, func(dataItem float64, streamBarIndex int) {
+		ind.Data = append(ind.Data, dataItem)
+	})
+
+	return &ind, err
+}
+
+// NewAnchoredVwapWithSrcLen creates an Anchored Volume Weighted Average Price Indicator (AnchoredVwap) for offline usage
+func NewAnchoredVwapWithSrcLen(sourceLength uint, anchorBarIndex int) (indicator *AnchoredVwap, err error) {
+	ind, err := NewAnchoredVwap(anchorBarIndex)
+
+	// only initialise the storage if there is enough source data to require it
+	if err == nil && sourceLength > uint(anchorBarIndex) {
+		ind.Data = make([]float64, 0, sourceLength-uint(anchorBarIndex)+1)
+	}
+
+	return ind, err
+}
+
+// NewAnchoredVwapForStream creates an Anchored Volume Weighted Average Price Indicator (AnchoredVwap) for online usage with a source data stream
+func NewAnchoredVwapForStream(priceStream gotrade.DOHLCVStreamSubscriber, anchorBarIndex int) (indicator *AnchoredVwap, err error) {
+	ind, err := NewAnchoredVwap(anchorBarIndex)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewAnchoredVwapForStreamWithSrcLen creates an Anchored Volume Weighted Average Price Indicator (AnchoredVwap) for offline usage with a source data stream
+func NewAnchoredVwapForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, anchorBarIndex int) (indicator *AnchoredVwap, err error) {
+	ind, err := NewAnchoredVwapWithSrcLen(sourceLength, anchorBarIndex)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// Reanchor moves the anchor to the stream bar index barIndex, resets the sums and discards the results of the
+// previous anchor
+func (ind *AnchoredVwap) Reanchor(barIndex int) error {
+	err := ind.AnchoredVwapWithoutStorage.Reanchor(barIndex)
+	if err != nil {
+		return err
+	}
+
+	ind.Data = ind.Data[:0]
+	return nil
+}
+
+// ValuesInRange returns the AnchoredVwap results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *AnchoredVwap) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the AnchoredVwap results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *AnchoredVwap) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,132 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating an anchoredvwapwithoutstorage", func() {
+	var (
+		indicator      *indicators.AnchoredVwapWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItem float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAnchoredVwapWithoutStorage(1, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given an anchorBarIndex below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAnchoredVwapWithoutStorage(0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+})
+
+var _ = Describe("when calculating an anchored volume weighted average price (anchoredvwap) with DOHLCV source data", func() {
+	var (
+		anchor    int = 10
+		indicator *indicators.AnchoredVwap
+		bars      []gotrade.DOHLCV
+	)
+
+	// bars of a rising price with a volume that cycles, so that the weighting matters
+	start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 60; i++ {
+		price := 100.0 + float64(i)
+		bars = append(bars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price+2.0, price-1.0, price+0.5, float64(1000*(1+i%4))))
+	}
+
+	// the volume weighted average typical price of the bars from the stream bar index fromBar to toBar
+	manualVwap := func(fromBar int, toBar int) float64 {
+		totalPriceVolume, totalVolume := 0.0, 0.0
+		for i := fromBar - 1; i < toBar; i++ {
+			bar := bars[i]
+			typicalPrice := (bar.H() + bar.L() + bar.C()) / 3.0
+			totalPriceVolume += typicalPrice * bar.V()
+			totalVolume += bar.V()
+		}
+		return totalPriceVolume / totalVolume
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewAnchoredVwap(anchor)
+	})
+
+	Context("and the indicator has received the ticks before the anchor", func() {
+		BeforeEach(func() {
+			for i := 0; i < anchor-1; i++ {
+				indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			}
+		})
+
+		It("should have no results", func() {
+			Expect(indicator.Data).To(BeEmpty())
+			Expect(indicator.Length()).To(Equal(0))
+			Expect(indicator.ValidFromBar()).To(Equal(-1))
+		})
+	})
+
+	Context("and the indicator has received all of its ticks", func() {
+		BeforeEach(func() {
+			for i := range bars {
+				indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			}
+		})
+
+		It("should have a result from the anchor bar onward", func() {
+			Expect(len(indicator.Data)).To(Equal(len(bars) - anchor + 1))
+			Expect(indicator.ValidFromBar()).To(Equal(anchor))
+		})
+
+		It("should match the volume weighted average typical price from the anchor", func() {
+			for i := range indicator.Data {
+				Expect(indicator.Data[i]).To(BeNumerically("~", manualVwap(anchor, anchor+i), 1e-9))
+			}
+		})
+
+		Context("and the indicator is reanchored", func() {
+			var reanchor int = 40
+
+			BeforeEach(func() {
+				indicator.Reanchor(reanchor)
+				for i := range bars {
+					indicator.ReceiveDOHLCVTick(bars[i], i+1)
+				}
+			})
+
+			It("should have discarded the results of the previous anchor", func() {
+				Expect(indicator.AnchorBarIndex()).To(Equal(reanchor))
+				Expect(len(indicator.Data)).To(Equal(len(bars) - reanchor + 1))
+				Expect(indicator.ValidFromBar()).To(Equal(reanchor))
+			})
+
+			It("should match the volume weighted average typical price from the new anchor", func() {
+				for i := range indicator.Data {
+					Expect(indicator.Data[i]).To(BeNumerically("~", manualVwap(reanchor, reanchor+i), 1e-9))
+				}
+			})
+		})
+	})
+
+	It("should not reanchor below the minimum", func() {
+		err := indicator.Reanchor(0)
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		Expect(indicator.AnchorBarIndex()).To(Equal(anchor))
+	})
+})
@@ -1,10 +1,9 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
+	"io"
 )
 
 // An Aroon (Aroon), no storage, for use in other indicators
@@ -12,11 +11,9 @@ type AroonWithoutStorage struct {
 	*baseIndicatorWithFloatBoundsAroon
 
 	// private variables
-	periodCounter     int
-	periodHighHistory *list.List
-	periodLowHistory  *list.List
+	periodHighHistory *monotonicDeque
+	periodLowHistory  *monotonicDeque
 	aroonFactor       float64
-	timePeriod        int
 }
 
 // NewAroonWithoutStorage creates an Aroon (Aroon) without storage
@@ -40,9 +37,8 @@ func NewAroonWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableA
 	lookback := timePeriod
 	ind := AroonWithoutStorage{
 		baseIndicatorWithFloatBoundsAroon: newBaseIndicatorWithFloatBoundsAroon(lookback, valueAvailableAction),
-		periodCounter:                     (timePeriod + 1) * -1,
-		periodHighHistory:                 list.New(),
-		periodLowHistory:                  list.New(),
+		periodHighHistory:                 newMonotonicDeque(timePeriod+1, true),
+		periodLowHistory:                  newMonotonicDeque(timePeriod+1, false),
 		aroonFactor:                       100.0 / float64(timePeriod),
 	}
 
@@ -132,51 +128,29 @@ func NewDefaultAroonForStreamWithSrcLen(sourceLength uint, priceStream gotrade.D
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *AroonWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
-	ind.periodCounter += 1
-	ind.periodHighHistory.PushBack(tickData.H())
-	ind.periodLowHistory.PushBack(tickData.L())
-
-	if ind.periodHighHistory.Len() > (1 + ind.GetLookbackPeriod()) {
-		var first = ind.periodHighHistory.Front()
-		ind.periodHighHistory.Remove(first)
-		first = ind.periodLowHistory.Front()
-		ind.periodLowHistory.Remove(first)
-	}
+	ind.periodHighHistory.push(tickData.H())
+	ind.periodLowHistory.push(tickData.L())
+
+	if ind.periodHighHistory.isFull() {
+		var daysSinceHigh = ind.periodHighHistory.barsSinceExtreme()
+		var daysSinceLow = ind.periodLowHistory.barsSinceExtreme()
 
-	if ind.periodCounter >= 0 {
-		var aroonUp float64
-		var aroonDwn float64
-
-		var highValue float64 = math.SmallestNonzeroFloat64
-		var highIdx int = -1
-		var i int = (1 + ind.GetLookbackPeriod())
-		for e := ind.periodHighHistory.Front(); e != nil; e = e.Next() {
-			i--
-			var value float64 = e.Value.(float64)
-			if highValue <= value {
-				highValue = value
-				highIdx = i
-			}
-		}
-		var daysSinceHigh = highIdx
-
-		var lowValue float64 = math.MaxFloat64
-		var lowIdx int = -1
-		i = (1 + ind.GetLookbackPeriod())
-		for e := ind.periodLowHistory.Front(); e != nil; e = e.Next() {
-			i--
-			var value float64 = e.Value.(float64)
-			if lowValue >= value {
-				lowValue = value
-				lowIdx = i
-			}
-
-		}
-		var daysSinceLow = lowIdx
-
-		aroonUp = ind.aroonFactor * float64(ind.GetLookbackPeriod()-daysSinceHigh)
-		aroonDwn = ind.aroonFactor * float64(ind.GetLookbackPeriod()-daysSinceLow)
+		aroonUp := ind.aroonFactor * float64(ind.GetLookbackPeriod()-daysSinceHigh)
+		aroonDwn := ind.aroonFactor * float64(ind.GetLookbackPeriod()-daysSinceLow)
 
 		ind.UpdateIndicatorWithNewValue(aroonUp, aroonDwn, streamBarIndex)
 	}
 }
+
+// SetTiePolicy sets which of several equal highs, or lows, within the time period the days since the high, or
+// low, are counted from, the default is TiePolicyNewest
+func (ind *AroonWithoutStorage) SetTiePolicy(tiePolicy TiePolicy) {
+	ind.periodHighHistory.setTiePolicy(tiePolicy)
+	ind.periodLowHistory.setTiePolicy(tiePolicy)
+}
+
+// WriteCSV writes the Aroon results as rows after a header of barIndex,up,down, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *Aroon) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("up", ind.Up), floatCSVColumn("down", ind.Down))
+}
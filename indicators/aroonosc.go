@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 type AroonOscWithoutStorage struct {
@@ -127,3 +128,15 @@ func NewDefaultAroonOscForStreamWithSrcLen(sourceLength uint, priceStream gotrad
 func (ind *AroonOsc) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.aroon.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the AroonOsc results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *AroonOsc) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the AroonOsc results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *AroonOsc) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,211 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"strconv"
+)
+
+// An AroonTrend is the strength of the trend given by an Aroon
+type AroonTrend int
+
+const (
+	// neither the Aroon up nor the Aroon down is dominant
+	AroonConsolidation AroonTrend = iota
+	// the Aroon up is at or above the strong threshold and the Aroon down is at or below the weak threshold
+	AroonStrongUptrend
+	// the Aroon down is at or above the strong threshold and the Aroon up is at or below the weak threshold
+	AroonStrongDowntrend
+)
+
+type ValueAvailableActionAroonTrendState func(dataItemTrend AroonTrend, dataItemOscillator float64, streamBarIndex int)
+
+// An Aroon Trend State Indicator (AroonTrendState), no storage, for use in other indicators
+// classifies each Aroon result as a strong uptrend, a strong downtrend or a consolidation using the strong and
+// weak thresholds, along with the Aroon oscillator, up - down
+type AroonTrendStateWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionAroonTrendState
+	aroon                *AroonWithoutStorage
+	strongThreshold      float64
+	weakThreshold        float64
+	currentTrend         AroonTrend
+}
+
+// NewAroonTrendStateWithoutStorage creates an Aroon Trend State Indicator (AroonTrendState) without storage
+func NewAroonTrendStateWithoutStorage(timePeriod int, strongThreshold float64, weakThreshold float64, valueAvailableAction ValueAvailableActionAroonTrendState) (indicator *AroonTrendStateWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum weakThreshold for this indicator is 0
+	if weakThreshold < 0.0 {
+		return nil, errors.New("weakThreshold is less than the minimum (0)")
+	}
+
+	// the maximum strongThreshold for this indicator is 100
+	if strongThreshold > 100.0 {
+		return nil, errors.New("strongThreshold is greater than the maximum (100)")
+	}
+
+	// the thresholds must not overlap
+	if weakThreshold >= strongThreshold {
+		return nil, errors.New("weakThreshold is greater than the maximum (the strongThreshold)")
+	}
+
+	ind := AroonTrendStateWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		strongThreshold:      strongThreshold,
+		weakThreshold:        weakThreshold,
+	}
+
+	ind.aroon, err = NewAroonWithoutStorage(timePeriod,
+		func(dataItemAroonUp float64, dataItemAroonDown float64, streamBarIndex int) {
+			trend := AroonConsolidation
+			if dataItemAroonUp >= ind.strongThreshold && dataItemAroonDown <= ind.weakThreshold {
+				trend = AroonStrongUptrend
+			} else if dataItemAroonDown >= ind.strongThreshold && dataItemAroonUp <= ind.weakThreshold {
+				trend = AroonStrongDowntrend
+			}
+			ind.currentTrend = trend
+
+			oscillator := ind.quantize(dataItemAroonUp - dataItemAroonDown)
+
+			ind.UpdateMinMax(oscillator, oscillator)
+
+			ind.IncDataLength()
+
+			ind.SetValidFromBar(streamBarIndex)
+
+			// notify of a new result value though the value available action
+			ind.valueAvailableAction(trend, oscillator, streamBarIndex)
+		})
+
+	ind.baseIndicator = newBaseIndicator(ind.aroon.GetLookbackPeriod())
+
+	return &ind, err
+}
+
+// CurrentTrend returns the trend of the last result
+func (ind *AroonTrendStateWithoutStorage) CurrentTrend() AroonTrend {
+	return ind.currentTrend
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *AroonTrendStateWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.aroon.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// An Aroon Trend State Indicator (AroonTrendState)
+type AroonTrendState struct {
+	*AroonTrendStateWithoutStorage
+
+	// public variables
+	Trend      []AroonTrend
+	Oscillator []float64
+}
+
+// NewAroonTrendState creates an Aroon Trend State Indicator (AroonTrendState) for online usage
+func NewAroonTrendState(timePeriod int, strongThreshold float64, weakThreshold float64) (indicator *AroonTrendState, err error) {
+	ind := AroonTrendState{}
+	ind.AroonTrendStateWithoutStorage, err = NewAroonTrendStateWithoutStorage(timePeriod, strongThreshold, weakThreshold,
+		func(dataItemTrend AroonTrend, dataItemOscillator float64, streamBarIndex int) {
+			ind.Trend = append(ind.Trend, dataItemTrend)
+			ind.Oscillator = append(ind.Oscillator, dataItemOscillator)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultAroonTrendState creates an Aroon Trend State Indicator (AroonTrendState) for online usage with default parameters
+//	- timePeriod: 14
+//	- strongThreshold: 70.0
+//	- weakThreshold: 30.0
+func NewDefaultAroonTrendState() (indicator *AroonTrendState, err error) {
+	timePeriod := 14
+	strongThreshold := 70.0
+	weakThreshold := 30.0
+	return NewAroonTrendState(timePeriod, strongThreshold, weakThreshold)
+}
+
+// NewAroonTrendStateWithSrcLen creates an Aroon Trend State Indicator (AroonTrendState) for offline usage
+func NewAroonTrendStateWithSrcLen(sourceLength uint, timePeriod int, strongThreshold float64, weakThreshold float64) (indicator *AroonTrendState, err error) {
+	ind, err := NewAroonTrendState(timePeriod, strongThreshold, weakThreshold)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Trend = make([]AroonTrend, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Oscillator = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultAroonTrendStateWithSrcLen creates an Aroon Trend State Indicator (AroonTrendState) for offline usage with default parameters
+func NewDefaultAroonTrendStateWithSrcLen(sourceLength uint) (indicator *AroonTrendState, err error) {
+	ind, err := NewDefaultAroonTrendState()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Trend = make([]AroonTrend, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Oscillator = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewAroonTrendStateForStream creates an Aroon Trend State Indicator (AroonTrendState) for online usage with a source data stream
+func NewAroonTrendStateForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, strongThreshold float64, weakThreshold float64) (indicator *AroonTrendState, err error) {
+	ind, err := NewAroonTrendState(timePeriod, strongThreshold, weakThreshold)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultAroonTrendStateForStream creates an Aroon Trend State Indicator (AroonTrendState) for online usage with a source data stream
+func NewDefaultAroonTrendStateForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *AroonTrendState, err error) {
+	ind, err := NewDefaultAroonTrendState()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewAroonTrendStateForStreamWithSrcLen creates an Aroon Trend State Indicator (AroonTrendState) for offline usage with a source data stream
+func NewAroonTrendStateForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, strongThreshold float64, weakThreshold float64) (indicator *AroonTrendState, err error) {
+	ind, err := NewAroonTrendStateWithSrcLen(sourceLength, timePeriod, strongThreshold, weakThreshold)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultAroonTrendStateForStreamWithSrcLen creates an Aroon Trend State Indicator (AroonTrendState) for offline usage with a source data stream
+func NewDefaultAroonTrendStateForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *AroonTrendState, err error) {
+	ind, err := NewDefaultAroonTrendStateWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the AroonTrendState results as rows after a header of barIndex,trend,oscillator, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *AroonTrendState) WriteCSV(w io.Writer, startBarOffset int) error {
+	trend := csvColumn{name: "trend", length: len(ind.Trend), format: func(index int) string {
+		return strconv.Itoa(int(ind.Trend[index]))
+	}}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), trend, floatCSVColumn("oscillator", ind.Oscillator))
+}
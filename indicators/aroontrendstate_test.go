@@ -0,0 +1,155 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating an aroontrendstatewithoutstorage", func() {
+	var (
+		indicator      *indicators.AroonTrendStateWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemTrend indicators.AroonTrend, dataItemOscillator float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAroonTrendStateWithoutStorage(14, 70.0, 30.0, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAroonTrendStateWithoutStorage(1, 70.0, 30.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a strongThreshold above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAroonTrendStateWithoutStorage(14, 101.0, 30.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+
+	Context("and the indicator was given a weakThreshold above the strongThreshold", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewAroonTrendStateWithoutStorage(14, 30.0, 70.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating an aroon trend state (aroontrendstate) with DOHLCV source data", func() {
+	var (
+		period    int = 14
+		segment   int = 30
+		indicator *indicators.AroonTrendState
+		aroon     *indicators.Aroon
+		bars      []gotrade.DOHLCV
+	)
+
+	// an uptrend, then a flat section, then a downtrend
+	start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	price := 100.0
+	for i := 0; i < 3*segment; i++ {
+		if i < segment {
+			price += 1.0
+		} else if i >= 2*segment {
+			price -= 1.0
+		}
+		bars = append(bars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price+1.0, price-1.0, price, 1000.0))
+	}
+
+	// the trend of the result for the stream bar index
+	trendAt := func(streamBarIndex int) indicators.AroonTrend {
+		return indicator.Trend[streamBarIndex-indicator.ValidFromBar()]
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultAroonTrendState()
+		aroon, _ = indicators.NewAroon(period)
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			aroon.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+	})
+
+	It("should have a result for each aroon result", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(aroon.GetLookbackPeriod()))
+		Expect(len(indicator.Trend)).To(Equal(len(aroon.Up)))
+		Expect(len(indicator.Oscillator)).To(Equal(len(aroon.Up)))
+	})
+
+	It("the oscillator should be the aroon up less the aroon down", func() {
+		for i := range indicator.Oscillator {
+			Expect(indicator.Oscillator[i]).To(Equal(aroon.Up[i] - aroon.Down[i]))
+		}
+	})
+
+	It("the trend should follow the thresholds", func() {
+		for i := range indicator.Trend {
+			expected := indicators.AroonConsolidation
+			if aroon.Up[i] >= 70.0 && aroon.Down[i] <= 30.0 {
+				expected = indicators.AroonStrongUptrend
+			} else if aroon.Down[i] >= 70.0 && aroon.Up[i] <= 30.0 {
+				expected = indicators.AroonStrongDowntrend
+			}
+			Expect(indicator.Trend[i]).To(Equal(expected))
+		}
+	})
+
+	It("should be a strong uptrend, then a consolidation, then a strong downtrend", func() {
+		Expect(trendAt(segment)).To(Equal(indicators.AroonStrongUptrend))
+		Expect(trendAt(2 * segment)).To(Equal(indicators.AroonConsolidation))
+		Expect(trendAt(3 * segment)).To(Equal(indicators.AroonStrongDowntrend))
+		Expect(indicator.CurrentTrend()).To(Equal(indicators.AroonStrongDowntrend))
+
+		// the trend passes through a consolidation between the strong trends
+		transitions := []indicators.AroonTrend{indicator.Trend[0]}
+		for i := 1; i < len(indicator.Trend); i++ {
+			if indicator.Trend[i] != indicator.Trend[i-1] {
+				transitions = append(transitions, indicator.Trend[i])
+			}
+		}
+		Expect(transitions).To(Equal([]indicators.AroonTrend{indicators.AroonStrongUptrend, indicators.AroonConsolidation, indicators.AroonStrongDowntrend}))
+	})
+
+	It("stricter thresholds should classify fewer bars as strong trends", func() {
+		strict, _ := indicators.NewAroonTrendState(period, 95.0, 5.0)
+		for i := range bars {
+			strict.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+
+		countStrong := func(trends []indicators.AroonTrend) int {
+			count := 0
+			for i := range trends {
+				if trends[i] != indicators.AroonConsolidation {
+					count++
+				}
+			}
+			return count
+		}
+		Expect(countStrong(strict.Trend)).To(BeNumerically("<", countStrong(indicator.Trend)))
+	})
+})
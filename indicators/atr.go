@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // An Average True Range Indicator (Atr), no storage, for use in other indicators
@@ -149,3 +150,15 @@ func (ind *AtrWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamB
 	// update the current true range
 	ind.trueRange.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the Atr results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Atr) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Atr results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Atr) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
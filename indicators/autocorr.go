@@ -0,0 +1,231 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// the variance, relative to the square of the mean, below which a series is taken to be flat
+// so that rounding in the running sums does not produce a correlation for a flat period
+const autoCorrFlatVarianceTolerance float64 = 0.000000000001
+
+// a value of the period of an autocorrelation aligned with the value lag bars before it
+type autoCorrPair struct {
+	value  float64
+	lagged float64
+}
+
+// An Autocorrelation Indicator (AutoCorr), no storage, for use in other indicators
+// the correlation of the last timePeriod values with the values lag bars before each of them, near 1.0 for a
+// trending series and negative for a mean reverting series, a result of 0.0 is given while either series is flat
+type AutoCorrWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	lagHistory          *list.List
+	periodHistory       *list.List
+	periodTotal         float64
+	periodTotalSq       float64
+	periodLaggedTotal   float64
+	periodLaggedTotalSq float64
+	periodTotalProduct  float64
+	timePeriod          int
+	lag                 int
+}
+
+// NewAutoCorrWithoutStorage creates an Autocorrelation Indicator (AutoCorr) without storage
+func NewAutoCorrWithoutStorage(timePeriod int, lag int, valueAvailableAction ValueAvailableActionFloat) (indicator *AutoCorrWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum lag for this indicator is 1
+	if lag < 1 {
+		return nil, errors.New("lag is less than the minimum (1)")
+	}
+
+	// check the maximum lag
+	if lag > MaximumLookbackPeriod {
+		return nil, errors.New("lag is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1 + lag
+	ind := AutoCorrWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		lagHistory:                   list.New(),
+		periodHistory:                list.New(),
+		timePeriod:                   timePeriod,
+		lag:                          lag,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *AutoCorrWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.lagHistory.PushBack(tickData)
+
+	// the value lag bars ago is only available once lag bars have been seen
+	if ind.lagHistory.Len() <= ind.lag {
+		return
+	}
+
+	var first = ind.lagHistory.Front()
+	pair := autoCorrPair{value: tickData, lagged: first.Value.(float64)}
+	ind.lagHistory.Remove(first)
+
+	ind.periodHistory.PushBack(pair)
+	ind.addPair(pair, 1.0)
+
+	if ind.periodHistory.Len() > ind.timePeriod {
+		var oldest = ind.periodHistory.Front()
+		ind.addPair(oldest.Value.(autoCorrPair), -1.0)
+		ind.periodHistory.Remove(oldest)
+	}
+
+	if ind.periodHistory.Len() == ind.timePeriod {
+		n := float64(ind.timePeriod)
+		mean := ind.periodTotal / n
+		laggedMean := ind.periodLaggedTotal / n
+		variance := ind.periodTotalSq/n - mean*mean
+		laggedVariance := ind.periodLaggedTotalSq/n - laggedMean*laggedMean
+		covariance := ind.periodTotalProduct/n - mean*laggedMean
+
+		var result float64 = 0.0
+		if variance > autoCorrFlatVarianceTolerance*mean*mean && laggedVariance > autoCorrFlatVarianceTolerance*laggedMean*laggedMean {
+			result = covariance / math.Sqrt(variance*laggedVariance)
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// addPair adds, sign 1.0, or removes, sign -1.0, a pair from the period totals
+func (ind *AutoCorrWithoutStorage) addPair(pair autoCorrPair, sign float64) {
+	ind.periodTotal += sign * pair.value
+	ind.periodTotalSq += sign * pair.value * pair.value
+	ind.periodLaggedTotal += sign * pair.lagged
+	ind.periodLaggedTotalSq += sign * pair.lagged * pair.lagged
+	ind.periodTotalProduct += sign * pair.value * pair.lagged
+}
+
+// An Autocorrelation Indicator (AutoCorr)
+type AutoCorr struct {
+	*AutoCorrWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewAutoCorr creates an Autocorrelation Indicator (AutoCorr) for online usage
+func NewAutoCorr(timePeriod int, lag int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *AutoCorr, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := AutoCorr{
+		selectData: selectData,
+	}
+
+	ind.AutoCorrWithoutStorage, err = NewAutoCorrWithoutStorage(timePeriod, lag,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultAutoCorr creates an Autocorrelation Indicator (AutoCorr) for online usage with default parameters
+//	- timePeriod: 50
+//	- lag: 1
+func NewDefaultAutoCorr() (indicator *AutoCorr, err error) {
+	timePeriod := 50
+	lag := 1
+	return NewAutoCorr(timePeriod, lag, gotrade.UseClosePrice)
+}
+
+// NewAutoCorrWithSrcLen creates an Autocorrelation Indicator (AutoCorr) for offline usage
+func NewAutoCorrWithSrcLen(sourceLength uint, timePeriod int, lag int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *AutoCorr, err error) {
+	ind, err := NewAutoCorr(timePeriod, lag, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultAutoCorrWithSrcLen creates an Autocorrelation Indicator (AutoCorr) for offline usage with default parameters
+func NewDefaultAutoCorrWithSrcLen(sourceLength uint) (indicator *AutoCorr, err error) {
+	ind, err := NewDefaultAutoCorr()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewAutoCorrForStream creates an Autocorrelation Indicator (AutoCorr) for online usage with a source data stream
+func NewAutoCorrForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, lag int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *AutoCorr, err error) {
+	ind, err := NewAutoCorr(timePeriod, lag, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultAutoCorrForStream creates an Autocorrelation Indicator (AutoCorr) for online usage with a source data stream
+func NewDefaultAutoCorrForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *AutoCorr, err error) {
+	ind, err := NewDefaultAutoCorr()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewAutoCorrForStreamWithSrcLen creates an Autocorrelation Indicator (AutoCorr) for offline usage with a source data stream
+func NewAutoCorrForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, lag int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *AutoCorr, err error) {
+	ind, err := NewAutoCorrWithSrcLen(sourceLength, timePeriod, lag, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultAutoCorrForStreamWithSrcLen creates an Autocorrelation Indicator (AutoCorr) for offline usage with a source data stream
+func NewDefaultAutoCorrForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *AutoCorr, err error) {
+	ind, err := NewDefaultAutoCorrWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *AutoCorr) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the AutoCorr results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *AutoCorr) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the AutoCorr results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *AutoCorr) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
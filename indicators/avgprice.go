@@ -2,6 +2,7 @@ package indicators
 
 import (
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // An Average Price (AvgPrice), no storage, for use in other indicators
@@ -72,3 +73,15 @@ func (ind *AvgPriceWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, st
 
 	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 }
+
+// ValuesInRange returns the AvgPrice results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *AvgPrice) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the AvgPrice results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *AvgPrice) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,30 @@
+package indicators
+
+// ComputeSma computes the Simple Moving Average (Sma) of the whole input in a single pass, for offline usage over
+// large data sets where the per tick callbacks of the Sma are not required. The input is treated as the bars
+// 1 to len(input), the results and the bar of the first result, validFromBar, are returned. The results are
+// identical to those of the Sma, an invalid period or an input shorter than the period returns no results and
+// a validFromBar of -1
+func ComputeSma(input []float64, period int) (results []float64, validFromBar int) {
+	if period < 2 || period > MaximumLookbackPeriod || len(input) < period {
+		return []float64{}, -1
+	}
+
+	results = make([]float64, 0, len(input)-period+1)
+	timePeriod := float64(period)
+
+	// the period total is updated in the same order as the Sma, so that the rounding is the same
+	var periodTotal float64 = 0.0
+	for i := range input {
+		if i >= period {
+			periodTotal -= input[i-period]
+		}
+		periodTotal += input[i]
+
+		if i >= period-1 {
+			results = append(results, periodTotal/timePeriod)
+		}
+	}
+
+	return results, period
+}
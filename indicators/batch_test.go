@@ -0,0 +1,85 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"testing"
+)
+
+var _ = Describe("when computing a simple moving average (sma) in a single pass", func() {
+	var input []float64
+
+	BeforeEach(func() {
+		input = make([]float64, len(sourceDOHLCVData))
+		for i := range sourceDOHLCVData {
+			input[i] = sourceDOHLCVData[i].C()
+		}
+	})
+
+	It("the results should be identical to the streaming sma", func() {
+		for _, period := range []int{2, 3, 10, 30} {
+			sma, _ := indicators.NewSma(period, gotrade.UseClosePrice)
+			for i := range sourceDOHLCVData {
+				sma.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+
+			results, validFromBar := indicators.ComputeSma(input, period)
+			Expect(results).To(Equal(sma.Data))
+			Expect(validFromBar).To(Equal(sma.ValidFromBar()))
+		}
+	})
+
+	It("a period below the minimum should return no results", func() {
+		results, validFromBar := indicators.ComputeSma(input, 1)
+		Expect(results).To(BeEmpty())
+		Expect(validFromBar).To(Equal(-1))
+	})
+
+	It("an input shorter than the period should return no results", func() {
+		results, validFromBar := indicators.ComputeSma(input[:4], 5)
+		Expect(results).To(BeEmpty())
+		Expect(validFromBar).To(Equal(-1))
+	})
+
+	It("an input equal to the period should return a single result", func() {
+		results, validFromBar := indicators.ComputeSma([]float64{1.0, 2.0, 3.0, 4.0, 5.0}, 5)
+		Expect(results).To(Equal([]float64{3.0}))
+		Expect(validFromBar).To(Equal(5))
+	})
+})
+
+// the length of the input used by the benchmarks
+const benchmarkInputLength = 1000000
+
+func benchmarkInput() []float64 {
+	input := make([]float64, benchmarkInputLength)
+	for i := range input {
+		input[i] = 100.0 + 10.0*math.Sin(float64(i)/50.0)
+	}
+	return input
+}
+
+func BenchmarkComputeSma(b *testing.B) {
+	input := benchmarkInput()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		indicators.ComputeSma(input, 30)
+	}
+}
+
+func BenchmarkStreamingSma(b *testing.B) {
+	input := benchmarkInput()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		results := make([]float64, 0, len(input))
+		sma, _ := indicators.NewSmaWithoutStorage(30, func(dataItem float64, streamBarIndex int) {
+			results = append(results, dataItem)
+		})
+		for i := range input {
+			sma.ReceiveTick(input[i], i+1)
+		}
+	}
+}
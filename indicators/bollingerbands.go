@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Bollinger Band Indicator (BollingerBand), no storage, for use in other indicators
@@ -11,14 +12,20 @@ type BollingerBandsWithoutStorage struct {
 
 	// private variables
 	valueAvailableAction ValueAvailableActionBollinger
-	sma                  *SmaWithoutStorage
+	movingAverage        MovingAverageWithoutStorage
 	stdDev               *StdDevWithoutStorage
-	currentSma           float64
+	currentMovingAverage float64
 	timePeriod           int
 }
 
 // NewBollingerBandsWithoutStorage creates a Bollinger Band Indicator (BollingerBand) without storage
 func NewBollingerBandsWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionBollinger) (indicator *BollingerBandsWithoutStorage, err error) {
+	return NewBollingerBandsWithMaTypeWithoutStorage(timePeriod, MaTypeSma, valueAvailableAction)
+}
+
+// NewBollingerBandsWithMaTypeWithoutStorage creates a Bollinger Band Indicator (BollingerBand) without storage
+// with a middle band of the given MaType
+func NewBollingerBandsWithMaTypeWithoutStorage(timePeriod int, maType MaType, valueAvailableAction ValueAvailableActionBollinger) (indicator *BollingerBandsWithoutStorage, err error) {
 
 	// an indicator without storage MUST have a value available action
 	if valueAvailableAction == nil {
@@ -35,25 +42,38 @@ func NewBollingerBandsWithoutStorage(timePeriod int, valueAvailableAction ValueA
 		return nil, errors.New("timePeriod is greater than the maximum (100000)")
 	}
 
-	lookback := timePeriod - 1
 	ind := BollingerBandsWithoutStorage{
-		baseIndicatorWithFloatBoundsBollinger: newBaseIndicatorWithFloatBoundsBollinger(lookback, valueAvailableAction),
-		currentSma:                            0.0,
-		timePeriod:                            timePeriod,
+		currentMovingAverage: 0.0,
+		timePeriod:           timePeriod,
 	}
 
-	ind.sma, err = NewSmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
-		ind.currentSma = dataItem
+	ind.movingAverage, err = NewMovingAverageWithoutStorage(maType, timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentMovingAverage = dataItem
 	})
 
+	if err != nil {
+		return nil, err
+	}
+
 	ind.stdDev, err = NewStdDevWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
 
-		var upperBand = ind.currentSma + 2*dataItem
-		var lowerBand = ind.currentSma - 2*dataItem
+		// the bands are only available once the middle band is, which for some moving averages lags the standard deviation
+		if ind.movingAverage.Length() == 0 {
+			return
+		}
+
+		var upperBand = ind.currentMovingAverage + 2*dataItem
+		var lowerBand = ind.currentMovingAverage - 2*dataItem
 
-		ind.UpdateIndicatorWithNewValue(upperBand, ind.currentSma, lowerBand, streamBarIndex)
+		ind.UpdateIndicatorWithNewValue(upperBand, ind.currentMovingAverage, lowerBand, streamBarIndex)
 	})
 
+	lookback := ind.movingAverage.GetLookbackPeriod()
+	if ind.stdDev.GetLookbackPeriod() > lookback {
+		lookback = ind.stdDev.GetLookbackPeriod()
+	}
+	ind.baseIndicatorWithFloatBoundsBollinger = newBaseIndicatorWithFloatBoundsBollinger(lookback, valueAvailableAction)
+
 	return &ind, nil
 }
 
@@ -70,6 +90,11 @@ type BollingerBands struct {
 
 // NewBollingerBands creates a Bollinger Band Indicator (BollingerBand) for online usage
 func NewBollingerBands(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerBands, err error) {
+	return NewBollingerBandsWithMaType(timePeriod, MaTypeSma, selectData)
+}
+
+// NewBollingerBandsWithMaType creates a Bollinger Band Indicator (BollingerBand) for online usage with a middle band of the given MaType
+func NewBollingerBandsWithMaType(timePeriod int, maType MaType, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerBands, err error) {
 
 	if selectData == nil {
 		return nil, ErrDOHLCVDataSelectFuncIsNil
@@ -79,8 +104,9 @@ func NewBollingerBands(timePeriod int, selectData gotrade.DOHLCVDataSelectionFun
 		selectData: selectData,
 	}
 
-	ind.BollingerBandsWithoutStorage, err = NewBollingerBandsWithoutStorage(
+	ind.BollingerBandsWithoutStorage, err = NewBollingerBandsWithMaTypeWithoutStorage(
 		timePeriod,
+		maType,
 		func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
 			ind.UpperBand = append(ind.UpperBand, dataItemUpperBand)
 			ind.MiddleBand = append(ind.MiddleBand, dataItemMiddleBand)
@@ -162,6 +188,12 @@ func (ind *BollingerBands) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarI
 
 // ReceiveTick consumes a source data float price tick
 func (ind *BollingerBandsWithoutStorage) RecieveTick(tickData float64, streamBarIndex int) {
-	ind.sma.ReceiveTick(tickData, streamBarIndex)
+	ind.movingAverage.ReceiveTick(tickData, streamBarIndex)
 	ind.stdDev.ReceiveTick(tickData, streamBarIndex)
 }
+
+// WriteCSV writes the BollingerBands results as rows after a header of barIndex,upperBand,middleBand,lowerBand, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *BollingerBands) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("upperBand", ind.UpperBand), floatCSVColumn("middleBand", ind.MiddleBand), floatCSVColumn("lowerBand", ind.LowerBand))
+}
@@ -5,6 +5,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/thetruetrade/gotrade"
 	"github.com/thetruetrade/gotrade/indicators"
+	"math"
 )
 
 var _ = Describe("when creating a bollingerbandswithoutstorage", func() {
@@ -492,3 +493,61 @@ var _ = Describe("when calculating bollinger bands with DOHLCV source data", fun
 		})
 	})
 })
+
+var _ = Describe("when calculating bollinger bands with the middle band of a given MaType", func() {
+	var (
+		period    int = 5
+		indicator *indicators.BollingerBands
+		ema       *indicators.Ema
+		stdDev    *indicators.StdDev
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewBollingerBandsWithMaType(period, indicators.MaTypeEma, gotrade.UseClosePrice)
+		ema, _ = indicators.NewEma(period, gotrade.UseClosePrice)
+		stdDev, _ = indicators.NewStdDev(period, gotrade.UseClosePrice)
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			ema.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			stdDev.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("the middle band should be the moving average of the MaType", func() {
+		Expect(indicator.MiddleBand).To(Equal(ema.Data))
+	})
+
+	It("the outer bands should be two standard deviations from the middle band", func() {
+		for i := range indicator.MiddleBand {
+			// the standard deviation of a flat period can be NaN, which the bands then carry
+			if math.IsNaN(stdDev.Data[i]) {
+				Expect(math.IsNaN(indicator.UpperBand[i])).To(BeTrue())
+				continue
+			}
+			Expect(indicator.UpperBand[i]).To(BeNumerically("~", ema.Data[i]+2*stdDev.Data[i], 0.0000001))
+			Expect(indicator.LowerBand[i]).To(BeNumerically("~", ema.Data[i]-2*stdDev.Data[i], 0.0000001))
+		}
+	})
+
+	Context("and the moving average lags the standard deviation", func() {
+		BeforeEach(func() {
+			indicator, _ = indicators.NewBollingerBandsWithMaType(period, indicators.MaTypeTema, gotrade.UseClosePrice)
+			for i := 0; i < len(sourceDOHLCVData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("the lookback period should be that of the moving average", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(3 * (period - 1)))
+			Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+			Expect(len(indicator.MiddleBand)).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+		})
+	})
+
+	Context("and the MaType is not supported", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			_, err := indicators.NewBollingerBandsWithMaType(period, indicators.MaType(99), gotrade.UseClosePrice)
+			Expect(err).To(Equal(indicators.ErrMaTypeNotSupported))
+		})
+	})
+})
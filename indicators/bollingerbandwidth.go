@@ -0,0 +1,165 @@
+// Bollinger Bandwidth (BollingerBandwidth)
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Bollinger Bandwidth Indicator (BollingerBandwidth), no storage, for use in other indicators
+// The width of the Bollinger Bands relative to the middle band, (upper band - lower band) / middle band, which
+// narrows as the volatility contracts. A result of 0.0 is given while the middle band is 0.0
+type BollingerBandwidthWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	bands      *BollingerBandsWithoutStorage
+	timePeriod int
+}
+
+// NewBollingerBandwidthWithoutStorage creates a Bollinger Bandwidth Indicator (BollingerBandwidth) without storage
+func NewBollingerBandwidthWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *BollingerBandwidthWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	ind := BollingerBandwidthWithoutStorage{
+		timePeriod: timePeriod,
+	}
+
+	ind.bands, err = NewBollingerBandsWithoutStorage(timePeriod,
+		func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
+			var result float64 = 0.0
+			if dataItemMiddleBand != 0.0 {
+				result = (dataItemUpperBand - dataItemLowerBand) / dataItemMiddleBand
+			}
+
+			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+		})
+
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(ind.bands.GetLookbackPeriod(), valueAvailableAction)
+
+	return &ind, err
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *BollingerBandwidthWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.bands.RecieveTick(tickData, streamBarIndex)
+}
+
+// A Bollinger Bandwidth Indicator (BollingerBandwidth)
+type BollingerBandwidth struct {
+	*BollingerBandwidthWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewBollingerBandwidth creates a Bollinger Bandwidth Indicator (BollingerBandwidth) for online usage
+func NewBollingerBandwidth(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerBandwidth, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := BollingerBandwidth{
+		selectData: selectData,
+	}
+
+	ind.BollingerBandwidthWithoutStorage, err = NewBollingerBandwidthWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultBollingerBandwidth creates a Bollinger Bandwidth Indicator (BollingerBandwidth) for online usage with default parameters
+//	- timePeriod: 5
+func NewDefaultBollingerBandwidth() (indicator *BollingerBandwidth, err error) {
+	timePeriod := 5
+	return NewBollingerBandwidth(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewBollingerBandwidthWithSrcLen creates a Bollinger Bandwidth Indicator (BollingerBandwidth) for offline usage
+func NewBollingerBandwidthWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerBandwidth, err error) {
+	ind, err := NewBollingerBandwidth(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultBollingerBandwidthWithSrcLen creates a Bollinger Bandwidth Indicator (BollingerBandwidth) for offline usage with default parameters
+func NewDefaultBollingerBandwidthWithSrcLen(sourceLength uint) (indicator *BollingerBandwidth, err error) {
+	ind, err := NewDefaultBollingerBandwidth()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewBollingerBandwidthForStream creates a Bollinger Bandwidth Indicator (BollingerBandwidth) for online usage with a source data stream
+func NewBollingerBandwidthForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerBandwidth, err error) {
+	ind, err := NewBollingerBandwidth(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultBollingerBandwidthForStream creates a Bollinger Bandwidth Indicator (BollingerBandwidth) for online usage with a source data stream
+func NewDefaultBollingerBandwidthForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *BollingerBandwidth, err error) {
+	ind, err := NewDefaultBollingerBandwidth()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewBollingerBandwidthForStreamWithSrcLen creates a Bollinger Bandwidth Indicator (BollingerBandwidth) for offline usage with a source data stream
+func NewBollingerBandwidthForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerBandwidth, err error) {
+	ind, err := NewBollingerBandwidthWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultBollingerBandwidthForStreamWithSrcLen creates a Bollinger Bandwidth Indicator (BollingerBandwidth) for offline usage with a source data stream
+func NewDefaultBollingerBandwidthForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *BollingerBandwidth, err error) {
+	ind, err := NewDefaultBollingerBandwidthWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *BollingerBandwidth) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the BollingerBandwidth results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *BollingerBandwidth) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the BollingerBandwidth results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *BollingerBandwidth) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
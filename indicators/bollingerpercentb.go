@@ -0,0 +1,173 @@
+// Bollinger Percent B (BollingerPercentB)
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Bollinger Percent B Indicator (BollingerPercentB), no storage, for use in other indicators
+// The position of the price within the Bollinger Bands, (price - lower band) / (upper band - lower band), 0.0 at
+// the lower band and 1.0 at the upper band, outside of 0.0 to 1.0 when the price is outside of the bands. A price
+// within bands without any width is given a result of 0.5
+type BollingerPercentBWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	bands        *BollingerBandsWithoutStorage
+	currentPrice float64
+	timePeriod   int
+}
+
+// NewBollingerPercentBWithoutStorage creates a Bollinger Percent B Indicator (BollingerPercentB) without storage
+func NewBollingerPercentBWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *BollingerPercentBWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	ind := BollingerPercentBWithoutStorage{
+		timePeriod: timePeriod,
+	}
+
+	ind.bands, err = NewBollingerBandsWithoutStorage(timePeriod,
+		func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
+			var result float64
+			width := dataItemUpperBand - dataItemLowerBand
+
+			// bands without any width place the price in the middle
+			if width != 0.0 {
+				result = (ind.currentPrice - dataItemLowerBand) / width
+			} else {
+				result = 0.5
+			}
+
+			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+		})
+
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(ind.bands.GetLookbackPeriod(), valueAvailableAction)
+
+	return &ind, err
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *BollingerPercentBWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.currentPrice = tickData
+	ind.bands.RecieveTick(tickData, streamBarIndex)
+}
+
+// A Bollinger Percent B Indicator (BollingerPercentB)
+type BollingerPercentB struct {
+	*BollingerPercentBWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewBollingerPercentB creates a Bollinger Percent B Indicator (BollingerPercentB) for online usage
+func NewBollingerPercentB(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerPercentB, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := BollingerPercentB{
+		selectData: selectData,
+	}
+
+	ind.BollingerPercentBWithoutStorage, err = NewBollingerPercentBWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultBollingerPercentB creates a Bollinger Percent B Indicator (BollingerPercentB) for online usage with default parameters
+//	- timePeriod: 5
+func NewDefaultBollingerPercentB() (indicator *BollingerPercentB, err error) {
+	timePeriod := 5
+	return NewBollingerPercentB(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewBollingerPercentBWithSrcLen creates a Bollinger Percent B Indicator (BollingerPercentB) for offline usage
+func NewBollingerPercentBWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerPercentB, err error) {
+	ind, err := NewBollingerPercentB(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultBollingerPercentBWithSrcLen creates a Bollinger Percent B Indicator (BollingerPercentB) for offline usage with default parameters
+func NewDefaultBollingerPercentBWithSrcLen(sourceLength uint) (indicator *BollingerPercentB, err error) {
+	ind, err := NewDefaultBollingerPercentB()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewBollingerPercentBForStream creates a Bollinger Percent B Indicator (BollingerPercentB) for online usage with a source data stream
+func NewBollingerPercentBForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerPercentB, err error) {
+	ind, err := NewBollingerPercentB(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultBollingerPercentBForStream creates a Bollinger Percent B Indicator (BollingerPercentB) for online usage with a source data stream
+func NewDefaultBollingerPercentBForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *BollingerPercentB, err error) {
+	ind, err := NewDefaultBollingerPercentB()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewBollingerPercentBForStreamWithSrcLen creates a Bollinger Percent B Indicator (BollingerPercentB) for offline usage with a source data stream
+func NewBollingerPercentBForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *BollingerPercentB, err error) {
+	ind, err := NewBollingerPercentBWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultBollingerPercentBForStreamWithSrcLen creates a Bollinger Percent B Indicator (BollingerPercentB) for offline usage with a source data stream
+func NewDefaultBollingerPercentBForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *BollingerPercentB, err error) {
+	ind, err := NewDefaultBollingerPercentBWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *BollingerPercentB) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the BollingerPercentB results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *BollingerPercentB) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the BollingerPercentB results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *BollingerPercentB) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
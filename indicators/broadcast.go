@@ -0,0 +1,118 @@
+package indicators
+
+import (
+	"container/list"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"time"
+)
+
+// a value of a coarse timeframe indicator waiting to become available to the fine timeframe bars
+type broadcastValue struct {
+	value            float64
+	byTime           bool
+	availableFromBar int
+	availableFrom    time.Time
+}
+
+// A Broadcast (Broadcast), no storage, for use in other indicators
+// forward fills the values of a coarse timeframe indicator, such as a daily one, onto the bars of a fine timeframe
+// stream, such as a minute one. Each fine bar makes the most recent coarse value available as of that bar, a
+// coarse value is only forwarded from the fine bar index, or the fine bar date, it is received as available
+// from, so no coarse value is seen by a fine bar before the coarse bar it belongs to has completed. There are no
+// results until the first coarse value is available
+type BroadcastWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	pending         *list.List
+	hasCurrentValue bool
+	currentValue    float64
+}
+
+// NewBroadcastWithoutStorage creates a Broadcast (Broadcast) without storage
+func NewBroadcastWithoutStorage(valueAvailableAction ValueAvailableActionFloat) (indicator *BroadcastWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	ind := BroadcastWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		pending:                      list.New(),
+	}
+
+	return &ind, nil
+}
+
+// ReceiveCoarseValue consumes a value of the coarse timeframe indicator that is available to the fine bars from
+// the fine stream bar index availableFromBar onward, the coarse values must be received in order
+func (ind *BroadcastWithoutStorage) ReceiveCoarseValue(value float64, availableFromBar int) {
+	ind.pending.PushBack(broadcastValue{value: value, availableFromBar: availableFromBar})
+}
+
+// ReceiveCoarseValueAtTime consumes a value of the coarse timeframe indicator that is available to the fine bars
+// dated at or after availableFrom, typically the close of the coarse bar, the coarse values must be received in order
+func (ind *BroadcastWithoutStorage) ReceiveCoarseValueAtTime(value float64, availableFrom time.Time) {
+	ind.pending.PushBack(broadcastValue{value: value, byTime: true, availableFrom: availableFrom})
+}
+
+// ReceiveDOHLCVTick consumes a fine timeframe source data DOHLCV price tick
+func (ind *BroadcastWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	for ind.pending.Len() > 0 {
+		var first = ind.pending.Front()
+		var coarse = first.Value.(broadcastValue)
+
+		// the coarse value is not yet available as of this fine bar, nor are any after it
+		if (coarse.byTime && tickData.D().Before(coarse.availableFrom)) || (!coarse.byTime && streamBarIndex < coarse.availableFromBar) {
+			break
+		}
+
+		ind.currentValue = coarse.value
+		ind.hasCurrentValue = true
+		ind.pending.Remove(first)
+	}
+
+	if ind.hasCurrentValue {
+		ind.UpdateIndicatorWithNewValue(ind.currentValue, streamBarIndex)
+	}
+}
+
+// A Broadcast (Broadcast)
+type Broadcast struct {
+	*BroadcastWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewBroadcast creates a Broadcast (Broadcast) for online usage
+func NewBroadcast() (indicator *Broadcast, err error) {
+	ind := Broadcast{}
+	ind.BroadcastWithoutStorage, err = NewBroadcastWithoutStorage(func(dataItem float64, streamBarIndex int) {
+		ind.Data = append(ind.Data, dataItem)
+	})
+
+	return &ind, err
+}
+
+// NewBroadcastForStream creates a Broadcast (Broadcast) for online usage with a fine timeframe source data stream
+func NewBroadcastForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Broadcast, err error) {
+	ind, err := NewBroadcast()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ValuesInRange returns the Broadcast results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Broadcast) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Broadcast results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Broadcast) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
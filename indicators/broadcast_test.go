@@ -0,0 +1,127 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a broadcastwithoutstorage", func() {
+	var (
+		indicator      *indicators.BroadcastWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewBroadcastWithoutStorage(nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when broadcasting a daily indicator (broadcast) onto hourly source data", func() {
+	var (
+		barsPerDay int = 6
+		days       int = 5
+		indicator  *indicators.Broadcast
+		start      = time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		fineBars   []gotrade.DOHLCV
+	)
+
+	// the hourly bars from 10:00 of each day, each closing at the day number
+	for day := 0; day < days; day++ {
+		for hour := 0; hour < barsPerDay; hour++ {
+			price := float64(day + 1)
+			fineBars = append(fineBars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, day).Add(time.Duration(10+hour)*time.Hour), price, price, price, price, 100.0))
+		}
+	}
+
+	// the value of a daily indicator for each day, the daily close
+	dailyValue := func(day int) float64 {
+		return float64(day + 1)
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewBroadcast()
+	})
+
+	Context("and the daily values are aligned by the close of their day", func() {
+		BeforeEach(func() {
+			// all of the daily values are received up front, as with offline source data
+			for day := 0; day < days; day++ {
+				indicator.ReceiveCoarseValueAtTime(dailyValue(day), start.AddDate(0, 0, day+1))
+			}
+			for i := range fineBars {
+				indicator.ReceiveDOHLCVTick(fineBars[i], i+1)
+			}
+		})
+
+		It("should have no results during the first day", func() {
+			Expect(indicator.ValidFromBar()).To(Equal(barsPerDay + 1))
+			Expect(len(indicator.Data)).To(Equal(len(fineBars) - barsPerDay))
+		})
+
+		It("each hourly bar should have the value of the previous day only", func() {
+			for i := range indicator.Data {
+				bar := fineBars[i+barsPerDay]
+				day := int(bar.D().Sub(start).Hours() / 24)
+				Expect(indicator.Data[i]).To(Equal(dailyValue(day - 1)))
+			}
+		})
+
+		It("the value of a day should not be seen by any hourly bar of that day", func() {
+			for i := range indicator.Data {
+				Expect(indicator.Data[i]).To(BeNumerically("<", fineBars[i+barsPerDay].C()))
+			}
+		})
+	})
+
+	Context("and the daily values are aligned by the hourly bar index", func() {
+		BeforeEach(func() {
+			for day := 0; day < days; day++ {
+				indicator.ReceiveCoarseValue(dailyValue(day), (day+1)*barsPerDay+1)
+			}
+			for i := range fineBars {
+				indicator.ReceiveDOHLCVTick(fineBars[i], i+1)
+			}
+		})
+
+		It("each hourly bar should have the value of the previous day only", func() {
+			Expect(indicator.ValidFromBar()).To(Equal(barsPerDay + 1))
+			for i := range indicator.Data {
+				day := (i + barsPerDay) / barsPerDay
+				Expect(indicator.Data[i]).To(Equal(dailyValue(day - 1)))
+			}
+		})
+	})
+
+	Context("and the daily values are received as their day completes", func() {
+		BeforeEach(func() {
+			for i := range fineBars {
+				day := i / barsPerDay
+				if i > 0 && i%barsPerDay == 0 {
+					indicator.ReceiveCoarseValueAtTime(dailyValue(day-1), start.AddDate(0, 0, day))
+				}
+				indicator.ReceiveDOHLCVTick(fineBars[i], i+1)
+			}
+		})
+
+		It("the value should change at the first hourly bar of each day", func() {
+			Expect(len(indicator.Data)).To(Equal(len(fineBars) - barsPerDay))
+			for i := 1; i < len(indicator.Data); i++ {
+				if (i+barsPerDay)%barsPerDay == 0 {
+					Expect(indicator.Data[i]).To(Equal(indicator.Data[i-1] + 1.0))
+				} else {
+					Expect(indicator.Data[i]).To(Equal(indicator.Data[i-1]))
+				}
+			}
+		})
+	})
+})
@@ -0,0 +1,165 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"strconv"
+)
+
+// A BwMfiState is the Bill Williams classification of a bar by the change of the BwMfi and of the volume from
+// the previous bar, an unchanged BwMfi or volume is treated as a fall
+type BwMfiState int
+
+const (
+	// the first bar, without a previous bar to compare to
+	BwMfiStateNone BwMfiState = iota
+	// the BwMfi and the volume are both up, the market is moving with participation
+	BwMfiStateGreen
+	// the BwMfi and the volume are both down, interest in the market is fading
+	BwMfiStateFade
+	// the BwMfi is up but the volume is down, the move lacks participation
+	BwMfiStateFake
+	// the BwMfi is down but the volume is up, a battle that often precedes a breakout
+	BwMfiStateSquat
+)
+
+type ValueAvailableActionBwMfi func(dataItemMfi float64, dataItemState BwMfiState, streamBarIndex int)
+
+// A Bill Williams Market Facilitation Index Indicator (BwMfi), no storage, for use in other indicators
+// the price range moved per unit of volume, (high - low) / volume, classified by its change and the change of the
+// volume from the previous bar. A result of 0.0 is given for a bar without volume
+type BwMfiWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionBwMfi
+	periodCounter        int
+	previousMfi          float64
+	previousVolume       float64
+	currentState         BwMfiState
+}
+
+// NewBwMfiWithoutStorage creates a Bill Williams Market Facilitation Index Indicator (BwMfi) without storage
+func NewBwMfiWithoutStorage(valueAvailableAction ValueAvailableActionBwMfi) (indicator *BwMfiWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	ind := BwMfiWithoutStorage{
+		baseIndicator:        newBaseIndicator(lookback),
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		periodCounter:        -1,
+	}
+
+	return &ind, nil
+}
+
+// CurrentState returns the classification of the last result
+func (ind *BwMfiWithoutStorage) CurrentState() BwMfiState {
+	return ind.currentState
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *BwMfiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.periodCounter += 1
+
+	var mfi float64 = 0.0
+	if tickData.V() != 0.0 {
+		mfi = (tickData.H() - tickData.L()) / tickData.V()
+	}
+
+	state := BwMfiStateNone
+	if ind.periodCounter > 0 {
+		mfiUp := mfi > ind.previousMfi
+		volumeUp := tickData.V() > ind.previousVolume
+
+		if mfiUp && volumeUp {
+			state = BwMfiStateGreen
+		} else if mfiUp {
+			state = BwMfiStateFake
+		} else if volumeUp {
+			state = BwMfiStateSquat
+		} else {
+			state = BwMfiStateFade
+		}
+	}
+
+	ind.previousMfi = mfi
+	ind.previousVolume = tickData.V()
+	ind.currentState = state
+
+	result := ind.quantize(mfi)
+
+	ind.UpdateMinMax(result, result)
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(result, state, streamBarIndex)
+}
+
+// A Bill Williams Market Facilitation Index Indicator (BwMfi)
+type BwMfi struct {
+	*BwMfiWithoutStorage
+
+	// public variables
+	Mfi   []float64
+	State []BwMfiState
+}
+
+// NewBwMfi creates a Bill Williams Market Facilitation Index Indicator (BwMfi) for online usage
+func NewBwMfi() (indicator *BwMfi, err error) {
+	ind := BwMfi{}
+	ind.BwMfiWithoutStorage, err = NewBwMfiWithoutStorage(
+		func(dataItemMfi float64, dataItemState BwMfiState, streamBarIndex int) {
+			ind.Mfi = append(ind.Mfi, dataItemMfi)
+			ind.State = append(ind.State, dataItemState)
+		})
+
+	return &ind, err
+}
+
+// NewBwMfiWithSrcLen creates a Bill Williams Market Facilitation Index Indicator (BwMfi) for offline usage
+func NewBwMfiWithSrcLen(sourceLength uint) (indicator *BwMfi, err error) {
+	ind, err := NewBwMfi()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Mfi = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.State = make([]BwMfiState, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewBwMfiForStream creates a Bill Williams Market Facilitation Index Indicator (BwMfi) for online usage with a source data stream
+func NewBwMfiForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *BwMfi, err error) {
+	ind, err := NewBwMfi()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewBwMfiForStreamWithSrcLen creates a Bill Williams Market Facilitation Index Indicator (BwMfi) for offline usage with a source data stream
+func NewBwMfiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *BwMfi, err error) {
+	ind, err := NewBwMfiWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the BwMfi results as rows after a header of barIndex,mfi,state, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *BwMfi) WriteCSV(w io.Writer, startBarOffset int) error {
+	state := csvColumn{name: "state", length: len(ind.State), format: func(index int) string {
+		return strconv.Itoa(int(ind.State[index]))
+	}}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("mfi", ind.Mfi), state)
+}
@@ -0,0 +1,105 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a bwmfiwithoutstorage", func() {
+	Context("and the indicator was not given a value available action", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			indicator, err := indicators.NewBwMfiWithoutStorage(nil)
+			Expect(indicator).To(BeNil())
+			Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating a market facilitation index (bwmfi) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.BwMfi
+	)
+
+	// a bar around the price of 100 with the range and volume
+	newBar := func(barRange float64, volume float64) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(time.Now(), 100.0, 100.0+barRange/2.0, 100.0-barRange/2.0, 100.0, volume)
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewBwMfi()
+	})
+
+	It("should have a result from the first bar, without a state", func() {
+		indicator.ReceiveDOHLCVTick(newBar(2.0, 1000.0), 1)
+
+		Expect(indicator.GetLookbackPeriod()).To(Equal(0))
+		Expect(indicator.ValidFromBar()).To(Equal(1))
+		Expect(indicator.Mfi).To(Equal([]float64{0.002}))
+		Expect(indicator.State).To(Equal([]indicators.BwMfiState{indicators.BwMfiStateNone}))
+	})
+
+	It("should be 0 for a bar without volume", func() {
+		indicator.ReceiveDOHLCVTick(newBar(2.0, 0.0), 1)
+
+		Expect(indicator.Mfi).To(Equal([]float64{0.0}))
+	})
+
+	It("should be green when the mfi and the volume are both up", func() {
+		indicator.ReceiveDOHLCVTick(newBar(2.0, 1000.0), 1)
+		indicator.ReceiveDOHLCVTick(newBar(6.0, 2000.0), 2)
+
+		Expect(indicator.Mfi[1]).To(BeNumerically(">", indicator.Mfi[0]))
+		Expect(indicator.CurrentState()).To(Equal(indicators.BwMfiStateGreen))
+	})
+
+	It("should fade when the mfi and the volume are both down", func() {
+		indicator.ReceiveDOHLCVTick(newBar(6.0, 2000.0), 1)
+		indicator.ReceiveDOHLCVTick(newBar(1.0, 1000.0), 2)
+
+		Expect(indicator.Mfi[1]).To(BeNumerically("<", indicator.Mfi[0]))
+		Expect(indicator.CurrentState()).To(Equal(indicators.BwMfiStateFade))
+	})
+
+	It("should be fake when the mfi is up but the volume is down", func() {
+		indicator.ReceiveDOHLCVTick(newBar(2.0, 2000.0), 1)
+		indicator.ReceiveDOHLCVTick(newBar(2.0, 1000.0), 2)
+
+		Expect(indicator.Mfi[1]).To(BeNumerically(">", indicator.Mfi[0]))
+		Expect(indicator.CurrentState()).To(Equal(indicators.BwMfiStateFake))
+	})
+
+	It("should squat when the mfi is down but the volume is up", func() {
+		indicator.ReceiveDOHLCVTick(newBar(2.0, 1000.0), 1)
+		indicator.ReceiveDOHLCVTick(newBar(2.0, 2000.0), 2)
+
+		Expect(indicator.Mfi[1]).To(BeNumerically("<", indicator.Mfi[0]))
+		Expect(indicator.CurrentState()).To(Equal(indicators.BwMfiStateSquat))
+	})
+
+	It("should store the state of each bar", func() {
+		ranges := []float64{2.0, 6.0, 1.0, 1.0, 1.0}
+		volumes := []float64{1000.0, 2000.0, 1000.0, 500.0, 1000.0}
+		for i := range ranges {
+			indicator.ReceiveDOHLCVTick(newBar(ranges[i], volumes[i]), i+1)
+		}
+
+		Expect(indicator.State).To(Equal([]indicators.BwMfiState{
+			indicators.BwMfiStateNone,
+			indicators.BwMfiStateGreen,
+			indicators.BwMfiStateFade,
+			indicators.BwMfiStateFake,
+			indicators.BwMfiStateSquat}))
+		Expect(indicator.Mfi).To(HaveLen(len(ranges)))
+	})
+})
+
+var _ = Describe("when creating a market facilitation index (bwmfi) for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewBwMfiForStream(stream)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
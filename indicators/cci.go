@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 	"math"
 )
 
@@ -164,3 +165,15 @@ func (ind *Cci) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	// add it to the average
 	ind.typicalPriceAvg.ReceiveTick(typicalPrice, streamBarIndex)
 }
+
+// ValuesInRange returns the Cci results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Cci) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Cci results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Cci) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,130 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+var (
+	ErrChainHasNoStages = errors.New("A Chain requires at least one ChainStage")
+)
+
+// A ChainStage creates an indicator without storage for a chain, reporting its results to the given value available action, e.g.
+//	func(valueAvailableAction ValueAvailableActionFloat) (FloatIndicatorWithoutStorage, error) {
+//		return NewRsiWithoutStorage(14, valueAvailableAction)
+//	}
+type ChainStage func(valueAvailableAction ValueAvailableActionFloat) (FloatIndicatorWithoutStorage, error)
+
+// Pipe creates a value available action that passes each result, with its stream bar index, on to the sink indicator
+func Pipe(sink FloatIndicatorWithoutStorage) ValueAvailableActionFloat {
+	return func(dataItem float64, streamBarIndex int) {
+		sink.ReceiveTick(dataItem, streamBarIndex)
+	}
+}
+
+// A Chain of Indicators (Chain), no storage, for use in other indicators
+// each source data tick is received by the first stage, the results of each stage are piped into the next
+// and the results of the last stage are the results of the chain
+type ChainWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	stages []FloatIndicatorWithoutStorage
+}
+
+// NewChainWithoutStorage creates a Chain of Indicators (Chain) without storage
+func NewChainWithoutStorage(valueAvailableAction ValueAvailableActionFloat, stages ...ChainStage) (indicator *ChainWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	if len(stages) == 0 {
+		return nil, ErrChainHasNoStages
+	}
+
+	ind := ChainWithoutStorage{
+		stages: make([]FloatIndicatorWithoutStorage, len(stages)),
+	}
+
+	// the stages are created from the last, so that each stage can be piped into the stage after it
+	var stageAction ValueAvailableActionFloat = func(dataItem float64, streamBarIndex int) {
+		ind.UpdateIndicatorWithNewValue(dataItem, streamBarIndex)
+	}
+
+	lookback := 0
+	for i := len(stages) - 1; i >= 0; i-- {
+		stage, err := stages[i](stageAction)
+		if err != nil {
+			return nil, err
+		}
+
+		// each stage lags the stage before it by its own lookback period
+		lookback += stage.GetLookbackPeriod()
+		ind.stages[i] = stage
+		stageAction = Pipe(stage)
+	}
+
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction)
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *ChainWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.stages[0].ReceiveTick(tickData, streamBarIndex)
+}
+
+// A Chain of Indicators (Chain)
+type Chain struct {
+	*ChainWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewChain creates a Chain of Indicators (Chain) for online usage
+func NewChain(selectData gotrade.DOHLCVDataSelectionFunc, stages ...ChainStage) (indicator *Chain, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Chain{
+		selectData: selectData,
+	}
+
+	ind.ChainWithoutStorage, err = NewChainWithoutStorage(
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		}, stages...)
+
+	return &ind, err
+}
+
+// NewChainForStream creates a Chain of Indicators (Chain) for online usage with a source data stream
+func NewChainForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc, stages ...ChainStage) (indicator *Chain, err error) {
+	ind, err := NewChain(selectData, stages...)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Chain) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Chain results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Chain) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Chain results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Chain) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
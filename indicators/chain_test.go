@@ -0,0 +1,158 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var rsiChainStage indicators.ChainStage = func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+	return indicators.NewRsiWithoutStorage(14, valueAvailableAction)
+}
+
+var smaChainStage indicators.ChainStage = func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+	return indicators.NewSmaWithoutStorage(3, valueAvailableAction)
+}
+
+var _ = Describe("when creating a chainwithoutstorage", func() {
+	var (
+		indicator      *indicators.ChainWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewChainWithoutStorage(nil, rsiChainStage)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was not given any stages", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewChainWithoutStorage(fakeFloatValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrChainHasNoStages))
+		})
+	})
+
+	Context("and a stage could not be created", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewChainWithoutStorage(fakeFloatValAvailable, rsiChainStage,
+				func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+					return indicators.NewSmaWithoutStorage(1, valueAvailableAction)
+				})
+		})
+
+		It("the indicator should not be created and return the error of the stage", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).NotTo(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when calculating a chain of an sma of an rsi", func() {
+	var (
+		indicator *indicators.Chain
+		expected  []float64
+		inputs    IndicatorWithFloatBoundsSharedSpecInputs
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewChain(gotrade.UseClosePrice, rsiChainStage, smaChainStage)
+		inputs = NewIndicatorWithFloatBoundsSharedSpecInputs(indicator, len(sourceDOHLCVData), indicator,
+			func() float64 {
+				return GetFloatDataMax(indicator.Data)
+			},
+			func() float64 {
+				return GetFloatDataMin(indicator.Data)
+			})
+
+		// the same indicators wired together by hand
+		expected = []float64{}
+		sma, _ := indicators.NewSmaWithoutStorage(3, func(dataItem float64, streamBarIndex int) {
+			expected = append(expected, dataItem)
+		})
+		rsi, _ := indicators.NewRsiWithoutStorage(14, func(dataItem float64, streamBarIndex int) {
+			sma.ReceiveTick(dataItem, streamBarIndex)
+		})
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			rsi.ReceiveTick(sourceDOHLCVData[i].C(), i+1)
+		}
+	})
+
+	Context("and the indicator has not yet received any ticks", func() {
+		ShouldBeAnInitialisedIndicator(&inputs)
+
+		ShouldNotHaveAnyFloatBoundsSetYet(&inputs)
+	})
+
+	Context("and the indicator has received less ticks than the lookback period", func() {
+
+		BeforeEach(func() {
+			for i := 0; i < indicator.GetLookbackPeriod(); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		ShouldBeAnIndicatorThatHasReceivedFewerTicksThanItsLookbackPeriod(&inputs)
+
+		ShouldNotHaveAnyFloatBoundsSetYet(&inputs)
+	})
+
+	Context("and the indicator has received ticks equal to the lookback period", func() {
+
+		BeforeEach(func() {
+			for i := 0; i <= indicator.GetLookbackPeriod(); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		ShouldBeAnIndicatorThatHasReceivedTicksEqualToItsLookbackPeriod(&inputs)
+
+		ShouldHaveFloatBoundsSetToMinMaxOfResults(&inputs)
+	})
+
+	Context("and the indicator has recieved all of its ticks", func() {
+		BeforeEach(func() {
+			for i := 0; i < len(sourceDOHLCVData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		ShouldBeAnIndicatorThatHasReceivedAllOfItsTicks(&inputs)
+
+		ShouldHaveFloatBoundsSetToMinMaxOfResults(&inputs)
+
+		It("the lookback period should be the sum of the lookback periods of the stages", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(14 + 2))
+		})
+
+		It("the results should match the manually wired indicators", func() {
+			Expect(indicator.Data).To(Equal(expected))
+		})
+	})
+
+	Context("given the indicator is created via the standard constructor with a nil data selection func", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			nilIndicator, err := indicators.NewChain(nil, rsiChainStage, smaChainStage)
+			Expect(nilIndicator).To(BeNil())
+			Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream", func() {
+		It("should have requested to be attached to the stream", func() {
+			stream := newFakeDOHLCVStreamSubscriber()
+			streamIndicator, _ := indicators.NewChainForStream(stream, gotrade.UseClosePrice, rsiChainStage, smaChainStage)
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(streamIndicator))
+		})
+	})
+})
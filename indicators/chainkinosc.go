@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Chaikin Oscillator Indicator (ChaikinOsc), no storage, for use in other indicators
@@ -174,3 +175,15 @@ func NewDefaultChaikinOscForStreamWithSrcLen(sourceLength uint, priceStream gotr
 func (ind *ChaikinOsc) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.adl.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the ChaikinOsc results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *ChaikinOsc) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the ChaikinOsc results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *ChaikinOsc) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
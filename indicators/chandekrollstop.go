@@ -0,0 +1,214 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionChandeKrollStop func(dataItemHighStop float64, dataItemLowStop float64, streamBarIndex int)
+
+// A Chande Kroll Stop Indicator (ChandeKrollStop), no storage, for use in other indicators
+// the preliminary high stop is the highest high of the timePeriod less multiplier times the Atr of the timePeriod,
+// the preliminary low stop is the lowest low of the timePeriod plus multiplier times the Atr. The high stop is the
+// highest preliminary high stop of the stopPeriod, trailing below the price of an uptrend, the low stop is the
+// lowest preliminary low stop of the stopPeriod, trailing above the price of a downtrend
+type ChandeKrollStopWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionChandeKrollStop
+	multiplier           float64
+	atr                  *AtrWithoutStorage
+	highestHigh          *HhvWithoutStorage
+	lowestLow            *LlvWithoutStorage
+	highStops            *HhvWithoutStorage
+	lowStops             *LlvWithoutStorage
+	currentHighestHigh   float64
+	currentLowestLow     float64
+	currentHighStop      float64
+}
+
+// NewChandeKrollStopWithoutStorage creates a Chande Kroll Stop Indicator (ChandeKrollStop) without storage
+func NewChandeKrollStopWithoutStorage(timePeriod int, multiplier float64, stopPeriod int, valueAvailableAction ValueAvailableActionChandeKrollStop) (indicator *ChandeKrollStopWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum multiplier for this indicator is 0
+	if multiplier < 0.0 {
+		return nil, errors.New("multiplier is less than the minimum (0)")
+	}
+
+	// check the maximum multiplier
+	if multiplier > math.MaxFloat64 {
+		return nil, errors.New("multiplier is greater than the maximum float64 size")
+	}
+
+	// the minimum stopPeriod for this indicator is 1
+	if stopPeriod < 1 {
+		return nil, errors.New("stopPeriod is less than the minimum (1)")
+	}
+
+	// check the maximum stopPeriod
+	if stopPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("stopPeriod is greater than the maximum (100000)")
+	}
+
+	ind := ChandeKrollStopWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		multiplier:           multiplier,
+	}
+
+	ind.highestHigh, err = NewHhvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentHighestHigh = dataItem
+	})
+
+	ind.lowestLow, err = NewLlvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentLowestLow = dataItem
+	})
+
+	// the Atr is the last of the period indicators to have a value, the highest high and lowest low are current
+	ind.atr, err = NewAtrWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.highStops.ReceiveTick(ind.currentHighestHigh-ind.multiplier*dataItem, streamBarIndex)
+		ind.lowStops.ReceiveTick(ind.currentLowestLow+ind.multiplier*dataItem, streamBarIndex)
+	})
+
+	ind.highStops, err = NewHhvWithoutStorage(stopPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentHighStop = dataItem
+	})
+
+	ind.lowStops, err = NewLlvWithoutStorage(stopPeriod, func(dataItem float64, streamBarIndex int) {
+		highStop := ind.quantize(ind.currentHighStop)
+		lowStop := ind.quantize(dataItem)
+
+		ind.UpdateMinMax(math.Min(highStop, lowStop), math.Max(highStop, lowStop))
+
+		ind.IncDataLength()
+
+		ind.SetValidFromBar(streamBarIndex)
+
+		// notify of a new result value though the value available action
+		ind.valueAvailableAction(highStop, lowStop, streamBarIndex)
+	})
+
+	lookback := ind.atr.GetLookbackPeriod() + ind.highStops.GetLookbackPeriod()
+	ind.baseIndicator = newBaseIndicator(lookback)
+
+	return &ind, err
+}
+
+// A Chande Kroll Stop Indicator (ChandeKrollStop)
+type ChandeKrollStop struct {
+	*ChandeKrollStopWithoutStorage
+
+	// public variables
+	HighStop []float64
+	LowStop  []float64
+}
+
+// NewChandeKrollStop creates a Chande Kroll Stop Indicator (ChandeKrollStop) for online usage
+func NewChandeKrollStop(timePeriod int, multiplier float64, stopPeriod int) (indicator *ChandeKrollStop, err error) {
+	ind := ChandeKrollStop{}
+	ind.ChandeKrollStopWithoutStorage, err = NewChandeKrollStopWithoutStorage(timePeriod, multiplier, stopPeriod, func(dataItemHighStop float64, dataItemLowStop float64, streamBarIndex int) {
+		ind.HighStop = append(ind.HighStop, dataItemHighStop)
+		ind.LowStop = append(ind.LowStop, dataItemLowStop)
+	})
+
+	return &ind, err
+}
+
+// NewDefaultChandeKrollStop creates a Chande Kroll Stop Indicator (ChandeKrollStop) for online usage with default parameters
+//	- timePeriod: 10
+//	- multiplier: 1.0
+//	- stopPeriod: 9
+func NewDefaultChandeKrollStop() (indicator *ChandeKrollStop, err error) {
+	timePeriod := 10
+	multiplier := 1.0
+	stopPeriod := 9
+	return NewChandeKrollStop(timePeriod, multiplier, stopPeriod)
+}
+
+// NewChandeKrollStopWithSrcLen creates a Chande Kroll Stop Indicator (ChandeKrollStop) for offline usage
+func NewChandeKrollStopWithSrcLen(sourceLength uint, timePeriod int, multiplier float64, stopPeriod int) (indicator *ChandeKrollStop, err error) {
+	ind, err := NewChandeKrollStop(timePeriod, multiplier, stopPeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.HighStop = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.LowStop = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultChandeKrollStopWithSrcLen creates a Chande Kroll Stop Indicator (ChandeKrollStop) for offline usage with default parameters
+func NewDefaultChandeKrollStopWithSrcLen(sourceLength uint) (indicator *ChandeKrollStop, err error) {
+	ind, err := NewDefaultChandeKrollStop()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.HighStop = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.LowStop = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewChandeKrollStopForStream creates a Chande Kroll Stop Indicator (ChandeKrollStop) for online usage with a source data stream
+func NewChandeKrollStopForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, multiplier float64, stopPeriod int) (indicator *ChandeKrollStop, err error) {
+	ind, err := NewChandeKrollStop(timePeriod, multiplier, stopPeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultChandeKrollStopForStream creates a Chande Kroll Stop Indicator (ChandeKrollStop) for online usage with a source data stream
+func NewDefaultChandeKrollStopForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ChandeKrollStop, err error) {
+	ind, err := NewDefaultChandeKrollStop()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewChandeKrollStopForStreamWithSrcLen creates a Chande Kroll Stop Indicator (ChandeKrollStop) for offline usage with a source data stream
+func NewChandeKrollStopForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, multiplier float64, stopPeriod int) (indicator *ChandeKrollStop, err error) {
+	ind, err := NewChandeKrollStopWithSrcLen(sourceLength, timePeriod, multiplier, stopPeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultChandeKrollStopForStreamWithSrcLen creates a Chande Kroll Stop Indicator (ChandeKrollStop) for offline usage with a source data stream
+func NewDefaultChandeKrollStopForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ChandeKrollStop, err error) {
+	ind, err := NewDefaultChandeKrollStopWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the ChandeKrollStop results as rows after a header of barIndex,highStop,lowStop, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *ChandeKrollStop) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("highStop", ind.HighStop), floatCSVColumn("lowStop", ind.LowStop))
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *ChandeKrollStopWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.highestHigh.ReceiveTick(tickData.H(), streamBarIndex)
+	ind.lowestLow.ReceiveTick(tickData.L(), streamBarIndex)
+	ind.atr.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
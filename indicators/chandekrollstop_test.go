@@ -0,0 +1,151 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a chandekrollstopwithoutstorage", func() {
+	var (
+		indicator      *indicators.ChandeKrollStopWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemHighStop float64, dataItemLowStop float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewChandeKrollStopWithoutStorage(10, 1.0, 9, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewChandeKrollStopWithoutStorage(0, 1.0, 9, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a multiplier below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewChandeKrollStopWithoutStorage(10, -1.0, 9, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a stopPeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewChandeKrollStopWithoutStorage(10, 1.0, indicators.MaximumLookbackPeriod+1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a chande kroll stop (chandekrollstop) with DOHLCV source data", func() {
+	var (
+		period     int = 5
+		stopPeriod int = 3
+		indicator  *indicators.ChandeKrollStop
+		bars       []gotrade.DOHLCV
+	)
+
+	// a series trending by step each bar with a range of 2.0
+	trendingBars := func(step float64) []gotrade.DOHLCV {
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		result := []gotrade.DOHLCV{}
+		for i := 0; i < 40; i++ {
+			price := 100.0 + step*float64(i)
+			result = append(result, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price+1.0, price-1.0, price, 1000.0))
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewChandeKrollStop(period, 1.0, stopPeriod)
+	})
+
+	It("the defaulted parameters should be applied", func() {
+		indicator, _ = indicators.NewDefaultChandeKrollStop()
+		Expect(indicator.GetLookbackPeriod()).To(Equal(10 + 9 - 1))
+	})
+
+	Context("and the source data is an uptrend", func() {
+		BeforeEach(func() {
+			bars = trendingBars(1.0)
+			for i := range bars {
+				indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			}
+		})
+
+		It("should produce a result for each bar after the lookback period", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(period + stopPeriod - 1))
+			Expect(len(indicator.HighStop)).To(Equal(len(bars) - indicator.GetLookbackPeriod()))
+			Expect(len(indicator.LowStop)).To(Equal(len(bars) - indicator.GetLookbackPeriod()))
+			Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		})
+
+		It("the high stop should trail upward below the price", func() {
+			for i := range indicator.HighStop {
+				bar := bars[i+indicator.GetLookbackPeriod()]
+				// the true range is 2.0 after the first bar, the highest high is that of the bar
+				Expect(indicator.HighStop[i]).To(BeNumerically("~", bar.H()-2.0, 1e-9))
+				Expect(indicator.HighStop[i]).To(BeNumerically("<", bar.C()))
+				if i > 0 {
+					Expect(indicator.HighStop[i]).To(BeNumerically(">", indicator.HighStop[i-1]))
+				}
+			}
+		})
+
+		It("the low stop should be the lowest preliminary low stop of the stop period", func() {
+			for i := range indicator.LowStop {
+				// the oldest preliminary low stop of the stop period, the lowest low of its period plus the true range
+				firstBar := i + indicator.GetLookbackPeriod() - (stopPeriod - 1)
+				Expect(indicator.LowStop[i]).To(BeNumerically("~", bars[firstBar-(period-1)].L()+2.0, 1e-9))
+			}
+		})
+
+		It("the bounds should be the bounds of the stops", func() {
+			Expect(indicator.MaxValue()).To(Equal(GetFloatDataMax(indicator.HighStop)))
+			Expect(indicator.MinValue()).To(Equal(GetFloatDataMin(indicator.LowStop)))
+		})
+	})
+
+	Context("and the source data is a downtrend", func() {
+		BeforeEach(func() {
+			bars = trendingBars(-1.0)
+			for i := range bars {
+				indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			}
+		})
+
+		It("the low stop should trail downward above the price", func() {
+			for i := range indicator.LowStop {
+				bar := bars[i+indicator.GetLookbackPeriod()]
+				Expect(indicator.LowStop[i]).To(BeNumerically("~", bar.L()+2.0, 1e-9))
+				Expect(indicator.LowStop[i]).To(BeNumerically(">", bar.C()))
+				if i > 0 {
+					Expect(indicator.LowStop[i]).To(BeNumerically("<", indicator.LowStop[i-1]))
+				}
+			}
+		})
+	})
+})
@@ -0,0 +1,174 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// A Choppiness Index Indicator (Choppiness), no storage, for use in other indicators
+// the sum of the TrueRange over the timePeriod relative to the range of the highest high and lowest low over the
+// same bars, 100 * log10(sum(trueRange) / (highestHigh - lowestLow)) / log10(timePeriod). The result is near 100
+// for a sideways market, where the bars overlap, and near 0 for a trending market. A window without a range is
+// taken as sideways and gives 100
+type ChoppinessWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	trueRange   *TrueRangeWithoutStorage
+	sum         *SumWithoutStorage
+	hhv         *HhvWithoutStorage
+	llv         *LlvWithoutStorage
+	highestHigh float64
+	lowestLow   float64
+	timePeriod  int
+}
+
+// NewChoppinessWithoutStorage creates a Choppiness Index Indicator (Choppiness) without storage
+func NewChoppinessWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *ChoppinessWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod
+	ind := ChoppinessWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		timePeriod:                   timePeriod,
+	}
+
+	ind.hhv, err = NewHhvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.highestHigh = dataItem
+	})
+
+	ind.llv, err = NewLlvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.lowestLow = dataItem
+	})
+
+	ind.sum, err = NewSumWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		result := 100.0
+		highLowRange := ind.highestHigh - ind.lowestLow
+		if highLowRange > 0.0 {
+			result = 100.0 * math.Log10(dataItem/highLowRange) / math.Log10(float64(ind.timePeriod))
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	})
+
+	ind.trueRange, err = NewTrueRangeWithoutStorage(func(dataItem float64, streamBarIndex int) {
+		ind.sum.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	return &ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *ChoppinessWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// the highest high and lowest low of the bar are received before its true range
+	ind.hhv.ReceiveTick(tickData.H(), streamBarIndex)
+	ind.llv.ReceiveTick(tickData.L(), streamBarIndex)
+	ind.trueRange.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// A Choppiness Index Indicator (Choppiness)
+type Choppiness struct {
+	*ChoppinessWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewChoppiness creates a Choppiness Index Indicator (Choppiness) for online usage
+func NewChoppiness(timePeriod int) (indicator *Choppiness, err error) {
+	ind := Choppiness{}
+
+	ind.ChoppinessWithoutStorage, err = NewChoppinessWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultChoppiness creates a Choppiness Index Indicator (Choppiness) for online usage with default parameters
+//	- timePeriod: 14
+func NewDefaultChoppiness() (indicator *Choppiness, err error) {
+	timePeriod := 14
+	return NewChoppiness(timePeriod)
+}
+
+// NewChoppinessWithSrcLen creates a Choppiness Index Indicator (Choppiness) for offline usage
+func NewChoppinessWithSrcLen(sourceLength uint, timePeriod int) (indicator *Choppiness, err error) {
+	ind, err := NewChoppiness(timePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultChoppinessWithSrcLen creates a Choppiness Index Indicator (Choppiness) for offline usage with default parameters
+func NewDefaultChoppinessWithSrcLen(sourceLength uint) (indicator *Choppiness, err error) {
+	ind, err := NewDefaultChoppiness()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewChoppinessForStream creates a Choppiness Index Indicator (Choppiness) for online usage with a source data stream
+func NewChoppinessForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Choppiness, err error) {
+	ind, err := NewChoppiness(timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultChoppinessForStream creates a Choppiness Index Indicator (Choppiness) for online usage with a source data stream
+func NewDefaultChoppinessForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Choppiness, err error) {
+	ind, err := NewDefaultChoppiness()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewChoppinessForStreamWithSrcLen creates a Choppiness Index Indicator (Choppiness) for offline usage with a source data stream
+func NewChoppinessForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Choppiness, err error) {
+	ind, err := NewChoppinessWithSrcLen(sourceLength, timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultChoppinessForStreamWithSrcLen creates a Choppiness Index Indicator (Choppiness) for offline usage with a source data stream
+func NewDefaultChoppinessForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Choppiness, err error) {
+	ind, err := NewDefaultChoppinessWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ValuesInRange returns the Choppiness results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Choppiness) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Choppiness results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Choppiness) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,193 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// A Coefficient of Variation Indicator (CoeffVar), no storage, for use in other indicators
+// the standard deviation of the period divided by its mean, optionally as a percentage,
+// a result of 0.0 is given for a period with a mean of zero
+type CoeffVarWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodHistory *list.List
+	periodTotal   float64
+	periodTotalSq float64
+	timePeriod    int
+	asPercent     bool
+}
+
+// NewCoeffVarWithoutStorage creates a Coefficient of Variation Indicator (CoeffVar) without storage
+func NewCoeffVarWithoutStorage(timePeriod int, asPercent bool, valueAvailableAction ValueAvailableActionFloat) (indicator *CoeffVarWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := CoeffVarWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		timePeriod:                   timePeriod,
+		asPercent:                    asPercent,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *CoeffVarWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.periodHistory.PushBack(tickData)
+	ind.periodTotal += tickData
+	ind.periodTotalSq += tickData * tickData
+
+	if ind.periodHistory.Len() > ind.timePeriod {
+		var first = ind.periodHistory.Front()
+		var firstValue = first.Value.(float64)
+		ind.periodTotal -= firstValue
+		ind.periodTotalSq -= firstValue * firstValue
+		ind.periodHistory.Remove(first)
+	}
+
+	if ind.periodHistory.Len() == ind.timePeriod {
+		mean := ind.periodTotal / float64(ind.timePeriod)
+		variance := ind.periodTotalSq/float64(ind.timePeriod) - mean*mean
+
+		// rounding in the running sums can leave a flat period with a tiny negative variance
+		if variance < 0.0 {
+			variance = 0.0
+		}
+
+		var result float64 = 0.0
+		if mean != 0.0 {
+			result = math.Sqrt(variance) / mean
+			if ind.asPercent {
+				result *= 100.0
+			}
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// A Coefficient of Variation Indicator (CoeffVar)
+type CoeffVar struct {
+	*CoeffVarWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewCoeffVar creates a Coefficient of Variation Indicator (CoeffVar) for online usage
+func NewCoeffVar(timePeriod int, asPercent bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *CoeffVar, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := CoeffVar{
+		selectData: selectData,
+	}
+
+	ind.CoeffVarWithoutStorage, err = NewCoeffVarWithoutStorage(timePeriod, asPercent,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultCoeffVar creates a Coefficient of Variation Indicator (CoeffVar) for online usage with default parameters
+//	- timePeriod: 20
+//	- asPercent: false
+func NewDefaultCoeffVar() (indicator *CoeffVar, err error) {
+	timePeriod := 20
+	asPercent := false
+	return NewCoeffVar(timePeriod, asPercent, gotrade.UseClosePrice)
+}
+
+// NewCoeffVarWithSrcLen creates a Coefficient of Variation Indicator (CoeffVar) for offline usage
+func NewCoeffVarWithSrcLen(sourceLength uint, timePeriod int, asPercent bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *CoeffVar, err error) {
+	ind, err := NewCoeffVar(timePeriod, asPercent, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultCoeffVarWithSrcLen creates a Coefficient of Variation Indicator (CoeffVar) for offline usage with default parameters
+func NewDefaultCoeffVarWithSrcLen(sourceLength uint) (indicator *CoeffVar, err error) {
+	ind, err := NewDefaultCoeffVar()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewCoeffVarForStream creates a Coefficient of Variation Indicator (CoeffVar) for online usage with a source data stream
+func NewCoeffVarForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, asPercent bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *CoeffVar, err error) {
+	ind, err := NewCoeffVar(timePeriod, asPercent, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultCoeffVarForStream creates a Coefficient of Variation Indicator (CoeffVar) for online usage with a source data stream
+func NewDefaultCoeffVarForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *CoeffVar, err error) {
+	ind, err := NewDefaultCoeffVar()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewCoeffVarForStreamWithSrcLen creates a Coefficient of Variation Indicator (CoeffVar) for offline usage with a source data stream
+func NewCoeffVarForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, asPercent bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *CoeffVar, err error) {
+	ind, err := NewCoeffVarWithSrcLen(sourceLength, timePeriod, asPercent, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultCoeffVarForStreamWithSrcLen creates a Coefficient of Variation Indicator (CoeffVar) for offline usage with a source data stream
+func NewDefaultCoeffVarForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *CoeffVar, err error) {
+	ind, err := NewDefaultCoeffVarWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *CoeffVar) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the CoeffVar results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *CoeffVar) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the CoeffVar results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *CoeffVar) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
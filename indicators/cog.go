@@ -0,0 +1,181 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Center of Gravity Oscillator (Cog), no storage, for use in other indicators
+// Ehlers' center of gravity of the last timePeriod values, -sum((1 + i) * value[i]) / sum(value[i]) where value[0]
+// is the latest value, which turns ahead of the turns of a cycle in the values with little lag. The weighted and
+// plain sums are updated as the values enter and leave the period, a result of 0.0 is given while the sum is 0.0
+type CogWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodHistory *list.List
+	sum           float64
+	weightedSum   float64
+	timePeriod    int
+}
+
+// NewCogWithoutStorage creates a Center of Gravity Oscillator (Cog) without storage
+func NewCogWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *CogWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := CogWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *CogWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if ind.periodHistory.Len() == ind.timePeriod {
+		// the oldest value, of weight timePeriod, leaves the period
+		var first = ind.periodHistory.Front()
+		oldest := first.Value.(float64)
+		ind.periodHistory.Remove(first)
+
+		ind.weightedSum -= float64(ind.timePeriod) * oldest
+		ind.sum -= oldest
+	}
+
+	// the weight of each of the values in the period increases by one and the new value has a weight of one
+	ind.weightedSum += ind.sum + tickData
+	ind.sum += tickData
+	ind.periodHistory.PushBack(tickData)
+
+	if ind.periodHistory.Len() == ind.timePeriod {
+		var result float64 = 0.0
+		if ind.sum != 0.0 {
+			result = -ind.weightedSum / ind.sum
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// A Center of Gravity Oscillator (Cog)
+type Cog struct {
+	*CogWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewCog creates a Center of Gravity Oscillator (Cog) for online usage
+func NewCog(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Cog, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Cog{
+		selectData: selectData,
+	}
+
+	ind.CogWithoutStorage, err = NewCogWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultCog creates a Center of Gravity Oscillator (Cog) for online usage with default parameters
+//	- timePeriod: 10
+func NewDefaultCog() (indicator *Cog, err error) {
+	timePeriod := 10
+	return NewCog(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewCogWithSrcLen creates a Center of Gravity Oscillator (Cog) for offline usage
+func NewCogWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Cog, err error) {
+	ind, err := NewCog(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultCogWithSrcLen creates a Center of Gravity Oscillator (Cog) for offline usage with default parameters
+func NewDefaultCogWithSrcLen(sourceLength uint) (indicator *Cog, err error) {
+	ind, err := NewDefaultCog()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewCogForStream creates a Center of Gravity Oscillator (Cog) for online usage with a source data stream
+func NewCogForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Cog, err error) {
+	ind, err := NewCog(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultCogForStream creates a Center of Gravity Oscillator (Cog) for online usage with a source data stream
+func NewDefaultCogForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Cog, err error) {
+	ind, err := NewDefaultCog()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewCogForStreamWithSrcLen creates a Center of Gravity Oscillator (Cog) for offline usage with a source data stream
+func NewCogForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Cog, err error) {
+	ind, err := NewCogWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultCogForStreamWithSrcLen creates a Center of Gravity Oscillator (Cog) for offline usage with a source data stream
+func NewDefaultCogForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Cog, err error) {
+	ind, err := NewDefaultCogWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Cog) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Cog results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Cog) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Cog results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Cog) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
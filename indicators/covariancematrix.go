@@ -0,0 +1,248 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"strconv"
+)
+
+type ValueAvailableActionCovarianceMatrix func(dataItem [][]float64, streamBarIndex int)
+
+// the ticks of each stream received for a bar of a covariance matrix, until the bar is received by all streams
+type covarianceMatrixBar struct {
+	values   []float64
+	received []bool
+	count    int
+}
+
+// A Covariance Matrix Indicator (CovarianceMatrix), no storage, for use in other indicators
+// the pairwise rolling covariance of a basket of streams over the timePeriod, a streamCount x streamCount
+// symmetric matrix, with the variance of each stream on the diagonal. The ticks of the streams are aligned by
+// their streamBarIndex, so any stream may lag the others, and a bar missed by any of the streams is skipped,
+// neither giving a result nor entering the time period. The covariance is that of the population, as for the
+// regression of a Spread, and is kept up to date from running sums of each stream and of each pair
+type CovarianceMatrixWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+
+	// private variables
+	valueAvailableAction ValueAvailableActionCovarianceMatrix
+	streamCount          int
+	timePeriod           int
+	pendingBars          map[int]*covarianceMatrixBar
+	periodHistory        *list.List
+	periodTotals         []float64
+	periodProductTotals  [][]float64
+}
+
+// NewCovarianceMatrixWithoutStorage creates a Covariance Matrix Indicator (CovarianceMatrix) without storage
+func NewCovarianceMatrixWithoutStorage(streamCount int, timePeriod int, valueAvailableAction ValueAvailableActionCovarianceMatrix) (indicator *CovarianceMatrixWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum streamCount for this indicator is 2
+	if streamCount < 2 {
+		return nil, errors.New("streamCount is less than the minimum (2)")
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := CovarianceMatrixWithoutStorage{
+		baseIndicator:        newBaseIndicator(lookback),
+		baseFloatBounds:      newBaseFloatBounds(),
+		valueAvailableAction: valueAvailableAction,
+		streamCount:          streamCount,
+		timePeriod:           timePeriod,
+		pendingBars:          make(map[int]*covarianceMatrixBar),
+		periodHistory:        list.New(),
+		periodTotals:         make([]float64, streamCount),
+		periodProductTotals:  make([][]float64, streamCount),
+	}
+
+	for i := range ind.periodProductTotals {
+		ind.periodProductTotals[i] = make([]float64, streamCount)
+	}
+
+	return &ind, nil
+}
+
+// StreamCount returns the number of streams in the basket
+func (ind *CovarianceMatrixWithoutStorage) StreamCount() int {
+	return ind.streamCount
+}
+
+// ReceiveTick consumes a source data float price tick of the stream at streamIndex, a tick of a stream outside
+// the basket is ignored
+func (ind *CovarianceMatrixWithoutStorage) ReceiveTick(streamIndex int, tickData float64, streamBarIndex int) {
+	if streamIndex < 0 || streamIndex >= ind.streamCount {
+		return
+	}
+
+	bar, ok := ind.pendingBars[streamBarIndex]
+	if !ok {
+		bar = &covarianceMatrixBar{
+			values:   make([]float64, ind.streamCount),
+			received: make([]bool, ind.streamCount),
+		}
+		ind.pendingBars[streamBarIndex] = bar
+	}
+
+	if !bar.received[streamIndex] {
+		bar.received[streamIndex] = true
+		bar.count++
+	}
+	bar.values[streamIndex] = tickData
+
+	if bar.count < ind.streamCount {
+		return
+	}
+
+	// any bar pending from before this bar was missed by one of the streams and can never be aligned
+	for barIndex := range ind.pendingBars {
+		if barIndex <= streamBarIndex {
+			delete(ind.pendingBars, barIndex)
+		}
+	}
+
+	ind.receiveBar(bar.values, streamBarIndex)
+}
+
+func (ind *CovarianceMatrixWithoutStorage) receiveBar(values []float64, streamBarIndex int) {
+	ind.updateTotals(values, 1.0)
+	ind.periodHistory.PushBack(values)
+
+	if ind.periodHistory.Len() > ind.timePeriod {
+		var first = ind.periodHistory.Front()
+		ind.updateTotals(first.Value.([]float64), -1.0)
+		ind.periodHistory.Remove(first)
+	}
+
+	if ind.periodHistory.Len() < ind.timePeriod {
+		return
+	}
+
+	n := float64(ind.timePeriod)
+	result := make([][]float64, ind.streamCount)
+	for i := range result {
+		result[i] = make([]float64, ind.streamCount)
+	}
+
+	for i := 0; i < ind.streamCount; i++ {
+		for j := i; j < ind.streamCount; j++ {
+			covariance := ind.periodProductTotals[i][j]/n - (ind.periodTotals[i]/n)*(ind.periodTotals[j]/n)
+			result[i][j] = covariance
+			result[j][i] = covariance
+
+			ind.UpdateMinMax(covariance, covariance)
+		}
+	}
+
+	// increment the number of results this indicator can be expected to return
+	ind.IncDataLength()
+
+	// set the streamBarIndex from which this indicator returns valid results
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(result, streamBarIndex)
+}
+
+// updateTotals adds, or with a sign of -1.0 removes, the values of a bar to the running sums of each stream and
+// of each pair, only the upper triangle of the products being kept
+func (ind *CovarianceMatrixWithoutStorage) updateTotals(values []float64, sign float64) {
+	for i := 0; i < ind.streamCount; i++ {
+		ind.periodTotals[i] += sign * values[i]
+		for j := i; j < ind.streamCount; j++ {
+			ind.periodProductTotals[i][j] += sign * values[i] * values[j]
+		}
+	}
+}
+
+// A Covariance Matrix Indicator (CovarianceMatrix)
+type CovarianceMatrix struct {
+	*CovarianceMatrixWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+	streams    []*spreadLeg
+
+	// public variables
+	Data [][][]float64
+}
+
+// NewCovarianceMatrix creates a Covariance Matrix Indicator (CovarianceMatrix) for online usage
+func NewCovarianceMatrix(streamCount int, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *CovarianceMatrix, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := CovarianceMatrix{
+		selectData: selectData,
+	}
+
+	ind.CovarianceMatrixWithoutStorage, err = NewCovarianceMatrixWithoutStorage(streamCount, timePeriod,
+		func(dataItem [][]float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ind.streams = make([]*spreadLeg, streamCount)
+	for i := range ind.streams {
+		streamIndex := i
+		ind.streams[i] = &spreadLeg{selectData: selectData, receiveTick: func(tickData float64, streamBarIndex int) {
+			ind.ReceiveTick(streamIndex, tickData, streamBarIndex)
+		}}
+	}
+
+	return &ind, nil
+}
+
+// NewCovarianceMatrixForStreams creates a Covariance Matrix Indicator (CovarianceMatrix) for online usage with a
+// source data stream for each of the basket
+func NewCovarianceMatrixForStreams(priceStreams []gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *CovarianceMatrix, err error) {
+	ind, err := NewCovarianceMatrix(len(priceStreams), timePeriod, selectData)
+	if err != nil {
+		return ind, err
+	}
+	for i := range priceStreams {
+		priceStreams[i].AddTickSubscription(ind.Stream(i))
+	}
+	return ind, err
+}
+
+// Stream returns the receiver of the source data DOHLCV price ticks of the stream at streamIndex
+func (ind *CovarianceMatrix) Stream(streamIndex int) gotrade.DOHLCVTickReceiver {
+	return ind.streams[streamIndex]
+}
+
+// WriteCSV writes the CovarianceMatrix results as barIndex,covariance rows after a header, a column for each pair
+// of the upper triangle of the matrix, named by the stream indexes of the pair. The bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *CovarianceMatrix) WriteCSV(w io.Writer, startBarOffset int) error {
+	columns := []csvColumn{}
+	for i := 0; i < ind.streamCount; i++ {
+		for j := i; j < ind.streamCount; j++ {
+			row, column := i, j
+			columns = append(columns, csvColumn{name: "covariance" + strconv.Itoa(row) + "_" + strconv.Itoa(column), length: len(ind.Data), format: func(index int) string {
+				return strconv.FormatFloat(ind.Data[index][row][column], 'g', -1, 64)
+			}})
+		}
+	}
+
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), columns...)
+}
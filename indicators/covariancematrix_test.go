@@ -0,0 +1,157 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+)
+
+var _ = Describe("when creating a covariancematrixwithoutstorage", func() {
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewCovarianceMatrixWithoutStorage(3, 10, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created with a streamCount below the minimum", func() {
+		indicator, _ := indicators.NewCovarianceMatrixWithoutStorage(1, 10, func(dataItem [][]float64, streamBarIndex int) {})
+		Expect(indicator).To(BeNil())
+	})
+
+	It("the indicator should not be created with a timePeriod below the minimum", func() {
+		indicator, _ := indicators.NewCovarianceMatrixWithoutStorage(3, 1, func(dataItem [][]float64, streamBarIndex int) {})
+		Expect(indicator).To(BeNil())
+	})
+
+	It("the indicator should not be created with a timePeriod above the maximum", func() {
+		indicator, _ := indicators.NewCovarianceMatrixWithoutStorage(3, indicators.MaximumLookbackPeriod+1, func(dataItem [][]float64, streamBarIndex int) {})
+		Expect(indicator).To(BeNil())
+	})
+
+	It("the indicator should not be created with a nil data selection func", func() {
+		indicator, err := indicators.NewCovarianceMatrix(3, 10, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+})
+
+var _ = Describe("when calculating a covariance matrix of three streams", func() {
+	var (
+		period    int = 10
+		indicator *indicators.CovarianceMatrix
+		streams   [][]float64
+	)
+
+	// the population covariance of the period of bars ending at the bar of streams i and j, computed directly
+	bruteForceCovariance := func(i int, j int, bar int) float64 {
+		meanI, meanJ := 0.0, 0.0
+		for k := bar - period; k < bar; k++ {
+			meanI += streams[i][k]
+			meanJ += streams[j][k]
+		}
+		meanI /= float64(period)
+		meanJ /= float64(period)
+
+		total := 0.0
+		for k := bar - period; k < bar; k++ {
+			total += (streams[i][k] - meanI) * (streams[j][k] - meanJ)
+		}
+		return total / float64(period)
+	}
+
+	BeforeEach(func() {
+		// the close, a stream moving against the close and a stream of its own
+		streams = [][]float64{{}, {}, {}}
+		for i := range sourceDOHLCVData {
+			streams[0] = append(streams[0], sourceDOHLCVData[i].C())
+			streams[1] = append(streams[1], 200.0-0.5*sourceDOHLCVData[i].C())
+			streams[2] = append(streams[2], 50.0+10.0*math.Sin(float64(i)))
+		}
+
+		indicator, _ = indicators.NewCovarianceMatrix(3, period, gotrade.UseClosePrice)
+		for bar := 1; bar <= len(sourceDOHLCVData); bar++ {
+			for i := range streams {
+				indicator.ReceiveTick(i, streams[i][bar-1], bar)
+			}
+		}
+	})
+
+	It("should have a result once the time period of each stream has been received", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(period - 1))
+		Expect(indicator.ValidFromBar()).To(Equal(period))
+		Expect(indicator.Data).To(HaveLen(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+		Expect(indicator.Length()).To(Equal(len(indicator.Data)))
+	})
+
+	It("should match the covariance computed directly for each pair", func() {
+		for r := range indicator.Data {
+			bar := indicator.ValidFromBar() + r
+			for i := 0; i < 3; i++ {
+				for j := 0; j < 3; j++ {
+					Expect(indicator.Data[r][i][j]).To(BeNumerically("~", bruteForceCovariance(i, j, bar), 0.000001))
+				}
+			}
+		}
+	})
+
+	It("should be symmetric with the variance of each stream on the diagonal", func() {
+		for r := range indicator.Data {
+			for i := 0; i < 3; i++ {
+				Expect(indicator.Data[r][i][i]).To(BeNumerically(">=", 0.0))
+				for j := 0; j < 3; j++ {
+					Expect(indicator.Data[r][i][j]).To(Equal(indicator.Data[r][j][i]))
+				}
+			}
+			Expect(indicator.Data[r][0][1]).To(BeNumerically("<=", 0.0))
+		}
+	})
+})
+
+var _ = Describe("when calculating a covariance matrix of streams not aligned by bar", func() {
+	var (
+		indicator  *indicators.CovarianceMatrixWithoutStorage
+		resultBars []int
+	)
+
+	BeforeEach(func() {
+		resultBars = []int{}
+		indicator, _ = indicators.NewCovarianceMatrixWithoutStorage(3, 2, func(dataItem [][]float64, streamBarIndex int) {
+			resultBars = append(resultBars, streamBarIndex)
+		})
+	})
+
+	It("should wait for a lagging stream and skip a bar missed by a stream", func() {
+		for bar := 1; bar <= 6; bar++ {
+			indicator.ReceiveTick(0, float64(bar), bar)
+			indicator.ReceiveTick(1, float64(bar*2), bar)
+		}
+		Expect(resultBars).To(BeEmpty())
+
+		// the third stream misses bar 3
+		for _, bar := range []int{1, 2, 4, 5, 6} {
+			indicator.ReceiveTick(2, float64(bar*3), bar)
+		}
+
+		Expect(resultBars).To(Equal([]int{2, 4, 5, 6}))
+	})
+})
+
+var _ = Describe("when creating a covariance matrix for use with a basket of price streams", func() {
+	It("should have requested each stream be attached to its price stream", func() {
+		priceStreams := []gotrade.DOHLCVStreamSubscriber{}
+		fakes := []*fakeDOHLCVStreamSubscriber{}
+		for i := 0; i < 3; i++ {
+			fake := newFakeDOHLCVStreamSubscriber()
+			fakes = append(fakes, fake)
+			priceStreams = append(priceStreams, fake)
+		}
+
+		indicator, _ := indicators.NewCovarianceMatrixForStreams(priceStreams, 10, gotrade.UseClosePrice)
+		Expect(indicator.StreamCount()).To(Equal(3))
+		for i := range fakes {
+			Expect(fakes[i].lastCallToAddTickSubscriptionArg).To(Equal(indicator.Stream(i)))
+		}
+	})
+})
@@ -0,0 +1,279 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"strconv"
+)
+
+var (
+	ErrCrossStageIsNil = errors.New("A ChainStage is required for each series of a Cross")
+)
+
+// BarsSinceNoCross is the bars since the last cross given before the first cross
+const BarsSinceNoCross int = -1
+
+// A CrossDirection is the direction in which series a crossed series b, or the level, on a bar
+type CrossDirection int
+
+const (
+	// series a did not cross on the bar
+	CrossDirectionNone CrossDirection = iota
+	// series a crossed above series b on the bar
+	CrossDirectionUp
+	// series a crossed below series b on the bar
+	CrossDirectionDown
+)
+
+type ValueAvailableActionCross func(dataItemDirection CrossDirection, dataItemBarsSinceCross int, streamBarIndex int)
+
+// A Cross Indicator (Cross), no storage, for use in other indicators
+// the direction in which series a crossed series b, or a fixed level, on each bar, and the number of bars since the
+// last cross, which is 0 on the bar of a cross and BarsSinceNoCross before the first cross. The ticks of the
+// series are aligned by their streamBarIndex as for a Spread. A bar on which a equals b is not a cross, a is only
+// taken to have crossed once it is on the other side of b from where it was before it touched
+type CrossWithoutStorage struct {
+	*baseIndicator
+	*baseIntBounds
+
+	// private variables
+	valueAvailableAction ValueAvailableActionCross
+	level                float64
+	pendingA             map[int]float64
+	pendingB             map[int]float64
+	previousSide         int
+	currentDirection     CrossDirection
+	barsSinceCross       int
+}
+
+// NewCrossWithoutStorage creates a Cross Indicator (Cross) without storage of series a over series b
+func NewCrossWithoutStorage(valueAvailableAction ValueAvailableActionCross) (indicator *CrossWithoutStorage, err error) {
+	return NewLevelCrossWithoutStorage(0.0, valueAvailableAction)
+}
+
+// NewLevelCrossWithoutStorage creates a Cross Indicator (Cross) without storage of series a over a fixed level,
+// such as an Rsi over 50, received from ReceiveTick
+func NewLevelCrossWithoutStorage(level float64, valueAvailableAction ValueAvailableActionCross) (indicator *CrossWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	ind := CrossWithoutStorage{
+		baseIndicator:        newBaseIndicator(lookback),
+		baseIntBounds:        newBaseIntBounds(),
+		valueAvailableAction: valueAvailableAction,
+		level:                level,
+		pendingA:             make(map[int]float64),
+		pendingB:             make(map[int]float64),
+		barsSinceCross:       BarsSinceNoCross,
+	}
+
+	return &ind, nil
+}
+
+// CurrentDirection returns the direction of the cross of the last result, CrossDirectionNone when there was no cross
+func (ind *CrossWithoutStorage) CurrentDirection() CrossDirection {
+	return ind.currentDirection
+}
+
+// BarsSinceCross returns the number of bars since the last cross of the last result, 0 on the bar of a cross and
+// BarsSinceNoCross before the first cross
+func (ind *CrossWithoutStorage) BarsSinceCross() int {
+	return ind.barsSinceCross
+}
+
+// ReceiveTick consumes a source data float price tick of series a, crossing the level
+func (ind *CrossWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.receivePair(tickData, ind.level, streamBarIndex)
+}
+
+// ReceiveTickA consumes a source data float price tick of series a
+func (ind *CrossWithoutStorage) ReceiveTickA(tickData float64, streamBarIndex int) {
+	if b, ok := ind.pendingB[streamBarIndex]; ok {
+		ind.receivePair(tickData, b, streamBarIndex)
+		return
+	}
+	ind.pendingA[streamBarIndex] = tickData
+}
+
+// ReceiveTickB consumes a source data float price tick of series b
+func (ind *CrossWithoutStorage) ReceiveTickB(tickData float64, streamBarIndex int) {
+	if a, ok := ind.pendingA[streamBarIndex]; ok {
+		ind.receivePair(a, tickData, streamBarIndex)
+		return
+	}
+	ind.pendingB[streamBarIndex] = tickData
+}
+
+func (ind *CrossWithoutStorage) receivePair(a float64, b float64, streamBarIndex int) {
+	// any bar pending from before this bar was missed by the other series and can never be aligned
+	for barIndex := range ind.pendingA {
+		if barIndex <= streamBarIndex {
+			delete(ind.pendingA, barIndex)
+		}
+	}
+	for barIndex := range ind.pendingB {
+		if barIndex <= streamBarIndex {
+			delete(ind.pendingB, barIndex)
+		}
+	}
+
+	side := 0
+	if a > b {
+		side = 1
+	} else if a < b {
+		side = -1
+	}
+
+	direction := CrossDirectionNone
+	if side != 0 {
+		if ind.previousSide != 0 && side != ind.previousSide {
+			direction = CrossDirectionUp
+			if side < 0 {
+				direction = CrossDirectionDown
+			}
+		}
+		ind.previousSide = side
+	}
+
+	if direction != CrossDirectionNone {
+		ind.barsSinceCross = 0
+	} else if ind.barsSinceCross != BarsSinceNoCross {
+		ind.barsSinceCross++
+	}
+	ind.currentDirection = direction
+
+	ind.UpdateMinMax(int64(ind.barsSinceCross), int64(ind.barsSinceCross))
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(direction, ind.barsSinceCross, streamBarIndex)
+}
+
+// A Cross Indicator (Cross)
+type Cross struct {
+	*CrossWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+	stageA     FloatIndicatorWithoutStorage
+	stageB     FloatIndicatorWithoutStorage
+
+	// public variables
+	Direction []CrossDirection
+	BarsSince []int
+}
+
+// NewCross creates a Cross Indicator (Cross) for online usage of the results of stageA crossing those of stageB,
+// such as a fast and a slow moving average, each stage receiving the selected data of each tick
+func NewCross(stageA ChainStage, stageB ChainStage, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Cross, err error) {
+	if stageB == nil {
+		return nil, ErrCrossStageIsNil
+	}
+
+	ind, err := newCross(0.0, stageA, selectData)
+	if err != nil {
+		return nil, err
+	}
+
+	ind.stageB, err = stageB(func(dataItem float64, streamBarIndex int) {
+		ind.ReceiveTickB(dataItem, streamBarIndex)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the first result is given once both stages have results
+	if ind.stageB.GetLookbackPeriod() > ind.lookbackPeriod {
+		ind.lookbackPeriod = ind.stageB.GetLookbackPeriod()
+	}
+
+	return ind, nil
+}
+
+// NewLevelCross creates a Cross Indicator (Cross) for online usage of the results of the stage crossing a fixed
+// level, such as an Rsi crossing 50, the stage receiving the selected data of each tick
+func NewLevelCross(level float64, stage ChainStage, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Cross, err error) {
+	return newCross(level, stage, selectData)
+}
+
+func newCross(level float64, stageA ChainStage, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Cross, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	if stageA == nil {
+		return nil, ErrCrossStageIsNil
+	}
+
+	ind := Cross{
+		selectData: selectData,
+	}
+
+	ind.CrossWithoutStorage, err = NewLevelCrossWithoutStorage(level,
+		func(dataItemDirection CrossDirection, dataItemBarsSinceCross int, streamBarIndex int) {
+			ind.Direction = append(ind.Direction, dataItemDirection)
+			ind.BarsSince = append(ind.BarsSince, dataItemBarsSinceCross)
+		})
+
+	ind.stageA, err = stageA(func(dataItem float64, streamBarIndex int) {
+		if ind.stageB == nil {
+			ind.ReceiveTick(dataItem, streamBarIndex)
+			return
+		}
+		ind.ReceiveTickA(dataItem, streamBarIndex)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ind.lookbackPeriod = ind.stageA.GetLookbackPeriod()
+
+	return &ind, nil
+}
+
+// NewCrossForStream creates a Cross Indicator (Cross) for online usage with a source data stream
+func NewCrossForStream(priceStream gotrade.DOHLCVStreamSubscriber, stageA ChainStage, stageB ChainStage, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Cross, err error) {
+	ind, err := NewCross(stageA, stageB, selectData)
+	if err != nil {
+		return ind, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewLevelCrossForStream creates a Cross Indicator (Cross) of a fixed level for online usage with a source data stream
+func NewLevelCrossForStream(priceStream gotrade.DOHLCVStreamSubscriber, level float64, stage ChainStage, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Cross, err error) {
+	ind, err := NewLevelCross(level, stage, selectData)
+	if err != nil {
+		return ind, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Cross) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.stageA.ReceiveTick(selectedData, streamBarIndex)
+	if ind.stageB != nil {
+		ind.stageB.ReceiveTick(selectedData, streamBarIndex)
+	}
+}
+
+// WriteCSV writes the Cross results as rows after a header of barIndex,direction,barsSince, the bar index of each
+// result is its stream bar index plus the startBarOffset
+func (ind *Cross) WriteCSV(w io.Writer, startBarOffset int) error {
+	direction := csvColumn{name: "direction", length: len(ind.Direction), format: func(index int) string {
+		return strconv.Itoa(int(ind.Direction[index]))
+	}}
+	barsSince := csvColumn{name: "barsSince", length: len(ind.BarsSince), format: func(index int) string {
+		return strconv.Itoa(ind.BarsSince[index])
+	}}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), direction, barsSince)
+}
@@ -0,0 +1,201 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when creating a crosswithoutstorage", func() {
+	smaStage := func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+		return indicators.NewSmaWithoutStorage(3, valueAvailableAction)
+	}
+
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewCrossWithoutStorage(nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created with a nil data selection func", func() {
+		indicator, err := indicators.NewCross(smaStage, smaStage, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+
+	It("the indicator should not be created without a stage for each series", func() {
+		indicator, err := indicators.NewCross(smaStage, nil, gotrade.UseClosePrice)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrCrossStageIsNil))
+
+		indicator, err = indicators.NewLevelCross(50.0, nil, gotrade.UseClosePrice)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrCrossStageIsNil))
+	})
+
+	It("the indicator should return the error of a stage", func() {
+		badStage := func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+			return indicators.NewSmaWithoutStorage(0, valueAvailableAction)
+		}
+		indicator, err := indicators.NewCross(smaStage, badStage, gotrade.UseClosePrice)
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+	})
+})
+
+var _ = Describe("when calculating the bars since a cross of a level (cross)", func() {
+	var (
+		indicator  *indicators.CrossWithoutStorage
+		directions []indicators.CrossDirection
+		barsSince  []int
+	)
+
+	BeforeEach(func() {
+		directions, barsSince = nil, nil
+		indicator, _ = indicators.NewLevelCrossWithoutStorage(0.0, func(dataItemDirection indicators.CrossDirection, dataItemBarsSinceCross int, streamBarIndex int) {
+			directions = append(directions, dataItemDirection)
+			barsSince = append(barsSince, dataItemBarsSinceCross)
+		})
+
+		// crosses down at bar 3, touches the level at bar 6 without crossing, crosses up at bar 8 and down at bar 11
+		values := []float64{1, 2, -1, -2, -3, 0, -1, 2, 3, 4, -5}
+		for i, value := range values {
+			indicator.ReceiveTick(value, i+1)
+		}
+	})
+
+	It("should have a result for each bar", func() {
+		Expect(indicator.ValidFromBar()).To(Equal(1))
+		Expect(indicator.Length()).To(Equal(11))
+	})
+
+	It("should have the direction of each cross", func() {
+		none, up, down := indicators.CrossDirectionNone, indicators.CrossDirectionUp, indicators.CrossDirectionDown
+		Expect(directions).To(Equal([]indicators.CrossDirection{none, none, down, none, none, none, none, up, none, none, down}))
+		Expect(indicator.CurrentDirection()).To(Equal(down))
+	})
+
+	It("should count the bars since the last cross, resetting on each cross", func() {
+		Expect(barsSince).To(Equal([]int{-1, -1, 0, 1, 2, 3, 4, 0, 1, 2, 0}))
+		Expect(indicator.BarsSinceCross()).To(Equal(0))
+		Expect(indicator.MaxValue()).To(Equal(int64(4)))
+	})
+
+	It("should give the sentinel before the first cross", func() {
+		Expect(barsSince[0]).To(Equal(indicators.BarsSinceNoCross))
+	})
+})
+
+var _ = Describe("when calculating the bars since a cross of two series (cross) received out of step", func() {
+	It("should align the ticks of the series by their bar", func() {
+		barsSince := []int{}
+		indicator, _ := indicators.NewCrossWithoutStorage(func(dataItemDirection indicators.CrossDirection, dataItemBarsSinceCross int, streamBarIndex int) {
+			barsSince = append(barsSince, dataItemBarsSinceCross)
+		})
+
+		indicator.ReceiveTickA(1.0, 1)
+		indicator.ReceiveTickA(3.0, 2)
+		indicator.ReceiveTickB(2.0, 1)
+		indicator.ReceiveTickB(2.0, 2)
+		indicator.ReceiveTickB(2.0, 3)
+		indicator.ReceiveTickA(4.0, 3)
+
+		Expect(barsSince).To(Equal([]int{-1, 0, 1}))
+		Expect(indicator.CurrentDirection()).To(Equal(indicators.CrossDirectionNone))
+	})
+})
+
+var _ = Describe("when calculating the crosses of a fast and a slow sma (cross) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.Cross
+		fast      []float64
+		slow      []float64
+	)
+
+	fastStage := func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+		return indicators.NewSmaWithoutStorage(3, valueAvailableAction)
+	}
+	slowStage := func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+		return indicators.NewSmaWithoutStorage(10, valueAvailableAction)
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewCross(fastStage, slowStage, gotrade.UseClosePrice)
+		fastSma, _ := indicators.NewSma(3, gotrade.UseClosePrice)
+		slowSma, _ := indicators.NewSma(10, gotrade.UseClosePrice)
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			fastSma.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			slowSma.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+		fast = fastSma.ValuesInRange(slowSma.ValidFromBar(), len(sourceDOHLCVData))
+		slow = slowSma.Data
+	})
+
+	It("should have a result once both stages have results", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(9))
+		Expect(indicator.ValidFromBar()).To(Equal(10))
+		Expect(indicator.Direction).To(HaveLen(len(sourceDOHLCVData) - 9))
+		Expect(indicator.BarsSince).To(HaveLen(len(sourceDOHLCVData) - 9))
+	})
+
+	It("should cross where the fast sma moves to the other side of the slow sma", func() {
+		crosses := 0
+		for i := 1; i < len(slow); i++ {
+			switch {
+			case fast[i-1] < slow[i-1] && fast[i] > slow[i]:
+				Expect(indicator.Direction[i]).To(Equal(indicators.CrossDirectionUp))
+				crosses++
+			case fast[i-1] > slow[i-1] && fast[i] < slow[i]:
+				Expect(indicator.Direction[i]).To(Equal(indicators.CrossDirectionDown))
+				crosses++
+			}
+		}
+		Expect(crosses).To(BeNumerically(">", 2))
+	})
+
+	It("should count up from 0 between the crosses", func() {
+		for i := 1; i < len(indicator.BarsSince); i++ {
+			if indicator.Direction[i] != indicators.CrossDirectionNone {
+				Expect(indicator.BarsSince[i]).To(Equal(0))
+			} else if indicator.BarsSince[i-1] != indicators.BarsSinceNoCross {
+				Expect(indicator.BarsSince[i]).To(Equal(indicator.BarsSince[i-1] + 1))
+			}
+		}
+	})
+})
+
+var _ = Describe("when calculating the crosses of an rsi over 50 (cross) with DOHLCV source data", func() {
+	It("should give a result for each rsi result", func() {
+		rsiStage := func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+			return indicators.NewRsiWithoutStorage(14, valueAvailableAction)
+		}
+		indicator, _ := indicators.NewLevelCross(50.0, rsiStage, gotrade.UseClosePrice)
+		rsi, _ := indicators.NewRsi(14, gotrade.UseClosePrice)
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			rsi.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+
+		Expect(indicator.GetLookbackPeriod()).To(Equal(rsi.GetLookbackPeriod()))
+		Expect(indicator.ValidFromBar()).To(Equal(rsi.ValidFromBar()))
+		Expect(indicator.BarsSince).To(HaveLen(len(rsi.Data)))
+		for i := 1; i < len(rsi.Data); i++ {
+			if rsi.Data[i-1] < 50.0 && rsi.Data[i] > 50.0 {
+				Expect(indicator.Direction[i]).To(Equal(indicators.CrossDirectionUp))
+			}
+		}
+	})
+})
+
+var _ = Describe("when creating a cross for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		smaStage := func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+			return indicators.NewSmaWithoutStorage(3, valueAvailableAction)
+		}
+		indicator, _ := indicators.NewLevelCrossForStream(stream, 100.0, smaStage, gotrade.UseClosePrice)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
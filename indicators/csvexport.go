@@ -0,0 +1,66 @@
+package indicators
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// a csvColumn is a named output of a storage indicator written by WriteCSV
+type csvColumn struct {
+	name   string
+	length int
+	format func(index int) string
+}
+
+func floatCSVColumn(name string, data []float64) csvColumn {
+	return csvColumn{name: name, length: len(data), format: func(index int) string {
+		return strconv.FormatFloat(data[index], 'g', -1, 64)
+	}}
+}
+
+func intCSVColumn(name string, data []int64) csvColumn {
+	return csvColumn{name: name, length: len(data), format: func(index int) string {
+		return strconv.FormatInt(data[index], 10)
+	}}
+}
+
+// writeCSV writes a header of barIndex and the column names followed by a row for each result, where the
+// first result is for the bar validFromBar. The bar index of each row is the stream bar index of the result
+// plus the startBarOffset, such as the index of the first bar of the stream in a larger data set
+func writeCSV(w io.Writer, startBarOffset int, validFromBar int, columns ...csvColumn) error {
+	writer := csv.NewWriter(w)
+
+	record := make([]string, len(columns)+1)
+	record[0] = "barIndex"
+	for i := range columns {
+		record[i+1] = columns[i].name
+	}
+	if err := writer.Write(record); err != nil {
+		return err
+	}
+
+	// the outputs of an indicator are produced together, so the shortest column bounds the rows
+	rows := 0
+	if validFromBar != -1 && len(columns) > 0 {
+		rows = columns[0].length
+		for i := range columns {
+			if columns[i].length < rows {
+				rows = columns[i].length
+			}
+		}
+	}
+
+	for row := 0; row < rows; row++ {
+		record[0] = strconv.Itoa(validFromBar + row + startBarOffset)
+		for i := range columns {
+			record[i+1] = columns[i].format(row)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
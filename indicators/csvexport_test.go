@@ -0,0 +1,122 @@
+package indicators_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"strconv"
+)
+
+var _ = Describe("when writing the results of an indicator as csv", func() {
+	var (
+		buffer  bytes.Buffer
+		records [][]string
+		err     error
+	)
+
+	readRecords := func() {
+		records, err = csv.NewReader(bytes.NewReader(buffer.Bytes())).ReadAll()
+		Expect(err).To(BeNil())
+	}
+
+	BeforeEach(func() {
+		buffer.Reset()
+	})
+
+	Context("given a single output indicator", func() {
+		var indicator *indicators.Sma
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewSma(5, gotrade.UseClosePrice)
+		})
+
+		Context("and the indicator has not yet received any ticks", func() {
+			It("only the header should be written", func() {
+				Expect(indicator.WriteCSV(&buffer, 0)).To(BeNil())
+				readRecords()
+				Expect(records).To(Equal([][]string{{"barIndex", "value"}}))
+			})
+		})
+
+		Context("and the indicator has recieved all of its ticks", func() {
+			BeforeEach(func() {
+				for i := range sourceDOHLCVData {
+					indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				}
+			})
+
+			It("the values should round trip with bar indices aligned to the valid from bar", func() {
+				Expect(indicator.WriteCSV(&buffer, 0)).To(BeNil())
+				readRecords()
+				Expect(records[0]).To(Equal([]string{"barIndex", "value"}))
+				Expect(records).To(HaveLen(len(indicator.Data) + 1))
+				for i, record := range records[1:] {
+					barIndex, _ := strconv.Atoi(record[0])
+					value, _ := strconv.ParseFloat(record[1], 64)
+					Expect(barIndex).To(Equal(indicator.ValidFromBar() + i))
+					Expect(value).To(Equal(indicator.Data[i]))
+				}
+			})
+
+			It("the bar indices should be shifted by the start bar offset", func() {
+				Expect(indicator.WriteCSV(&buffer, 100)).To(BeNil())
+				readRecords()
+				Expect(records[1][0]).To(Equal(strconv.Itoa(indicator.ValidFromBar() + 100)))
+			})
+
+			It("the values should match the values in range of the same bars", func() {
+				Expect(indicator.WriteCSV(&buffer, 0)).To(BeNil())
+				readRecords()
+				fromBar, _ := strconv.Atoi(records[10][0])
+				value, _ := strconv.ParseFloat(records[10][1], 64)
+				Expect(indicator.ValuesInRange(fromBar, fromBar)).To(Equal([]float64{value}))
+			})
+		})
+	})
+
+	Context("given a multiple output indicator", func() {
+		var indicator *indicators.BollingerBands
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewBollingerBands(5, gotrade.UseClosePrice)
+			for i := range sourceDOHLCVData {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("all the outputs should round trip after a header", func() {
+			Expect(indicator.WriteCSV(&buffer, 0)).To(BeNil())
+			readRecords()
+			Expect(records[0]).To(Equal([]string{"barIndex", "upperBand", "middleBand", "lowerBand"}))
+			Expect(records).To(HaveLen(len(indicator.MiddleBand) + 1))
+			for i, record := range records[1:] {
+				Expect(record[0]).To(Equal(strconv.Itoa(indicator.ValidFromBar() + i)))
+				Expect(record[1]).To(Equal(strconv.FormatFloat(indicator.UpperBand[i], 'g', -1, 64)))
+				Expect(record[2]).To(Equal(strconv.FormatFloat(indicator.MiddleBand[i], 'g', -1, 64)))
+				Expect(record[3]).To(Equal(strconv.FormatFloat(indicator.LowerBand[i], 'g', -1, 64)))
+			}
+		})
+	})
+
+	Context("given an integer output indicator", func() {
+		var indicator *indicators.HhvBars
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewHhvBars(5, gotrade.UseClosePrice)
+			for i := range sourceDOHLCVData {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("the values should be written as integers", func() {
+			Expect(indicator.WriteCSV(&buffer, 0)).To(BeNil())
+			readRecords()
+			for i, record := range records[1:] {
+				Expect(record[1]).To(Equal(strconv.FormatInt(indicator.Data[i], 10)))
+			}
+		})
+	})
+})
@@ -0,0 +1,147 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+var (
+	ErrDebounceHasNoSignal = errors.New("A Debounce requires a SignalStage")
+)
+
+// A DOHLCVSignalWithoutStorage is an indicator without storage that consumes DOHLCV data and produces a state per
+// bar, such as an ElderImpulse
+type DOHLCVSignalWithoutStorage interface {
+	Indicator
+	IndicatorWithIntBounds
+	// consumes a source data DOHLCV tick
+	ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
+}
+
+// A SignalStage creates a signal without storage for a Debounce, reporting its states to the given value available action, e.g.
+//	func(valueAvailableAction ValueAvailableActionInt) (DOHLCVSignalWithoutStorage, error) {
+//		return NewElderImpulseWithoutStorage(13, 12, 26, 9, valueAvailableAction)
+//	}
+type SignalStage func(valueAvailableAction ValueAvailableActionInt) (DOHLCVSignalWithoutStorage, error)
+
+// A Debounce of a Signal (Debounce), no storage, for use in other indicators
+// the state of a signal once it has been confirmed by the signal giving it for confirmations consecutive bars, so
+// that a flicker of the signal to another state for fewer bars is ignored. Each change of the state is given on
+// the bar that confirms it, confirmations - 1 bars after the signal changed, the first state of the signal is
+// taken as confirmed so that a result is given for every state of the signal
+type DebounceWithoutStorage struct {
+	*baseIndicatorWithIntBounds
+
+	// private variables
+	confirmations  int
+	pendingState   int64
+	pendingCount   int
+	confirmedState int64
+	hasFirstState  bool
+}
+
+// NewDebounceWithoutStorage creates a Debounce of a Signal (Debounce) without storage, the states of the signal
+// are received from ReceiveSignal, which can be given as the value available action of the signal
+func NewDebounceWithoutStorage(confirmations int, valueAvailableAction ValueAvailableActionInt) (indicator *DebounceWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum confirmations for this indicator is 1
+	if confirmations < 1 {
+		return nil, errors.New("confirmations is less than the minimum (1)")
+	}
+
+	// check the maximum confirmations
+	if confirmations > MaximumLookbackPeriod {
+		return nil, errors.New("confirmations is greater than the maximum (100000)")
+	}
+
+	lookback := 0
+	ind := DebounceWithoutStorage{
+		baseIndicatorWithIntBounds: newBaseIndicatorWithIntBounds(lookback, valueAvailableAction),
+		confirmations:              confirmations,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveSignal consumes the state of the signal on a bar
+func (ind *DebounceWithoutStorage) ReceiveSignal(state int64, streamBarIndex int) {
+	if ind.pendingCount > 0 && state == ind.pendingState {
+		ind.pendingCount += 1
+	} else {
+		ind.pendingState = state
+		ind.pendingCount = 1
+	}
+
+	if !ind.hasFirstState || ind.pendingCount >= ind.confirmations {
+		ind.confirmedState = state
+		ind.hasFirstState = true
+	}
+
+	ind.UpdateIndicatorWithNewValue(ind.confirmedState, streamBarIndex)
+}
+
+// CurrentState returns the confirmed state of the last result
+func (ind *DebounceWithoutStorage) CurrentState() int64 {
+	return ind.confirmedState
+}
+
+// A Debounce of a Signal (Debounce)
+type Debounce struct {
+	*DebounceWithoutStorage
+	signal DOHLCVSignalWithoutStorage
+
+	// public variables
+	Data []int64
+}
+
+// NewDebounce creates a Debounce of a Signal (Debounce) for online usage, the signal receiving each tick
+func NewDebounce(confirmations int, signal SignalStage) (indicator *Debounce, err error) {
+	if signal == nil {
+		return nil, ErrDebounceHasNoSignal
+	}
+
+	ind := Debounce{}
+
+	ind.DebounceWithoutStorage, err = NewDebounceWithoutStorage(confirmations,
+		func(dataItem int64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ind.signal, err = signal(ind.ReceiveSignal)
+	if err != nil {
+		return nil, err
+	}
+
+	// the debounce gives a result for each state of the signal
+	ind.baseIndicatorWithIntBounds = newBaseIndicatorWithIntBounds(ind.signal.GetLookbackPeriod(), ind.valueAvailableAction)
+
+	return &ind, nil
+}
+
+// NewDebounceForStream creates a Debounce of a Signal (Debounce) for online usage with a source data stream
+func NewDebounceForStream(priceStream gotrade.DOHLCVStreamSubscriber, confirmations int, signal SignalStage) (indicator *Debounce, err error) {
+	ind, err := NewDebounce(confirmations, signal)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Debounce) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.signal.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// WriteCSV writes the Debounce results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Debounce) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), intCSVColumn("value", ind.Data))
+}
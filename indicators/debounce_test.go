@@ -0,0 +1,144 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var elderImpulseSignalStage indicators.SignalStage = func(valueAvailableAction indicators.ValueAvailableActionInt) (indicators.DOHLCVSignalWithoutStorage, error) {
+	return indicators.NewElderImpulseWithoutStorage(13, 12, 26, 9, valueAvailableAction)
+}
+
+var _ = Describe("when creating a debouncewithoutstorage", func() {
+	var (
+		indicator      *indicators.DebounceWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewDebounceWithoutStorage(3, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given confirmations below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewDebounceWithoutStorage(0, fakeIntValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when debouncing a flickering signal", func() {
+	var (
+		indicator *indicators.DebounceWithoutStorage
+		results   []int64
+		bars      []int
+		signal    []int64 = []int64{1, 1, 1, -1, 1, 1, -1, -1, -1, 0, -1, -1, -1}
+	)
+
+	BeforeEach(func() {
+		results = nil
+		bars = nil
+		indicator, _ = indicators.NewDebounceWithoutStorage(3, func(dataItem int64, streamBarIndex int) {
+			results = append(results, dataItem)
+			bars = append(bars, streamBarIndex)
+		})
+
+		for i := range signal {
+			indicator.ReceiveSignal(signal[i], i+1)
+		}
+	})
+
+	It("should give a result for every state of the signal, taking the first state as confirmed", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(0))
+		Expect(indicator.ValidFromBar()).To(Equal(1))
+		Expect(results).To(HaveLen(len(signal)))
+	})
+
+	It("should only change state for a state persisting for the confirmations, on the bar confirming it", func() {
+		Expect(results).To(Equal([]int64{1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1}))
+
+		changedOn := []int{}
+		for i := 1; i < len(results); i++ {
+			if results[i] != results[i-1] {
+				changedOn = append(changedOn, bars[i])
+			}
+		}
+
+		// the signal changed to -1 on bar 7, confirmed on bar 9, the flickers on bars 4 and 10 are ignored
+		Expect(changedOn).To(Equal([]int{9}))
+		Expect(indicator.CurrentState()).To(Equal(int64(-1)))
+	})
+})
+
+var _ = Describe("when debouncing a signal of a single confirmation", func() {
+	It("should pass the signal through unchanged", func() {
+		results := []int64{}
+		indicator, _ := indicators.NewDebounceWithoutStorage(1, func(dataItem int64, streamBarIndex int) {
+			results = append(results, dataItem)
+		})
+
+		signal := []int64{1, -1, 0, 1, 1}
+		for i := range signal {
+			indicator.ReceiveSignal(signal[i], i+1)
+		}
+
+		Expect(results).To(Equal(signal))
+	})
+})
+
+var _ = Describe("when calculating a debounce of an elder impulse with DOHLCV source data", func() {
+	var (
+		indicator *indicators.Debounce
+		expected  []int64
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDebounce(3, elderImpulseSignalStage)
+
+		// the debounce wired manually to the signal
+		expected = []int64{}
+		manual, _ := indicators.NewDebounceWithoutStorage(3, func(dataItem int64, streamBarIndex int) {
+			expected = append(expected, dataItem)
+		})
+		signal, _ := indicators.NewElderImpulseWithoutStorage(13, 12, 26, 9, manual.ReceiveSignal)
+
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			signal.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("the lookback period should be that of the signal", func() {
+		impulse, _ := indicators.NewElderImpulse(13, 12, 26, 9)
+		Expect(indicator.GetLookbackPeriod()).To(Equal(impulse.GetLookbackPeriod()))
+		Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		Expect(indicator.Data).To(HaveLen(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+	})
+
+	It("the results should match the manually wired signal", func() {
+		Expect(indicator.Data).To(Equal(expected))
+	})
+
+	It("should not be created without a signal", func() {
+		nilIndicator, err := indicators.NewDebounce(3, nil)
+		Expect(nilIndicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDebounceHasNoSignal))
+	})
+
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		streamIndicator, _ := indicators.NewDebounceForStream(stream, 3, elderImpulseSignalStage)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(streamIndicator))
+	})
+})
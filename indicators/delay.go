@@ -0,0 +1,175 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Delay Indicator (Delay), no storage, for use in other indicators
+// shifts the source data by the delayPeriod, the result for a bar being the value of the bar delayPeriod bars
+// before it, so a delayPeriod of 0 passes the values through unchanged. A negative delayPeriod shifts the values
+// forward, each value being made available as it is received for the future bar -delayPeriod bars after it, so
+// the results are for bar indices that have not yet been received
+type DelayWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodHistory *list.List
+	delayPeriod   int
+}
+
+// NewDelayWithoutStorage creates a Delay Indicator (Delay) without storage
+func NewDelayWithoutStorage(delayPeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *DelayWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum delayPeriod for this indicator is -100000, a forward shift
+	if delayPeriod < -MaximumLookbackPeriod {
+		return nil, errors.New("delayPeriod is less than the minimum (-100000)")
+	}
+
+	// check the maximum delayPeriod
+	if delayPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("delayPeriod is greater than the maximum (100000)")
+	}
+
+	lookback := 0
+	if delayPeriod > 0 {
+		lookback = delayPeriod
+	}
+
+	ind := DelayWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		delayPeriod:                  delayPeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *DelayWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	// a forward shift makes the value available for a future bar
+	if ind.delayPeriod <= 0 {
+		ind.UpdateIndicatorWithNewValue(tickData, streamBarIndex-ind.barIndexSpan(ind.delayPeriod))
+		return
+	}
+
+	ind.periodHistory.PushBack(tickData)
+
+	if ind.periodHistory.Len() > ind.delayPeriod {
+		var first = ind.periodHistory.Front()
+		ind.periodHistory.Remove(first)
+
+		ind.UpdateIndicatorWithNewValue(first.Value.(float64), streamBarIndex)
+	}
+}
+
+// A Delay Indicator (Delay)
+type Delay struct {
+	*DelayWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewDelay creates a Delay Indicator (Delay) for online usage
+func NewDelay(delayPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Delay, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Delay{
+		selectData: selectData,
+	}
+
+	ind.DelayWithoutStorage, err = NewDelayWithoutStorage(delayPeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultDelay creates a Delay Indicator (Delay) for online usage with default parameters
+//	- delayPeriod: 1
+func NewDefaultDelay() (indicator *Delay, err error) {
+	delayPeriod := 1
+	return NewDelay(delayPeriod, gotrade.UseClosePrice)
+}
+
+// NewDelayWithSrcLen creates a Delay Indicator (Delay) for offline usage
+func NewDelayWithSrcLen(sourceLength uint, delayPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Delay, err error) {
+	ind, err := NewDelay(delayPeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultDelayWithSrcLen creates a Delay Indicator (Delay) for offline usage with default parameters
+func NewDefaultDelayWithSrcLen(sourceLength uint) (indicator *Delay, err error) {
+	ind, err := NewDefaultDelay()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDelayForStream creates a Delay Indicator (Delay) for online usage with a source data stream
+func NewDelayForStream(priceStream gotrade.DOHLCVStreamSubscriber, delayPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Delay, err error) {
+	ind, err := NewDelay(delayPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDelayForStream creates a Delay Indicator (Delay) for online usage with a source data stream
+func NewDefaultDelayForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Delay, err error) {
+	ind, err := NewDefaultDelay()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDelayForStreamWithSrcLen creates a Delay Indicator (Delay) for offline usage with a source data stream
+func NewDelayForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, delayPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Delay, err error) {
+	ind, err := NewDelayWithSrcLen(sourceLength, delayPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDelayForStreamWithSrcLen creates a Delay Indicator (Delay) for offline usage with a source data stream
+func NewDefaultDelayForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Delay, err error) {
+	ind, err := NewDefaultDelayWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Delay) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Delay results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Delay) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Delay results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Delay) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -5,6 +5,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Double Exponential Moving Average Indicator (Dema), no storage, for use in other indicators
@@ -154,3 +155,15 @@ func (dema *Dema) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
 func (dema *DemaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
 	dema.ema1.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the Dema results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Dema) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Dema results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Dema) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
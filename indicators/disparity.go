@@ -0,0 +1,183 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Disparity Index Indicator (Disparity), no storage, for use in other indicators
+// the percentage the price is above, or below, its moving average of the timePeriod, 100 * (price - ma) / ma.
+// A result of 0.0 is given while the moving average is 0.0
+type DisparityWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	movingAverage MovingAverageWithoutStorage
+	currentPrice  float64
+	timePeriod    int
+}
+
+// NewDisparityWithoutStorage creates a Disparity Index Indicator (Disparity) without storage
+func NewDisparityWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *DisparityWithoutStorage, err error) {
+	return NewDisparityWithMaTypeWithoutStorage(timePeriod, MaTypeSma, valueAvailableAction)
+}
+
+// NewDisparityWithMaTypeWithoutStorage creates a Disparity Index Indicator (Disparity) without storage
+// of the distance from a moving average of the given MaType
+func NewDisparityWithMaTypeWithoutStorage(timePeriod int, maType MaType, valueAvailableAction ValueAvailableActionFloat) (indicator *DisparityWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	ind := DisparityWithoutStorage{
+		timePeriod: timePeriod,
+	}
+
+	ind.movingAverage, err = NewMovingAverageWithoutStorage(maType, timePeriod, func(dataItem float64, streamBarIndex int) {
+		var result float64 = 0.0
+		if dataItem != 0.0 {
+			result = 100.0 * (ind.currentPrice - dataItem) / dataItem
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := ind.movingAverage.GetLookbackPeriod()
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction)
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *DisparityWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.currentPrice = tickData
+	ind.movingAverage.ReceiveTick(tickData, streamBarIndex)
+}
+
+// A Disparity Index Indicator (Disparity)
+type Disparity struct {
+	*DisparityWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewDisparity creates a Disparity Index Indicator (Disparity) for online usage
+func NewDisparity(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Disparity, err error) {
+	return NewDisparityWithMaType(timePeriod, MaTypeSma, selectData)
+}
+
+// NewDisparityWithMaType creates a Disparity Index Indicator (Disparity) for online usage of the distance from a
+// moving average of the given MaType
+func NewDisparityWithMaType(timePeriod int, maType MaType, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Disparity, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Disparity{
+		selectData: selectData,
+	}
+
+	ind.DisparityWithoutStorage, err = NewDisparityWithMaTypeWithoutStorage(timePeriod, maType,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultDisparity creates a Disparity Index Indicator (Disparity) for online usage with default parameters
+//	- timePeriod: 14
+//	- selectData: useClosePrice
+func NewDefaultDisparity() (indicator *Disparity, err error) {
+	timePeriod := 14
+	return NewDisparity(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewDisparityWithSrcLen creates a Disparity Index Indicator (Disparity) for offline usage
+func NewDisparityWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Disparity, err error) {
+	ind, err := NewDisparity(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultDisparityWithSrcLen creates a Disparity Index Indicator (Disparity) for offline usage with default parameters
+func NewDefaultDisparityWithSrcLen(sourceLength uint) (indicator *Disparity, err error) {
+	ind, err := NewDefaultDisparity()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDisparityForStream creates a Disparity Index Indicator (Disparity) for online usage with a source data stream
+func NewDisparityForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Disparity, err error) {
+	ind, err := NewDisparity(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDisparityForStream creates a Disparity Index Indicator (Disparity) for online usage with a source data stream
+func NewDefaultDisparityForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Disparity, err error) {
+	ind, err := NewDefaultDisparity()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDisparityForStreamWithSrcLen creates a Disparity Index Indicator (Disparity) for offline usage with a source data stream
+func NewDisparityForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Disparity, err error) {
+	ind, err := NewDisparityWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDisparityForStreamWithSrcLen creates a Disparity Index Indicator (Disparity) for offline usage with a source data stream
+func NewDefaultDisparityForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Disparity, err error) {
+	ind, err := NewDefaultDisparityWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Disparity) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Disparity results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Disparity) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Disparity results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Disparity) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,158 @@
+// Donchian Channel (DonchianChannel)
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Donchian Channel Indicator (DonchianChannel), no storage, for use in other indicators
+type DonchianChannelWithoutStorage struct {
+	*baseIndicatorWithFloatBoundsBollinger
+
+	// private variables
+	hhv         *HhvWithoutStorage
+	llv         *LlvWithoutStorage
+	currentHigh float64
+	timePeriod  int
+}
+
+// NewDonchianChannelWithoutStorage creates a Donchian Channel Indicator (DonchianChannel) without storage
+func NewDonchianChannelWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionBollinger) (indicator *DonchianChannelWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := DonchianChannelWithoutStorage{
+		baseIndicatorWithFloatBoundsBollinger: newBaseIndicatorWithFloatBoundsBollinger(lookback, valueAvailableAction),
+		timePeriod:                            timePeriod,
+	}
+
+	ind.hhv, err = NewHhvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentHigh = dataItem
+	})
+
+	ind.llv, err = NewLlvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		var upperBand = ind.currentHigh
+		var lowerBand = dataItem
+		var middleBand = (upperBand + lowerBand) / 2.0
+
+		ind.UpdateIndicatorWithNewValue(upperBand, middleBand, lowerBand, streamBarIndex)
+	})
+
+	return &ind, err
+}
+
+// A Donchian Channel Indicator (DonchianChannel)
+type DonchianChannel struct {
+	*DonchianChannelWithoutStorage
+
+	// public variables
+	UpperBand  []float64
+	MiddleBand []float64
+	LowerBand  []float64
+}
+
+// NewDonchianChannel creates a Donchian Channel Indicator (DonchianChannel) for online usage
+func NewDonchianChannel(timePeriod int) (indicator *DonchianChannel, err error) {
+	ind := DonchianChannel{}
+
+	ind.DonchianChannelWithoutStorage, err = NewDonchianChannelWithoutStorage(timePeriod,
+		func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
+			ind.UpperBand = append(ind.UpperBand, dataItemUpperBand)
+			ind.MiddleBand = append(ind.MiddleBand, dataItemMiddleBand)
+			ind.LowerBand = append(ind.LowerBand, dataItemLowerBand)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultDonchianChannel creates a Donchian Channel Indicator (DonchianChannel) for online usage with default parameters
+//	- timePeriod: 20
+func NewDefaultDonchianChannel() (indicator *DonchianChannel, err error) {
+	timePeriod := 20
+	return NewDonchianChannel(timePeriod)
+}
+
+// NewDonchianChannelWithSrcLen creates a Donchian Channel Indicator (DonchianChannel) for offline usage
+func NewDonchianChannelWithSrcLen(sourceLength uint, timePeriod int) (indicator *DonchianChannel, err error) {
+	ind, err := NewDonchianChannel(timePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.UpperBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.MiddleBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.LowerBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultDonchianChannelWithSrcLen creates a Donchian Channel Indicator (DonchianChannel) for offline usage with default parameters
+func NewDefaultDonchianChannelWithSrcLen(sourceLength uint) (indicator *DonchianChannel, err error) {
+	ind, err := NewDefaultDonchianChannel()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.UpperBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.MiddleBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.LowerBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDonchianChannelForStream creates a Donchian Channel Indicator (DonchianChannel) for online usage with a source data stream
+func NewDonchianChannelForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *DonchianChannel, err error) {
+	ind, err := NewDonchianChannel(timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDonchianChannelForStream creates a Donchian Channel Indicator (DonchianChannel) for online usage with a source data stream
+func NewDefaultDonchianChannelForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *DonchianChannel, err error) {
+	ind, err := NewDefaultDonchianChannel()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDonchianChannelForStreamWithSrcLen creates a Donchian Channel Indicator (DonchianChannel) for offline usage with a source data stream
+func NewDonchianChannelForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *DonchianChannel, err error) {
+	ind, err := NewDonchianChannelWithSrcLen(sourceLength, timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDonchianChannelForStreamWithSrcLen creates a Donchian Channel Indicator (DonchianChannel) for offline usage with a source data stream
+func NewDefaultDonchianChannelForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *DonchianChannel, err error) {
+	ind, err := NewDefaultDonchianChannelWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *DonchianChannelWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// the highest high must be known before the lowest low completes the channel
+	ind.hhv.ReceiveTick(tickData.H(), streamBarIndex)
+	ind.llv.ReceiveTick(tickData.L(), streamBarIndex)
+}
+
+// WriteCSV writes the DonchianChannel results as rows after a header of barIndex,upperBand,middleBand,lowerBand, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *DonchianChannel) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("upperBand", ind.UpperBand), floatCSVColumn("middleBand", ind.MiddleBand), floatCSVColumn("lowerBand", ind.LowerBand))
+}
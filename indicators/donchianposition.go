@@ -0,0 +1,158 @@
+// Donchian Channel Position (DonchianPosition)
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Donchian Channel Position Indicator (DonchianPosition), no storage, for use in other indicators
+// The position of the close within the channel, 0.0 at the lower band and 1.0 at the upper band.
+type DonchianPositionWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	channel      *DonchianChannelWithoutStorage
+	currentClose float64
+	timePeriod   int
+}
+
+// NewDonchianPositionWithoutStorage creates a Donchian Channel Position Indicator (DonchianPosition) without storage
+func NewDonchianPositionWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *DonchianPositionWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := DonchianPositionWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		timePeriod:                   timePeriod,
+	}
+
+	ind.channel, err = NewDonchianChannelWithoutStorage(timePeriod,
+		func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
+			var result float64
+			width := dataItemUpperBand - dataItemLowerBand
+
+			// a channel without any width places the close in the middle
+			if width != 0.0 {
+				result = (ind.currentClose - dataItemLowerBand) / width
+			} else {
+				result = 0.5
+			}
+
+			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+		})
+
+	return &ind, err
+}
+
+// A Donchian Channel Position Indicator (DonchianPosition)
+type DonchianPosition struct {
+	*DonchianPositionWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewDonchianPosition creates a Donchian Channel Position Indicator (DonchianPosition) for online usage
+func NewDonchianPosition(timePeriod int) (indicator *DonchianPosition, err error) {
+	ind := DonchianPosition{}
+
+	ind.DonchianPositionWithoutStorage, err = NewDonchianPositionWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultDonchianPosition creates a Donchian Channel Position Indicator (DonchianPosition) for online usage with default parameters
+//	- timePeriod: 20
+func NewDefaultDonchianPosition() (indicator *DonchianPosition, err error) {
+	timePeriod := 20
+	return NewDonchianPosition(timePeriod)
+}
+
+// NewDonchianPositionWithSrcLen creates a Donchian Channel Position Indicator (DonchianPosition) for offline usage
+func NewDonchianPositionWithSrcLen(sourceLength uint, timePeriod int) (indicator *DonchianPosition, err error) {
+	ind, err := NewDonchianPosition(timePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultDonchianPositionWithSrcLen creates a Donchian Channel Position Indicator (DonchianPosition) for offline usage with default parameters
+func NewDefaultDonchianPositionWithSrcLen(sourceLength uint) (indicator *DonchianPosition, err error) {
+	ind, err := NewDefaultDonchianPosition()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDonchianPositionForStream creates a Donchian Channel Position Indicator (DonchianPosition) for online usage with a source data stream
+func NewDonchianPositionForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *DonchianPosition, err error) {
+	ind, err := NewDonchianPosition(timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDonchianPositionForStream creates a Donchian Channel Position Indicator (DonchianPosition) for online usage with a source data stream
+func NewDefaultDonchianPositionForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *DonchianPosition, err error) {
+	ind, err := NewDefaultDonchianPosition()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDonchianPositionForStreamWithSrcLen creates a Donchian Channel Position Indicator (DonchianPosition) for offline usage with a source data stream
+func NewDonchianPositionForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *DonchianPosition, err error) {
+	ind, err := NewDonchianPositionWithSrcLen(sourceLength, timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDonchianPositionForStreamWithSrcLen creates a Donchian Channel Position Indicator (DonchianPosition) for offline usage with a source data stream
+func NewDefaultDonchianPositionForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *DonchianPosition, err error) {
+	ind, err := NewDefaultDonchianPositionWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *DonchianPositionWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.currentClose = tickData.C()
+	ind.channel.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// ValuesInRange returns the DonchianPosition results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *DonchianPosition) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the DonchianPosition results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *DonchianPosition) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
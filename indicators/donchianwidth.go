@@ -0,0 +1,147 @@
+// Donchian Channel Width (DonchianWidth)
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Donchian Channel Width Indicator (DonchianWidth), no storage, for use in other indicators
+type DonchianWidthWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	channel    *DonchianChannelWithoutStorage
+	timePeriod int
+}
+
+// NewDonchianWidthWithoutStorage creates a Donchian Channel Width Indicator (DonchianWidth) without storage
+func NewDonchianWidthWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *DonchianWidthWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := DonchianWidthWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		timePeriod:                   timePeriod,
+	}
+
+	ind.channel, err = NewDonchianChannelWithoutStorage(timePeriod,
+		func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
+			result := dataItemUpperBand - dataItemLowerBand
+
+			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+		})
+
+	return &ind, err
+}
+
+// A Donchian Channel Width Indicator (DonchianWidth)
+type DonchianWidth struct {
+	*DonchianWidthWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewDonchianWidth creates a Donchian Channel Width Indicator (DonchianWidth) for online usage
+func NewDonchianWidth(timePeriod int) (indicator *DonchianWidth, err error) {
+	ind := DonchianWidth{}
+
+	ind.DonchianWidthWithoutStorage, err = NewDonchianWidthWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultDonchianWidth creates a Donchian Channel Width Indicator (DonchianWidth) for online usage with default parameters
+//	- timePeriod: 20
+func NewDefaultDonchianWidth() (indicator *DonchianWidth, err error) {
+	timePeriod := 20
+	return NewDonchianWidth(timePeriod)
+}
+
+// NewDonchianWidthWithSrcLen creates a Donchian Channel Width Indicator (DonchianWidth) for offline usage
+func NewDonchianWidthWithSrcLen(sourceLength uint, timePeriod int) (indicator *DonchianWidth, err error) {
+	ind, err := NewDonchianWidth(timePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultDonchianWidthWithSrcLen creates a Donchian Channel Width Indicator (DonchianWidth) for offline usage with default parameters
+func NewDefaultDonchianWidthWithSrcLen(sourceLength uint) (indicator *DonchianWidth, err error) {
+	ind, err := NewDefaultDonchianWidth()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDonchianWidthForStream creates a Donchian Channel Width Indicator (DonchianWidth) for online usage with a source data stream
+func NewDonchianWidthForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *DonchianWidth, err error) {
+	ind, err := NewDonchianWidth(timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDonchianWidthForStream creates a Donchian Channel Width Indicator (DonchianWidth) for online usage with a source data stream
+func NewDefaultDonchianWidthForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *DonchianWidth, err error) {
+	ind, err := NewDefaultDonchianWidth()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDonchianWidthForStreamWithSrcLen creates a Donchian Channel Width Indicator (DonchianWidth) for offline usage with a source data stream
+func NewDonchianWidthForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *DonchianWidth, err error) {
+	ind, err := NewDonchianWidthWithSrcLen(sourceLength, timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDonchianWidthForStreamWithSrcLen creates a Donchian Channel Width Indicator (DonchianWidth) for offline usage with a source data stream
+func NewDefaultDonchianWidthForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *DonchianWidth, err error) {
+	ind, err := NewDefaultDonchianWidthWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *DonchianWidthWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.channel.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// ValuesInRange returns the DonchianWidth results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *DonchianWidth) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the DonchianWidth results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *DonchianWidth) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -392,3 +392,37 @@ var _ = Describe("when calculating a directional movement indicator (Dx) with DO
 		})
 	})
 })
+
+var _ = Describe("when calculating a directional movement indicator (Dx) with directional source data", func() {
+	var (
+		period    int = 14
+		indicator *indicators.Dx
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDx(period)
+	})
+
+	It("should be 100 when every bar moves up", func() {
+		receiveAllDOHLCVTicks(indicator, steppedDOHLCVData(1.0))
+		Expect(indicator.Data).NotTo(BeEmpty())
+		for i := range indicator.Data {
+			Expect(indicator.Data[i]).To(BeNumerically("~", 100.0, 1e-9))
+		}
+	})
+
+	It("should be 100 when every bar moves down", func() {
+		receiveAllDOHLCVTicks(indicator, steppedDOHLCVData(-1.0))
+		for i := range indicator.Data {
+			Expect(indicator.Data[i]).To(BeNumerically("~", 100.0, 1e-9))
+		}
+	})
+
+	It("should be 0 when the bars do not move, guarding the sum of the directional indicators being 0", func() {
+		receiveAllDOHLCVTicks(indicator, steppedDOHLCVData(0.0))
+		Expect(indicator.Data).NotTo(BeEmpty())
+		for i := range indicator.Data {
+			Expect(indicator.Data[i]).To(Equal(0.0))
+		}
+	})
+})
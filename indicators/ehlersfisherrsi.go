@@ -0,0 +1,192 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// An Ehlers Fisher Rsi Indicator (EhlersFisherRsi), no storage, for use in other indicators
+// the Fisher transform of the Rsi of the rsiPeriod normalized to -1 to 1 over the high and low of the last
+// timePeriod Rsi values and smoothed by the smoothingFactor, with the transform itself smoothed as
+// 0.5 * transform + 0.5 * prior result as given by Ehlers. The turns of the Rsi are sharpened into clear peaks
+type EhlersFisherRsiWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	rsi        *RsiWithoutStorage
+	normalizer *rollingNormalizer
+	previous   float64
+}
+
+// NewEhlersFisherRsiWithoutStorage creates an Ehlers Fisher Rsi Indicator (EhlersFisherRsi) without storage
+func NewEhlersFisherRsiWithoutStorage(rsiPeriod int, timePeriod int, smoothingFactor float64, valueAvailableAction ValueAvailableActionFloat) (indicator *EhlersFisherRsiWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum rsiPeriod for this indicator is 2
+	if rsiPeriod < 2 {
+		return nil, errors.New("rsiPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum rsiPeriod
+	if rsiPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("rsiPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// smoothingFactor must be above 0
+	if smoothingFactor <= 0.0 {
+		return nil, errors.New("smoothingFactor is less than the minimum (above 0)")
+	}
+
+	// the maximum smoothingFactor for this indicator is 1, no smoothing
+	if smoothingFactor > 1.0 {
+		return nil, errors.New("smoothingFactor is greater than the maximum (1)")
+	}
+
+	ind := EhlersFisherRsiWithoutStorage{
+		normalizer: newRollingNormalizer(timePeriod, smoothingFactor),
+	}
+
+	ind.rsi, err = NewRsiWithoutStorage(rsiPeriod, func(dataItem float64, streamBarIndex int) {
+		normalized := ind.normalizer.rollingNormalize(dataItem)
+		result := fisherTransform(normalized) + 0.5*ind.previous
+		ind.previous = result
+
+		// the results are made available once the window of the normalizer is full
+		if ind.normalizer.isFull() {
+			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+		}
+	})
+
+	lookback := ind.rsi.GetLookbackPeriod() + timePeriod - 1
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction)
+
+	return &ind, err
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *EhlersFisherRsiWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.rsi.ReceiveTick(tickData, streamBarIndex)
+}
+
+// An Ehlers Fisher Rsi Indicator (EhlersFisherRsi)
+type EhlersFisherRsi struct {
+	*EhlersFisherRsiWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewEhlersFisherRsi creates an Ehlers Fisher Rsi Indicator (EhlersFisherRsi) for online usage
+func NewEhlersFisherRsi(rsiPeriod int, timePeriod int, smoothingFactor float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *EhlersFisherRsi, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := EhlersFisherRsi{
+		selectData: selectData,
+	}
+
+	ind.EhlersFisherRsiWithoutStorage, err = NewEhlersFisherRsiWithoutStorage(rsiPeriod, timePeriod, smoothingFactor,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultEhlersFisherRsi creates an Ehlers Fisher Rsi Indicator (EhlersFisherRsi) for online usage with default parameters
+//	- rsiPeriod: 14
+//	- timePeriod: 10
+//	- smoothingFactor: 0.33
+func NewDefaultEhlersFisherRsi() (indicator *EhlersFisherRsi, err error) {
+	rsiPeriod := 14
+	timePeriod := 10
+	smoothingFactor := 0.33
+	return NewEhlersFisherRsi(rsiPeriod, timePeriod, smoothingFactor, gotrade.UseClosePrice)
+}
+
+// NewEhlersFisherRsiWithSrcLen creates an Ehlers Fisher Rsi Indicator (EhlersFisherRsi) for offline usage
+func NewEhlersFisherRsiWithSrcLen(sourceLength uint, rsiPeriod int, timePeriod int, smoothingFactor float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *EhlersFisherRsi, err error) {
+	ind, err := NewEhlersFisherRsi(rsiPeriod, timePeriod, smoothingFactor, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultEhlersFisherRsiWithSrcLen creates an Ehlers Fisher Rsi Indicator (EhlersFisherRsi) for offline usage with default parameters
+func NewDefaultEhlersFisherRsiWithSrcLen(sourceLength uint) (indicator *EhlersFisherRsi, err error) {
+	ind, err := NewDefaultEhlersFisherRsi()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewEhlersFisherRsiForStream creates an Ehlers Fisher Rsi Indicator (EhlersFisherRsi) for online usage with a source data stream
+func NewEhlersFisherRsiForStream(priceStream gotrade.DOHLCVStreamSubscriber, rsiPeriod int, timePeriod int, smoothingFactor float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *EhlersFisherRsi, err error) {
+	ind, err := NewEhlersFisherRsi(rsiPeriod, timePeriod, smoothingFactor, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultEhlersFisherRsiForStream creates an Ehlers Fisher Rsi Indicator (EhlersFisherRsi) for online usage with a source data stream
+func NewDefaultEhlersFisherRsiForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *EhlersFisherRsi, err error) {
+	ind, err := NewDefaultEhlersFisherRsi()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewEhlersFisherRsiForStreamWithSrcLen creates an Ehlers Fisher Rsi Indicator (EhlersFisherRsi) for offline usage with a source data stream
+func NewEhlersFisherRsiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, rsiPeriod int, timePeriod int, smoothingFactor float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *EhlersFisherRsi, err error) {
+	ind, err := NewEhlersFisherRsiWithSrcLen(sourceLength, rsiPeriod, timePeriod, smoothingFactor, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultEhlersFisherRsiForStreamWithSrcLen creates an Ehlers Fisher Rsi Indicator (EhlersFisherRsi) for offline usage with a source data stream
+func NewDefaultEhlersFisherRsiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *EhlersFisherRsi, err error) {
+	ind, err := NewDefaultEhlersFisherRsiWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *EhlersFisherRsi) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the EhlersFisherRsi results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *EhlersFisherRsi) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the EhlersFisherRsi results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *EhlersFisherRsi) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
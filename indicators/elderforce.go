@@ -0,0 +1,192 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionElderForce func(dataItemShort float64, dataItemLong float64, streamBarIndex int)
+
+// An Elder Force Index Indicator (ElderForce), no storage, for use in other indicators
+// the ForceIndex of the shortTimePeriod and of the longTimePeriod made available together for each bar, as Elder
+// watches the short line for the short term pressure of the buyers and sellers and the long line for the longer
+// term. The results are available once the short line is valid, the value of the long line is a NaN until it is
+// valid at the bar of its own lookback period, LongValidFromBar
+type ElderForceWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+
+	// private variables
+	valueAvailableAction ValueAvailableActionElderForce
+	short                *ForceIndexWithoutStorage
+	long                 *ForceIndexWithoutStorage
+	currentShort         float64
+	currentLong          float64
+	hasShort             bool
+	longValidFromBar     int
+}
+
+// NewElderForceWithoutStorage creates an Elder Force Index Indicator (ElderForce) without storage
+func NewElderForceWithoutStorage(shortTimePeriod int, longTimePeriod int, valueAvailableAction ValueAvailableActionElderForce) (indicator *ElderForceWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the short line must be shorter than the long line
+	if shortTimePeriod >= longTimePeriod {
+		return nil, errors.New("shortTimePeriod is greater than the maximum (the longTimePeriod - 1)")
+	}
+
+	ind := ElderForceWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		valueAvailableAction: valueAvailableAction,
+		currentLong:          math.NaN(),
+		longValidFromBar:     -1,
+	}
+
+	ind.short, err = NewForceIndexWithoutStorage(shortTimePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentShort = dataItem
+		ind.hasShort = true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ind.long, err = NewForceIndexWithoutStorage(longTimePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentLong = dataItem
+		if ind.longValidFromBar == -1 {
+			ind.longValidFromBar = streamBarIndex
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ind.baseIndicator = newBaseIndicator(ind.short.GetLookbackPeriod())
+
+	return &ind, nil
+}
+
+// LongValidFromBar returns the stream bar index from which the long line is valid, -1 while it is not yet valid
+func (ind *ElderForceWithoutStorage) LongValidFromBar() int {
+	return ind.longValidFromBar
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *ElderForceWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.short.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	ind.long.ReceiveDOHLCVTick(tickData, streamBarIndex)
+
+	// the results are available once the short line is valid
+	if !ind.hasShort {
+		return
+	}
+
+	ind.UpdateMinMax(ind.currentShort, ind.currentShort)
+	if ind.longValidFromBar != -1 {
+		ind.UpdateMinMax(ind.currentLong, ind.currentLong)
+	}
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(ind.currentShort, ind.currentLong, streamBarIndex)
+}
+
+// An Elder Force Index Indicator (ElderForce)
+type ElderForce struct {
+	*ElderForceWithoutStorage
+
+	// public variables
+	Short []float64
+	Long  []float64
+}
+
+// NewElderForce creates an Elder Force Index Indicator (ElderForce) for online usage
+func NewElderForce(shortTimePeriod int, longTimePeriod int) (indicator *ElderForce, err error) {
+	ind := ElderForce{}
+	ind.ElderForceWithoutStorage, err = NewElderForceWithoutStorage(shortTimePeriod, longTimePeriod,
+		func(dataItemShort float64, dataItemLong float64, streamBarIndex int) {
+			ind.Short = append(ind.Short, dataItemShort)
+			ind.Long = append(ind.Long, dataItemLong)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultElderForce creates an Elder Force Index Indicator (ElderForce) for online usage with default parameters
+//	- shortTimePeriod: 13
+//	- longTimePeriod: 100
+func NewDefaultElderForce() (indicator *ElderForce, err error) {
+	shortTimePeriod := 13
+	longTimePeriod := 100
+	return NewElderForce(shortTimePeriod, longTimePeriod)
+}
+
+// NewElderForceWithSrcLen creates an Elder Force Index Indicator (ElderForce) for offline usage
+func NewElderForceWithSrcLen(sourceLength uint, shortTimePeriod int, longTimePeriod int) (indicator *ElderForce, err error) {
+	ind, err := NewElderForce(shortTimePeriod, longTimePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if err == nil && sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Short = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Long = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultElderForceWithSrcLen creates an Elder Force Index Indicator (ElderForce) for offline usage with default parameters
+func NewDefaultElderForceWithSrcLen(sourceLength uint) (indicator *ElderForce, err error) {
+	ind, err := NewDefaultElderForce()
+
+	// only initialise the storage if there is enough source data to require it
+	if err == nil && sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Short = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Long = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewElderForceForStream creates an Elder Force Index Indicator (ElderForce) for online usage with a source data stream
+func NewElderForceForStream(priceStream gotrade.DOHLCVStreamSubscriber, shortTimePeriod int, longTimePeriod int) (indicator *ElderForce, err error) {
+	ind, err := NewElderForce(shortTimePeriod, longTimePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultElderForceForStream creates an Elder Force Index Indicator (ElderForce) for online usage with a source data stream
+func NewDefaultElderForceForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ElderForce, err error) {
+	ind, err := NewDefaultElderForce()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewElderForceForStreamWithSrcLen creates an Elder Force Index Indicator (ElderForce) for offline usage with a source data stream
+func NewElderForceForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, shortTimePeriod int, longTimePeriod int) (indicator *ElderForce, err error) {
+	ind, err := NewElderForceWithSrcLen(sourceLength, shortTimePeriod, longTimePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultElderForceForStreamWithSrcLen creates an Elder Force Index Indicator (ElderForce) for offline usage with a source data stream
+func NewDefaultElderForceForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ElderForce, err error) {
+	ind, err := NewDefaultElderForceWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the ElderForce results as barIndex,short,long rows after a header, the bar index of each result
+// is its stream bar index plus the startBarOffset
+func (ind *ElderForce) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("short", ind.Short), floatCSVColumn("long", ind.Long))
+}
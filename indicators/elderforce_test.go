@@ -0,0 +1,109 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var _ = Describe("when creating an elderforcewithoutstorage", func() {
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewElderForceWithoutStorage(13, 100, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created with a shortTimePeriod below the minimum", func() {
+		indicator, _ := indicators.NewElderForceWithoutStorage(1, 100, func(dataItemShort float64, dataItemLong float64, streamBarIndex int) {})
+		Expect(indicator).To(BeNil())
+	})
+
+	It("the indicator should not be created with a shortTimePeriod not shorter than the longTimePeriod", func() {
+		indicator, _ := indicators.NewElderForceWithoutStorage(100, 100, func(dataItemShort float64, dataItemLong float64, streamBarIndex int) {})
+		Expect(indicator).To(BeNil())
+	})
+
+	It("the indicator should not be created with a longTimePeriod above the maximum", func() {
+		indicator, _ := indicators.NewElderForceWithoutStorage(13, indicators.MaximumLookbackPeriod+1, func(dataItemShort float64, dataItemLong float64, streamBarIndex int) {})
+		Expect(indicator).To(BeNil())
+	})
+})
+
+var _ = Describe("when calculating an elder force index (elderforce) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.ElderForce
+		short     *indicators.ForceIndex
+		long      *indicators.ForceIndex
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultElderForce()
+		short, _ = indicators.NewForceIndex(13)
+		long, _ = indicators.NewForceIndex(100)
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			short.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			long.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should have results from the lookback period of the short line", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(short.GetLookbackPeriod()))
+		Expect(indicator.ValidFromBar()).To(Equal(short.ValidFromBar()))
+		Expect(indicator.Short).To(HaveLen(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+		Expect(indicator.Long).To(HaveLen(len(indicator.Short)))
+	})
+
+	It("the short line should be the force index of the short time period", func() {
+		Expect(indicator.Short).To(Equal(short.Data))
+	})
+
+	It("the long line should be a NaN until it is valid at its own lookback period", func() {
+		Expect(indicator.LongValidFromBar()).To(Equal(long.ValidFromBar()))
+
+		firstLong := indicator.LongValidFromBar() - indicator.ValidFromBar()
+		for i := 0; i < firstLong; i++ {
+			Expect(math.IsNaN(indicator.Long[i])).To(BeTrue())
+		}
+		Expect(indicator.Long[firstLong:]).To(Equal(long.Data))
+	})
+})
+
+var _ = Describe("when calculating an elder force index (elderforce) with a varying volume", func() {
+	var (
+		indicator *indicators.ElderForce
+		start     time.Time = time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultElderForce()
+
+		// a choppy close on a volume swinging between quiet and busy bars
+		for bar := 1; bar <= 250; bar++ {
+			closePrice := 100.0 + 5.0*math.Sin(float64(bar)*0.7) + 2.0*math.Cos(float64(bar)*1.9)
+			volume := 1000.0 + 900.0*math.Sin(float64(bar)*0.3)
+			indicator.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, bar), closePrice, closePrice, closePrice, closePrice, volume), bar)
+		}
+	})
+
+	// the total of the absolute bar to bar changes of the results
+	changes := func(data []float64) float64 {
+		total := 0.0
+		for i := 1; i < len(data); i++ {
+			total += math.Abs(data[i] - data[i-1])
+		}
+		return total
+	}
+
+	It("the short line should be noisier and the long line smoother", func() {
+		firstLong := indicator.LongValidFromBar() - indicator.ValidFromBar()
+		shortChanges := changes(indicator.Short[firstLong:])
+		longChanges := changes(indicator.Long[firstLong:])
+
+		Expect(longChanges).To(BeNumerically(">", 0.0))
+		Expect(shortChanges).To(BeNumerically(">", 3.0*longChanges))
+	})
+})
@@ -0,0 +1,198 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// An Elder Impulse System Indicator (ElderImpulse), no storage, for use in other indicators
+// combines the direction of an Ema of the close of the emaPeriod with that of the histogram of a Macd of the close,
+// the result is 1, green, while both are rising, -1, red, while both are falling and 0, blue, otherwise
+type ElderImpulseWithoutStorage struct {
+	*baseIndicatorWithIntBounds
+
+	// private variables
+	ema                  *EmaWithoutStorage
+	macd                 *Macd
+	hasPreviousEma       bool
+	previousEma          float64
+	emaDirection         int64
+	hasEmaDirection      bool
+	hasPreviousHistogram bool
+	previousHistogram    float64
+}
+
+// NewElderImpulseWithoutStorage creates an Elder Impulse System Indicator (ElderImpulse) without storage
+func NewElderImpulseWithoutStorage(emaPeriod int, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int, valueAvailableAction ValueAvailableActionInt) (indicator *ElderImpulseWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum emaPeriod for this indicator is 2
+	if emaPeriod < 2 {
+		return nil, errors.New("emaPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum emaPeriod
+	if emaPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("emaPeriod is greater than the maximum (100000)")
+	}
+
+	ind := ElderImpulseWithoutStorage{}
+
+	ind.ema, err = NewEmaWithoutStorage(emaPeriod, func(dataItem float64, streamBarIndex int) {
+		if ind.hasPreviousEma {
+			ind.emaDirection = impulseDirection(dataItem, ind.previousEma)
+			ind.hasEmaDirection = true
+		}
+		ind.hasPreviousEma = true
+		ind.previousEma = dataItem
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ind.macd, err = NewMacd(fastTimePeriod, slowTimePeriod, signalTimePeriod, gotrade.UseClosePrice)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the Macd results are consumed as they are made available rather than stored
+	ind.macd.valueAvailableAction = func(dataItemMacd float64, dataItemSignal float64, dataItemHistogram float64, streamBarIndex int) {
+		hasPreviousHistogram := ind.hasPreviousHistogram
+		histogramDirection := impulseDirection(dataItemHistogram, ind.previousHistogram)
+		ind.hasPreviousHistogram = true
+		ind.previousHistogram = dataItemHistogram
+
+		// the impulse is only available once both the Ema and the histogram have a direction
+		if !hasPreviousHistogram || !ind.hasEmaDirection {
+			return
+		}
+
+		var result int64 = 0
+		if ind.emaDirection == histogramDirection {
+			result = ind.emaDirection
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+
+	lookback := ind.macd.GetLookbackPeriod() + 1
+	if ind.ema.GetLookbackPeriod()+1 > lookback {
+		lookback = ind.ema.GetLookbackPeriod() + 1
+	}
+	ind.baseIndicatorWithIntBounds = newBaseIndicatorWithIntBounds(lookback, valueAvailableAction)
+
+	return &ind, nil
+}
+
+// impulseDirection returns 1 when the value has risen from the previous value, -1 when it has fallen and 0 when unchanged
+func impulseDirection(value float64, previousValue float64) int64 {
+	if value > previousValue {
+		return 1
+	} else if value < previousValue {
+		return -1
+	}
+	return 0
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *ElderImpulseWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.ema.ReceiveTick(tickData.C(), streamBarIndex)
+	ind.macd.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// An Elder Impulse System Indicator (ElderImpulse)
+type ElderImpulse struct {
+	*ElderImpulseWithoutStorage
+
+	// public variables
+	Data []int64
+}
+
+// NewElderImpulse creates an Elder Impulse System Indicator (ElderImpulse) for online usage
+func NewElderImpulse(emaPeriod int, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int) (indicator *ElderImpulse, err error) {
+	ind := ElderImpulse{}
+	ind.ElderImpulseWithoutStorage, err = NewElderImpulseWithoutStorage(emaPeriod, fastTimePeriod, slowTimePeriod, signalTimePeriod,
+		func(dataItem int64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultElderImpulse creates an Elder Impulse System Indicator (ElderImpulse) for online usage with default parameters
+//	- emaPeriod: 13
+//	- fastTimePeriod: 12
+//	- slowTimePeriod: 26
+//	- signalTimePeriod: 9
+func NewDefaultElderImpulse() (indicator *ElderImpulse, err error) {
+	emaPeriod := 13
+	fastTimePeriod := 12
+	slowTimePeriod := 26
+	signalTimePeriod := 9
+	return NewElderImpulse(emaPeriod, fastTimePeriod, slowTimePeriod, signalTimePeriod)
+}
+
+// NewElderImpulseWithSrcLen creates an Elder Impulse System Indicator (ElderImpulse) for offline usage
+func NewElderImpulseWithSrcLen(sourceLength uint, emaPeriod int, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int) (indicator *ElderImpulse, err error) {
+	ind, err := NewElderImpulse(emaPeriod, fastTimePeriod, slowTimePeriod, signalTimePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if err == nil && sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]int64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultElderImpulseWithSrcLen creates an Elder Impulse System Indicator (ElderImpulse) for offline usage with default parameters
+func NewDefaultElderImpulseWithSrcLen(sourceLength uint) (indicator *ElderImpulse, err error) {
+	ind, err := NewDefaultElderImpulse()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]int64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewElderImpulseForStream creates an Elder Impulse System Indicator (ElderImpulse) for online usage with a source data stream
+func NewElderImpulseForStream(priceStream gotrade.DOHLCVStreamSubscriber, emaPeriod int, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int) (indicator *ElderImpulse, err error) {
+	ind, err := NewElderImpulse(emaPeriod, fastTimePeriod, slowTimePeriod, signalTimePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultElderImpulseForStream creates an Elder Impulse System Indicator (ElderImpulse) for online usage with a source data stream
+func NewDefaultElderImpulseForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ElderImpulse, err error) {
+	ind, err := NewDefaultElderImpulse()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewElderImpulseForStreamWithSrcLen creates an Elder Impulse System Indicator (ElderImpulse) for offline usage with a source data stream
+func NewElderImpulseForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, emaPeriod int, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int) (indicator *ElderImpulse, err error) {
+	ind, err := NewElderImpulseWithSrcLen(sourceLength, emaPeriod, fastTimePeriod, slowTimePeriod, signalTimePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultElderImpulseForStreamWithSrcLen creates an Elder Impulse System Indicator (ElderImpulse) for offline usage with a source data stream
+func NewDefaultElderImpulseForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ElderImpulse, err error) {
+	ind, err := NewDefaultElderImpulseWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the ElderImpulse results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *ElderImpulse) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), intCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,132 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating an elderimpulsewithoutstorage", func() {
+	var (
+		indicator      *indicators.ElderImpulseWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItem int64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewElderImpulseWithoutStorage(13, 12, 26, 9, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given an emaPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewElderImpulseWithoutStorage(1, 12, 26, 9, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a slowTimePeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewElderImpulseWithoutStorage(13, 12, indicators.MaximumLookbackPeriod+1, 9, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating an elder impulse system (elderimpulse) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.ElderImpulse
+		bars      []gotrade.DOHLCV
+	)
+
+	// a flat section, an accelerating rise, a steady rise, then an accelerating fall
+	start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	price := 100.0
+	for i := 0; i < 150; i++ {
+		if i >= 40 && i < 70 {
+			price += 0.05 * float64(i-39)
+		} else if i >= 70 && i < 100 {
+			price += 1.5
+		} else if i >= 100 {
+			price -= 0.05 * float64(i-99)
+		}
+		bars = append(bars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price+1.0, price-1.0, price, 1000.0))
+	}
+
+	// the impulse of the result for the stream bar index
+	impulseAt := func(streamBarIndex int) int64 {
+		return indicator.Data[streamBarIndex-indicator.ValidFromBar()]
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultElderImpulse()
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+	})
+
+	It("should have a result once both the ema and the macd histogram have a direction", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(26 + 9 - 2 + 1))
+		Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		Expect(len(indicator.Data)).To(Equal(len(bars) - indicator.GetLookbackPeriod()))
+		Expect(indicator.MinValue()).To(Equal(int64(-1)))
+		Expect(indicator.MaxValue()).To(Equal(int64(1)))
+	})
+
+	It("should be green while the accelerating rise lifts both the ema and the histogram", func() {
+		for bar := 45; bar <= 70; bar++ {
+			Expect(impulseAt(bar)).To(Equal(int64(1)))
+		}
+	})
+
+	It("should be blue while the steady rise lifts the ema but the histogram falls back", func() {
+		for bar := 80; bar <= 100; bar++ {
+			Expect(impulseAt(bar)).To(Equal(int64(0)))
+		}
+	})
+
+	It("should be red while the fall drops both the ema and the histogram", func() {
+		for bar := 112; bar <= 118; bar++ {
+			Expect(impulseAt(bar)).To(Equal(int64(-1)))
+		}
+	})
+
+	It("should agree with the directions of a separate ema and macd", func() {
+		ema, _ := indicators.NewEma(13, gotrade.UseClosePrice)
+		macd, _ := indicators.NewDefaultMacd()
+		for i := range bars {
+			ema.ReceiveDOHLCVTick(bars[i], i+1)
+			macd.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+
+		for i := range indicator.Data {
+			bar := indicator.ValidFromBar() + i
+			emaValues := ema.ValuesInRange(bar-1, bar)
+			histogram := macd.Histogram[bar-macd.ValidFromBar()-1 : bar-macd.ValidFromBar()+1]
+
+			var expected int64 = 0
+			if emaValues[1] > emaValues[0] && histogram[1] > histogram[0] {
+				expected = 1
+			} else if emaValues[1] < emaValues[0] && histogram[1] < histogram[0] {
+				expected = -1
+			}
+			Expect(indicator.Data[i]).To(Equal(expected))
+		}
+	})
+})
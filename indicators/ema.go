@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // An Exponential Moving Average Indicator (Ema), no storage, for use in other indicators
@@ -10,11 +11,9 @@ type EmaWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
 
 	// private variables
-	periodTotal   float64
-	periodCounter int
-	multiplier    float64
-	previousEma   float64
-	timePeriod    int
+	core              *emaCore[NumericFloat64]
+	timePeriod        int
+	progressiveWarmup bool
 }
 
 // NewEmaWithoutStorage creates an Exponential Moving Average Indicator (Ema) without storage
@@ -38,14 +37,29 @@ func NewEmaWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	lookback := timePeriod - 1
 	ind := EmaWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
-		periodCounter:                timePeriod * -1,
-		multiplier:                   float64(2.0 / float64(timePeriod+1.0)),
+		core:                         newEmaCore[NumericFloat64](timePeriod),
 		timePeriod:                   timePeriod,
 	}
 
 	return &ind, err
 }
 
+// NewEmaWithOffsetWithoutStorage creates an Exponential Moving Average Indicator (Ema) without storage
+// displaced by offset bars, a positive offset plots the results into the future and a negative one into the past
+func NewEmaWithOffsetWithoutStorage(timePeriod int, offset int, valueAvailableAction ValueAvailableActionFloat) (indicator *EmaWithoutStorage, err error) {
+	ind, err := NewEmaWithoutStorage(timePeriod, valueAvailableAction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ind.setOffset(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return ind, nil
+}
+
 // An Exponential Moving Average Indicator (Ema)
 type Ema struct {
 	*EmaWithoutStorage
@@ -73,6 +87,24 @@ func NewEma(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 	return &ind, err
 }
 
+// NewEmaWithOffset creates an Exponential Moving Average Indicator (Ema) for online usage displaced by offset bars
+func NewEmaWithOffset(timePeriod int, offset int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Ema, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Ema{
+		selectData: selectData,
+	}
+
+	ind.EmaWithoutStorage, err = NewEmaWithOffsetWithoutStorage(timePeriod, offset,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
 // NewDefaultEma creates an Exponential Moving Average (Ema) for online usage with default parameters
 //	- timePeriod: 25
 func NewDefaultEma() (indicator *Ema, err error) {
@@ -111,6 +143,14 @@ func NewEmaForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int,
 	return ind, err
 }
 
+// NewEmaWithOffsetForStream creates an Exponential Moving Average Indicator (Ema) for online usage with a source data stream
+// displaced by offset bars
+func NewEmaWithOffsetForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, offset int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Ema, err error) {
+	ind, err := NewEmaWithOffset(timePeriod, offset, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
 // NewDefaultEmaForStream creates an Exponential Moving Average (Ema) for online usage with a source data stream
 func NewDefaultEmaForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Ema, err error) {
 	ind, err := NewDefaultEma()
@@ -138,23 +178,40 @@ func (ind *Ema) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
-func (ind *EmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodCounter += 1
-	if ind.periodCounter < 0 {
-		ind.periodTotal += tickData
-	} else if ind.periodCounter == 0 {
+// SetProgressiveWarmup sets whether the running average of the ticks received so far is made available for each
+// bar of the Sma seed, rather than no result until the seed completes, as shown by some charting platforms. The
+// lookback period is reduced by that of the seed, keeping any lookback added by an output transform, and the
+// ValidFromBar is that of the first result, the results from the last bar of the seed are unchanged. It should be
+// set before any ticks are received
+func (ind *EmaWithoutStorage) SetProgressiveWarmup(progressiveWarmup bool) {
+	if ind.progressiveWarmup {
+		ind.lookbackPeriod += ind.timePeriod - 1
+	}
 
-		ind.periodTotal += tickData
-		result := ind.periodTotal / float64(ind.timePeriod)
-		ind.previousEma = result
+	ind.progressiveWarmup = progressiveWarmup
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	if ind.progressiveWarmup {
+		ind.lookbackPeriod -= ind.timePeriod - 1
+	}
+}
 
-	} else if ind.periodCounter > 0 {
+func (ind *EmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	result, isAvailable := ind.core.receive(NumericFloat64(tickData))
+	if isAvailable {
+		ind.UpdateIndicatorWithNewValue(float64(result), streamBarIndex)
+	} else if ind.progressiveWarmup {
+		ind.UpdateIndicatorWithNewValue(float64(ind.core.seedAverage()), streamBarIndex)
+	}
+}
 
-		result := (tickData-ind.previousEma)*ind.multiplier + ind.previousEma
-		ind.previousEma = result
+// ValuesInRange returns the Ema results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Ema) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-	}
+// WriteCSV writes the Ema results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Ema) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
 }
@@ -5,6 +5,8 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/thetruetrade/gotrade"
 	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"strconv"
 )
 
 var _ = Describe("when creating an emawithoutstorage", func() {
@@ -405,3 +407,226 @@ var _ = Describe("when calculating an exponential moving average (ema) with DOHL
 		})
 	})
 })
+
+var _ = Describe("when calculating an exponential moving average (ema) with an offset", func() {
+	var (
+		period    int = 5
+		indicator *indicators.Ema
+		plain     *indicators.Ema
+	)
+
+	receiveAll := func() {
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			plain.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	}
+
+	Context("given the indicator is created with an offset above the maximum", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			_, err := indicators.NewEmaWithOffset(period, indicators.MaximumLookbackPeriod+1, gotrade.UseClosePrice)
+			Expect(err).To(MatchError(ContainSubstring(indicators.ErrStrAboveMaximum)))
+		})
+	})
+
+	Context("given the indicator is created with an offset below the minimum", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			_, err := indicators.NewEmaWithOffset(period, -indicators.MaximumLookbackPeriod-1, gotrade.UseClosePrice)
+			Expect(err).To(MatchError(ContainSubstring(indicators.ErrStrBelowMinimum)))
+		})
+	})
+
+	for _, offset := range []int{3, -2} {
+		offset := offset
+
+		Context("given the indicator is created with an offset of "+strconv.Itoa(offset)+" and has received all of its ticks", func() {
+			BeforeEach(func() {
+				indicator, _ = indicators.NewEmaWithOffset(period, offset, gotrade.UseClosePrice)
+				plain, _ = indicators.NewEma(period, gotrade.UseClosePrice)
+				receiveAll()
+			})
+
+			It("should report the offset", func() {
+				Expect(indicator.Offset()).To(Equal(offset))
+			})
+
+			It("the results should be unchanged", func() {
+				Expect(indicator.Data).To(Equal(plain.Data))
+				Expect(indicator.MaxValue()).To(Equal(plain.MaxValue()))
+				Expect(indicator.MinValue()).To(Equal(plain.MinValue()))
+			})
+
+			It("the results should be valid from the bar shifted by the offset", func() {
+				Expect(indicator.ValidFromBar()).To(Equal(plain.ValidFromBar() + offset))
+			})
+
+			It("the result of each bar should be the unshifted result of the bar offset bars earlier", func() {
+				for bar := plain.ValidFromBar(); bar <= len(sourceDOHLCVData); bar++ {
+					Expect(indicator.ValuesInRange(bar+offset, bar+offset)).To(Equal(plain.ValuesInRange(bar, bar)))
+				}
+			})
+		})
+	}
+})
+
+var _ = Describe("when calculating an exponential moving average (ema) with a minimum number of valid bars", func() {
+	var (
+		period       int = 5
+		minValidBars int = 20
+		indicator    *indicators.Ema
+		plain        *indicators.Ema
+	)
+
+	receiveAll := func() {
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			plain.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewEma(period, gotrade.UseClosePrice)
+		plain, _ = indicators.NewEma(period, gotrade.UseClosePrice)
+		indicator.SetMinValidBars(minValidBars)
+	})
+
+	Context("and the indicator has received fewer ticks than the minimum valid bars", func() {
+		BeforeEach(func() {
+			for i := 0; i < minValidBars-1; i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("should have no results even though the lookback period has passed", func() {
+			Expect(indicator.Data).To(BeEmpty())
+			Expect(indicator.Length()).To(Equal(0))
+			Expect(indicator.ValidFromBar()).To(Equal(-1))
+		})
+	})
+
+	Context("and the indicator has received all of its ticks", func() {
+		BeforeEach(func() {
+			receiveAll()
+		})
+
+		It("should have results from the minimum valid bar", func() {
+			Expect(indicator.ValidFromBar()).To(Equal(minValidBars))
+			Expect(len(indicator.Data)).To(Equal(len(sourceDOHLCVData) - minValidBars + 1))
+			Expect(indicator.Length()).To(Equal(len(indicator.Data)))
+		})
+
+		It("should leave the lookback period unchanged", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(plain.GetLookbackPeriod()))
+		})
+
+		It("the results should be the unwithheld results from the minimum valid bar", func() {
+			Expect(indicator.Data).To(Equal(plain.ValuesInRange(minValidBars, len(sourceDOHLCVData))))
+		})
+	})
+
+	It("should fill the withheld results along with the lookback period when the warm-up fill is set", func() {
+		indicator.SetWarmupFill(true)
+		receiveAll()
+		Expect(indicator.ValidFromBar()).To(Equal(1))
+		Expect(len(indicator.Data)).To(Equal(len(sourceDOHLCVData)))
+		for i := 0; i < minValidBars-1; i++ {
+			Expect(math.IsNaN(indicator.Data[i])).To(BeTrue())
+		}
+		Expect(indicator.Data[minValidBars-1:]).To(Equal(plain.ValuesInRange(minValidBars, len(sourceDOHLCVData))))
+	})
+
+	It("should have no effect when within the lookback period", func() {
+		indicator.SetMinValidBars(period - 1)
+		receiveAll()
+		Expect(indicator.Data).To(Equal(plain.Data))
+		Expect(indicator.ValidFromBar()).To(Equal(plain.ValidFromBar()))
+	})
+})
+
+var _ = Describe("when calculating an exponential moving average (ema) with a progressive warm-up", func() {
+	var (
+		period    int = 5
+		indicator *indicators.Ema
+		plain     *indicators.Ema
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewEma(period, gotrade.UseClosePrice)
+		indicator.SetProgressiveWarmup(true)
+		plain, _ = indicators.NewEma(period, gotrade.UseClosePrice)
+
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			plain.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should have a result for every bar from the first tick received", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(0))
+		Expect(indicator.ValidFromBar()).To(Equal(1))
+		Expect(indicator.Data).To(HaveLen(len(sourceDOHLCVData)))
+		Expect(indicator.Length()).To(Equal(len(indicator.Data)))
+	})
+
+	It("the results during the seed should be the running average of the ticks received", func() {
+		total := 0.0
+		for i := 0; i < period-1; i++ {
+			total += sourceDOHLCVData[i].C()
+			Expect(indicator.Data[i]).To(BeNumerically("~", total/float64(i+1), 0.0000001))
+		}
+	})
+
+	It("the results should converge with those without the progressive warm-up once the seed completes", func() {
+		Expect(indicator.ValuesInRange(plain.ValidFromBar(), len(sourceDOHLCVData))).To(Equal(plain.Data))
+	})
+
+	It("should restore the lookback period when the progressive warm-up is unset", func() {
+		indicator.SetProgressiveWarmup(false)
+		Expect(indicator.GetLookbackPeriod()).To(Equal(period - 1))
+	})
+})
+
+var _ = Describe("when calculating an exponential moving average (ema) with a progressive warm-up and a percent change", func() {
+	var (
+		period int = 5
+	)
+
+	It("should keep the lookback of the percent change when the progressive warm-up is set after it", func() {
+		indicator, _ := indicators.NewEma(period, gotrade.UseClosePrice)
+		indicator.SetOutputTransform(indicators.OutputTransformPercentChange)
+		indicator.SetProgressiveWarmup(true)
+		Expect(indicator.GetLookbackPeriod()).To(Equal(1))
+
+		indicator.SetProgressiveWarmup(false)
+		Expect(indicator.GetLookbackPeriod()).To(Equal(period))
+	})
+
+	It("should keep the lookback of the percent change when the progressive warm-up is set before it", func() {
+		indicator, _ := indicators.NewEma(period, gotrade.UseClosePrice)
+		indicator.SetProgressiveWarmup(true)
+		indicator.SetOutputTransform(indicators.OutputTransformPercentChange)
+		Expect(indicator.GetLookbackPeriod()).To(Equal(1))
+
+		indicator.SetOutputTransform(indicators.OutputTransformNone)
+		Expect(indicator.GetLookbackPeriod()).To(Equal(0))
+	})
+
+	It("should be valid from the bar after the first tick received", func() {
+		indicator, _ := indicators.NewEma(period, gotrade.UseClosePrice)
+		indicator.SetOutputTransform(indicators.OutputTransformPercentChange)
+		indicator.SetProgressiveWarmup(true)
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+
+		Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		Expect(indicator.Data).To(HaveLen(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+	})
+
+	It("should not change the lookback period when the progressive warm-up is set twice", func() {
+		indicator, _ := indicators.NewEma(period, gotrade.UseClosePrice)
+		indicator.SetProgressiveWarmup(true)
+		indicator.SetProgressiveWarmup(true)
+		Expect(indicator.GetLookbackPeriod()).To(Equal(0))
+	})
+})
@@ -0,0 +1,234 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// An Entropy Indicator (Entropy), no storage, for use in other indicators
+// the normalised Shannon entropy of the last timePeriod returns, (price - price') / price', binned into bins buckets
+// of equal width spanning -returnRange to returnRange, the returns beyond the range falling into the outer buckets.
+// The entropy, -sum(p * log(p)) / log(bins), is near 0.0 for an ordered series whose returns share a bucket and
+// near 1.0 for a random series spread across the buckets, a return from a previous price of 0.0 is given as 0.0
+type EntropyWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodHistory *list.List
+	binCounts     []int
+	returnRange   float64
+	previousPrice float64
+	hasPrevious   bool
+	timePeriod    int
+}
+
+// NewEntropyWithoutStorage creates an Entropy Indicator (Entropy) without storage
+func NewEntropyWithoutStorage(timePeriod int, bins int, returnRange float64, valueAvailableAction ValueAvailableActionFloat) (indicator *EntropyWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum bins for this indicator is 2
+	if bins < 2 {
+		return nil, errors.New("bins is less than the minimum (2)")
+	}
+
+	// check the maximum bins
+	if bins > MaximumLookbackPeriod {
+		return nil, errors.New("bins is greater than the maximum (100000)")
+	}
+
+	// returnRange must be above 0
+	if returnRange <= 0.0 {
+		return nil, errors.New("returnRange is less than the minimum (above 0)")
+	}
+
+	// check the maximum returnRange
+	if returnRange > math.MaxFloat64 {
+		return nil, errors.New("returnRange is greater than the maximum float64 size")
+	}
+
+	lookback := timePeriod
+	ind := EntropyWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		binCounts:                    make([]int, bins),
+		returnRange:                  returnRange,
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *EntropyWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.hasPrevious {
+		ind.hasPrevious = true
+		ind.previousPrice = tickData
+		return
+	}
+
+	var priceReturn float64 = 0.0
+	if ind.previousPrice != 0.0 {
+		priceReturn = (tickData - ind.previousPrice) / ind.previousPrice
+	}
+	ind.previousPrice = tickData
+
+	bin := ind.bin(priceReturn)
+	ind.periodHistory.PushBack(bin)
+	ind.binCounts[bin] += 1
+
+	if ind.periodHistory.Len() > ind.timePeriod {
+		var first = ind.periodHistory.Front()
+		ind.binCounts[first.Value.(int)] -= 1
+		ind.periodHistory.Remove(first)
+	}
+
+	if ind.periodHistory.Len() == ind.timePeriod {
+		var entropy float64 = 0.0
+		for _, count := range ind.binCounts {
+			if count > 0 {
+				p := float64(count) / float64(ind.timePeriod)
+				entropy -= p * math.Log(p)
+			}
+		}
+		result := entropy / math.Log(float64(len(ind.binCounts)))
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// bin returns the bucket of the return, clamped to the outer buckets beyond the return range
+func (ind *EntropyWithoutStorage) bin(priceReturn float64) int {
+	bins := len(ind.binCounts)
+	bin := int(math.Floor((priceReturn + ind.returnRange) / (2.0 * ind.returnRange) * float64(bins)))
+	if bin < 0 {
+		bin = 0
+	} else if bin >= bins {
+		bin = bins - 1
+	}
+	return bin
+}
+
+// An Entropy Indicator (Entropy)
+type Entropy struct {
+	*EntropyWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewEntropy creates an Entropy Indicator (Entropy) for online usage
+func NewEntropy(timePeriod int, bins int, returnRange float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Entropy, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Entropy{
+		selectData: selectData,
+	}
+
+	ind.EntropyWithoutStorage, err = NewEntropyWithoutStorage(timePeriod, bins, returnRange,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultEntropy creates an Entropy Indicator (Entropy) for online usage with default parameters
+//	- timePeriod: 20
+//	- bins: 10
+//	- returnRange: 0.05
+func NewDefaultEntropy() (indicator *Entropy, err error) {
+	timePeriod := 20
+	bins := 10
+	returnRange := 0.05
+	return NewEntropy(timePeriod, bins, returnRange, gotrade.UseClosePrice)
+}
+
+// NewEntropyWithSrcLen creates an Entropy Indicator (Entropy) for offline usage
+func NewEntropyWithSrcLen(sourceLength uint, timePeriod int, bins int, returnRange float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Entropy, err error) {
+	ind, err := NewEntropy(timePeriod, bins, returnRange, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultEntropyWithSrcLen creates an Entropy Indicator (Entropy) for offline usage with default parameters
+func NewDefaultEntropyWithSrcLen(sourceLength uint) (indicator *Entropy, err error) {
+	ind, err := NewDefaultEntropy()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewEntropyForStream creates an Entropy Indicator (Entropy) for online usage with a source data stream
+func NewEntropyForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, bins int, returnRange float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Entropy, err error) {
+	ind, err := NewEntropy(timePeriod, bins, returnRange, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultEntropyForStream creates an Entropy Indicator (Entropy) for online usage with a source data stream
+func NewDefaultEntropyForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Entropy, err error) {
+	ind, err := NewDefaultEntropy()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewEntropyForStreamWithSrcLen creates an Entropy Indicator (Entropy) for offline usage with a source data stream
+func NewEntropyForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, bins int, returnRange float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Entropy, err error) {
+	ind, err := NewEntropyWithSrcLen(sourceLength, timePeriod, bins, returnRange, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultEntropyForStreamWithSrcLen creates an Entropy Indicator (Entropy) for offline usage with a source data stream
+func NewDefaultEntropyForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Entropy, err error) {
+	ind, err := NewDefaultEntropyWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Entropy) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Entropy results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Entropy) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Entropy results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Entropy) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,6 @@
+package indicators
+
+// RollingNormalize exposes a rolling normalizer to the tests of the indicators_test package
+func RollingNormalize(timePeriod int, smoothingFactor float64) func(value float64) float64 {
+	return newRollingNormalizer(timePeriod, smoothingFactor).rollingNormalize
+}
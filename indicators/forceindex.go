@@ -0,0 +1,158 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Force Index Indicator (ForceIndex), no storage, for use in other indicators
+// the Ema of the timePeriod of the raw force of each bar, (close - previous close) * volume, as given by Elder,
+// the price change weighted by the volume behind it
+type ForceIndexWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	ema           *EmaWithoutStorage
+	periodCounter int
+	previousClose float64
+}
+
+// NewForceIndexWithoutStorage creates a Force Index Indicator (ForceIndex) without storage
+func NewForceIndexWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *ForceIndexWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	ind := ForceIndexWithoutStorage{
+		periodCounter: -1,
+	}
+
+	ind.ema, err = NewEmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.UpdateIndicatorWithNewValue(dataItem, streamBarIndex)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the raw force needs the previous close
+	lookback := 1 + ind.ema.GetLookbackPeriod()
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction)
+
+	return &ind, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *ForceIndexWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.periodCounter += 1
+
+	if ind.periodCounter > 0 {
+		force := (tickData.C() - ind.previousClose) * tickData.V()
+		ind.ema.ReceiveTick(force, streamBarIndex)
+	}
+
+	ind.previousClose = tickData.C()
+}
+
+// A Force Index Indicator (ForceIndex)
+type ForceIndex struct {
+	*ForceIndexWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewForceIndex creates a Force Index Indicator (ForceIndex) for online usage
+func NewForceIndex(timePeriod int) (indicator *ForceIndex, err error) {
+	ind := ForceIndex{}
+	ind.ForceIndexWithoutStorage, err = NewForceIndexWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultForceIndex creates a Force Index Indicator (ForceIndex) for online usage with default parameters
+//	- timePeriod: 13
+func NewDefaultForceIndex() (indicator *ForceIndex, err error) {
+	timePeriod := 13
+	return NewForceIndex(timePeriod)
+}
+
+// NewForceIndexWithSrcLen creates a Force Index Indicator (ForceIndex) for offline usage
+func NewForceIndexWithSrcLen(sourceLength uint, timePeriod int) (indicator *ForceIndex, err error) {
+	ind, err := NewForceIndex(timePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultForceIndexWithSrcLen creates a Force Index Indicator (ForceIndex) for offline usage with default parameters
+func NewDefaultForceIndexWithSrcLen(sourceLength uint) (indicator *ForceIndex, err error) {
+	ind, err := NewDefaultForceIndex()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewForceIndexForStream creates a Force Index Indicator (ForceIndex) for online usage with a source data stream
+func NewForceIndexForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *ForceIndex, err error) {
+	ind, err := NewForceIndex(timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultForceIndexForStream creates a Force Index Indicator (ForceIndex) for online usage with a source data stream
+func NewDefaultForceIndexForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ForceIndex, err error) {
+	ind, err := NewDefaultForceIndex()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewForceIndexForStreamWithSrcLen creates a Force Index Indicator (ForceIndex) for offline usage with a source data stream
+func NewForceIndexForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *ForceIndex, err error) {
+	ind, err := NewForceIndexWithSrcLen(sourceLength, timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultForceIndexForStreamWithSrcLen creates a Force Index Indicator (ForceIndex) for offline usage with a source data stream
+func NewDefaultForceIndexForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ForceIndex, err error) {
+	ind, err := NewDefaultForceIndexWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ValuesInRange returns the ForceIndex results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *ForceIndex) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the ForceIndex results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *ForceIndex) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,193 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// A Fractional Period Simple Moving Average Indicator (FractionalSma), no storage, for use in other indicators
+// for a timePeriod that is not a whole number of bars the result is interpolated, by the fractional part
+// of the timePeriod, between the Sma over the floor and the Sma over the ceiling of the timePeriod
+type FractionalSmaWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodHistory  *list.List
+	floorTotal     float64
+	ceilTotal      float64
+	floorPeriod    int
+	ceilPeriod     int
+	fractionalPart float64
+	timePeriod     float64
+}
+
+// NewFractionalSmaWithoutStorage creates a Fractional Period Simple Moving Average Indicator (FractionalSma) without storage
+func NewFractionalSmaWithoutStorage(timePeriod float64, valueAvailableAction ValueAvailableActionFloat) (indicator *FractionalSmaWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 1
+	if timePeriod < 1.0 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > float64(MaximumLookbackPeriod) {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	floorPeriod := int(math.Floor(timePeriod))
+	ceilPeriod := int(math.Ceil(timePeriod))
+	lookback := ceilPeriod - 1
+	ind := FractionalSmaWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		floorPeriod:                  floorPeriod,
+		ceilPeriod:                   ceilPeriod,
+		fractionalPart:               timePeriod - float64(floorPeriod),
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *FractionalSmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.periodHistory.PushBack(tickData)
+	ind.floorTotal += tickData
+	ind.ceilTotal += tickData
+
+	// the floor window drops its oldest value before the ceiling window does
+	if ind.periodHistory.Len() > ind.floorPeriod {
+		// the ceiling window is at most one bar longer, so this is at most one step from the front
+		var oldestInFloor = ind.periodHistory.Front()
+		for i := ind.periodHistory.Len() - ind.floorPeriod - 1; i > 0; i-- {
+			oldestInFloor = oldestInFloor.Next()
+		}
+		ind.floorTotal -= oldestInFloor.Value.(float64)
+	}
+
+	if ind.periodHistory.Len() > ind.ceilPeriod {
+		var first = ind.periodHistory.Front()
+		ind.ceilTotal -= first.Value.(float64)
+		ind.periodHistory.Remove(first)
+	}
+
+	if ind.periodHistory.Len() == ind.ceilPeriod {
+		floorSma := ind.floorTotal / float64(ind.floorPeriod)
+		ceilSma := ind.ceilTotal / float64(ind.ceilPeriod)
+		result := floorSma + ind.fractionalPart*(ceilSma-floorSma)
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// A Fractional Period Simple Moving Average Indicator (FractionalSma)
+type FractionalSma struct {
+	*FractionalSmaWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewFractionalSma creates a Fractional Period Simple Moving Average Indicator (FractionalSma) for online usage
+func NewFractionalSma(timePeriod float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *FractionalSma, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := FractionalSma{
+		selectData: selectData,
+	}
+
+	ind.FractionalSmaWithoutStorage, err = NewFractionalSmaWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultFractionalSma creates a Fractional Period Simple Moving Average Indicator (FractionalSma) for online usage with default parameters
+//	- timePeriod: 10.0
+func NewDefaultFractionalSma() (indicator *FractionalSma, err error) {
+	timePeriod := 10.0
+	return NewFractionalSma(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewFractionalSmaWithSrcLen creates a Fractional Period Simple Moving Average Indicator (FractionalSma) for offline usage
+func NewFractionalSmaWithSrcLen(sourceLength uint, timePeriod float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *FractionalSma, err error) {
+	ind, err := NewFractionalSma(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultFractionalSmaWithSrcLen creates a Fractional Period Simple Moving Average Indicator (FractionalSma) for offline usage with default parameters
+func NewDefaultFractionalSmaWithSrcLen(sourceLength uint) (indicator *FractionalSma, err error) {
+	ind, err := NewDefaultFractionalSma()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewFractionalSmaForStream creates a Fractional Period Simple Moving Average Indicator (FractionalSma) for online usage with a source data stream
+func NewFractionalSmaForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *FractionalSma, err error) {
+	ind, err := NewFractionalSma(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultFractionalSmaForStream creates a Fractional Period Simple Moving Average Indicator (FractionalSma) for online usage with a source data stream
+func NewDefaultFractionalSmaForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *FractionalSma, err error) {
+	ind, err := NewDefaultFractionalSma()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewFractionalSmaForStreamWithSrcLen creates a Fractional Period Simple Moving Average Indicator (FractionalSma) for offline usage with a source data stream
+func NewFractionalSmaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *FractionalSma, err error) {
+	ind, err := NewFractionalSmaWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultFractionalSmaForStreamWithSrcLen creates a Fractional Period Simple Moving Average Indicator (FractionalSma) for offline usage with a source data stream
+func NewDefaultFractionalSmaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *FractionalSma, err error) {
+	ind, err := NewDefaultFractionalSmaWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *FractionalSma) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the FractionalSma results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *FractionalSma) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the FractionalSma results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *FractionalSma) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
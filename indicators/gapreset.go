@@ -0,0 +1,34 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+type baseGapReset struct {
+	maxGap       time.Duration
+	previousDate time.Time
+	hasPrevious  bool
+}
+
+func newBaseGapReset() *baseGapReset {
+	return &baseGapReset{}
+}
+
+// GapReset sets the policy of resetting the cumulative state of the indicator when the time between the dates of
+// consecutive bars exceeds maxGap, such as the overnight gap between two sessions, so that the bar after the gap
+// starts the accumulation afresh. A maxGap of 0 disables the policy, which is the default
+func (ind *baseGapReset) GapReset(maxGap time.Duration) {
+	ind.maxGap = maxGap
+}
+
+// gapExceeded records the date of the tick and returns whether the time since the date of the previous tick
+// exceeds the maxGap of the policy
+func (ind *baseGapReset) gapExceeded(tickData gotrade.DOHLCV) bool {
+	exceeded := ind.maxGap > 0 && ind.hasPrevious && tickData.D().Sub(ind.previousDate) > ind.maxGap
+
+	ind.previousDate = tickData.D()
+	ind.hasPrevious = true
+
+	return exceeded
+}
@@ -0,0 +1,106 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when calculating cumulative indicators with a gap reset policy", func() {
+	var (
+		maxGap       = 2 * time.Hour
+		sessionBars  = 6
+		bars         []gotrade.DOHLCV
+		secondBars   []gotrade.DOHLCV
+		sessionStart = time.Date(2014, 1, 2, 9, 0, 0, 0, time.UTC)
+	)
+
+	// a session of hourly bars from start
+	newSession := func(start time.Time, price float64) []gotrade.DOHLCV {
+		session := []gotrade.DOHLCV{}
+		for i := 0; i < sessionBars; i++ {
+			closePrice := price + float64(i%3) - 1.0
+			session = append(session, gotrade.NewDOHLCVDataItem(start.Add(time.Duration(i)*time.Hour), price, closePrice+2.0, closePrice-1.5, closePrice, float64(1000*(1+i%4))))
+		}
+		return session
+	}
+
+	BeforeEach(func() {
+		// two sessions separated by an overnight gap
+		secondBars = newSession(sessionStart.AddDate(0, 0, 1), 110.0)
+		bars = append(newSession(sessionStart, 100.0), secondBars...)
+	})
+
+	It("the obv should restart from the first bar after the gap", func() {
+		indicator, _ := indicators.NewObv()
+		indicator.GapReset(maxGap)
+		secondSession, _ := indicators.NewObv()
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+		for i := range secondBars {
+			secondSession.ReceiveDOHLCVTick(secondBars[i], i+1)
+		}
+
+		Expect(indicator.Data[sessionBars]).To(Equal(secondBars[0].V()))
+		Expect(indicator.Data[sessionBars:]).To(Equal(secondSession.Data))
+	})
+
+	It("the adl should restart from the first bar after the gap", func() {
+		indicator, _ := indicators.NewAdl()
+		indicator.GapReset(maxGap)
+		secondSession, _ := indicators.NewAdl()
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+		for i := range secondBars {
+			secondSession.ReceiveDOHLCVTick(secondBars[i], i+1)
+		}
+
+		Expect(indicator.Data[sessionBars:]).To(Equal(secondSession.Data))
+	})
+
+	It("the vwap should restart from the first bar after the gap", func() {
+		indicator, _ := indicators.NewDefaultVwapBands()
+		indicator.GapReset(maxGap)
+		secondSession, _ := indicators.NewDefaultVwapBands()
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+		for i := range secondBars {
+			secondSession.ReceiveDOHLCVTick(secondBars[i], i+1)
+		}
+
+		typicalPrice := (secondBars[0].H() + secondBars[0].L() + secondBars[0].C()) / 3.0
+		Expect(indicator.Vwap[sessionBars]).To(BeNumerically("~", typicalPrice, 0.0000001))
+		Expect(indicator.Vwap[sessionBars:]).To(Equal(secondSession.Vwap))
+		Expect(indicator.UpperOuter[sessionBars:]).To(Equal(secondSession.UpperOuter))
+	})
+
+	It("the cumulative value should carry across the gap without the policy", func() {
+		indicator, _ := indicators.NewObv()
+		withPolicy, _ := indicators.NewObv()
+		withPolicy.GapReset(maxGap)
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			withPolicy.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+
+		Expect(indicator.Data[:sessionBars]).To(Equal(withPolicy.Data[:sessionBars]))
+		Expect(indicator.Data[sessionBars]).NotTo(Equal(withPolicy.Data[sessionBars]))
+	})
+
+	It("the cumulative value should carry across gaps within the maxGap", func() {
+		indicator, _ := indicators.NewAdl()
+		indicator.GapReset(25 * time.Hour)
+		withoutPolicy, _ := indicators.NewAdl()
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			withoutPolicy.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+
+		Expect(indicator.Data).To(Equal(withoutPolicy.Data))
+	})
+})
@@ -0,0 +1,164 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionGatorOsc func(dataItemUpper float64, dataItemLower float64, streamBarIndex int)
+
+// A Gator Oscillator Indicator (GatorOsc), no storage, for use in other indicators
+// the convergence and divergence of the three lines of an Alligator, the upper bar is the distance between the
+// jaw and the teeth, |jaw - teeth|, the lower bar the negative distance between the teeth and the lips,
+// -|teeth - lips|, both for the same shifted bars as the Alligator
+type GatorOscWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionGatorOsc
+	alligator            *AlligatorWithoutStorage
+}
+
+// NewGatorOscWithoutStorage creates a Gator Oscillator Indicator (GatorOsc) without storage
+func NewGatorOscWithoutStorage(jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int, valueAvailableAction ValueAvailableActionGatorOsc) (indicator *GatorOscWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	ind := GatorOscWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+	}
+
+	ind.alligator, err = NewAlligatorWithoutStorage(jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift,
+		func(dataItemJaw float64, dataItemTeeth float64, dataItemLips float64, streamBarIndex int) {
+			upper := ind.quantize(math.Abs(dataItemJaw - dataItemTeeth))
+			lower := ind.quantize(-math.Abs(dataItemTeeth - dataItemLips))
+
+			ind.UpdateMinMax(lower, upper)
+
+			ind.IncDataLength()
+
+			ind.SetValidFromBar(streamBarIndex)
+
+			// notify of a new result value though the value available action
+			ind.valueAvailableAction(upper, lower, streamBarIndex)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ind.baseIndicator = newBaseIndicator(ind.alligator.GetLookbackPeriod())
+
+	return &ind, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *GatorOscWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.alligator.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// A Gator Oscillator Indicator (GatorOsc)
+type GatorOsc struct {
+	*GatorOscWithoutStorage
+
+	// public variables
+	Upper []float64
+	Lower []float64
+}
+
+// NewGatorOsc creates a Gator Oscillator Indicator (GatorOsc) for online usage
+func NewGatorOsc(jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int) (indicator *GatorOsc, err error) {
+	ind := GatorOsc{}
+	ind.GatorOscWithoutStorage, err = NewGatorOscWithoutStorage(jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift,
+		func(dataItemUpper float64, dataItemLower float64, streamBarIndex int) {
+			ind.Upper = append(ind.Upper, dataItemUpper)
+			ind.Lower = append(ind.Lower, dataItemLower)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultGatorOsc creates a Gator Oscillator Indicator (GatorOsc) for online usage with default parameters
+//	- jawPeriod: 13
+//	- jawShift: 8
+//	- teethPeriod: 8
+//	- teethShift: 5
+//	- lipsPeriod: 5
+//	- lipsShift: 3
+func NewDefaultGatorOsc() (indicator *GatorOsc, err error) {
+	jawPeriod := 13
+	jawShift := 8
+	teethPeriod := 8
+	teethShift := 5
+	lipsPeriod := 5
+	lipsShift := 3
+	return NewGatorOsc(jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift)
+}
+
+// NewGatorOscWithSrcLen creates a Gator Oscillator Indicator (GatorOsc) for offline usage
+func NewGatorOscWithSrcLen(sourceLength uint, jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int) (indicator *GatorOsc, err error) {
+	ind, err := NewGatorOsc(jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Upper = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Lower = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultGatorOscWithSrcLen creates a Gator Oscillator Indicator (GatorOsc) for offline usage with default parameters
+func NewDefaultGatorOscWithSrcLen(sourceLength uint) (indicator *GatorOsc, err error) {
+	ind, err := NewDefaultGatorOsc()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Upper = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Lower = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewGatorOscForStream creates a Gator Oscillator Indicator (GatorOsc) for online usage with a source data stream
+func NewGatorOscForStream(priceStream gotrade.DOHLCVStreamSubscriber, jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int) (indicator *GatorOsc, err error) {
+	ind, err := NewGatorOsc(jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultGatorOscForStream creates a Gator Oscillator Indicator (GatorOsc) for online usage with a source data stream
+func NewDefaultGatorOscForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *GatorOsc, err error) {
+	ind, err := NewDefaultGatorOsc()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewGatorOscForStreamWithSrcLen creates a Gator Oscillator Indicator (GatorOsc) for offline usage with a source data stream
+func NewGatorOscForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, jawPeriod int, jawShift int, teethPeriod int, teethShift int, lipsPeriod int, lipsShift int) (indicator *GatorOsc, err error) {
+	ind, err := NewGatorOscWithSrcLen(sourceLength, jawPeriod, jawShift, teethPeriod, teethShift, lipsPeriod, lipsShift)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultGatorOscForStreamWithSrcLen creates a Gator Oscillator Indicator (GatorOsc) for offline usage with a source data stream
+func NewDefaultGatorOscForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *GatorOsc, err error) {
+	ind, err := NewDefaultGatorOscWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the GatorOsc results as rows after a header of barIndex,upper,lower, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *GatorOsc) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("upper", ind.Upper), floatCSVColumn("lower", ind.Lower))
+}
@@ -0,0 +1,75 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+)
+
+var _ = Describe("when creating a gatoroscwithoutstorage", func() {
+	var (
+		indicator      *indicators.GatorOscWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemUpper float64, dataItemLower float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewGatorOscWithoutStorage(13, 8, 8, 5, 5, 3, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a jawShift above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewGatorOscWithoutStorage(13, indicators.MaximumLookbackPeriod+1, 8, 5, 5, 3, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a gator oscillator (gatorosc) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.GatorOsc
+		alligator *indicators.Alligator
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultGatorOsc()
+		alligator, _ = indicators.NewDefaultAlligator()
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			alligator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should have a result for each alligator result, for the same shifted bars", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(alligator.GetLookbackPeriod()))
+		Expect(indicator.ValidFromBar()).To(Equal(alligator.ValidFromBar()))
+		Expect(len(indicator.Upper)).To(Equal(len(alligator.Jaw)))
+		Expect(len(indicator.Lower)).To(Equal(len(alligator.Jaw)))
+	})
+
+	It("the upper bar should be the distance between the jaw and the teeth", func() {
+		for i := range indicator.Upper {
+			Expect(indicator.Upper[i]).To(Equal(math.Abs(alligator.Jaw[i] - alligator.Teeth[i])))
+			Expect(indicator.Upper[i]).To(BeNumerically(">=", 0.0))
+		}
+	})
+
+	It("the lower bar should be the negative distance between the teeth and the lips", func() {
+		for i := range indicator.Lower {
+			Expect(indicator.Lower[i]).To(Equal(-math.Abs(alligator.Teeth[i] - alligator.Lips[i])))
+			Expect(indicator.Lower[i]).To(BeNumerically("<=", 0.0))
+		}
+	})
+})
@@ -0,0 +1,190 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// A Geometric Mean Indicator (GeoMean), no storage, for use in other indicators
+// the mean is taken from the sum of the logs of the values, avoiding the overflow of a running product.
+// The geometric mean is only defined for positive values, no result is given for a bar while the period
+// contains a value that is not positive, so the results of such bars are skipped
+type GeoMeanWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodLogTotal     float64
+	periodHistory      *list.List
+	nonPositiveCounter int
+	timePeriod         int
+}
+
+// NewGeoMeanWithoutStorage creates a Geometric Mean Indicator (GeoMean) without storage
+func NewGeoMeanWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *GeoMeanWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := GeoMeanWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *GeoMeanWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.addToPeriod(tickData)
+
+	if ind.periodHistory.Len() > ind.timePeriod {
+		var first = ind.periodHistory.Front()
+		ind.removeFromPeriod(first.Value.(float64))
+		ind.periodHistory.Remove(first)
+	}
+
+	// the result is skipped while the period contains a value that is not positive
+	if ind.periodHistory.Len() == ind.timePeriod && ind.nonPositiveCounter == 0 {
+		result := math.Exp(ind.periodLogTotal / float64(ind.timePeriod))
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+func (ind *GeoMeanWithoutStorage) addToPeriod(value float64) {
+	ind.periodHistory.PushBack(value)
+	if value > 0.0 {
+		ind.periodLogTotal += math.Log(value)
+	} else {
+		ind.nonPositiveCounter++
+	}
+}
+
+func (ind *GeoMeanWithoutStorage) removeFromPeriod(value float64) {
+	if value > 0.0 {
+		ind.periodLogTotal -= math.Log(value)
+	} else {
+		ind.nonPositiveCounter--
+	}
+}
+
+// A Geometric Mean Indicator (GeoMean)
+type GeoMean struct {
+	*GeoMeanWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewGeoMean creates a Geometric Mean Indicator (GeoMean) for online usage
+func NewGeoMean(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *GeoMean, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := GeoMean{
+		selectData: selectData,
+	}
+
+	ind.GeoMeanWithoutStorage, err = NewGeoMeanWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultGeoMean creates a Geometric Mean Indicator (GeoMean) for online usage with default parameters
+//	- timePeriod: 20
+func NewDefaultGeoMean() (indicator *GeoMean, err error) {
+	timePeriod := 20
+	return NewGeoMean(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewGeoMeanWithSrcLen creates a Geometric Mean Indicator (GeoMean) for offline usage
+func NewGeoMeanWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *GeoMean, err error) {
+	ind, err := NewGeoMean(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultGeoMeanWithSrcLen creates a Geometric Mean Indicator (GeoMean) for offline usage with default parameters
+func NewDefaultGeoMeanWithSrcLen(sourceLength uint) (indicator *GeoMean, err error) {
+	ind, err := NewDefaultGeoMean()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewGeoMeanForStream creates a Geometric Mean Indicator (GeoMean) for online usage with a source data stream
+func NewGeoMeanForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *GeoMean, err error) {
+	ind, err := NewGeoMean(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultGeoMeanForStream creates a Geometric Mean Indicator (GeoMean) for online usage with a source data stream
+func NewDefaultGeoMeanForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *GeoMean, err error) {
+	ind, err := NewDefaultGeoMean()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewGeoMeanForStreamWithSrcLen creates a Geometric Mean Indicator (GeoMean) for offline usage with a source data stream
+func NewGeoMeanForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *GeoMean, err error) {
+	ind, err := NewGeoMeanWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultGeoMeanForStreamWithSrcLen creates a Geometric Mean Indicator (GeoMean) for offline usage with a source data stream
+func NewDefaultGeoMeanForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *GeoMean, err error) {
+	ind, err := NewDefaultGeoMeanWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *GeoMean) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the GeoMean results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *GeoMean) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the GeoMean results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *GeoMean) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
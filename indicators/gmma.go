@@ -0,0 +1,202 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+	"strconv"
+)
+
+// the time periods of the short term, traders, and long term, investors, groups of emas of a Gmma
+var (
+	GmmaShortTimePeriods = [...]int{3, 5, 8, 10, 12, 15}
+	GmmaLongTimePeriods  = [...]int{30, 35, 40, 45, 50, 60}
+)
+
+// the number of emas of a Gmma, the short group followed by the long group
+const GmmaSeriesCount int = len(GmmaShortTimePeriods) + len(GmmaLongTimePeriods)
+
+// A GmmaGroupState is the separation of the short group of emas of a Gmma from the long group
+type GmmaGroupState int
+
+const (
+	// the groups overlap, the short group has compressed into the long group
+	GmmaGroupsInterleaved GmmaGroupState = iota
+	// every short group ema is above every long group ema
+	GmmaShortAboveLong
+	// every short group ema is below every long group ema
+	GmmaShortBelowLong
+)
+
+// GmmaGroupStateOf returns the group separation of the GmmaSeriesCount ema values of a Gmma result
+func GmmaGroupStateOf(values []float64) GmmaGroupState {
+	shortMin, shortMax := math.MaxFloat64, -math.MaxFloat64
+	for i := 0; i < len(GmmaShortTimePeriods); i++ {
+		shortMin = math.Min(shortMin, values[i])
+		shortMax = math.Max(shortMax, values[i])
+	}
+
+	longMin, longMax := math.MaxFloat64, -math.MaxFloat64
+	for i := len(GmmaShortTimePeriods); i < GmmaSeriesCount; i++ {
+		longMin = math.Min(longMin, values[i])
+		longMax = math.Max(longMax, values[i])
+	}
+
+	if shortMin > longMax {
+		return GmmaShortAboveLong
+	}
+
+	if shortMax < longMin {
+		return GmmaShortBelowLong
+	}
+
+	return GmmaGroupsInterleaved
+}
+
+// A Guppy Multiple Moving Average Indicator (Gmma), no storage, for use in other indicators
+// the emas of the short group, GmmaShortTimePeriods, followed by those of the long group, GmmaLongTimePeriods,
+// are made available together for each bar once the longest ema is valid
+type GmmaWithoutStorage struct {
+	*baseIndicatorWithFloatBoundsMulti
+
+	// private variables
+	emas          []*EmaWithoutStorage
+	currentValues []float64
+	groupState    GmmaGroupState
+}
+
+// NewGmmaWithoutStorage creates a Guppy Multiple Moving Average Indicator (Gmma) without storage
+func NewGmmaWithoutStorage(valueAvailableAction ValueAvailableActionMulti) (indicator *GmmaWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	timePeriods := append(GmmaShortTimePeriods[:], GmmaLongTimePeriods[:]...)
+
+	ind := GmmaWithoutStorage{
+		emas:          make([]*EmaWithoutStorage, GmmaSeriesCount),
+		currentValues: make([]float64, GmmaSeriesCount),
+	}
+
+	lookback := 0
+	for i := range timePeriods {
+		// each ema updates its own slot of the current values
+		seriesIndex := i
+		ind.emas[i], err = NewEmaWithoutStorage(timePeriods[i], func(dataItem float64, streamBarIndex int) {
+			ind.currentValues[seriesIndex] = dataItem
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if ind.emas[i].GetLookbackPeriod() > lookback {
+			lookback = ind.emas[i].GetLookbackPeriod()
+		}
+	}
+
+	ind.baseIndicatorWithFloatBoundsMulti = newBaseIndicatorWithFloatBoundsMulti(lookback, valueAvailableAction)
+
+	return &ind, nil
+}
+
+// GroupState returns the group separation of the last result
+func (ind *GmmaWithoutStorage) GroupState() GmmaGroupState {
+	return ind.groupState
+}
+
+// ShortAboveLong returns true if every short group ema of the last result is above every long group ema
+func (ind *GmmaWithoutStorage) ShortAboveLong() bool {
+	return ind.groupState == GmmaShortAboveLong
+}
+
+// A Guppy Multiple Moving Average Indicator (Gmma)
+type Gmma struct {
+	*GmmaWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	// the results of each ema, the short group followed by the long group
+	Series [][]float64
+}
+
+// NewGmma creates a Guppy Multiple Moving Average Indicator (Gmma) for online usage
+func NewGmma(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Gmma, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Gmma{
+		selectData: selectData,
+		Series:     make([][]float64, GmmaSeriesCount),
+	}
+
+	ind.GmmaWithoutStorage, err = NewGmmaWithoutStorage(
+		func(dataItems []float64, streamBarIndex int) {
+			for i := range dataItems {
+				ind.Series[i] = append(ind.Series[i], dataItems[i])
+			}
+		})
+
+	return &ind, err
+}
+
+// NewGmmaWithSrcLen creates a Guppy Multiple Moving Average Indicator (Gmma) for offline usage
+func NewGmmaWithSrcLen(sourceLength uint, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Gmma, err error) {
+	ind, err := NewGmma(selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		for i := range ind.Series {
+			ind.Series[i] = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		}
+	}
+
+	return ind, err
+}
+
+// NewGmmaForStream creates a Guppy Multiple Moving Average Indicator (Gmma) for online usage with a source data stream
+func NewGmmaForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Gmma, err error) {
+	ind, err := NewGmma(selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewGmmaForStreamWithSrcLen creates a Guppy Multiple Moving Average Indicator (Gmma) for offline usage with a source data stream
+func NewGmmaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Gmma, err error) {
+	ind, err := NewGmmaWithSrcLen(sourceLength, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Gmma) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *GmmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	for i := range ind.emas {
+		ind.emas[i].ReceiveTick(tickData, streamBarIndex)
+	}
+
+	// the results are available once every ema, the longest last, has a value
+	if ind.emas[GmmaSeriesCount-1].Length() > 0 {
+		ind.groupState = GmmaGroupStateOf(ind.currentValues)
+		ind.UpdateIndicatorWithNewValue(ind.currentValues, streamBarIndex)
+	}
+}
+
+// WriteCSV writes the Gmma results as rows after a header of barIndex followed by an emaN column for each ema
+// time period, the short group then the long group, the bar index of each result is its stream bar index plus
+// the startBarOffset
+func (ind *Gmma) WriteCSV(w io.Writer, startBarOffset int) error {
+	timePeriods := append(GmmaShortTimePeriods[:], GmmaLongTimePeriods[:]...)
+	columns := make([]csvColumn, len(timePeriods))
+	for i := range timePeriods {
+		columns[i] = floatCSVColumn("ema"+strconv.Itoa(timePeriods[i]), ind.Series[i])
+	}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), columns...)
+}
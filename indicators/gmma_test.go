@@ -0,0 +1,151 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when creating a gmmawithoutstorage", func() {
+	var (
+		indicator      *indicators.GmmaWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewGmmaWithoutStorage(nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating a guppy multiple moving average (gmma) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.Gmma
+	)
+
+	Context("given the indicator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			indicator, _ = indicators.NewGmma(gotrade.UseClosePrice)
+		})
+
+		It("the lookback period should be that of the longest ema", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(59))
+		})
+
+		Context("and the indicator has recieved all of its ticks", func() {
+			BeforeEach(func() {
+				for i := range sourceDOHLCVData {
+					indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				}
+			})
+
+			It("all twelve series should be produced for each bar after the lookback period", func() {
+				Expect(len(indicator.Series)).To(Equal(12))
+				for i := range indicator.Series {
+					Expect(len(indicator.Series[i])).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+				}
+				Expect(indicator.Length()).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+				Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+			})
+
+			It("each series should equal the ema of its time period", func() {
+				timePeriods := append(indicators.GmmaShortTimePeriods[:], indicators.GmmaLongTimePeriods[:]...)
+				for i := range timePeriods {
+					ema, _ := indicators.NewEma(timePeriods[i], gotrade.UseClosePrice)
+					for j := range sourceDOHLCVData {
+						ema.ReceiveDOHLCVTick(sourceDOHLCVData[j], j+1)
+					}
+					Expect(indicator.Series[i]).To(Equal(ema.Data[len(ema.Data)-len(indicator.Series[i]):]))
+				}
+			})
+
+			It("the bounds should be the bounds of all the series", func() {
+				max, min := GetFloatDataMax(indicator.Series[0]), GetFloatDataMin(indicator.Series[0])
+				for i := range indicator.Series {
+					if GetFloatDataMax(indicator.Series[i]) > max {
+						max = GetFloatDataMax(indicator.Series[i])
+					}
+					if GetFloatDataMin(indicator.Series[i]) < min {
+						min = GetFloatDataMin(indicator.Series[i])
+					}
+				}
+				Expect(indicator.MaxValue()).To(Equal(max))
+				Expect(indicator.MinValue()).To(Equal(min))
+			})
+		})
+	})
+
+	Context("given the indicator is created via the standard constructor with a nil data selection func", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			indicator, err := indicators.NewGmma(nil)
+			Expect(indicator).To(BeNil())
+			Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+		})
+	})
+})
+
+var _ = Describe("when determining the group state of a guppy multiple moving average (gmma)", func() {
+	var (
+		indicator *indicators.GmmaWithoutStorage
+		states    []indicators.GmmaGroupState
+		bar       int
+	)
+
+	receive := func(value float64) {
+		bar++
+		indicator.ReceiveTick(value, bar)
+	}
+
+	BeforeEach(func() {
+		states = nil
+		bar = 0
+		indicator, _ = indicators.NewGmmaWithoutStorage(func(dataItems []float64, streamBarIndex int) {
+			states = append(states, indicators.GmmaGroupStateOf(dataItems))
+		})
+	})
+
+	Context("and the source data is rising", func() {
+		BeforeEach(func() {
+			for i := 0; i < 100; i++ {
+				receive(100.0 + float64(i))
+			}
+		})
+
+		It("the short group should be above the long group", func() {
+			Expect(indicator.GroupState()).To(Equal(indicators.GmmaShortAboveLong))
+			Expect(indicator.ShortAboveLong()).To(BeTrue())
+			Expect(states[len(states)-1]).To(Equal(indicators.GmmaShortAboveLong))
+		})
+
+		Context("and then the source data is falling", func() {
+			BeforeEach(func() {
+				for i := 0; i < 100; i++ {
+					receive(200.0 - float64(i))
+				}
+			})
+
+			It("the short group should be below the long group", func() {
+				Expect(indicator.GroupState()).To(Equal(indicators.GmmaShortBelowLong))
+				Expect(indicator.ShortAboveLong()).To(BeFalse())
+			})
+
+			It("the groups should have interleaved as the state flipped", func() {
+				flipped := 0
+				for i := 1; i < len(states); i++ {
+					if states[i] != states[i-1] {
+						flipped++
+						Expect(states[i-1] == indicators.GmmaGroupsInterleaved || states[i] == indicators.GmmaGroupsInterleaved).To(BeTrue())
+					}
+				}
+				Expect(flipped).To(Equal(2))
+			})
+		})
+	})
+})
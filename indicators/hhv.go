@@ -1,10 +1,9 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
+	"io"
 )
 
 // A Highest High Value Indicator (Hhv), no storage, for use in other indicators
@@ -12,10 +11,7 @@ type HhvWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
 
 	// private variables
-	periodHistory    *list.List
-	currentHigh      float64
-	currentHighIndex int
-	timePeriod       int
+	periodHistory *monotonicDeque
 }
 
 // NewHhvWithoutStorage creates a Highest High Value Indicator (Hhv) without storage
@@ -39,10 +35,7 @@ func NewHhvWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	lookback := timePeriod - 1
 	ind := HhvWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
-		currentHigh:                  math.SmallestNonzeroFloat64,
-		currentHighIndex:             0,
-		periodHistory:                list.New(),
-		timePeriod:                   timePeriod,
+		periodHistory:                newMonotonicDeque(timePeriod, true),
 	}
 
 	return &ind, nil
@@ -140,50 +133,23 @@ func (ind *Hhv) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 }
 
 func (ind *HhvWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodHistory.PushBack(tickData)
-
-	// resize the history
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-
-		// make sure we haven't just removed the current high
-		if ind.currentHighIndex == ind.timePeriod-1 {
-			ind.currentHigh = math.SmallestNonzeroFloat64
-			// we have we need to find the new high in the history
-			var i int = ind.timePeriod - 1
-			for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
-				value := e.Value.(float64)
-				if value > ind.currentHigh {
-					ind.currentHigh = value
-					ind.currentHighIndex = i
-				}
-				i -= 1
-			}
-		} else {
-			if tickData > ind.currentHigh {
-				ind.currentHigh = tickData
-				ind.currentHighIndex = 0
-			} else {
-				ind.currentHighIndex += 1
-			}
-		}
-		var result = ind.currentHigh
+	ind.periodHistory.push(tickData)
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	if ind.periodHistory.isFull() {
+		var result = ind.periodHistory.extreme()
 
-	} else {
-		if tickData > ind.currentHigh {
-			ind.currentHigh = tickData
-			ind.currentHighIndex = 0
-		} else {
-			ind.currentHighIndex += 1
-		}
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
 
-		if ind.periodHistory.Len() == ind.timePeriod {
-			var result = ind.currentHigh
+// ValuesInRange returns the Hhv results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Hhv) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
 
-			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-		}
-	}
+// WriteCSV writes the Hhv results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Hhv) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
 }
@@ -1,10 +1,9 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
+	"io"
 )
 
 // A Highest High Value Bars Indicator (HhvBars), no storage, for use in other indicators
@@ -12,10 +11,7 @@ type HhvBarsWithoutStorage struct {
 	*baseIndicatorWithIntBounds
 
 	// private variables
-	periodHistory    *list.List
-	currentHigh      float64
-	currentHighIndex int64
-	timePeriod       int
+	periodHistory *monotonicDeque
 }
 
 // NewHhvBarsWithoutStorage creates a Highest High Value Bars Indicator Indicator (HhvBars) without storage
@@ -40,12 +36,12 @@ func NewHhvBarsWithoutStorage(timePeriod int, valueAvailableAction ValueAvailabl
 
 	ind := HhvBarsWithoutStorage{
 		baseIndicatorWithIntBounds: newBaseIndicatorWithIntBounds(lookback, valueAvailableAction),
-		currentHigh:                math.SmallestNonzeroFloat64,
-		currentHighIndex:           0,
-		periodHistory:              list.New(),
-		timePeriod:                 timePeriod,
+		periodHistory:              newMonotonicDeque(timePeriod, true),
 	}
 
+	// the bars are counted since the oldest of equal extremes unless set otherwise
+	ind.periodHistory.setTiePolicy(TiePolicyOldest)
+
 	return &ind, nil
 }
 
@@ -141,52 +137,23 @@ func (ind *HhvBars) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex in
 }
 
 func (ind *HhvBarsWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodHistory.PushBack(tickData)
-
-	// resize the history
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-
-		// make sure we haven't just removed the current high
-		if ind.currentHighIndex == int64(ind.timePeriod-1) {
-			ind.currentHigh = math.SmallestNonzeroFloat64
-			// we have we need to find the new high in the history
-			var i int = ind.timePeriod - 1
-			for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
-				value := e.Value.(float64)
-				if value > ind.currentHigh {
-					ind.currentHigh = value
-					ind.currentHighIndex = int64(i)
-				}
-				i -= 1
-			}
-		} else {
-			if tickData > ind.currentHigh {
-				ind.currentHigh = tickData
-				ind.currentHighIndex = 0
-			} else {
-				ind.currentHighIndex += 1
-			}
-		}
-
-		var result = ind.currentHighIndex
-
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-
-	} else {
-		if tickData > ind.currentHigh {
-			ind.currentHigh = tickData
-			ind.currentHighIndex = 0
-		} else {
-			ind.currentHighIndex += 1
-		}
+	ind.periodHistory.push(tickData)
 
-		if ind.periodHistory.Len() == ind.timePeriod {
-			var result = ind.currentHighIndex
+	if ind.periodHistory.isFull() {
+		var result = int64(ind.periodHistory.barsSinceExtreme())
 
-			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-		}
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
+}
+
+// SetTiePolicy sets which of several equal highest values within the time period the result counts the bars since,
+// the default is TiePolicyOldest
+func (ind *HhvBarsWithoutStorage) SetTiePolicy(tiePolicy TiePolicy) {
+	ind.periodHistory.setTiePolicy(tiePolicy)
+}
 
+// WriteCSV writes the HhvBars results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *HhvBars) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), intCSVColumn("value", ind.Data))
 }
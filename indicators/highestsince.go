@@ -0,0 +1,210 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"strconv"
+)
+
+type ValueAvailableActionHighestSince func(dataItemHighest float64, dataItemHighestBar int, streamBarIndex int)
+
+// A Highest Since Indicator (HighestSince), no storage, for use in other indicators
+// the running highest value and the stream bar index at which it occurred, from the bar the indicator was armed at,
+// such as the highest close since the entry bar of a trailing stop. No result is given until the indicator is armed
+// and the ticks before the armed bar are ignored, so that no value before the armed bar contributes. A value equal
+// to the highest keeps the bar of its first occurrence
+type HighestSinceWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionHighestSince
+	armed                bool
+	armedBarIndex        int
+	hasHighest           bool
+	currentHighest       float64
+	currentHighestBar    int
+}
+
+// NewHighestSinceWithoutStorage creates a Highest Since Indicator (HighestSince) without storage, the indicator
+// is created disarmed
+func NewHighestSinceWithoutStorage(valueAvailableAction ValueAvailableActionHighestSince) (indicator *HighestSinceWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	ind := HighestSinceWithoutStorage{
+		baseIndicator:        newBaseIndicator(lookback),
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+	}
+
+	return &ind, nil
+}
+
+// Arm starts tracking the highest value from the stream bar index barIndex and resets the results, the results
+// restart from the first tick received at or after the armed bar. As no ticks are retained, arming at a bar that
+// has already been received tracks from the next tick received
+func (ind *HighestSinceWithoutStorage) Arm(barIndex int) error {
+
+	// the minimum barIndex for this indicator is 1, the first bar of a stream
+	if barIndex < 1 {
+		return errors.New("barIndex is less than the minimum (1)")
+	}
+
+	ind.reset()
+	ind.armed = true
+	ind.armedBarIndex = barIndex
+
+	return nil
+}
+
+// Disarm stops tracking the highest value and resets the results, no result is given until the indicator is armed again
+func (ind *HighestSinceWithoutStorage) Disarm() {
+	ind.reset()
+	ind.armed = false
+	ind.armedBarIndex = 0
+}
+
+func (ind *HighestSinceWithoutStorage) reset() {
+	ind.hasHighest = false
+	ind.currentHighest = 0.0
+	ind.currentHighestBar = 0
+
+	ind.validFromBar = -1
+	ind.dataLength = 0
+	*ind.baseFloatBounds = *newBaseFloatBounds()
+}
+
+// IsArmed returns whether the highest value is being tracked
+func (ind *HighestSinceWithoutStorage) IsArmed() bool {
+	return ind.armed
+}
+
+// ArmedBarIndex returns the stream bar index from which the highest value is tracked, 0 while disarmed
+func (ind *HighestSinceWithoutStorage) ArmedBarIndex() int {
+	return ind.armedBarIndex
+}
+
+// CurrentHighest returns the highest value of the last result
+func (ind *HighestSinceWithoutStorage) CurrentHighest() float64 {
+	return ind.currentHighest
+}
+
+// CurrentHighestBar returns the stream bar index of the highest value of the last result
+func (ind *HighestSinceWithoutStorage) CurrentHighestBar() int {
+	return ind.currentHighestBar
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *HighestSinceWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.armed || streamBarIndex < ind.armedBarIndex {
+		return
+	}
+
+	if !ind.hasHighest || tickData > ind.currentHighest {
+		ind.hasHighest = true
+		ind.currentHighest = tickData
+		ind.currentHighestBar = streamBarIndex
+	}
+
+	highest := ind.quantize(ind.currentHighest)
+
+	ind.UpdateMinMax(highest, highest)
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(highest, ind.currentHighestBar, streamBarIndex)
+}
+
+// A Highest Since Indicator (HighestSince)
+type HighestSince struct {
+	*HighestSinceWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Highest    []float64
+	HighestBar []int
+}
+
+// NewHighestSince creates a Highest Since Indicator (HighestSince) for online usage, the indicator is created disarmed
+func NewHighestSince(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HighestSince, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := HighestSince{
+		selectData: selectData,
+	}
+	ind.HighestSinceWithoutStorage, err = NewHighestSinceWithoutStorage(
+		func(dataItemHighest float64, dataItemHighestBar int, streamBarIndex int) {
+			ind.Highest = append(ind.Highest, dataItemHighest)
+			ind.HighestBar = append(ind.HighestBar, dataItemHighestBar)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultHighestSince creates a Highest Since Indicator (HighestSince) of the close price for online usage
+func NewDefaultHighestSince() (indicator *HighestSince, err error) {
+	return NewHighestSince(gotrade.UseClosePrice)
+}
+
+// NewHighestSinceForStream creates a Highest Since Indicator (HighestSince) for online usage with a source data stream
+func NewHighestSinceForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *HighestSince, err error) {
+	ind, err := NewHighestSince(selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultHighestSinceForStream creates a Highest Since Indicator (HighestSince) of the close price for online usage with a source data stream
+func NewDefaultHighestSinceForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HighestSince, err error) {
+	ind, err := NewDefaultHighestSince()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// Arm starts tracking the highest value from the stream bar index barIndex and discards the results of the
+// previous arming
+func (ind *HighestSince) Arm(barIndex int) error {
+	err := ind.HighestSinceWithoutStorage.Arm(barIndex)
+	if err != nil {
+		return err
+	}
+
+	ind.Highest = ind.Highest[:0]
+	ind.HighestBar = ind.HighestBar[:0]
+	return nil
+}
+
+// Disarm stops tracking the highest value and discards the results
+func (ind *HighestSince) Disarm() {
+	ind.HighestSinceWithoutStorage.Disarm()
+
+	ind.Highest = ind.Highest[:0]
+	ind.HighestBar = ind.HighestBar[:0]
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *HighestSince) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// WriteCSV writes the HighestSince results as rows after a header of barIndex,highest,highestBar, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *HighestSince) WriteCSV(w io.Writer, startBarOffset int) error {
+	highestBar := csvColumn{name: "highestBar", length: len(ind.HighestBar), format: func(index int) string {
+		return strconv.Itoa(ind.HighestBar[index])
+	}}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("highest", ind.Highest), highestBar)
+}
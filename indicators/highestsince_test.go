@@ -0,0 +1,167 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a highestsincewithoutstorage", func() {
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewHighestSinceWithoutStorage(nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created with a nil data selection func", func() {
+		indicator, err := indicators.NewHighestSince(nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+
+	It("the indicator should not be armed at a barIndex below the minimum", func() {
+		indicator, _ := indicators.NewDefaultHighestSince()
+		err := indicator.Arm(0)
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		Expect(indicator.IsArmed()).To(BeFalse())
+	})
+})
+
+var _ = Describe("when calculating the highest since an armed bar (highestsince) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.HighestSince
+		bars      []gotrade.DOHLCV
+	)
+
+	// the closes peak at bar 5 before the arm bar, then rise to a lower peak at bar 14 and fall away, with the
+	// highs 3 above the close
+	closes := []float64{10, 12, 15, 18, 25, 20, 14, 11, 12, 13, 15, 17, 19, 21, 18, 16, 21, 15, 12, 10}
+	start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, closePrice := range closes {
+		bars = append(bars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), closePrice, closePrice+3.0, closePrice-1.0, closePrice, 1000.0))
+	}
+
+	receive := func(fromBar int, toBar int) {
+		for bar := fromBar; bar <= toBar; bar++ {
+			indicator.ReceiveDOHLCVTick(bars[bar-1], bar)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultHighestSince()
+	})
+
+	Context("and the indicator has not been armed", func() {
+		BeforeEach(func() {
+			receive(1, 10)
+		})
+
+		It("should have no results", func() {
+			Expect(indicator.IsArmed()).To(BeFalse())
+			Expect(indicator.Highest).To(BeEmpty())
+			Expect(indicator.Length()).To(Equal(0))
+			Expect(indicator.ValidFromBar()).To(Equal(-1))
+		})
+	})
+
+	Context("and the indicator was armed mid stream", func() {
+		BeforeEach(func() {
+			receive(1, 7)
+			indicator.Arm(8)
+			receive(8, 20)
+		})
+
+		It("should have a result for each bar from the armed bar", func() {
+			Expect(indicator.ArmedBarIndex()).To(Equal(8))
+			Expect(indicator.ValidFromBar()).To(Equal(8))
+			Expect(indicator.Highest).To(HaveLen(13))
+			Expect(indicator.HighestBar).To(HaveLen(13))
+			Expect(indicator.Length()).To(Equal(13))
+		})
+
+		It("should not be affected by the higher closes before the armed bar", func() {
+			Expect(indicator.Highest[0]).To(Equal(11.0))
+			Expect(indicator.HighestBar[0]).To(Equal(8))
+			Expect(indicator.CurrentHighest()).To(Equal(21.0))
+			Expect(indicator.MaxValue()).To(Equal(21.0))
+		})
+
+		It("should have the running highest close and the bar where it occurred", func() {
+			Expect(indicator.Highest).To(Equal([]float64{11, 12, 13, 15, 17, 19, 21, 21, 21, 21, 21, 21, 21}))
+			Expect(indicator.HighestBar).To(Equal([]int{8, 9, 10, 11, 12, 13, 14, 14, 14, 14, 14, 14, 14}))
+			Expect(indicator.CurrentHighestBar()).To(Equal(14))
+		})
+	})
+
+	Context("and the indicator was armed at a bar before it was received", func() {
+		BeforeEach(func() {
+			indicator.Arm(12)
+			receive(1, 20)
+		})
+
+		It("should ignore the ticks before the armed bar", func() {
+			Expect(indicator.ValidFromBar()).To(Equal(12))
+			Expect(indicator.Highest[0]).To(Equal(17.0))
+			Expect(indicator.CurrentHighest()).To(Equal(21.0))
+		})
+	})
+
+	Context("and the indicator was rearmed", func() {
+		BeforeEach(func() {
+			indicator.Arm(1)
+			receive(1, 15)
+			indicator.Arm(16)
+			receive(16, 20)
+		})
+
+		It("should only track the highest since the new armed bar", func() {
+			Expect(indicator.ValidFromBar()).To(Equal(16))
+			Expect(indicator.Highest).To(Equal([]float64{16, 21, 21, 21, 21}))
+			Expect(indicator.HighestBar).To(Equal([]int{16, 17, 17, 17, 17}))
+			Expect(indicator.MaxValue()).To(Equal(21.0))
+			Expect(indicator.MinValue()).To(Equal(16.0))
+		})
+	})
+
+	Context("and the indicator was disarmed", func() {
+		BeforeEach(func() {
+			indicator.Arm(1)
+			receive(1, 10)
+			indicator.Disarm()
+			receive(11, 20)
+		})
+
+		It("should have discarded the results and stopped tracking", func() {
+			Expect(indicator.IsArmed()).To(BeFalse())
+			Expect(indicator.ArmedBarIndex()).To(Equal(0))
+			Expect(indicator.Highest).To(BeEmpty())
+			Expect(indicator.HighestBar).To(BeEmpty())
+			Expect(indicator.Length()).To(Equal(0))
+			Expect(indicator.ValidFromBar()).To(Equal(-1))
+		})
+	})
+
+	Context("and the indicator tracks the high price", func() {
+		BeforeEach(func() {
+			indicator, _ = indicators.NewHighestSince(gotrade.UseHighPrice)
+			indicator.Arm(8)
+			receive(1, 20)
+		})
+
+		It("should have the running highest high", func() {
+			Expect(indicator.Highest[0]).To(Equal(14.0))
+			Expect(indicator.CurrentHighest()).To(Equal(24.0))
+			Expect(indicator.CurrentHighestBar()).To(Equal(14))
+		})
+	})
+})
+
+var _ = Describe("when creating a highestsince for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewDefaultHighestSinceForStream(stream)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
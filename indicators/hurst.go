@@ -0,0 +1,261 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// the smallest sub-window size of the rescaled range analysis, the rescaled range of fewer changes is too noisy
+const hurstMinimumSubWindowSize int = 8
+
+// the variance, relative to the square of the mean, below which the changes of a sub-window are taken to have no
+// variation so that rounding in the changes of a linear series does not produce a rescaled range
+const hurstFlatVarianceTolerance float64 = 0.000000000001
+
+// A Hurst Exponent Indicator (Hurst), no storage, for use in other indicators
+// estimates the Hurst exponent of the last timePeriod changes in value using rescaled range (R/S) analysis, above
+// 0.5 for a persistent, trending, series, 0.5 for a random walk and below 0.5 for a mean reverting series. The
+// changes are split into sub-windows of 8, 16, 32 and so on changes up to the timePeriod, the rescaled range of
+// each sub-window is averaged per size and the result is the slope of the fit of the log of the average rescaled
+// range against the log of the size, the rescaled range of the small sizes is biased upward so a random walk is a
+// little above 0.5. A result of 0.5 is given while fewer than two of the sizes have any variation.
+// The analysis is repeated in full for every bar, so each tick costs in the order of timePeriod * log(timePeriod)
+type HurstWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodHistory    *list.List
+	changes          []float64
+	hasPreviousValue bool
+	previousValue    float64
+	timePeriod       int
+}
+
+// NewHurstWithoutStorage creates a Hurst Exponent Indicator (Hurst) without storage
+func NewHurstWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *HurstWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 16, two of the smallest sub-window sizes
+	if timePeriod < 2*hurstMinimumSubWindowSize {
+		return nil, errors.New("timePeriod is less than the minimum (16)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the first change needs a previous value
+	lookback := timePeriod
+	ind := HurstWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		changes:                      make([]float64, timePeriod),
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *HurstWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.hasPreviousValue {
+		ind.previousValue = tickData
+		ind.hasPreviousValue = true
+		return
+	}
+
+	ind.periodHistory.PushBack(tickData - ind.previousValue)
+	ind.previousValue = tickData
+
+	if ind.periodHistory.Len() > ind.timePeriod {
+		var first = ind.periodHistory.Front()
+		ind.periodHistory.Remove(first)
+	}
+
+	if ind.periodHistory.Len() == ind.timePeriod {
+		i := 0
+		for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
+			ind.changes[i] = e.Value.(float64)
+			i++
+		}
+
+		ind.UpdateIndicatorWithNewValue(hurstExponent(ind.changes), streamBarIndex)
+	}
+}
+
+// hurstExponent returns the slope of the log of the average rescaled range of the sub-windows of the changes
+// against the log of the sub-window size
+func hurstExponent(changes []float64) float64 {
+	var sumX, sumY, sumXY, sumXSquare float64
+	sizes := 0
+	for size := hurstMinimumSubWindowSize; size <= len(changes); size *= 2 {
+		var totalRescaledRange float64
+		subWindows := 0
+		for start := 0; start+size <= len(changes); start += size {
+			rescaledRange, ok := rescaledRange(changes[start : start+size])
+			if ok {
+				totalRescaledRange += rescaledRange
+				subWindows++
+			}
+		}
+
+		// a size without variation in any of its sub-windows has no rescaled range
+		if subWindows == 0 || totalRescaledRange == 0.0 {
+			continue
+		}
+
+		x := math.Log(float64(size))
+		y := math.Log(totalRescaledRange / float64(subWindows))
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXSquare += x * x
+		sizes++
+	}
+
+	if sizes < 2 {
+		return 0.5
+	}
+
+	n := float64(sizes)
+	return (n*sumXY - sumX*sumY) / (n*sumXSquare - sumX*sumX)
+}
+
+// rescaledRange returns the range of the cumulative deviations of the changes from their mean divided by their
+// standard deviation, and false when the changes have no variation
+func rescaledRange(changes []float64) (float64, bool) {
+	n := float64(len(changes))
+	var mean float64
+	for _, change := range changes {
+		mean += change
+	}
+	mean /= n
+
+	var cumulativeDeviation, maxDeviation, minDeviation, sumSquareDeviation float64
+	for _, change := range changes {
+		deviation := change - mean
+		cumulativeDeviation += deviation
+		maxDeviation = math.Max(maxDeviation, cumulativeDeviation)
+		minDeviation = math.Min(minDeviation, cumulativeDeviation)
+		sumSquareDeviation += deviation * deviation
+	}
+
+	variance := sumSquareDeviation / n
+	if variance <= hurstFlatVarianceTolerance*mean*mean {
+		return 0.0, false
+	}
+
+	return (maxDeviation - minDeviation) / math.Sqrt(variance), true
+}
+
+// A Hurst Exponent Indicator (Hurst)
+type Hurst struct {
+	*HurstWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewHurst creates a Hurst Exponent Indicator (Hurst) for online usage
+func NewHurst(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Hurst, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Hurst{
+		selectData: selectData,
+	}
+
+	ind.HurstWithoutStorage, err = NewHurstWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultHurst creates a Hurst Exponent Indicator (Hurst) for online usage with default parameters
+//	- timePeriod: 100
+func NewDefaultHurst() (indicator *Hurst, err error) {
+	timePeriod := 100
+	return NewHurst(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewHurstWithSrcLen creates a Hurst Exponent Indicator (Hurst) for offline usage
+func NewHurstWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Hurst, err error) {
+	ind, err := NewHurst(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultHurstWithSrcLen creates a Hurst Exponent Indicator (Hurst) for offline usage with default parameters
+func NewDefaultHurstWithSrcLen(sourceLength uint) (indicator *Hurst, err error) {
+	ind, err := NewDefaultHurst()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewHurstForStream creates a Hurst Exponent Indicator (Hurst) for online usage with a source data stream
+func NewHurstForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Hurst, err error) {
+	ind, err := NewHurst(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultHurstForStream creates a Hurst Exponent Indicator (Hurst) for online usage with a source data stream
+func NewDefaultHurstForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Hurst, err error) {
+	ind, err := NewDefaultHurst()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewHurstForStreamWithSrcLen creates a Hurst Exponent Indicator (Hurst) for offline usage with a source data stream
+func NewHurstForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Hurst, err error) {
+	ind, err := NewHurstWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultHurstForStreamWithSrcLen creates a Hurst Exponent Indicator (Hurst) for offline usage with a source data stream
+func NewDefaultHurstForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Hurst, err error) {
+	ind, err := NewDefaultHurstWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Hurst) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Hurst results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Hurst) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Hurst results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Hurst) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
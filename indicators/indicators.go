@@ -39,6 +39,8 @@ var (
 	ErrLookbackPeriodMustBeGreaterThanZero  = errors.New("Lookback period must be greater than 0")
 	ErrValueAvailableActionIsNil            = errors.New("A ValueAvailableAction is required")
 	ErrDOHLCVDataSelectFuncIsNil            = errors.New("A DOHLCVDataSelectionFunc is required")
+	ErrNoTickToRevise                       = errors.New("No tick has been received to revise")
+	ErrCenteringRequiresSourceLength        = errors.New("A centered indicator requires a known source length")
 	ErrStrBelowMinimum                      = "is less than the minimum"
 	ErrStrAboveMaximum                      = "is greater than the maximum"
 
@@ -75,9 +77,28 @@ type IndicatorWithIntBounds interface {
 	MaxValue() int64
 }
 
+// A FloatIndicatorWithoutStorage is an indicator without storage that consumes and produces float data
+type FloatIndicatorWithoutStorage interface {
+	Indicator
+	IndicatorWithFloatBounds
+	// consumes a source data float tick
+	ReceiveTick(tickData float64, streamBarIndex int)
+}
+
+// A DOHLCVFloatIndicatorWithoutStorage is an indicator without storage that consumes DOHLCV data and produces float data
+type DOHLCVFloatIndicatorWithoutStorage interface {
+	Indicator
+	IndicatorWithFloatBounds
+	// consumes a source data DOHLCV tick
+	ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
+}
+
 type baseFloatBounds struct {
-	minValue float64
-	maxValue float64
+	minValue        float64
+	maxValue        float64
+	hasBoundsFilter bool
+	filterMin       float64
+	filterMax       float64
 }
 
 func newBaseFloatBounds() *baseFloatBounds {
@@ -93,18 +114,57 @@ func (ind *baseFloatBounds) MaxValue() float64 {
 	return ind.maxValue
 }
 
+// SetBoundsFilter sets the inclusive range of the results that update the bounds, so that outliers such as those
+// of an indicator that has not yet settled do not skew them. The results outside the range are still made
+// available, they only do not update the MinValue and MaxValue. It should be set before any ticks are received
+func (ind *baseFloatBounds) SetBoundsFilter(min float64, max float64) {
+	ind.hasBoundsFilter = true
+	ind.filterMin = min
+	ind.filterMax = max
+}
+
+// isWithinBoundsFilter returns whether the candidate is within the bounds filter, when one is set
+func (ind *baseFloatBounds) isWithinBoundsFilter(candidate float64) bool {
+	return !ind.hasBoundsFilter || (candidate >= ind.filterMin && candidate <= ind.filterMax)
+}
+
 func (ind *baseFloatBounds) UpdateMinMax(minCandidate float64, maxCandidate float64) {
 	// update the maximum result value
-	if maxCandidate > ind.maxValue {
+	if maxCandidate > ind.maxValue && ind.isWithinBoundsFilter(maxCandidate) {
 		ind.maxValue = maxCandidate
 	}
 
 	// update the minimum result value
-	if minCandidate < ind.minValue {
+	if minCandidate < ind.minValue && ind.isWithinBoundsFilter(minCandidate) {
 		ind.minValue = minCandidate
 	}
 }
 
+// baseQuantizer rounds the results of an indicator to the nearest multiple of a tick size, such as that of an instrument
+type baseQuantizer struct {
+	tickSize float64
+}
+
+func newBaseQuantizer() *baseQuantizer {
+	return &baseQuantizer{}
+}
+
+// Quantize rounds each result to the nearest multiple of tickSize before the bounds are updated and the result
+// is made available, a tickSize of 0 leaves the results unrounded
+func (ind *baseQuantizer) Quantize(tickSize float64) {
+	if tickSize < 0.0 {
+		tickSize = 0.0
+	}
+	ind.tickSize = tickSize
+}
+
+func (ind *baseQuantizer) quantize(value float64) float64 {
+	if ind.tickSize == 0.0 {
+		return value
+	}
+	return math.Floor(value/ind.tickSize+0.5) * ind.tickSize
+}
+
 type baseIntBounds struct {
 	minValue int64
 	maxValue int64
@@ -139,6 +199,7 @@ type baseIndicator struct {
 	validFromBar   int
 	dataLength     int
 	lookbackPeriod int
+	barIndexSkip   int
 }
 
 func newBaseIndicator(lookbackPeriod int) *baseIndicator {
@@ -154,6 +215,25 @@ func (ind *baseIndicator) GetLookbackPeriod() int {
 	return ind.lookbackPeriod
 }
 
+// BarsUntilValid returns the number of source data bars consumed before the first result, for a composite
+// indicator this is the combined warm-up of the indicators it is built from, as held by its lookback period
+func (ind *baseIndicator) BarsUntilValid() int {
+	return ind.lookbackPeriod
+}
+
+// SetBarIndexStep sets the step between the stream bar indices of consecutive bars, as given by a stream with a
+// custom bar indexing, so that a result displaced by a number of bars is displaced by that number of steps
+func (ind *baseIndicator) SetBarIndexStep(step int) {
+	if step > 0 {
+		ind.barIndexSkip = step - 1
+	}
+}
+
+// barIndexSpan returns the span of the stream bar indices of the number of bars
+func (ind *baseIndicator) barIndexSpan(bars int) int {
+	return bars * (ind.barIndexSkip + 1)
+}
+
 func (ind *baseIndicator) Length() int {
 	return ind.dataLength
 }
@@ -170,6 +250,35 @@ func (ind *baseIndicator) SetValidFromBar(streamBarIndex int) {
 	}
 }
 
+// valuesInRange returns the portion of data for the inclusive bar range fromBar to toBar,
+// where data[0] holds the result for the bar validFromBar, clamped to the available results
+func valuesInRange(data []float64, validFromBar int, fromBar int, toBar int) []float64 {
+	// no results are available yet
+	if validFromBar == -1 || len(data) == 0 {
+		return []float64{}
+	}
+
+	// translate the bar range into offsets into the data
+	fromIndex := fromBar - validFromBar
+	toIndex := toBar - validFromBar + 1
+
+	// clamp to the valid region
+	if fromIndex < 0 {
+		fromIndex = 0
+	}
+
+	if toIndex > len(data) {
+		toIndex = len(data)
+	}
+
+	// the range lies entirely outside of the valid region
+	if fromIndex >= toIndex {
+		return []float64{}
+	}
+
+	return data[fromIndex:toIndex]
+}
+
 type baseIndicatorWithTimePeriod struct {
 	timePeriod int
 }
@@ -177,19 +286,51 @@ type baseIndicatorWithTimePeriod struct {
 type baseIndicatorWithFloatBounds struct {
 	*baseIndicator
 	*baseFloatBounds
+	*baseQuantizer
+	*baseOutputTransform
+	*baseWarmupFill
+	*baseMinValidBars
+	*baseActionErrorHandler
 	valueAvailableAction ValueAvailableActionFloat
+	offset               int
+	latestValue          float64
 }
 
 func newBaseIndicatorWithFloatBounds(lookbackPeriod int, valueAvailableAction ValueAvailableActionFloat) *baseIndicatorWithFloatBounds {
 	ind := baseIndicatorWithFloatBounds{
-		baseIndicator:        newBaseIndicator(lookbackPeriod),
-		baseFloatBounds:      newBaseFloatBounds(),
-		valueAvailableAction: valueAvailableAction,
+		baseIndicator:          newBaseIndicator(lookbackPeriod),
+		baseFloatBounds:        newBaseFloatBounds(),
+		baseQuantizer:          newBaseQuantizer(),
+		baseOutputTransform:    newBaseOutputTransform(),
+		baseWarmupFill:         newBaseWarmupFill(),
+		baseMinValidBars:       newBaseMinValidBars(),
+		baseActionErrorHandler: newBaseActionErrorHandler(),
+		valueAvailableAction:   valueAvailableAction,
 	}
 	return &ind
 }
 
 func (ind *baseIndicatorWithFloatBounds) UpdateIndicatorWithNewValue(newValue float64, streamBarIndex int) {
+	// transform the result, if required, a transform may have no result to make available
+	newValue, isAvailable := ind.transform(newValue)
+	if !isAvailable {
+		return
+	}
+
+	// withhold the result until the minimum valid bars have been received, if required
+	if ind.withholdResult() {
+		return
+	}
+
+	// displace the result by the offset, if any
+	streamBarIndex += ind.barIndexSpan(ind.offset)
+
+	// fill the lookback period ahead of the first result, if required
+	ind.fillWarmup(streamBarIndex)
+
+	// round the results to the tick size, if any, before they are bounded and made available
+	newValue = ind.quantize(newValue)
+
 	// increment the number of results this indicator can be expected to return
 	ind.IncDataLength()
 
@@ -199,13 +340,86 @@ func (ind *baseIndicatorWithFloatBounds) UpdateIndicatorWithNewValue(newValue fl
 	// update the min max data bounds
 	ind.UpdateMinMax(newValue, newValue)
 
+	ind.latestValue = newValue
+
 	// notify of a new result value though the value available action
-	ind.valueAvailableAction(newValue, streamBarIndex)
+	ind.notifyValueAvailable(newValue, streamBarIndex)
+}
+
+// LatestValue returns the latest result made available, 0.0 before the first result
+func (ind *baseIndicatorWithFloatBounds) LatestValue() float64 {
+	return ind.latestValue
+}
+
+// Offset returns the number of bars each result is displaced by, positive into the future and negative into the past,
+// with a bar index step greater than 1 the stream bar index is displaced by that number of steps
+func (ind *baseIndicatorWithFloatBounds) Offset() int {
+	return ind.offset
+}
+
+// setOffset displaces the streamBarIndex of each result by offset bars without changing the result
+func (ind *baseIndicatorWithFloatBounds) setOffset(offset int) error {
+	// the minimum offset is -100000, a displacement into the past
+	if offset < -MaximumLookbackPeriod {
+		return errors.New("offset is less than the minimum (-100000)")
+	}
+
+	// check the maximum offset
+	if offset > MaximumLookbackPeriod {
+		return errors.New("offset is greater than the maximum (100000)")
+	}
+
+	ind.offset = offset
+	return nil
+}
+
+// setCentered displaces the streamBarIndex of each result back by half the timePeriod, onto the bar at the center
+// of the window of the result, this uses future data and so is only for offline usage
+func (ind *baseIndicatorWithFloatBounds) setCentered(timePeriod int) error {
+	return ind.setOffset(-(timePeriod / 2))
+}
+
+// baseIndicatorWithFloatBoundsState is a snapshot of the base indicator state, allowing an indicator
+// to back out the effect of the most recently received tick
+type baseIndicatorWithFloatBoundsState struct {
+	validFromBar      int
+	dataLength        int
+	minValue          float64
+	maxValue          float64
+	hasPreviousOutput bool
+	previousOutput    float64
+	withheldResults   int
+	latestValue       float64
+}
+
+func (ind *baseIndicatorWithFloatBounds) saveState() baseIndicatorWithFloatBoundsState {
+	return baseIndicatorWithFloatBoundsState{
+		validFromBar:      ind.validFromBar,
+		dataLength:        ind.dataLength,
+		minValue:          ind.minValue,
+		maxValue:          ind.maxValue,
+		hasPreviousOutput: ind.hasPreviousOutput,
+		previousOutput:    ind.previousOutput,
+		withheldResults:   ind.withheldResults,
+		latestValue:       ind.latestValue,
+	}
+}
+
+func (ind *baseIndicatorWithFloatBounds) restoreState(state baseIndicatorWithFloatBoundsState) {
+	ind.validFromBar = state.validFromBar
+	ind.dataLength = state.dataLength
+	ind.minValue = state.minValue
+	ind.maxValue = state.maxValue
+	ind.hasPreviousOutput = state.hasPreviousOutput
+	ind.previousOutput = state.previousOutput
+	ind.withheldResults = state.withheldResults
+	ind.latestValue = state.latestValue
 }
 
 type baseIndicatorWithFloatBoundsAroon struct {
 	*baseIndicator
 	*baseFloatBounds
+	*baseQuantizer
 	valueAvailableAction ValueAvailableActionAroon
 }
 
@@ -213,12 +427,17 @@ func newBaseIndicatorWithFloatBoundsAroon(lookbackPeriod int, valueAvailableActi
 	ind := baseIndicatorWithFloatBoundsAroon{
 		baseIndicator:        newBaseIndicator(lookbackPeriod),
 		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
 		valueAvailableAction: valueAvailableAction,
 	}
 	return &ind
 }
 
 func (ind *baseIndicatorWithFloatBoundsAroon) UpdateIndicatorWithNewValue(newAroonUpValue float64, newAroonDwnValue float64, streamBarIndex int) {
+	// round the results to the tick size, if any, before they are bounded and made available
+	newAroonUpValue = ind.quantize(newAroonUpValue)
+	newAroonDwnValue = ind.quantize(newAroonDwnValue)
+
 	// increment the number of results this indicator can be expected to return
 	ind.IncDataLength()
 
@@ -232,9 +451,45 @@ func (ind *baseIndicatorWithFloatBoundsAroon) UpdateIndicatorWithNewValue(newAro
 	ind.valueAvailableAction(newAroonUpValue, newAroonDwnValue, streamBarIndex)
 }
 
+type baseIndicatorWithFloatBoundsDrawdown struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+	valueAvailableAction ValueAvailableActionDrawdown
+}
+
+func newBaseIndicatorWithFloatBoundsDrawdown(lookbackPeriod int, valueAvailableAction ValueAvailableActionDrawdown) *baseIndicatorWithFloatBoundsDrawdown {
+	ind := baseIndicatorWithFloatBoundsDrawdown{
+		baseIndicator:        newBaseIndicator(lookbackPeriod),
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+	}
+	return &ind
+}
+
+func (ind *baseIndicatorWithFloatBoundsDrawdown) UpdateIndicatorWithNewValue(newDrawdownValue float64, newMaxDrawdownValue float64, streamBarIndex int) {
+	// round the results to the tick size, if any, before they are bounded and made available
+	newDrawdownValue = ind.quantize(newDrawdownValue)
+	newMaxDrawdownValue = ind.quantize(newMaxDrawdownValue)
+
+	// increment the number of results this indicator can be expected to return
+	ind.IncDataLength()
+
+	// set the streamBarIndex from which this indicator returns valid results
+	ind.SetValidFromBar(streamBarIndex)
+
+	// update the min max data bounds
+	ind.UpdateMinMax(math.Min(newDrawdownValue, newMaxDrawdownValue), math.Max(newDrawdownValue, newMaxDrawdownValue))
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(newDrawdownValue, newMaxDrawdownValue, streamBarIndex)
+}
+
 type baseIndicatorWithFloatBoundsBollinger struct {
 	*baseIndicator
 	*baseFloatBounds
+	*baseQuantizer
 	valueAvailableAction ValueAvailableActionBollinger
 }
 
@@ -242,12 +497,18 @@ func newBaseIndicatorWithFloatBoundsBollinger(lookbackPeriod int, valueAvailable
 	ind := baseIndicatorWithFloatBoundsBollinger{
 		baseIndicator:        newBaseIndicator(lookbackPeriod),
 		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
 		valueAvailableAction: valueAvailableAction,
 	}
 	return &ind
 }
 
 func (ind *baseIndicatorWithFloatBoundsBollinger) UpdateIndicatorWithNewValue(newUpperBandValue float64, newMiddleBandValue float64, newLowerBandValue float64, streamBarIndex int) {
+	// round the results to the tick size, if any, before they are bounded and made available
+	newUpperBandValue = ind.quantize(newUpperBandValue)
+	newMiddleBandValue = ind.quantize(newMiddleBandValue)
+	newLowerBandValue = ind.quantize(newLowerBandValue)
+
 	// increment the number of results this indicator can be expected to return
 	ind.IncDataLength()
 
@@ -264,6 +525,7 @@ func (ind *baseIndicatorWithFloatBoundsBollinger) UpdateIndicatorWithNewValue(ne
 type baseIndicatorWithFloatBoundsStoch struct {
 	*baseIndicator
 	*baseFloatBounds
+	*baseQuantizer
 	valueAvailableAction ValueAvailableActionStoch
 }
 
@@ -271,12 +533,17 @@ func newBaseIndicatorWithFloatBoundsStoch(lookbackPeriod int, valueAvailableActi
 	ind := baseIndicatorWithFloatBoundsStoch{
 		baseIndicator:        newBaseIndicator(lookbackPeriod),
 		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
 		valueAvailableAction: valueAvailableAction,
 	}
 	return &ind
 }
 
 func (ind *baseIndicatorWithFloatBoundsStoch) UpdateIndicatorWithNewValue(newSlowKValue float64, newSlowDValue float64, streamBarIndex int) {
+	// round the results to the tick size, if any, before they are bounded and made available
+	newSlowKValue = ind.quantize(newSlowKValue)
+	newSlowDValue = ind.quantize(newSlowDValue)
+
 	// increment the number of results this indicator can be expected to return
 	ind.IncDataLength()
 
@@ -293,6 +560,46 @@ func (ind *baseIndicatorWithFloatBoundsStoch) UpdateIndicatorWithNewValue(newSlo
 	ind.valueAvailableAction(newSlowKValue, newSlowDValue, streamBarIndex)
 }
 
+type baseIndicatorWithFloatBoundsMulti struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+	valueAvailableAction ValueAvailableActionMulti
+}
+
+func newBaseIndicatorWithFloatBoundsMulti(lookbackPeriod int, valueAvailableAction ValueAvailableActionMulti) *baseIndicatorWithFloatBoundsMulti {
+	ind := baseIndicatorWithFloatBoundsMulti{
+		baseIndicator:        newBaseIndicator(lookbackPeriod),
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+	}
+	return &ind
+}
+
+func (ind *baseIndicatorWithFloatBoundsMulti) UpdateIndicatorWithNewValue(newValues []float64, streamBarIndex int) {
+	// round the results to the tick size, if any, before they are bounded and made available, the results are
+	// copied so that the value available action may keep them
+	values := make([]float64, len(newValues))
+	for i := range newValues {
+		values[i] = ind.quantize(newValues[i])
+	}
+
+	// increment the number of results this indicator can be expected to return
+	ind.IncDataLength()
+
+	// set the streamBarIndex from which this indicator returns valid results
+	ind.SetValidFromBar(streamBarIndex)
+
+	// update the min max data bounds
+	for i := range values {
+		ind.UpdateMinMax(values[i], values[i])
+	}
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(values, streamBarIndex)
+}
+
 type baseIndicatorWithIntBounds struct {
 	*baseIndicator
 	*baseIntBounds
@@ -322,6 +629,35 @@ func (ind *baseIndicatorWithIntBounds) UpdateIndicatorWithNewValue(newValue int6
 	ind.valueAvailableAction(newValue, streamBarIndex)
 }
 
+type baseIndicatorWithNumericBounds[T Numeric[T]] struct {
+	*baseIndicator
+	*baseFloatBounds
+	valueAvailableAction ValueAvailableActionNumeric[T]
+}
+
+func newBaseIndicatorWithNumericBounds[T Numeric[T]](lookbackPeriod int, valueAvailableAction ValueAvailableActionNumeric[T]) *baseIndicatorWithNumericBounds[T] {
+	ind := baseIndicatorWithNumericBounds[T]{
+		baseIndicator:        newBaseIndicator(lookbackPeriod),
+		baseFloatBounds:      newBaseFloatBounds(),
+		valueAvailableAction: valueAvailableAction,
+	}
+	return &ind
+}
+
+func (ind *baseIndicatorWithNumericBounds[T]) UpdateIndicatorWithNewValue(newValue T, streamBarIndex int) {
+	// increment the number of results this indicator can be expected to return
+	ind.IncDataLength()
+
+	// set the streamBarIndex from which this indicator returns valid results
+	ind.SetValidFromBar(streamBarIndex)
+
+	// update the min max data bounds, tracked as float64 for charting
+	ind.UpdateMinMax(newValue.Float64(), newValue.Float64())
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(newValue, streamBarIndex)
+}
+
 type ValueAvailableActionFloat func(dataItem float64, streamBarIndex int)
 type ValueAvailableActionInt func(dataItem int64, streamBarIndex int)
 type ValueAvailableActionDOHLCV func(dataItem gotrade.DOHLCV, streamBarIndex int)
@@ -329,4 +665,7 @@ type ValueAvailableActionBollinger func(dataItemUpperBand float64, dataItemMiddl
 type ValueAvailableActionMacd func(dataItemMacd float64, dataItemSignal float64, dataItemHistogram float64, streamBarIndex int)
 type ValueAvailableActionAroon func(dataItemAroonUp float64, dataItemAroonDown float64, streamBarIndex int)
 type ValueAvailableActionStoch func(dataItemK float64, dataItemD float64, streamBarIndex int)
+type ValueAvailableActionDrawdown func(dataItemDrawdown float64, dataItemMaxDrawdown float64, streamBarIndex int)
 type ValueAvailableActionLinearReg func(dataItem float64, slope float64, intercept float64, streamBarIndex int)
+type ValueAvailableActionNumeric[T Numeric[T]] func(dataItem T, streamBarIndex int)
+type ValueAvailableActionMulti func(dataItems []float64, streamBarIndex int)
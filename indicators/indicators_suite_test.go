@@ -602,6 +602,23 @@ func GetDataMinDOHLCV(dohlcvArray []gotrade.DOHLCV, selectData gotrade.DOHLCVDat
 	return min
 }
 
+// bars whose range steps by step each bar, for the directional movement indicators
+func steppedDOHLCVData(step float64) []gotrade.DOHLCV {
+	start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	bars := []gotrade.DOHLCV{}
+	for i := 0; i < 40; i++ {
+		price := 100.0 + step*float64(i)
+		bars = append(bars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price+1.0, price-1.0, price, 1000.0))
+	}
+	return bars
+}
+
+func receiveAllDOHLCVTicks(receiver gotrade.DOHLCVTickReceiver, bars []gotrade.DOHLCV) {
+	for i := range bars {
+		receiver.ReceiveDOHLCVTick(bars[i], i+1)
+	}
+}
+
 func GetDataMaxMacd(macd []float64, signal []float64, histogram []float64) float64 {
 	max := math.SmallestNonzeroFloat64
 
@@ -833,3 +850,7 @@ func fakeMacdValAvailable(dataItemMacd float64, dataItemSignal float64, dataItem
 func FakeStochValueAvailable(dataItemK float64, dataItemD float64, streamBarIndex int) {
 
 }
+
+func fakeNumericValAvailable(dataItem indicators.NumericFloat64, streamBarIndex int) {
+
+}
@@ -0,0 +1,175 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// An Inertia Indicator (Inertia), no storage, for use in other indicators
+// the RelVolIndex of the stdDevPeriod and rsiPeriod smoothed by the LinReg of the regressionPeriod, the end point
+// of the regression line fitted to the RelVolIndex, as given by Dorsey. The direction of the volatility is taken as
+// the inertia of the trend, a result above 50 is given while the inertia is positive and below 50 while it is negative
+type InertiaWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	relVolIndex *RelVolIndexWithoutStorage
+	linReg      *LinRegWithoutStorage
+}
+
+// NewInertiaWithoutStorage creates an Inertia Indicator (Inertia) without storage
+func NewInertiaWithoutStorage(stdDevPeriod int, rsiPeriod int, regressionPeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *InertiaWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum regressionPeriod for this indicator is 2
+	if regressionPeriod < 2 {
+		return nil, errors.New("regressionPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum regressionPeriod
+	if regressionPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("regressionPeriod is greater than the maximum (100000)")
+	}
+
+	ind := InertiaWithoutStorage{}
+
+	ind.linReg, err = NewLinRegWithoutStorage(regressionPeriod,
+		func(dataItem float64, slope float64, intercept float64, streamBarIndex int) {
+			ind.UpdateIndicatorWithNewValue(dataItem, streamBarIndex)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ind.relVolIndex, err = NewRelVolIndexWithoutStorage(stdDevPeriod, rsiPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.linReg.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := ind.relVolIndex.GetLookbackPeriod() + ind.linReg.GetLookbackPeriod()
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction)
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *InertiaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.relVolIndex.ReceiveTick(tickData, streamBarIndex)
+}
+
+// An Inertia Indicator (Inertia)
+type Inertia struct {
+	*InertiaWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewInertia creates an Inertia Indicator (Inertia) for online usage
+func NewInertia(stdDevPeriod int, rsiPeriod int, regressionPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Inertia, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Inertia{
+		selectData: selectData,
+	}
+
+	ind.InertiaWithoutStorage, err = NewInertiaWithoutStorage(stdDevPeriod, rsiPeriod, regressionPeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultInertia creates an Inertia Indicator (Inertia) for online usage with default parameters
+//	- stdDevPeriod: 10
+//	- rsiPeriod: 14
+//	- regressionPeriod: 20
+func NewDefaultInertia() (indicator *Inertia, err error) {
+	stdDevPeriod := 10
+	rsiPeriod := 14
+	regressionPeriod := 20
+	return NewInertia(stdDevPeriod, rsiPeriod, regressionPeriod, gotrade.UseClosePrice)
+}
+
+// NewInertiaWithSrcLen creates an Inertia Indicator (Inertia) for offline usage
+func NewInertiaWithSrcLen(sourceLength uint, stdDevPeriod int, rsiPeriod int, regressionPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Inertia, err error) {
+	ind, err := NewInertia(stdDevPeriod, rsiPeriod, regressionPeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultInertiaWithSrcLen creates an Inertia Indicator (Inertia) for offline usage with default parameters
+func NewDefaultInertiaWithSrcLen(sourceLength uint) (indicator *Inertia, err error) {
+	ind, err := NewDefaultInertia()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewInertiaForStream creates an Inertia Indicator (Inertia) for online usage with a source data stream
+func NewInertiaForStream(priceStream gotrade.DOHLCVStreamSubscriber, stdDevPeriod int, rsiPeriod int, regressionPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Inertia, err error) {
+	ind, err := NewInertia(stdDevPeriod, rsiPeriod, regressionPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultInertiaForStream creates an Inertia Indicator (Inertia) for online usage with a source data stream
+func NewDefaultInertiaForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Inertia, err error) {
+	ind, err := NewDefaultInertia()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewInertiaForStreamWithSrcLen creates an Inertia Indicator (Inertia) for offline usage with a source data stream
+func NewInertiaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, stdDevPeriod int, rsiPeriod int, regressionPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Inertia, err error) {
+	ind, err := NewInertiaWithSrcLen(sourceLength, stdDevPeriod, rsiPeriod, regressionPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultInertiaForStreamWithSrcLen creates an Inertia Indicator (Inertia) for offline usage with a source data stream
+func NewDefaultInertiaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Inertia, err error) {
+	ind, err := NewDefaultInertiaWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Inertia) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Inertia results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Inertia) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Inertia results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Inertia) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -1,10 +1,9 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
+	"io"
 )
 
 // A Kaufman Adaptive Moving Average Indicator (Kama), no storage, for use in other indicators
@@ -12,16 +11,17 @@ type KamaWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
 
 	// private variables
-	periodTotal   float64
-	periodHistory *list.List
-	periodCounter int
-	constantMax   float64
-	constantDiff  float64
-	sumROC        float64
-	periodROC     float64
-	previousClose float64
-	previousKama  float64
-	timePeriod    int
+	core          *kamaCore[NumericFloat64]
+	lastTickState *kamaState
+	tickMode      TickMode
+	provisional   bool
+}
+
+// kamaState is the Kama state prior to the most recently received tick, used to revise that tick, the core keeps
+// its own prior state
+type kamaState struct {
+	base           baseIndicatorWithFloatBoundsState
+	streamBarIndex int
 }
 
 // NewKamaWithoutStorage creates a Kaufman Adaptive Moving Average Indicator (Kama) without storage
@@ -45,14 +45,7 @@ func NewKamaWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAc
 	lookback := timePeriod
 	ind := KamaWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
-		periodCounter:                (timePeriod + 1) * -1,
-		constantMax:                  float64(2.0 / (30.0 + 1.0)),
-		constantDiff:                 float64((2.0 / (2.0 + 1.0)) - (2.0 / (30.0 + 1.0))),
-		sumROC:                       0.0,
-		periodROC:                    0.0,
-		periodHistory:                list.New(),
-		previousClose:                math.SmallestNonzeroFloat64,
-		timePeriod:                   timePeriod,
+		core:                         newKamaCore[NumericFloat64](timePeriod),
 	}
 
 	return &ind, nil
@@ -145,78 +138,242 @@ func NewDefaultKamaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DO
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *Kama) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// the stored result of a provisional bar is replaced by that of the update
+	if ind.undoProvisionalBar() {
+		ind.Data = ind.Data[:ind.Length()]
+	}
+
 	var selectedData = ind.selectData(tickData)
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
 
-func (ind *KamaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodCounter += 1
-	ind.periodHistory.PushBack(tickData)
-
-	if ind.periodCounter <= 0 {
-		if ind.previousClose > math.SmallestNonzeroFloat64 {
-			ind.sumROC += math.Abs(tickData - ind.previousClose)
-		}
+// ReviseLastTick replaces the most recently received DOHLCV tick with a revised bar, such as a late volume or
+// close correction, without replaying the source data. Only the most recent tick can be revised
+func (ind *Kama) ReviseLastTick(tickData gotrade.DOHLCV) error {
+	resultProduced, streamBarIndex, err := ind.undoLastTick()
+	if err != nil {
+		return err
 	}
-	if ind.periodCounter == 0 {
-		var er float64 = 0.0
-		var sc float64 = 0.0
-		var closeMinusN float64 = ind.periodHistory.Front().Value.(float64)
-		ind.previousKama = ind.previousClose
-		ind.periodROC = tickData - closeMinusN
 
-		// calculate the efficiency ratio
-		if ind.sumROC <= ind.periodROC || isZero(ind.sumROC) {
-			er = 1.0
-		} else {
-			er = math.Abs(ind.periodROC / ind.sumROC)
-		}
+	// the stored result of the revised tick is replaced by that of the revision
+	if resultProduced {
+		ind.Data = ind.Data[:ind.Length()]
+	}
 
-		sc = (er * ind.constantDiff) + ind.constantMax
-		sc *= sc
-		ind.previousKama = ((tickData - ind.previousKama) * sc) + ind.previousKama
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+	return nil
+}
 
-		result := ind.previousKama
+// SetTickMode sets whether each tick received is a closed bar, the default, or a provisional update of the forming bar
+func (ind *KamaWithoutStorage) SetTickMode(tickMode TickMode) {
+	ind.tickMode = tickMode
+}
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+// CommitBar closes the forming bar, persisting the previousKama and period history of its latest update so that the
+// next tick received starts a new bar. It has no effect unless the tick mode is TickModeOnEveryTick
+func (ind *KamaWithoutStorage) CommitBar() {
+	ind.provisional = false
+}
 
-	} else if ind.periodCounter > 0 {
+// undoProvisionalBar backs out the latest update of an uncommitted bar, returning true if it produced a result
+func (ind *KamaWithoutStorage) undoProvisionalBar() bool {
+	if !ind.provisional {
+		return false
+	}
 
-		var er float64 = 0.0
-		var sc float64 = 0.0
-		var closeMinusN float64 = ind.periodHistory.Front().Value.(float64)
-		var closeMinusN1 float64 = ind.periodHistory.Front().Next().Value.(float64)
-		ind.periodROC = tickData - closeMinusN1
+	ind.provisional = false
+	resultProduced, _, _ := ind.undoLastTick()
+	return resultProduced
+}
 
-		ind.sumROC -= math.Abs(closeMinusN1 - closeMinusN)
-		ind.sumROC += math.Abs(tickData - ind.previousClose)
+// ReceiveTick consumes a source data float price tick, in TickModeOnEveryTick each tick before CommitBar
+// replaces the previous update of the forming bar and the value available action is notified again for the bar
+func (ind *KamaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.undoProvisionalBar()
+	ind.receiveTick(tickData, streamBarIndex)
+	ind.provisional = ind.tickMode == TickModeOnEveryTick
+}
 
-		// calculate the efficiency ratio
-		if ind.sumROC <= ind.periodROC || isZero(ind.sumROC) {
-			er = 1.0
-		} else {
-			er = math.Abs(ind.periodROC / ind.sumROC)
-		}
+func (ind *KamaWithoutStorage) receiveTick(tickData float64, streamBarIndex int) {
+	// keep the prior state so that this tick can be revised
+	lastTickState := kamaState{
+		base:           ind.saveState(),
+		streamBarIndex: streamBarIndex,
+	}
 
-		sc = (er * ind.constantDiff) + ind.constantMax
-		sc *= sc
-		ind.previousKama = ((tickData - ind.previousKama) * sc) + ind.previousKama
+	if result, isAvailable := ind.core.receive(NumericFloat64(tickData)); isAvailable {
+		ind.UpdateIndicatorWithNewValue(float64(result), streamBarIndex)
+	}
 
-		result := ind.previousKama
+	ind.lastTickState = &lastTickState
+}
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+// ReviseLastTick replaces the most recently received tick with a revised value, such as a late close correction,
+// without replaying the source data. Only the most recent tick can be revised, the value available action is
+// notified again for the same streamBarIndex if the revised tick produces a result
+func (ind *KamaWithoutStorage) ReviseLastTick(tickData float64) error {
+	_, streamBarIndex, err := ind.undoLastTick()
+	if err != nil {
+		return err
 	}
 
-	ind.previousClose = tickData
+	ind.ReceiveTick(tickData, streamBarIndex)
+	return nil
+}
 
-	if ind.periodHistory.Len() > (ind.timePeriod + 1) {
-		var first = ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
+// undoLastTick backs out the core and base updates of the most recently received tick
+func (ind *KamaWithoutStorage) undoLastTick() (resultProduced bool, streamBarIndex int, err error) {
+	if ind.lastTickState == nil {
+		return false, 0, ErrNoTickToRevise
 	}
 
+	state := ind.lastTickState
+	resultProduced = ind.Length() > state.base.dataLength
+
+	ind.core.undoLastTick()
+	ind.restoreState(state.base)
+	ind.lastTickState = nil
+
+	return resultProduced, state.streamBarIndex, nil
 }
 
 func isZero(value float64) bool {
 	var epsilon float64 = 0.00000000000001
 	return (((-epsilon) < value) && (value < epsilon))
 }
+
+// ValuesInRange returns the Kama results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Kama) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Kama results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Kama) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
+
+// A Kaufman Adaptive Moving Average Indicator (Kama) that keeps only its most recent results, for long lived online
+// usage where the storage should not grow without bound. The results are held in a ring of the capacity, each result
+// once the ring is full overwrites the oldest. The Length, MinValue and MaxValue are those of all of the results
+// since the indicator was created, not only of the results retained
+type KamaRingStorage struct {
+	*KamaWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+	ring       *floatRing
+}
+
+// NewKamaRingStorage creates a Kaufman Adaptive Moving Average Indicator (Kama) for online usage that retains the
+// capacity most recent results
+func NewKamaRingStorage(capacity int, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *KamaRingStorage, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	// the minimum capacity for this storage is 1
+	if capacity < 1 {
+		return nil, errors.New("capacity is less than the minimum (1)")
+	}
+
+	ind := KamaRingStorage{
+		selectData: selectData,
+		ring:       newFloatRing(capacity),
+	}
+
+	ind.KamaWithoutStorage, err = NewKamaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.ring.push(dataItem)
+	})
+
+	return &ind, err
+}
+
+// NewDefaultKamaRingStorage creates a Kaufman Adaptive Moving Average Indicator (Kama) for online usage that retains
+// the capacity most recent results with default parameters
+//	- timePeriod: 25
+func NewDefaultKamaRingStorage(capacity int) (indicator *KamaRingStorage, err error) {
+	timePeriod := 25
+	return NewKamaRingStorage(capacity, timePeriod, gotrade.UseClosePrice)
+}
+
+// NewKamaRingStorageForStream creates a Kaufman Adaptive Moving Average Indicator (Kama) for online usage with a
+// source data stream that retains the capacity most recent results
+func NewKamaRingStorageForStream(priceStream gotrade.DOHLCVStreamSubscriber, capacity int, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *KamaRingStorage, err error) {
+	ind, err := NewKamaRingStorage(capacity, timePeriod, selectData)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultKamaRingStorageForStream creates a Kaufman Adaptive Moving Average Indicator (Kama) for online usage
+// with a source data stream that retains the capacity most recent results with default parameters
+func NewDefaultKamaRingStorageForStream(priceStream gotrade.DOHLCVStreamSubscriber, capacity int) (indicator *KamaRingStorage, err error) {
+	ind, err := NewDefaultKamaRingStorage(capacity)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *KamaRingStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// the retained result of a provisional bar is replaced by that of the update
+	if ind.undoProvisionalBar() {
+		ind.ring.dropNewest()
+	}
+
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ReviseLastTick replaces the most recently received DOHLCV tick with a revised bar, such as a late volume or
+// close correction, without replaying the source data. Only the most recent tick can be revised
+func (ind *KamaRingStorage) ReviseLastTick(tickData gotrade.DOHLCV) error {
+	resultProduced, streamBarIndex, err := ind.undoLastTick()
+	if err != nil {
+		return err
+	}
+
+	// the retained result of the revised tick is replaced by that of the revision
+	if resultProduced {
+		ind.ring.dropNewest()
+	}
+
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+	return nil
+}
+
+// Capacity returns the number of the most recent results that are retained
+func (ind *KamaRingStorage) Capacity() int {
+	return len(ind.ring.values)
+}
+
+// Recent returns a copy of the retained results, oldest first
+func (ind *KamaRingStorage) Recent() []float64 {
+	return ind.ring.recent()
+}
+
+// RecentFromBar returns the source data bar of the oldest retained result, or -1 before the first result
+func (ind *KamaRingStorage) RecentFromBar() int {
+	if ind.ring.len() == 0 {
+		return -1
+	}
+	return ind.ValidFromBar() + ind.Length() - ind.ring.len()
+}
+
+// ValuesInRange returns the retained Kama results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are retained
+func (ind *KamaRingStorage) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Recent(), ind.RecentFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the retained Kama results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *KamaRingStorage) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.RecentFromBar(), floatCSVColumn("value", ind.Recent()))
+}
@@ -5,6 +5,8 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/thetruetrade/gotrade"
 	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
 )
 
 var _ = Describe("when creating an demawithoutstorage", func() {
@@ -406,3 +408,316 @@ var _ = Describe("when calculating a kaufmann adaptive moving average (kama) wit
 	})
 
 })
+
+var _ = Describe("when revising the last tick of a kaufman adaptive moving average (kama)", func() {
+	var (
+		period    int = 10
+		indicator *indicators.Kama
+		expected  *indicators.Kama
+		revised   gotrade.DOHLCV
+		err       error
+	)
+
+	reviseBar := func(bar gotrade.DOHLCV) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(bar.D(), bar.O(), bar.H()+1.0, bar.L(), bar.C()+0.75, bar.V()+1000.0)
+	}
+
+	// feeds ticks up to and including the revised bar to the indicator, revising the last one, and
+	// the same ticks with the revised bar in place to the expected indicator
+	feedWithRevision := func(revisedBarIndex int) {
+		revised = reviseBar(sourceDOHLCVData[revisedBarIndex])
+		for i := 0; i <= revisedBarIndex; i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			if i == revisedBarIndex {
+				expected.ReceiveDOHLCVTick(revised, i+1)
+			} else {
+				expected.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		}
+		err = indicator.ReviseLastTick(revised)
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewKama(period, gotrade.UseClosePrice)
+		expected, _ = indicators.NewKama(period, gotrade.UseClosePrice)
+	})
+
+	Context("and the indicator has not yet received any ticks", func() {
+		It("should return the appropriate error", func() {
+			Expect(indicator.ReviseLastTick(sourceDOHLCVData[0])).To(Equal(indicators.ErrNoTickToRevise))
+		})
+	})
+
+	Context("and the revised tick is within the lookback period", func() {
+		BeforeEach(func() {
+			feedWithRevision(period - 2)
+		})
+
+		It("the indicator state should equal feeding the revised tick from the start", func() {
+			Expect(err).To(BeNil())
+			Expect(indicator.Data).To(BeEmpty())
+			Expect(indicator.Length()).To(Equal(expected.Length()))
+			Expect(indicator.ValidFromBar()).To(Equal(expected.ValidFromBar()))
+		})
+
+		It("subsequent results should equal feeding the revised tick from the start", func() {
+			for i := period - 1; i < len(sourceDOHLCVData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				expected.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+			Expect(indicator.Data).To(Equal(expected.Data))
+		})
+	})
+
+	Context("and the revised tick produced the first result", func() {
+		BeforeEach(func() {
+			feedWithRevision(period)
+		})
+
+		It("the indicator state should equal feeding the revised tick from the start", func() {
+			Expect(err).To(BeNil())
+			Expect(indicator.Data).To(Equal(expected.Data))
+			Expect(indicator.ValidFromBar()).To(Equal(expected.ValidFromBar()))
+			Expect(indicator.MinValue()).To(Equal(expected.MinValue()))
+			Expect(indicator.MaxValue()).To(Equal(expected.MaxValue()))
+		})
+	})
+
+	Context("and the revised tick is after the lookback period", func() {
+		BeforeEach(func() {
+			feedWithRevision(60)
+		})
+
+		It("the indicator state should equal feeding the revised tick from the start", func() {
+			Expect(err).To(BeNil())
+			Expect(indicator.Data).To(Equal(expected.Data))
+			Expect(indicator.Length()).To(Equal(expected.Length()))
+			Expect(indicator.MinValue()).To(Equal(expected.MinValue()))
+			Expect(indicator.MaxValue()).To(Equal(expected.MaxValue()))
+			Expect(indicator.LatestValue()).To(Equal(expected.LatestValue()))
+		})
+
+		It("subsequent results should equal feeding the revised tick from the start", func() {
+			for i := 61; i < len(sourceDOHLCVData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				expected.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+			Expect(indicator.Data).To(Equal(expected.Data))
+		})
+
+		It("revising the same tick again should reflect only the latest revision", func() {
+			Expect(indicator.ReviseLastTick(sourceDOHLCVData[60])).To(BeNil())
+			Expect(indicator.ReviseLastTick(revised)).To(BeNil())
+			Expect(indicator.Data).To(Equal(expected.Data))
+		})
+	})
+})
+
+var _ = Describe("when calculating a kaufman adaptive moving average (kama) on every tick", func() {
+	var (
+		period    int = 30
+		indicator *indicators.Kama
+		expected  *indicators.Kama
+	)
+
+	intrabarUpdate := func(bar gotrade.DOHLCV, offset float64) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(bar.D(), bar.O(), bar.H()+offset, bar.L(), bar.C()+offset, bar.V())
+	}
+
+	// feeds each bar as intrabar updates, the last of which is the closed bar, followed by a commit
+	feedWithIntrabarUpdates := func(count int) {
+		for i := 0; i < count; i++ {
+			indicator.ReceiveDOHLCVTick(intrabarUpdate(sourceDOHLCVData[i], 1.5), i+1)
+			indicator.ReceiveDOHLCVTick(intrabarUpdate(sourceDOHLCVData[i], -0.5), i+1)
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			indicator.CommitBar()
+			expected.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewKama(period, gotrade.UseClosePrice)
+		indicator.SetTickMode(indicators.TickModeOnEveryTick)
+		expected, _ = indicators.NewKama(period, gotrade.UseClosePrice)
+	})
+
+	Context("and each bar has received intrabar updates followed by a commit", func() {
+		BeforeEach(func() {
+			feedWithIntrabarUpdates(len(sourceDOHLCVData))
+		})
+
+		It("the results should equal receiving only the closed bars", func() {
+			Expect(indicator.Data).To(Equal(expected.Data))
+			Expect(indicator.Length()).To(Equal(expected.Length()))
+			Expect(indicator.ValidFromBar()).To(Equal(expected.ValidFromBar()))
+		})
+
+		It("the bounds should equal receiving only the closed bars", func() {
+			Expect(indicator.MinValue()).To(Equal(expected.MinValue()))
+			Expect(indicator.MaxValue()).To(Equal(expected.MaxValue()))
+		})
+	})
+
+	Context("and the forming bar has received an update that is not yet committed", func() {
+		var update gotrade.DOHLCV
+
+		BeforeEach(func() {
+			feedWithIntrabarUpdates(60)
+			update = intrabarUpdate(sourceDOHLCVData[60], 2.0)
+			indicator.ReceiveDOHLCVTick(intrabarUpdate(sourceDOHLCVData[60], -1.0), 61)
+			indicator.ReceiveDOHLCVTick(update, 61)
+		})
+
+		It("the latest result should be recomputed from the provisional bar", func() {
+			expected.ReceiveDOHLCVTick(update, 61)
+			Expect(indicator.Data).To(Equal(expected.Data))
+			Expect(indicator.Length()).To(Equal(expected.Length()))
+			Expect(indicator.LatestValue()).To(Equal(expected.LatestValue()))
+		})
+
+		It("the closing update and commit should replace the provisional result", func() {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[60], 61)
+			indicator.CommitBar()
+			for i := 61; i < len(sourceDOHLCVData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				indicator.CommitBar()
+			}
+			for i := 60; i < len(sourceDOHLCVData); i++ {
+				expected.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+			Expect(indicator.Data).To(Equal(expected.Data))
+		})
+	})
+
+	Context("and the tick mode is on close", func() {
+		It("each tick should be a closed bar", func() {
+			indicator.SetTickMode(indicators.TickModeOnClose)
+			for i := range sourceDOHLCVData {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				expected.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+			Expect(indicator.Data).To(Equal(expected.Data))
+		})
+	})
+})
+
+var _ = Describe("when creating a kaufman adaptive moving average (kama) with ring storage", func() {
+	It("the indicator should not be created with a capacity below the minimum", func() {
+		indicator, err := indicators.NewKamaRingStorage(0, 10, gotrade.UseClosePrice)
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+	})
+
+	It("the indicator should not be created with a nil data selection func", func() {
+		indicator, err := indicators.NewKamaRingStorage(100, 10, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewDefaultKamaRingStorageForStream(stream, 100)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
+
+var _ = Describe("when calculating a kaufman adaptive moving average (kama) with ring storage", func() {
+	var (
+		period    int = 10
+		capacity  int = 100
+		ticks     int = 1000
+		indicator *indicators.KamaRingStorage
+		expected  *indicators.Kama
+	)
+
+	// a close price of a tick that rises and falls
+	newClose := func(i int) gotrade.DOHLCV {
+		closePrice := 100.0 + 10.0*math.Sin(float64(i)/25.0) + float64(i%7)*0.3
+		return gotrade.NewDOHLCVDataItem(time.Now(), closePrice, closePrice, closePrice, closePrice, 0.0)
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewKamaRingStorage(capacity, period, gotrade.UseClosePrice)
+		expected, _ = indicators.NewKama(period, gotrade.UseClosePrice)
+	})
+
+	Context("and fewer results than the capacity have been produced", func() {
+		It("should retain all of the results", func() {
+			Expect(indicator.Recent()).To(BeEmpty())
+			Expect(indicator.RecentFromBar()).To(Equal(-1))
+
+			for i := 1; i <= 50; i++ {
+				indicator.ReceiveDOHLCVTick(newClose(i), i)
+				expected.ReceiveDOHLCVTick(newClose(i), i)
+			}
+			Expect(indicator.Recent()).To(Equal(expected.Data))
+			Expect(indicator.RecentFromBar()).To(Equal(expected.ValidFromBar()))
+		})
+	})
+
+	Context("and more results than the capacity have been produced", func() {
+		BeforeEach(func() {
+			for i := 1; i <= ticks; i++ {
+				indicator.ReceiveDOHLCVTick(newClose(i), i)
+				expected.ReceiveDOHLCVTick(newClose(i), i)
+			}
+		})
+
+		It("should retain only the most recent results in order", func() {
+			Expect(indicator.Capacity()).To(Equal(capacity))
+			Expect(indicator.Recent()).To(HaveLen(capacity))
+			Expect(indicator.Recent()).To(Equal(expected.Data[len(expected.Data)-capacity:]))
+			Expect(indicator.RecentFromBar()).To(Equal(ticks - capacity + 1))
+		})
+
+		It("should count and bound all of the results since creation", func() {
+			Expect(indicator.Length()).To(Equal(expected.Length()))
+			Expect(indicator.MinValue()).To(Equal(expected.MinValue()))
+			Expect(indicator.MaxValue()).To(Equal(expected.MaxValue()))
+		})
+
+		It("should return the retained results for a bar range", func() {
+			Expect(indicator.ValuesInRange(1, ticks-capacity+10)).To(Equal(expected.ValuesInRange(ticks-capacity+1, ticks-capacity+10)))
+			Expect(indicator.ValuesInRange(1, ticks-capacity)).To(BeEmpty())
+		})
+
+		It("should replace the retained result of a revised tick", func() {
+			revised := newClose(ticks + 1)
+			indicator.ReviseLastTick(revised)
+			expected.ReviseLastTick(revised)
+			Expect(indicator.Recent()).To(Equal(expected.Data[len(expected.Data)-capacity:]))
+		})
+	})
+
+	Context("and each bar has received intrabar updates followed by a commit", func() {
+		It("should retain the results of the closed bars", func() {
+			indicator.SetTickMode(indicators.TickModeOnEveryTick)
+			for i := 1; i <= 300; i++ {
+				indicator.ReceiveDOHLCVTick(newClose(i+5), i)
+				indicator.ReceiveDOHLCVTick(newClose(i), i)
+				indicator.CommitBar()
+				expected.ReceiveDOHLCVTick(newClose(i), i)
+			}
+			Expect(indicator.Recent()).To(Equal(expected.Data[len(expected.Data)-capacity:]))
+		})
+	})
+})
+
+var _ = Describe("when backing out the last tick of a kaufman adaptive moving average (kama) without storage", func() {
+	It("the latest value should be restored with the rest of the state", func() {
+		period := 10
+		indicator, _ := indicators.NewKamaWithoutStorage(period, fakeFloatValAvailable)
+		expected, _ := indicators.NewKamaWithoutStorage(period, fakeFloatValAvailable)
+		for i := 0; i < 60; i++ {
+			indicator.ReceiveTick(sourceDOHLCVData[i].C(), i+1)
+			expected.ReceiveTick(sourceDOHLCVData[i].C(), i+1)
+		}
+
+		indicator.ReceiveTick(sourceDOHLCVData[60].C()+5.0, 61)
+		Expect(indicator.LatestValue()).NotTo(Equal(expected.LatestValue()))
+
+		Expect(indicator.ReviseLastTick(sourceDOHLCVData[60].C())).To(BeNil())
+		expected.ReceiveTick(sourceDOHLCVData[60].C(), 61)
+		Expect(indicator.LatestValue()).To(Equal(expected.LatestValue()))
+	})
+})
@@ -0,0 +1,168 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Kurtosis Indicator (Kurtosis), no storage, for use in other indicators
+// the sample excess kurtosis of the values in the period, 0.0 for a normal distribution, a result
+// of 0.0 is also given for a period with no variance
+type KurtosisWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	moments    *rollingMoments
+	timePeriod int
+}
+
+// NewKurtosisWithoutStorage creates a Kurtosis Indicator (Kurtosis) without storage
+func NewKurtosisWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *KurtosisWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 4
+	if timePeriod < 4 {
+		return nil, errors.New("timePeriod is less than the minimum (4)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := KurtosisWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		moments:                      newRollingMoments(timePeriod),
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *KurtosisWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.moments.Add(tickData)
+
+	if ind.moments.IsFull() {
+		m2, _, m4, isFlat := ind.moments.CentralMoments()
+
+		var result float64 = 0.0
+		if !isFlat {
+			n := float64(ind.timePeriod)
+			excess := m4/(m2*m2) - 3.0
+			result = ((n+1.0)*excess + 6.0) * (n - 1.0) / ((n - 2.0) * (n - 3.0))
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// A Kurtosis Indicator (Kurtosis)
+type Kurtosis struct {
+	*KurtosisWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewKurtosis creates a Kurtosis Indicator (Kurtosis) for online usage
+func NewKurtosis(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Kurtosis, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Kurtosis{
+		selectData: selectData,
+	}
+
+	ind.KurtosisWithoutStorage, err = NewKurtosisWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultKurtosis creates a Kurtosis Indicator (Kurtosis) for online usage with default parameters
+//	- timePeriod: 30
+func NewDefaultKurtosis() (indicator *Kurtosis, err error) {
+	timePeriod := 30
+	return NewKurtosis(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewKurtosisWithSrcLen creates a Kurtosis Indicator (Kurtosis) for offline usage
+func NewKurtosisWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Kurtosis, err error) {
+	ind, err := NewKurtosis(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultKurtosisWithSrcLen creates a Kurtosis Indicator (Kurtosis) for offline usage with default parameters
+func NewDefaultKurtosisWithSrcLen(sourceLength uint) (indicator *Kurtosis, err error) {
+	ind, err := NewDefaultKurtosis()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewKurtosisForStream creates a Kurtosis Indicator (Kurtosis) for online usage with a source data stream
+func NewKurtosisForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Kurtosis, err error) {
+	ind, err := NewKurtosis(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultKurtosisForStream creates a Kurtosis Indicator (Kurtosis) for online usage with a source data stream
+func NewDefaultKurtosisForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Kurtosis, err error) {
+	ind, err := NewDefaultKurtosis()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewKurtosisForStreamWithSrcLen creates a Kurtosis Indicator (Kurtosis) for offline usage with a source data stream
+func NewKurtosisForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Kurtosis, err error) {
+	ind, err := NewKurtosisWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultKurtosisForStreamWithSrcLen creates a Kurtosis Indicator (Kurtosis) for offline usage with a source data stream
+func NewDefaultKurtosisForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Kurtosis, err error) {
+	ind, err := NewDefaultKurtosisWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Kurtosis) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Kurtosis results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Kurtosis) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Kurtosis results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Kurtosis) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,186 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Laguerre Relative Strength Index Indicator (LaguerreRsi), no storage, for use in other indicators
+// the price is passed through a four stage Laguerre filter damped by gamma, with the stages seeded with the first
+// price. The result is the sum of the rises between successive stages as a fraction of the sum of the rises and
+// falls, between 0.0 and 1.0, a higher gamma gives a smoother but more lagged result. A result of 0.0 is given
+// while the stages are equal
+type LaguerreRsiWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	gamma     float64
+	hasStages bool
+	stages    [4]float64
+}
+
+// NewLaguerreRsiWithoutStorage creates a Laguerre Relative Strength Index Indicator (LaguerreRsi) without storage
+func NewLaguerreRsiWithoutStorage(gamma float64, valueAvailableAction ValueAvailableActionFloat) (indicator *LaguerreRsiWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum gamma for this indicator is 0
+	if gamma < 0.0 {
+		return nil, errors.New("gamma is less than the minimum (0)")
+	}
+
+	// gamma must be below 1
+	if gamma >= 1.0 {
+		return nil, errors.New("gamma is greater than the maximum (below 1)")
+	}
+
+	lookback := 0
+	ind := LaguerreRsiWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		gamma:                        gamma,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *LaguerreRsiWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.hasStages {
+		ind.hasStages = true
+		for i := range ind.stages {
+			ind.stages[i] = tickData
+		}
+	}
+
+	// each stage is damped by gamma and fed from the previous values of the stage before it
+	previous := ind.stages
+	ind.stages[0] = (1.0-ind.gamma)*tickData + ind.gamma*previous[0]
+	for i := 1; i < len(ind.stages); i++ {
+		ind.stages[i] = -ind.gamma*ind.stages[i-1] + previous[i-1] + ind.gamma*previous[i]
+	}
+
+	var totalUp, totalDown float64
+	for i := 1; i < len(ind.stages); i++ {
+		difference := ind.stages[i-1] - ind.stages[i]
+		if difference > 0.0 {
+			totalUp += difference
+		} else {
+			totalDown -= difference
+		}
+	}
+
+	var result float64 = 0.0
+	if totalUp+totalDown > 0.0 {
+		result = totalUp / (totalUp + totalDown)
+	}
+
+	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+}
+
+// A Laguerre Relative Strength Index Indicator (LaguerreRsi)
+type LaguerreRsi struct {
+	*LaguerreRsiWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewLaguerreRsi creates a Laguerre Relative Strength Index Indicator (LaguerreRsi) for online usage
+func NewLaguerreRsi(gamma float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LaguerreRsi, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := LaguerreRsi{
+		selectData: selectData,
+	}
+
+	ind.LaguerreRsiWithoutStorage, err = NewLaguerreRsiWithoutStorage(gamma,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultLaguerreRsi creates a Laguerre Relative Strength Index Indicator (LaguerreRsi) for online usage with default parameters
+//	- gamma: 0.5
+func NewDefaultLaguerreRsi() (indicator *LaguerreRsi, err error) {
+	gamma := 0.5
+	return NewLaguerreRsi(gamma, gotrade.UseClosePrice)
+}
+
+// NewLaguerreRsiWithSrcLen creates a Laguerre Relative Strength Index Indicator (LaguerreRsi) for offline usage
+func NewLaguerreRsiWithSrcLen(sourceLength uint, gamma float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LaguerreRsi, err error) {
+	ind, err := NewLaguerreRsi(gamma, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultLaguerreRsiWithSrcLen creates a Laguerre Relative Strength Index Indicator (LaguerreRsi) for offline usage with default parameters
+func NewDefaultLaguerreRsiWithSrcLen(sourceLength uint) (indicator *LaguerreRsi, err error) {
+	ind, err := NewDefaultLaguerreRsi()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewLaguerreRsiForStream creates a Laguerre Relative Strength Index Indicator (LaguerreRsi) for online usage with a source data stream
+func NewLaguerreRsiForStream(priceStream gotrade.DOHLCVStreamSubscriber, gamma float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LaguerreRsi, err error) {
+	ind, err := NewLaguerreRsi(gamma, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultLaguerreRsiForStream creates a Laguerre Relative Strength Index Indicator (LaguerreRsi) for online usage with a source data stream
+func NewDefaultLaguerreRsiForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LaguerreRsi, err error) {
+	ind, err := NewDefaultLaguerreRsi()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewLaguerreRsiForStreamWithSrcLen creates a Laguerre Relative Strength Index Indicator (LaguerreRsi) for offline usage with a source data stream
+func NewLaguerreRsiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, gamma float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *LaguerreRsi, err error) {
+	ind, err := NewLaguerreRsiWithSrcLen(sourceLength, gamma, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultLaguerreRsiForStreamWithSrcLen creates a Laguerre Relative Strength Index Indicator (LaguerreRsi) for offline usage with a source data stream
+func NewDefaultLaguerreRsiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *LaguerreRsi, err error) {
+	ind, err := NewDefaultLaguerreRsiWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *LaguerreRsi) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the LaguerreRsi results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *LaguerreRsi) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the LaguerreRsi results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *LaguerreRsi) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
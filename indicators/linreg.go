@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Linear Regression Indicator (LinReg), no storage, for use in other indicators
@@ -191,3 +192,15 @@ func (ind *LinRegWithoutStorage) ReceiveTick(tickData float64, streamBarIndex in
 	}
 
 }
+
+// ValuesInRange returns the LinReg results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *LinReg) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the LinReg results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *LinReg) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -2,6 +2,7 @@ package indicators
 
 import (
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Linear Regression Intercept Indicator (LinRegInt)
@@ -99,3 +100,15 @@ func (ind *LinRegInt) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex
 	var selectedData = ind.selectData(tickData)
 	ind.ReceiveTick(selectedData, streamBarIndex)
 }
+
+// ValuesInRange returns the LinRegInt results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *LinRegInt) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the LinRegInt results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *LinRegInt) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
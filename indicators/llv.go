@@ -1,10 +1,9 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
+	"io"
 )
 
 // A Lowest Low Value Indicator (Llv), no storage, for use in other indicators
@@ -12,10 +11,7 @@ type LlvWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
 
 	// private variables
-	periodHistory   *list.List
-	currentLow      float64
-	currentLowIndex int
-	timePeriod      int
+	periodHistory *monotonicDeque
 }
 
 // NewLlvWithoutStorage creates a Lowest Low Value Indicator Indicator (Llv) without storage
@@ -39,10 +35,7 @@ func NewLlvWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	lookback := timePeriod - 1
 	ind := LlvWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
-		currentLow:                   math.MaxFloat64,
-		currentLowIndex:              0,
-		periodHistory:                list.New(),
-		timePeriod:                   timePeriod,
+		periodHistory:                newMonotonicDeque(timePeriod, false),
 	}
 
 	return &ind, nil
@@ -140,51 +133,23 @@ func (ind *Llv) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 }
 
 func (ind *LlvWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodHistory.PushBack(tickData)
-
-	// resize the history
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-
-		// make sure we haven't just removed the current low
-		if ind.currentLowIndex == ind.timePeriod-1 {
-			ind.currentLow = math.MaxFloat64
-			// we have we need to find the new low in the history
-			var i int = ind.timePeriod - 1
-			for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
-				value := e.Value.(float64)
-				if value < ind.currentLow {
-					ind.currentLow = value
-					ind.currentLowIndex = i
-				}
-				i -= 1
-			}
-		} else {
-			if tickData < ind.currentLow {
-				ind.currentLow = tickData
-				ind.currentLowIndex = 0
-			} else {
-				ind.currentLowIndex += 1
-			}
-		}
-
-		var result = ind.currentLow
+	ind.periodHistory.push(tickData)
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	if ind.periodHistory.isFull() {
+		var result = ind.periodHistory.extreme()
 
-	} else {
-		if tickData < ind.currentLow {
-			ind.currentLow = tickData
-			ind.currentLowIndex = 0
-		} else {
-			ind.currentLowIndex += 1
-		}
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
 
-		if ind.periodHistory.Len() == ind.timePeriod {
-			var result = ind.currentLow
+// ValuesInRange returns the Llv results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Llv) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
 
-			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-		}
-	}
+// WriteCSV writes the Llv results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Llv) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
 }
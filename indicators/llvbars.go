@@ -1,10 +1,9 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
-	"math"
+	"io"
 )
 
 // A Lowest Low Value Bars Indicator (LlvBars), no storage, for use in other indicators
@@ -12,10 +11,7 @@ type LlvBarsWithoutStorage struct {
 	*baseIndicatorWithIntBounds
 
 	// private variables
-	periodHistory   *list.List
-	currentLow      float64
-	currentLowIndex int64
-	timePeriod      int
+	periodHistory *monotonicDeque
 }
 
 // NewLlvBarsWithoutStorage creates a Lowest Low Value Bars Indicator Indicator (LlvBars) without storage
@@ -40,12 +36,12 @@ func NewLlvBarsWithoutStorage(timePeriod int, valueAvailableAction ValueAvailabl
 
 	ind := LlvBarsWithoutStorage{
 		baseIndicatorWithIntBounds: newBaseIndicatorWithIntBounds(lookback, valueAvailableAction),
-		currentLow:                 math.MaxFloat64,
-		currentLowIndex:            0,
-		periodHistory:              list.New(),
-		timePeriod:                 timePeriod,
+		periodHistory:              newMonotonicDeque(timePeriod, false),
 	}
 
+	// the bars are counted since the oldest of equal extremes unless set otherwise
+	ind.periodHistory.setTiePolicy(TiePolicyOldest)
+
 	return &ind, nil
 }
 
@@ -141,52 +137,23 @@ func (ind *LlvBars) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex in
 }
 
 func (ind *LlvBarsWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodHistory.PushBack(tickData)
-
-	// resize the history
-	if ind.periodHistory.Len() > ind.timePeriod {
-		first := ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
-
-		// make sure we haven't just removed the current low
-		if ind.currentLowIndex == int64(ind.timePeriod-1) {
-			ind.currentLow = math.MaxFloat64
-			// we have we need to find the new low in the history
-			var i int = ind.timePeriod - 1
-			for e := ind.periodHistory.Front(); e != nil; e = e.Next() {
-				value := e.Value.(float64)
-				if value < ind.currentLow {
-					ind.currentLow = value
-					ind.currentLowIndex = int64(i)
-				}
-				i -= 1
-			}
-		} else {
-			if tickData < ind.currentLow {
-				ind.currentLow = tickData
-				ind.currentLowIndex = 0
-			} else {
-				ind.currentLowIndex += 1
-			}
-		}
-
-		var result = ind.currentLowIndex
-
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-
-	} else {
-		if tickData < ind.currentLow {
-			ind.currentLow = tickData
-			ind.currentLowIndex = 0
-		} else {
-			ind.currentLowIndex += 1
-		}
+	ind.periodHistory.push(tickData)
 
-		if ind.periodHistory.Len() == ind.timePeriod {
-			var result = ind.currentLowIndex
+	if ind.periodHistory.isFull() {
+		var result = int64(ind.periodHistory.barsSinceExtreme())
 
-			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-		}
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
+}
+
+// SetTiePolicy sets which of several equal lowest values within the time period the result counts the bars since,
+// the default is TiePolicyOldest
+func (ind *LlvBarsWithoutStorage) SetTiePolicy(tiePolicy TiePolicy) {
+	ind.periodHistory.setTiePolicy(tiePolicy)
+}
 
+// WriteCSV writes the LlvBars results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *LlvBars) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), intCSVColumn("value", ind.Data))
 }
@@ -4,12 +4,14 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Moving Average Convergence-Divergence (Macd) Indicator
 type Macd struct {
 	*baseIndicator
 	*baseFloatBounds
+	*baseQuantizer
 
 	// private variables
 	valueAvailableAction ValueAvailableActionMacd
@@ -72,6 +74,7 @@ func NewMacd(fastTimePeriod int, slowTimePeriod int, signalTimePeriod int, selec
 	ind := Macd{
 		baseIndicator:    newBaseIndicator(lookback),
 		baseFloatBounds:  newBaseFloatBounds(),
+		baseQuantizer:    newBaseQuantizer(),
 		fastTimePeriod:   fastTimePeriod,
 		slowTimePeriod:   slowTimePeriod,
 		signalTimePeriod: signalTimePeriod,
@@ -99,9 +102,9 @@ func NewMacd(fastTimePeriod int, slowTimePeriod int, signalTimePeriod int, selec
 
 		// Macd Histogram: Macd Line - Signal Line
 
-		macd := ind.currentFastEma - ind.currentSlowEma
-		signal := dataItem
-		histogram := macd - signal
+		macd := ind.quantize(ind.currentFastEma - ind.currentSlowEma)
+		signal := ind.quantize(dataItem)
+		histogram := ind.quantize(macd - signal)
 
 		ind.UpdateMinMax(macd, macd)
 		ind.UpdateMinMax(signal, signal)
@@ -205,3 +208,9 @@ func (ind *Macd) ReceiveTick(tickData float64, streamBarIndex int) {
 	}
 	ind.emaSlow.ReceiveTick(tickData, streamBarIndex)
 }
+
+// WriteCSV writes the Macd results as rows after a header of barIndex,macd,signal,histogram, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *Macd) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("macd", ind.Macd), floatCSVColumn("signal", ind.Signal), floatCSVColumn("histogram", ind.Histogram))
+}
@@ -0,0 +1,165 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+var (
+	ErrMaTypeNotSupported = errors.New("The MaType is not supported")
+)
+
+// A MaType selects the moving average used by the moving average factories
+type MaType int
+
+const (
+	MaTypeSma MaType = iota
+	MaTypeEma
+	MaTypeWma
+	MaTypeDema
+	MaTypeTema
+	MaTypeTrima
+	MaTypeKama
+	MaTypeT3
+)
+
+// the volume factor of a T3 created by the moving average factories
+const maTypeT3VolumeFactor float64 = 0.7
+
+// A MovingAverageWithoutStorage is a moving average, no storage, for use in other indicators
+type MovingAverageWithoutStorage interface {
+	FloatIndicatorWithoutStorage
+}
+
+// A MovingAverage is a moving average that stores its results
+type MovingAverage interface {
+	MovingAverageWithoutStorage
+	ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
+	ValuesInRange(fromBar int, toBar int) []float64
+	WriteCSV(w io.Writer, startBarOffset int) error
+}
+
+// NewMovingAverageWithoutStorage creates a moving average of the given MaType without storage
+func NewMovingAverageWithoutStorage(maType MaType, timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator MovingAverageWithoutStorage, err error) {
+
+	// each result is checked before conversion so that a nil indicator is returned as a nil interface
+	switch maType {
+	case MaTypeSma:
+		ind, err := NewSmaWithoutStorage(timePeriod, valueAvailableAction)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeEma:
+		ind, err := NewEmaWithoutStorage(timePeriod, valueAvailableAction)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeWma:
+		ind, err := NewWmaWithoutStorage(timePeriod, valueAvailableAction)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeDema:
+		ind, err := NewDemaWithoutStorage(timePeriod, valueAvailableAction)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeTema:
+		ind, err := NewTemaWithoutStorage(timePeriod, valueAvailableAction)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeTrima:
+		ind, err := NewTrimaWithoutStorage(timePeriod, valueAvailableAction)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeKama:
+		ind, err := NewKamaWithoutStorage(timePeriod, valueAvailableAction)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeT3:
+		ind, err := NewT3WithoutStorage(timePeriod, maTypeT3VolumeFactor, valueAvailableAction)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	}
+
+	return nil, ErrMaTypeNotSupported
+}
+
+// NewMovingAverage creates a moving average of the given MaType for online usage
+func NewMovingAverage(maType MaType, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator MovingAverage, err error) {
+	switch maType {
+	case MaTypeSma:
+		ind, err := NewSma(timePeriod, selectData)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeEma:
+		ind, err := NewEma(timePeriod, selectData)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeWma:
+		ind, err := NewWma(timePeriod, selectData)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeDema:
+		ind, err := NewDema(timePeriod, selectData)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeTema:
+		ind, err := NewTema(timePeriod, selectData)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeTrima:
+		ind, err := NewTrima(timePeriod, selectData)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeKama:
+		ind, err := NewKama(timePeriod, selectData)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	case MaTypeT3:
+		ind, err := NewT3(timePeriod, maTypeT3VolumeFactor, selectData)
+		if err != nil {
+			return nil, err
+		}
+		return ind, nil
+	}
+
+	return nil, ErrMaTypeNotSupported
+}
+
+// NewMovingAverageForStream creates a moving average of the given MaType for online usage with a source data stream
+func NewMovingAverageForStream(priceStream gotrade.DOHLCVStreamSubscriber, maType MaType, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator MovingAverage, err error) {
+	ind, err := NewMovingAverage(maType, timePeriod, selectData)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
@@ -0,0 +1,101 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when creating a moving average via the factory", func() {
+	var period int = 4
+
+	maTypeDirectConstructors := map[indicators.MaType]func() (indicators.MovingAverage, error){
+		indicators.MaTypeSma:   func() (indicators.MovingAverage, error) { return indicators.NewSma(period, gotrade.UseClosePrice) },
+		indicators.MaTypeEma:   func() (indicators.MovingAverage, error) { return indicators.NewEma(period, gotrade.UseClosePrice) },
+		indicators.MaTypeWma:   func() (indicators.MovingAverage, error) { return indicators.NewWma(period, gotrade.UseClosePrice) },
+		indicators.MaTypeDema:  func() (indicators.MovingAverage, error) { return indicators.NewDema(period, gotrade.UseClosePrice) },
+		indicators.MaTypeTema:  func() (indicators.MovingAverage, error) { return indicators.NewTema(period, gotrade.UseClosePrice) },
+		indicators.MaTypeTrima: func() (indicators.MovingAverage, error) { return indicators.NewTrima(period, gotrade.UseClosePrice) },
+		indicators.MaTypeKama:  func() (indicators.MovingAverage, error) { return indicators.NewKama(period, gotrade.UseClosePrice) },
+		indicators.MaTypeT3:    func() (indicators.MovingAverage, error) { return indicators.NewT3(period, 0.7, gotrade.UseClosePrice) },
+	}
+
+	for maType, newDirect := range maTypeDirectConstructors {
+		maType, newDirect := maType, newDirect
+
+		Context("and the moving average has received all of its ticks", func() {
+			var (
+				indicator      indicators.MovingAverage
+				direct         indicators.MovingAverage
+				indicatorError error
+			)
+
+			BeforeEach(func() {
+				indicator, indicatorError = indicators.NewMovingAverage(maType, period, gotrade.UseClosePrice)
+				direct, _ = newDirect()
+				for i := 0; i < len(sourceDOHLCVData); i++ {
+					indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+					direct.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				}
+			})
+
+			It("should have created the moving average of the requested type", func() {
+				Expect(indicatorError).To(BeNil())
+				Expect(indicator.GetLookbackPeriod()).To(Equal(direct.GetLookbackPeriod()))
+				Expect(indicator.Length()).To(Equal(direct.Length()))
+				Expect(indicator.ValuesInRange(1, len(sourceDOHLCVData))).To(Equal(direct.ValuesInRange(1, len(sourceDOHLCVData))))
+			})
+		})
+
+		Context("and the moving average is created without storage", func() {
+			var results []float64
+
+			BeforeEach(func() {
+				results = []float64{}
+				indicator, _ := indicators.NewMovingAverageWithoutStorage(maType, period, func(dataItem float64, streamBarIndex int) {
+					results = append(results, dataItem)
+				})
+				for i := 0; i < len(sourceDOHLCVData); i++ {
+					indicator.ReceiveTick(sourceDOHLCVData[i].C(), i+1)
+				}
+			})
+
+			It("should provide the same results as the moving average with storage", func() {
+				direct, _ := newDirect()
+				for i := 0; i < len(sourceDOHLCVData); i++ {
+					direct.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				}
+				Expect(results).To(Equal(direct.ValuesInRange(1, len(sourceDOHLCVData))))
+			})
+		})
+	}
+
+	Context("and the MaType is not supported", func() {
+		It("the moving average should not be created and return the appropriate error message", func() {
+			indicator, err := indicators.NewMovingAverage(indicators.MaType(99), period, gotrade.UseClosePrice)
+			Expect(indicator).To(BeNil())
+			Expect(err).To(Equal(indicators.ErrMaTypeNotSupported))
+		})
+	})
+
+	Context("and the moving average could not be created", func() {
+		It("should return a nil moving average and the error of its constructor", func() {
+			indicator, err := indicators.NewMovingAverage(indicators.MaTypeEma, 1, gotrade.UseClosePrice)
+			Expect(indicator).To(BeNil())
+			Expect(err).NotTo(BeNil())
+
+			withoutStorage, err := indicators.NewMovingAverageWithoutStorage(indicators.MaTypeT3, period, nil)
+			Expect(withoutStorage).To(BeNil())
+			Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the moving average is created for a price stream", func() {
+		It("should have requested to be attached to the stream", func() {
+			stream := newFakeDOHLCVStreamSubscriber()
+			indicator, _ := indicators.NewMovingAverageForStream(stream, indicators.MaTypeWma, period, gotrade.UseClosePrice)
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+})
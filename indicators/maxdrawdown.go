@@ -0,0 +1,122 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Maximum Drawdown Indicator (MaxDrawdown), no storage, for use in other indicators
+// the drawdown is the fall of the value from the running peak as a fraction of the peak, (value - peak) / peak,
+// 0.0 at a new peak and negative below it, the maximum drawdown is the lowest drawdown seen so far
+type MaxDrawdownWithoutStorage struct {
+	*baseIndicatorWithFloatBoundsDrawdown
+
+	// private variables
+	hasPeak            bool
+	peak               float64
+	currentMaxDrawdown float64
+}
+
+// NewMaxDrawdownWithoutStorage creates a Maximum Drawdown Indicator (MaxDrawdown) without storage
+func NewMaxDrawdownWithoutStorage(valueAvailableAction ValueAvailableActionDrawdown) (indicator *MaxDrawdownWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	ind := MaxDrawdownWithoutStorage{
+		baseIndicatorWithFloatBoundsDrawdown: newBaseIndicatorWithFloatBoundsDrawdown(lookback, valueAvailableAction),
+	}
+
+	return &ind, nil
+}
+
+// A Maximum Drawdown Indicator (MaxDrawdown)
+type MaxDrawdown struct {
+	*MaxDrawdownWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Drawdown    []float64
+	MaxDrawdown []float64
+}
+
+// NewMaxDrawdown creates a Maximum Drawdown Indicator (MaxDrawdown) for online usage
+func NewMaxDrawdown(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *MaxDrawdown, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := MaxDrawdown{
+		selectData: selectData,
+	}
+
+	ind.MaxDrawdownWithoutStorage, err = NewMaxDrawdownWithoutStorage(
+		func(dataItemDrawdown float64, dataItemMaxDrawdown float64, streamBarIndex int) {
+			ind.Drawdown = append(ind.Drawdown, dataItemDrawdown)
+			ind.MaxDrawdown = append(ind.MaxDrawdown, dataItemMaxDrawdown)
+		})
+
+	return &ind, err
+}
+
+// NewMaxDrawdownWithSrcLen creates a Maximum Drawdown Indicator (MaxDrawdown) for offline usage
+func NewMaxDrawdownWithSrcLen(sourceLength uint, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *MaxDrawdown, err error) {
+	ind, err := NewMaxDrawdown(selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Drawdown = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.MaxDrawdown = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewMaxDrawdownForStream creates a Maximum Drawdown Indicator (MaxDrawdown) for online usage with a source data stream
+func NewMaxDrawdownForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *MaxDrawdown, err error) {
+	ind, err := NewMaxDrawdown(selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewMaxDrawdownForStreamWithSrcLen creates a Maximum Drawdown Indicator (MaxDrawdown) for offline usage with a source data stream
+func NewMaxDrawdownForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *MaxDrawdown, err error) {
+	ind, err := NewMaxDrawdownWithSrcLen(sourceLength, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *MaxDrawdown) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *MaxDrawdownWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if !ind.hasPeak || tickData > ind.peak {
+		ind.hasPeak = true
+		ind.peak = tickData
+	}
+
+	// a drawdown is only measured from a positive peak
+	var drawdown float64 = 0.0
+	if ind.peak > 0.0 {
+		drawdown = (tickData - ind.peak) / ind.peak
+	}
+
+	if drawdown < ind.currentMaxDrawdown {
+		ind.currentMaxDrawdown = drawdown
+	}
+
+	ind.UpdateIndicatorWithNewValue(drawdown, ind.currentMaxDrawdown, streamBarIndex)
+}
+
+// WriteCSV writes the MaxDrawdown results as rows after a header of barIndex,drawdown,maxDrawdown, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *MaxDrawdown) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("drawdown", ind.Drawdown), floatCSVColumn("maxDrawdown", ind.MaxDrawdown))
+}
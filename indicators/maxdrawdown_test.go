@@ -0,0 +1,170 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when creating a maxdrawdownwithoutstorage", func() {
+	var (
+		indicator      *indicators.MaxDrawdownWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewMaxDrawdownWithoutStorage(nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating a maximum drawdown (maxdrawdown) with DOHLCV source data", func() {
+	var (
+		indicator      *indicators.MaxDrawdown
+		inputs         IndicatorWithFloatBoundsSharedSpecInputs
+		stream         *fakeDOHLCVStreamSubscriber
+		indicatorError error
+	)
+
+	Context("given the indicator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			indicator, _ = indicators.NewMaxDrawdown(gotrade.UseClosePrice)
+			inputs = NewIndicatorWithFloatBoundsSharedSpecInputs(indicator, len(sourceDOHLCVData), indicator,
+				func() float64 {
+					return GetFloatDataMax(indicator.Drawdown)
+				},
+				func() float64 {
+					return GetFloatDataMin(indicator.MaxDrawdown)
+				})
+		})
+
+		Context("and the indicator has not yet received any ticks", func() {
+			ShouldBeAnInitialisedIndicator(&inputs)
+
+			ShouldNotHaveAnyFloatBoundsSetYet(&inputs)
+		})
+
+		Context("and the indicator has received ticks equal to the lookback period", func() {
+
+			BeforeEach(func() {
+				for i := 0; i <= indicator.GetLookbackPeriod(); i++ {
+					indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				}
+			})
+
+			ShouldBeAnIndicatorThatHasReceivedTicksEqualToItsLookbackPeriod(&inputs)
+
+			ShouldHaveFloatBoundsSetToMinMaxOfResults(&inputs)
+		})
+
+		Context("and the indicator has recieved all of its ticks", func() {
+			BeforeEach(func() {
+				for i := 0; i < len(sourceDOHLCVData); i++ {
+					indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				}
+			})
+
+			ShouldBeAnIndicatorThatHasReceivedAllOfItsTicks(&inputs)
+
+			ShouldHaveFloatBoundsSetToMinMaxOfResults(&inputs)
+		})
+	})
+
+	Context("given the indicator is created via the standard constructor with a nil data selection func", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewMaxDrawdown(nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+		})
+	})
+
+	Context("given the indicator is created via the constructor with fixed source length", func() {
+		BeforeEach(func() {
+			indicator, _ = indicators.NewMaxDrawdownWithSrcLen(uint(len(sourceDOHLCVData)), gotrade.UseClosePrice)
+			for i := 0; i < len(sourceDOHLCVData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("no new storage capcity should have been allocated", func() {
+			Expect(len(indicator.Drawdown)).To(Equal(cap(indicator.Drawdown)))
+			Expect(len(indicator.MaxDrawdown)).To(Equal(cap(indicator.MaxDrawdown)))
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream", func() {
+		BeforeEach(func() {
+			stream = newFakeDOHLCVStreamSubscriber()
+			indicator, _ = indicators.NewMaxDrawdownForStream(stream, gotrade.UseClosePrice)
+		})
+
+		It("should have requested to be attached to the stream", func() {
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream with fixed source length", func() {
+		BeforeEach(func() {
+			stream = newFakeDOHLCVStreamSubscriber()
+			indicator, _ = indicators.NewMaxDrawdownForStreamWithSrcLen(uint(len(sourceDOHLCVData)), stream, gotrade.UseClosePrice)
+		})
+
+		It("should have pre-allocated storge for the output data", func() {
+			Expect(cap(indicator.Drawdown)).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+		})
+
+		It("should have requested to be attached to the stream", func() {
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+})
+
+var _ = Describe("when calculating a maximum drawdown (maxdrawdown) of a series with a peak, trough and recovery", func() {
+	var (
+		indicator    *indicators.MaxDrawdownWithoutStorage
+		drawdowns    []float64
+		maxDrawdowns []float64
+	)
+
+	BeforeEach(func() {
+		drawdowns, maxDrawdowns = []float64{}, []float64{}
+		indicator, _ = indicators.NewMaxDrawdownWithoutStorage(func(dataItemDrawdown float64, dataItemMaxDrawdown float64, streamBarIndex int) {
+			drawdowns = append(drawdowns, dataItemDrawdown)
+			maxDrawdowns = append(maxDrawdowns, dataItemMaxDrawdown)
+		})
+		for i, value := range []float64{100.0, 120.0, 90.0, 60.0, 80.0, 130.0, 117.0} {
+			indicator.ReceiveTick(value, i+1)
+		}
+	})
+
+	It("should emit from the first bar", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(0))
+		Expect(indicator.ValidFromBar()).To(Equal(1))
+	})
+
+	It("the drawdown should be the fall from the running peak", func() {
+		expected := []float64{0.0, 0.0, -0.25, -0.5, -1.0 / 3.0, 0.0, -0.1}
+		Expect(drawdowns).To(HaveLen(len(expected)))
+		for i := range expected {
+			Expect(drawdowns[i]).To(BeNumerically("~", expected[i], 0.0000001))
+		}
+	})
+
+	It("the maximum drawdown should hold the deepest drawdown through the recovery", func() {
+		expected := []float64{0.0, 0.0, -0.25, -0.5, -0.5, -0.5, -0.5}
+		Expect(maxDrawdowns).To(HaveLen(len(expected)))
+		for i := range expected {
+			Expect(maxDrawdowns[i]).To(BeNumerically("~", expected[i], 0.0000001))
+		}
+	})
+})
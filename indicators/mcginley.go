@@ -0,0 +1,189 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// the largest power term of a McGinley Dynamic, limiting the power of the ratio of the price to the previous
+// result so that a gap in the price cannot overflow it
+const mcGinleyMaxPowerTerm float64 = 100000000.0
+
+// A McGinley Dynamic Indicator (McGinley), no storage, for use in other indicators
+// a moving average that adjusts its speed to that of the price, md = md' + (price - md') / (k * timePeriod * (price / md')^4),
+// seeded with the first price. The power term is clamped so that the average neither overshoots the price nor
+// overflows on a gap, a previous result of 0.0 is reseeded with the price
+type McGinleyWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	hasPreviousResult bool
+	previousResult    float64
+	factor            float64
+}
+
+// NewMcGinleyWithoutStorage creates a McGinley Dynamic Indicator (McGinley) without storage
+func NewMcGinleyWithoutStorage(timePeriod int, k float64, valueAvailableAction ValueAvailableActionFloat) (indicator *McGinleyWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// k must be above 0
+	if k <= 0.0 {
+		return nil, errors.New("k is less than the minimum (above 0)")
+	}
+
+	// check the maximum k
+	if k > math.MaxFloat64 {
+		return nil, errors.New("k is greater than the maximum float64 size")
+	}
+
+	lookback := 0
+	ind := McGinleyWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		factor:                       k * float64(timePeriod),
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *McGinleyWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	var result float64
+	if !ind.hasPreviousResult || ind.previousResult == 0.0 {
+		result = tickData
+	} else {
+		powerTerm := math.Pow(tickData/ind.previousResult, 4)
+
+		// at most the whole distance to the price is covered, and a gap cannot overflow the power term
+		powerTerm = math.Max(powerTerm, 1.0/ind.factor)
+		powerTerm = math.Min(powerTerm, mcGinleyMaxPowerTerm)
+
+		result = ind.previousResult + (tickData-ind.previousResult)/(ind.factor*powerTerm)
+	}
+
+	ind.hasPreviousResult = true
+	ind.previousResult = result
+
+	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+}
+
+// A McGinley Dynamic Indicator (McGinley)
+type McGinley struct {
+	*McGinleyWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewMcGinley creates a McGinley Dynamic Indicator (McGinley) for online usage
+func NewMcGinley(timePeriod int, k float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *McGinley, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := McGinley{
+		selectData: selectData,
+	}
+
+	ind.McGinleyWithoutStorage, err = NewMcGinleyWithoutStorage(timePeriod, k,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultMcGinley creates a McGinley Dynamic Indicator (McGinley) for online usage with default parameters
+//	- timePeriod: 14
+//	- k: 0.6
+func NewDefaultMcGinley() (indicator *McGinley, err error) {
+	timePeriod := 14
+	k := 0.6
+	return NewMcGinley(timePeriod, k, gotrade.UseClosePrice)
+}
+
+// NewMcGinleyWithSrcLen creates a McGinley Dynamic Indicator (McGinley) for offline usage
+func NewMcGinleyWithSrcLen(sourceLength uint, timePeriod int, k float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *McGinley, err error) {
+	ind, err := NewMcGinley(timePeriod, k, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultMcGinleyWithSrcLen creates a McGinley Dynamic Indicator (McGinley) for offline usage with default parameters
+func NewDefaultMcGinleyWithSrcLen(sourceLength uint) (indicator *McGinley, err error) {
+	ind, err := NewDefaultMcGinley()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewMcGinleyForStream creates a McGinley Dynamic Indicator (McGinley) for online usage with a source data stream
+func NewMcGinleyForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, k float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *McGinley, err error) {
+	ind, err := NewMcGinley(timePeriod, k, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultMcGinleyForStream creates a McGinley Dynamic Indicator (McGinley) for online usage with a source data stream
+func NewDefaultMcGinleyForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *McGinley, err error) {
+	ind, err := NewDefaultMcGinley()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewMcGinleyForStreamWithSrcLen creates a McGinley Dynamic Indicator (McGinley) for offline usage with a source data stream
+func NewMcGinleyForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, k float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *McGinley, err error) {
+	ind, err := NewMcGinleyWithSrcLen(sourceLength, timePeriod, k, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultMcGinleyForStreamWithSrcLen creates a McGinley Dynamic Indicator (McGinley) for offline usage with a source data stream
+func NewDefaultMcGinleyForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *McGinley, err error) {
+	ind, err := NewDefaultMcGinleyWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *McGinley) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the McGinley results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *McGinley) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the McGinley results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *McGinley) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
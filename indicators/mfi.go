@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Money Flow Index Indicator (Mfi), no storage, for use in other indicators
@@ -204,3 +205,15 @@ func (ind *MfiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamB
 	ind.currentVolume = tickData.V()
 	ind.typicalPrice.ReceiveDOHLCVTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the Mfi results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Mfi) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Mfi results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Mfi) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
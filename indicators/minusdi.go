@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Minus Directional Indicator (MinusDi), no storage, for use in other indicators
@@ -194,3 +195,15 @@ func (ind *MinusDiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, str
 	ind.previousHigh = high
 	ind.previousLow = low
 }
+
+// ValuesInRange returns the MinusDi results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *MinusDi) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the MinusDi results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *MinusDi) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Minus Directional Movement Indicator (MinusDm), no storage, for use in other indicators
@@ -178,3 +179,15 @@ func (ind *MinusDmWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, str
 	ind.previousHigh = high
 	ind.previousLow = low
 }
+
+// ValuesInRange returns the MinusDm results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *MinusDm) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the MinusDm results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *MinusDm) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
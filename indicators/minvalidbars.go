@@ -0,0 +1,37 @@
+package indicators
+
+type baseMinValidBars struct {
+	minValidBars    int
+	withheldResults int
+}
+
+func newBaseMinValidBars() *baseMinValidBars {
+	return &baseMinValidBars{}
+}
+
+// SetMinValidBars sets the minimum number of source data bars to be received before results are made available,
+// independent of the lookback period, so that the early results of an indicator that has not yet settled, such as
+// an Ema, are withheld. The ValidFromBar is that of the first result made available and the lookback period is
+// unchanged, a minValidBars within the lookback period has no effect. It should be set before any ticks are received
+func (ind *baseMinValidBars) SetMinValidBars(minValidBars int) {
+	ind.minValidBars = minValidBars
+}
+
+// withholdResult returns whether the result is withheld, as fewer than the minimum valid bars have been received
+func (ind *baseIndicatorWithFloatBounds) withholdResult() bool {
+	if ind.validFromBar != -1 || ind.lookbackPeriod+1+ind.withheldResults >= ind.minValidBars {
+		return false
+	}
+
+	ind.withheldResults++
+	return true
+}
+
+// BarsUntilValid returns the number of source data bars consumed before the first result, which is the lookback
+// period unless results are withheld until the later of the minimum valid bars
+func (ind *baseIndicatorWithFloatBounds) BarsUntilValid() int {
+	if ind.minValidBars-1 > ind.lookbackPeriod {
+		return ind.minValidBars - 1
+	}
+	return ind.lookbackPeriod
+}
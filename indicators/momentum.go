@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Momentum Indicator (Mom), no storage, for use in other indicators
@@ -158,3 +159,15 @@ func (ind *MomWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 		ind.periodHistory.Remove(first)
 	}
 }
+
+// ValuesInRange returns the Mom results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Mom) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Mom results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Mom) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,85 @@
+package indicators
+
+// A TiePolicy selects which of several equal extremes within the time period a rolling max or min reports the
+// position of
+type TiePolicy int
+
+const (
+	// TiePolicyNewest reports the most recent of the equal extremes, the default of the Aroon
+	TiePolicyNewest TiePolicy = iota
+
+	// TiePolicyOldest reports the earliest of the equal extremes still within the time period, the default of the
+	// HhvBars and LlvBars, as in their reference data
+	TiePolicyOldest
+)
+
+// monotonicDeque tracks the max, or min, of the most recent timePeriod values received. It holds only the values
+// that can still become the extreme, in the order received, so the extreme is always at the front
+type monotonicDeque struct {
+	values     []float64
+	positions  []int
+	received   int
+	timePeriod int
+	isMax      bool
+	tiePolicy  TiePolicy
+}
+
+func newMonotonicDeque(timePeriod int, isMax bool) *monotonicDeque {
+	return &monotonicDeque{
+		values:     make([]float64, 0, timePeriod),
+		positions:  make([]int, 0, timePeriod),
+		timePeriod: timePeriod,
+		isMax:      isMax,
+	}
+}
+
+// push adds the value as the newest, dropping the values it supersedes and any extreme outside the time period
+func (d *monotonicDeque) push(value float64) {
+	for len(d.values) > 0 && d.supersedes(value, d.values[len(d.values)-1]) {
+		d.values = d.values[:len(d.values)-1]
+		d.positions = d.positions[:len(d.positions)-1]
+	}
+
+	d.values = append(d.values, value)
+	d.positions = append(d.positions, d.received)
+	d.received++
+
+	if d.positions[0] <= d.received-1-d.timePeriod {
+		d.values = d.values[1:]
+		d.positions = d.positions[1:]
+	}
+}
+
+// supersedes returns true if the newer value makes the older one unable to be reported, an equal older value is
+// only kept when the oldest of the equal extremes is reported
+func (d *monotonicDeque) supersedes(newer float64, older float64) bool {
+	if newer == older {
+		return d.tiePolicy == TiePolicyNewest
+	}
+
+	if d.isMax {
+		return newer > older
+	}
+
+	return newer < older
+}
+
+// isFull returns true once timePeriod values have been received
+func (d *monotonicDeque) isFull() bool {
+	return d.received >= d.timePeriod
+}
+
+// extreme returns the max, or min, of the values within the time period
+func (d *monotonicDeque) extreme() float64 {
+	return d.values[0]
+}
+
+// barsSinceExtreme returns how many values were received after the extreme, 0 when it is the newest value
+func (d *monotonicDeque) barsSinceExtreme() int {
+	return d.received - 1 - d.positions[0]
+}
+
+// setTiePolicy sets which of several equal extremes is reported, it should be set before any value is received
+func (d *monotonicDeque) setTiePolicy(tiePolicy TiePolicy) {
+	d.tiePolicy = tiePolicy
+}
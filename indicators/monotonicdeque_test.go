@@ -0,0 +1,106 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when tracking the bars since the extreme of a series with repeated equal extremes", func() {
+	var (
+		start time.Time = time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	bar := func(i int, high float64, low float64) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), low, high, low, high, 1000.0)
+	}
+
+	Context("given an hhvbars", func() {
+		var (
+			indicator *indicators.HhvBars
+			highs     []float64 = []float64{1.0, 5.0, 2.0, 5.0, 3.0, 4.0, 1.0}
+		)
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewHhvBars(5, gotrade.UseClosePrice)
+		})
+
+		feed := func() {
+			for i := range highs {
+				indicator.ReceiveDOHLCVTick(bar(i, highs[i], 0.0), i+1)
+			}
+		}
+
+		It("should count from the oldest of the equal highs within the time period by default", func() {
+			feed()
+			Expect(indicator.Data).To(Equal([]int64{3, 4, 3}))
+		})
+
+		It("should count from the newest of the equal highs given the newest tie policy", func() {
+			indicator.SetTiePolicy(indicators.TiePolicyNewest)
+			feed()
+			Expect(indicator.Data).To(Equal([]int64{1, 2, 3}))
+		})
+	})
+
+	Context("given an llvbars", func() {
+		var (
+			indicator *indicators.LlvBars
+			lows      []float64 = []float64{5.0, 1.0, 4.0, 1.0, 3.0, 2.0, 6.0}
+		)
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewLlvBars(5, gotrade.UseClosePrice)
+		})
+
+		feed := func() {
+			for i := range lows {
+				indicator.ReceiveDOHLCVTick(bar(i, lows[i], 0.0), i+1)
+			}
+		}
+
+		It("should count from the oldest of the equal lows within the time period by default", func() {
+			feed()
+			Expect(indicator.Data).To(Equal([]int64{3, 4, 3}))
+		})
+
+		It("should count from the newest of the equal lows given the newest tie policy", func() {
+			indicator.SetTiePolicy(indicators.TiePolicyNewest)
+			feed()
+			Expect(indicator.Data).To(Equal([]int64{1, 2, 3}))
+		})
+	})
+
+	Context("given an aroon", func() {
+		var (
+			indicator *indicators.Aroon
+			highs     []float64 = []float64{1.0, 5.0, 2.0, 5.0, 3.0}
+			lows      []float64 = []float64{0.5, 0.2, 0.4, 0.2, 0.3}
+		)
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewAroon(4)
+		})
+
+		feed := func() {
+			for i := range highs {
+				indicator.ReceiveDOHLCVTick(bar(i, highs[i], lows[i]), i+1)
+			}
+		}
+
+		It("should count the days since the newest of the equal highs and lows by default", func() {
+			feed()
+			Expect(indicator.Up).To(Equal([]float64{75.0}))
+			Expect(indicator.Down).To(Equal([]float64{75.0}))
+		})
+
+		It("should count the days since the oldest of the equal highs and lows given the oldest tie policy", func() {
+			indicator.SetTiePolicy(indicators.TiePolicyOldest)
+			feed()
+			Expect(indicator.Up).To(Equal([]float64{25.0}))
+			Expect(indicator.Down).To(Equal([]float64{25.0}))
+		})
+	})
+})
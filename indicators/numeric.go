@@ -0,0 +1,74 @@
+package indicators
+
+import (
+	"math"
+)
+
+// Numeric is the arithmetic required of a value type T by the moving average cores of the Sma, Ema and Kama,
+// implementing it over a decimal type allows exact arithmetic where float64 accumulation would drift
+// over long streams, NumericFloat64 is the float64 instantiation used by the Sma, Ema and Kama
+type Numeric[T any] interface {
+	Add(other T) T
+	Sub(other T) T
+	Mul(other T) T
+	Div(other T) T
+	Abs() T
+
+	// Cmp returns -1, 0 or +1 as the value is less than, equal to or greater than other
+	Cmp(other T) int
+
+	// IsZero returns whether the value is zero, to within the precision of the type
+	IsZero() bool
+
+	// FromInt returns the integer value as the same numeric type, for the constants an indicator requires
+	FromInt(value int64) T
+
+	// Float64 returns the nearest float64 to the value, for bounds and charting
+	Float64() float64
+}
+
+// NumericFloat64 is the float64 instantiation of Numeric
+type NumericFloat64 float64
+
+func (n NumericFloat64) Add(other NumericFloat64) NumericFloat64 {
+	return n + other
+}
+
+func (n NumericFloat64) Sub(other NumericFloat64) NumericFloat64 {
+	return n - other
+}
+
+func (n NumericFloat64) Mul(other NumericFloat64) NumericFloat64 {
+	return n * other
+}
+
+func (n NumericFloat64) Div(other NumericFloat64) NumericFloat64 {
+	return n / other
+}
+
+func (n NumericFloat64) Abs() NumericFloat64 {
+	return NumericFloat64(math.Abs(float64(n)))
+}
+
+func (n NumericFloat64) Cmp(other NumericFloat64) int {
+	if n < other {
+		return -1
+	}
+	if n > other {
+		return 1
+	}
+	return 0
+}
+
+// IsZero returns whether the value is within the epsilon the float64 indicators treat as zero
+func (n NumericFloat64) IsZero() bool {
+	return isZero(float64(n))
+}
+
+func (n NumericFloat64) FromInt(value int64) NumericFloat64 {
+	return NumericFloat64(value)
+}
+
+func (n NumericFloat64) Float64() float64 {
+	return float64(n)
+}
@@ -0,0 +1,123 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math/big"
+)
+
+// ratNumeric is an exact decimal Numeric used to verify the numeric indicators avoid floating point drift
+type ratNumeric struct {
+	value *big.Rat
+}
+
+func newRatNumeric(numerator int64, denominator int64) ratNumeric {
+	return ratNumeric{value: big.NewRat(numerator, denominator)}
+}
+
+func (n ratNumeric) Add(other ratNumeric) ratNumeric {
+	return ratNumeric{value: new(big.Rat).Add(n.value, other.value)}
+}
+
+func (n ratNumeric) Sub(other ratNumeric) ratNumeric {
+	return ratNumeric{value: new(big.Rat).Sub(n.value, other.value)}
+}
+
+func (n ratNumeric) Mul(other ratNumeric) ratNumeric {
+	return ratNumeric{value: new(big.Rat).Mul(n.value, other.value)}
+}
+
+func (n ratNumeric) Div(other ratNumeric) ratNumeric {
+	return ratNumeric{value: new(big.Rat).Quo(n.value, other.value)}
+}
+
+func (n ratNumeric) Abs() ratNumeric {
+	return ratNumeric{value: new(big.Rat).Abs(n.value)}
+}
+
+func (n ratNumeric) Cmp(other ratNumeric) int {
+	return n.value.Cmp(other.value)
+}
+
+func (n ratNumeric) IsZero() bool {
+	return n.value.Sign() == 0
+}
+
+func (n ratNumeric) FromInt(value int64) ratNumeric {
+	return newRatNumeric(value, 1)
+}
+
+func (n ratNumeric) Float64() float64 {
+	result, _ := n.value.Float64()
+	return result
+}
+
+var _ = Describe("when using the float64 numeric type", func() {
+	var (
+		a indicators.NumericFloat64 = 7.5
+		b indicators.NumericFloat64 = -2.5
+	)
+
+	It("should perform float64 arithmetic", func() {
+		Expect(a.Add(b)).To(Equal(indicators.NumericFloat64(5.0)))
+		Expect(a.Sub(b)).To(Equal(indicators.NumericFloat64(10.0)))
+		Expect(a.Mul(b)).To(Equal(indicators.NumericFloat64(-18.75)))
+		Expect(a.Div(b)).To(Equal(indicators.NumericFloat64(-3.0)))
+		Expect(b.Abs()).To(Equal(indicators.NumericFloat64(2.5)))
+	})
+
+	It("should compare values", func() {
+		Expect(a.Cmp(b)).To(Equal(1))
+		Expect(b.Cmp(a)).To(Equal(-1))
+		Expect(a.Cmp(a)).To(Equal(0))
+		Expect(a.IsZero()).To(BeFalse())
+		Expect(a.Sub(a).IsZero()).To(BeTrue())
+	})
+
+	It("should convert to and from other types", func() {
+		Expect(a.FromInt(3)).To(Equal(indicators.NumericFloat64(3.0)))
+		Expect(a.Float64()).To(Equal(7.5))
+	})
+})
+
+var _ = Describe("when calculating a numeric sma over a long series of decimal prices", func() {
+	var (
+		period      int = 10
+		seriesCount int = 200000
+		// the series settles on this price, 12345.6789, for its final period
+		finalNumerator   int64 = 123456789
+		finalDenominator int64 = 10000
+		floatResult      indicators.NumericFloat64
+		decimalResult    ratNumeric
+	)
+
+	BeforeEach(func() {
+		floatSma, _ := indicators.NewNumericSmaWithoutStorage(period, func(dataItem indicators.NumericFloat64, streamBarIndex int) {
+			floatResult = dataItem
+		})
+		decimalSma, _ := indicators.NewNumericSmaWithoutStorage(period, func(dataItem ratNumeric, streamBarIndex int) {
+			decimalResult = dataItem
+		})
+
+		for i := 0; i < seriesCount; i++ {
+			numerator := finalNumerator + int64(i%13)*37
+			if i >= seriesCount-period {
+				numerator = finalNumerator
+			}
+			price := newRatNumeric(numerator, finalDenominator)
+
+			floatSma.ReceiveTick(indicators.NumericFloat64(price.Float64()), i+1)
+			decimalSma.ReceiveTick(price, i+1)
+		}
+	})
+
+	It("the decimal sma should be exactly the final price", func() {
+		Expect(decimalResult.Cmp(newRatNumeric(finalNumerator, finalDenominator))).To(Equal(0))
+	})
+
+	It("the float64 sma should have drifted from the final price", func() {
+		Expect(floatResult.Float64()).NotTo(Equal(12345.6789))
+		Expect(floatResult.Float64()).To(BeNumerically("~", 12345.6789, 0.0001))
+	})
+})
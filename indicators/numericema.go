@@ -0,0 +1,95 @@
+package indicators
+
+import (
+	"errors"
+)
+
+// emaCore is the arithmetic of an Exponential Moving Average over a Numeric type, seeded by the Sma of the first
+// time period, shared by the Ema and the NumericEma
+type emaCore[T Numeric[T]] struct {
+	periodTotal   T
+	periodCounter int
+	multiplier    T
+	previousEma   T
+	timePeriod    int
+	hasTotal      bool
+}
+
+func newEmaCore[T Numeric[T]](timePeriod int) *emaCore[T] {
+	return &emaCore[T]{
+		periodCounter: timePeriod * -1,
+		timePeriod:    timePeriod,
+	}
+}
+
+// receive consumes a tick, returning the average once the seed is complete
+func (core *emaCore[T]) receive(tickData T) (result T, isAvailable bool) {
+	// the running total and multiplier take their numeric type from the first tick
+	if !core.hasTotal {
+		core.periodTotal = tickData.FromInt(0)
+		core.multiplier = tickData.FromInt(2).Div(tickData.FromInt(int64(core.timePeriod + 1)))
+		core.hasTotal = true
+	}
+
+	core.periodCounter += 1
+	if core.periodCounter < 0 {
+		core.periodTotal = core.periodTotal.Add(tickData)
+		return result, false
+	}
+
+	if core.periodCounter == 0 {
+		core.periodTotal = core.periodTotal.Add(tickData)
+		core.previousEma = core.periodTotal.Div(tickData.FromInt(int64(core.timePeriod)))
+	} else {
+		core.previousEma = tickData.Sub(core.previousEma).Mul(core.multiplier).Add(core.previousEma)
+	}
+
+	return core.previousEma, true
+}
+
+// seedAverage returns the average of the ticks received so far while the seed is incomplete
+func (core *emaCore[T]) seedAverage() T {
+	ticksReceived := core.timePeriod + core.periodCounter
+	return core.periodTotal.Div(core.periodTotal.FromInt(int64(ticksReceived)))
+}
+
+// An Exponential Moving Average Indicator over a Numeric type (NumericEma), no storage, for use in other indicators
+type NumericEmaWithoutStorage[T Numeric[T]] struct {
+	*baseIndicatorWithNumericBounds[T]
+
+	// private variables
+	core *emaCore[T]
+}
+
+// NewNumericEmaWithoutStorage creates an Exponential Moving Average Indicator over a Numeric type (NumericEma) without storage
+func NewNumericEmaWithoutStorage[T Numeric[T]](timePeriod int, valueAvailableAction ValueAvailableActionNumeric[T]) (indicator *NumericEmaWithoutStorage[T], err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := NumericEmaWithoutStorage[T]{
+		baseIndicatorWithNumericBounds: newBaseIndicatorWithNumericBounds(lookback, valueAvailableAction),
+		core:                           newEmaCore[T](timePeriod),
+	}
+
+	return &ind, nil
+}
+
+func (ind *NumericEmaWithoutStorage[T]) ReceiveTick(tickData T, streamBarIndex int) {
+	if result, isAvailable := ind.core.receive(tickData); isAvailable {
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
@@ -0,0 +1,187 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+)
+
+// kamaCore is the arithmetic of a Kaufman Adaptive Moving Average over a Numeric type, shared by the Kama and the
+// NumericKama
+type kamaCore[T Numeric[T]] struct {
+	periodHistory    *list.List
+	periodCounter    int
+	constantMax      T
+	constantDiff     T
+	sumROC           T
+	periodROC        T
+	previousClose    T
+	previousKama     T
+	hasPreviousClose bool
+	hasConstants     bool
+	timePeriod       int
+	lastState        *kamaCoreState[T]
+}
+
+// kamaCoreState is the kamaCore state prior to the most recently received tick, used to back out that tick
+type kamaCoreState[T Numeric[T]] struct {
+	periodCounter      int
+	sumROC             T
+	periodROC          T
+	previousClose      T
+	previousKama       T
+	hasPreviousClose   bool
+	removedFromHistory bool
+	removedValue       T
+}
+
+func newKamaCore[T Numeric[T]](timePeriod int) *kamaCore[T] {
+	return &kamaCore[T]{
+		periodCounter: (timePeriod + 1) * -1,
+		periodHistory: list.New(),
+		timePeriod:    timePeriod,
+	}
+}
+
+// receive consumes a tick, returning the average once the time period of changes is complete
+func (core *kamaCore[T]) receive(tickData T) (result T, isAvailable bool) {
+	// the constants and running sums take their numeric type from the first tick
+	if !core.hasConstants {
+		two := tickData.FromInt(2)
+		core.constantMax = two.Div(tickData.FromInt(31))
+		core.constantDiff = two.Div(tickData.FromInt(3)).Sub(core.constantMax)
+		core.sumROC = tickData.FromInt(0)
+		core.periodROC = tickData.FromInt(0)
+		core.hasConstants = true
+	}
+
+	// keep the prior state so that this tick can be backed out
+	lastState := kamaCoreState[T]{
+		periodCounter:    core.periodCounter,
+		sumROC:           core.sumROC,
+		periodROC:        core.periodROC,
+		previousClose:    core.previousClose,
+		previousKama:     core.previousKama,
+		hasPreviousClose: core.hasPreviousClose,
+	}
+
+	core.periodCounter += 1
+	core.periodHistory.PushBack(tickData)
+
+	if core.periodCounter <= 0 {
+		if core.hasPreviousClose {
+			core.sumROC = core.sumROC.Add(tickData.Sub(core.previousClose).Abs())
+		}
+	}
+	if core.periodCounter == 0 {
+		var closeMinusN = core.periodHistory.Front().Value.(T)
+		core.previousKama = core.previousClose
+		core.periodROC = tickData.Sub(closeMinusN)
+
+		result, isAvailable = core.nextKama(tickData), true
+
+	} else if core.periodCounter > 0 {
+
+		var closeMinusN = core.periodHistory.Front().Value.(T)
+		var closeMinusN1 = core.periodHistory.Front().Next().Value.(T)
+		core.periodROC = tickData.Sub(closeMinusN1)
+
+		core.sumROC = core.sumROC.Sub(closeMinusN1.Sub(closeMinusN).Abs())
+		core.sumROC = core.sumROC.Add(tickData.Sub(core.previousClose).Abs())
+
+		result, isAvailable = core.nextKama(tickData), true
+	}
+
+	core.previousClose = tickData
+	core.hasPreviousClose = true
+
+	if core.periodHistory.Len() > (core.timePeriod + 1) {
+		var first = core.periodHistory.Front()
+		lastState.removedFromHistory = true
+		lastState.removedValue = first.Value.(T)
+		core.periodHistory.Remove(first)
+	}
+
+	core.lastState = &lastState
+
+	return result, isAvailable
+}
+
+func (core *kamaCore[T]) nextKama(tickData T) T {
+	var er T
+
+	// calculate the efficiency ratio
+	if core.sumROC.Cmp(core.periodROC) <= 0 || core.sumROC.IsZero() {
+		er = tickData.FromInt(1)
+	} else {
+		er = core.periodROC.Div(core.sumROC).Abs()
+	}
+
+	sc := er.Mul(core.constantDiff).Add(core.constantMax)
+	sc = sc.Mul(sc)
+	core.previousKama = tickData.Sub(core.previousKama).Mul(sc).Add(core.previousKama)
+
+	return core.previousKama
+}
+
+// undoLastTick backs out the sumROC, previousKama and history updates of the most recently received tick
+func (core *kamaCore[T]) undoLastTick() {
+	if core.lastState == nil {
+		return
+	}
+
+	state := core.lastState
+
+	core.periodHistory.Remove(core.periodHistory.Back())
+	if state.removedFromHistory {
+		core.periodHistory.PushFront(state.removedValue)
+	}
+
+	core.periodCounter = state.periodCounter
+	core.sumROC = state.sumROC
+	core.periodROC = state.periodROC
+	core.previousClose = state.previousClose
+	core.previousKama = state.previousKama
+	core.hasPreviousClose = state.hasPreviousClose
+	core.lastState = nil
+}
+
+// A Kaufman Adaptive Moving Average Indicator over a Numeric type (NumericKama), no storage, for use in other indicators
+type NumericKamaWithoutStorage[T Numeric[T]] struct {
+	*baseIndicatorWithNumericBounds[T]
+
+	// private variables
+	core *kamaCore[T]
+}
+
+// NewNumericKamaWithoutStorage creates a Kaufman Adaptive Moving Average Indicator over a Numeric type (NumericKama) without storage
+func NewNumericKamaWithoutStorage[T Numeric[T]](timePeriod int, valueAvailableAction ValueAvailableActionNumeric[T]) (indicator *NumericKamaWithoutStorage[T], err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod
+	ind := NumericKamaWithoutStorage[T]{
+		baseIndicatorWithNumericBounds: newBaseIndicatorWithNumericBounds(lookback, valueAvailableAction),
+		core:                           newKamaCore[T](timePeriod),
+	}
+
+	return &ind, nil
+}
+
+func (ind *NumericKamaWithoutStorage[T]) ReceiveTick(tickData T, streamBarIndex int) {
+	if result, isAvailable := ind.core.receive(tickData); isAvailable {
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
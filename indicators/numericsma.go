@@ -0,0 +1,91 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+)
+
+// smaCore is the arithmetic of a Simple Moving Average over a Numeric type, shared by the Sma and the NumericSma
+type smaCore[T Numeric[T]] struct {
+	periodTotal   T
+	periodHistory *list.List
+	periodCounter int
+	timePeriod    int
+	hasTotal      bool
+}
+
+func newSmaCore[T Numeric[T]](timePeriod int) *smaCore[T] {
+	return &smaCore[T]{
+		periodCounter: timePeriod * -1,
+		periodHistory: list.New(),
+		timePeriod:    timePeriod,
+	}
+}
+
+// receive consumes a tick, returning the average once the time period is complete
+func (core *smaCore[T]) receive(tickData T) (result T, isAvailable bool) {
+	// the running total takes its numeric type from the first tick
+	if !core.hasTotal {
+		core.periodTotal = tickData.FromInt(0)
+		core.hasTotal = true
+	}
+
+	core.periodCounter += 1
+	core.periodHistory.PushBack(tickData)
+
+	if core.periodCounter > 0 {
+		var valueToRemove = core.periodHistory.Front()
+		core.periodTotal = core.periodTotal.Sub(valueToRemove.Value.(T))
+	}
+	if core.periodHistory.Len() > core.timePeriod {
+		var first = core.periodHistory.Front()
+		core.periodHistory.Remove(first)
+	}
+	core.periodTotal = core.periodTotal.Add(tickData)
+	if core.periodCounter < 0 {
+		return result, false
+	}
+
+	return core.periodTotal.Div(tickData.FromInt(int64(core.timePeriod))), true
+}
+
+// A Simple Moving Average Indicator over a Numeric type (NumericSma), no storage, for use in other indicators
+type NumericSmaWithoutStorage[T Numeric[T]] struct {
+	*baseIndicatorWithNumericBounds[T]
+
+	// private variables
+	core *smaCore[T]
+}
+
+// NewNumericSmaWithoutStorage creates a Simple Moving Average Indicator over a Numeric type (NumericSma) without storage
+func NewNumericSmaWithoutStorage[T Numeric[T]](timePeriod int, valueAvailableAction ValueAvailableActionNumeric[T]) (indicator *NumericSmaWithoutStorage[T], err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := NumericSmaWithoutStorage[T]{
+		baseIndicatorWithNumericBounds: newBaseIndicatorWithNumericBounds(lookback, valueAvailableAction),
+		core:                           newSmaCore[T](timePeriod),
+	}
+
+	return &ind, nil
+}
+
+func (ind *NumericSmaWithoutStorage[T]) ReceiveTick(tickData T, streamBarIndex int) {
+	if result, isAvailable := ind.core.receive(tickData); isAvailable {
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
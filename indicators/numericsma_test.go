@@ -0,0 +1,85 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when creating a numericsmawithoutstorage", func() {
+	var (
+		indicator      *indicators.NumericSmaWithoutStorage[indicators.NumericFloat64]
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewNumericSmaWithoutStorage[indicators.NumericFloat64](4, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewNumericSmaWithoutStorage[indicators.NumericFloat64](1, fakeNumericValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a timePeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewNumericSmaWithoutStorage[indicators.NumericFloat64](indicators.MaximumLookbackPeriod+1, fakeNumericValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when calculating a numeric sma (numericsma) over float64 values", func() {
+	var (
+		period    int = 3
+		indicator *indicators.NumericSmaWithoutStorage[indicators.NumericFloat64]
+		expected  *indicators.Sma
+		results   []float64
+	)
+
+	BeforeEach(func() {
+		results = []float64{}
+		expected, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+		indicator, _ = indicators.NewNumericSmaWithoutStorage[indicators.NumericFloat64](period, func(dataItem indicators.NumericFloat64, streamBarIndex int) {
+			results = append(results, float64(dataItem))
+		})
+
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			expected.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			indicator.ReceiveTick(indicators.NumericFloat64(sourceDOHLCVData[i].C()), i+1)
+		}
+	})
+
+	It("should have the same lookback and valid from bar as the float64 indicator", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(expected.GetLookbackPeriod()))
+		Expect(indicator.ValidFromBar()).To(Equal(expected.ValidFromBar()))
+	})
+
+	It("should match the results of the float64 indicator", func() {
+		Expect(results).To(HaveLen(len(expected.Data)))
+		for i := range results {
+			Expect(results[i]).To(BeNumerically("~", expected.Data[i], 0.0000001))
+		}
+	})
+
+	It("should have float bounds set to the min and max of the results", func() {
+		Expect(indicator.MinValue()).To(Equal(GetFloatDataMin(results)))
+		Expect(indicator.MaxValue()).To(Equal(GetFloatDataMax(results)))
+	})
+})
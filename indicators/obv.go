@@ -1,14 +1,18 @@
 package indicators
 
 import (
+	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // An On Balance Volume Indicator (Obv), no storage, for use in other indicators
 type ObvWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
+	*baseGapReset
 
 	// private variables
+	smoothingEma  *EmaWithoutStorage
 	periodCounter int
 	previousObv   float64
 	previousClose float64
@@ -16,20 +20,47 @@ type ObvWithoutStorage struct {
 
 // NewObvWithoutStorage creates an On Balance Volume Indicator (Obv) without storage
 func NewObvWithoutStorage(valueAvailableAction ValueAvailableActionFloat) (indicator *ObvWithoutStorage, err error) {
+	return NewObvWithSmoothingWithoutStorage(0, valueAvailableAction)
+}
+
+// NewObvWithSmoothingWithoutStorage creates an On Balance Volume Indicator (Obv) without storage
+// smoothed by an Ema of smoothingPeriod, a smoothingPeriod of 0 gives the raw line
+func NewObvWithSmoothingWithoutStorage(smoothingPeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *ObvWithoutStorage, err error) {
 
 	// an indicator without storage MUST have a value available action
 	if valueAvailableAction == nil {
 		return nil, ErrValueAvailableActionIsNil
 	}
 
+	// the minimum smoothingPeriod for the Ema is 2, 0 disables the smoothing
+	if smoothingPeriod < 0 || smoothingPeriod == 1 {
+		return nil, errors.New("smoothingPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum smoothingPeriod
+	if smoothingPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("smoothingPeriod is greater than the maximum (100000)")
+	}
+
 	lookback := 0
+	if smoothingPeriod > 0 {
+		lookback = smoothingPeriod - 1
+	}
+
 	ind := ObvWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		baseGapReset:                 newBaseGapReset(),
 		periodCounter:                -1,
 		previousObv:                  0.0,
 		previousClose:                0.0,
 	}
 
+	if smoothingPeriod > 0 {
+		ind.smoothingEma, _ = NewEmaWithoutStorage(smoothingPeriod, func(dataItem float64, streamBarIndex int) {
+			ind.UpdateIndicatorWithNewValue(dataItem, streamBarIndex)
+		})
+	}
+
 	return &ind, nil
 }
 
@@ -43,8 +74,13 @@ type Obv struct {
 
 // NewObv creates an On Balance Volume Indicator (Obv) for online usage
 func NewObv() (indicator *Obv, err error) {
+	return NewObvWithSmoothing(0)
+}
+
+// NewObvWithSmoothing creates an On Balance Volume Indicator (Obv) for online usage smoothed by an Ema of smoothingPeriod
+func NewObvWithSmoothing(smoothingPeriod int) (indicator *Obv, err error) {
 	ind := Obv{}
-	ind.ObvWithoutStorage, err = NewObvWithoutStorage(func(dataItem float64, streamBarIndex int) {
+	ind.ObvWithoutStorage, err = NewObvWithSmoothingWithoutStorage(smoothingPeriod, func(dataItem float64, streamBarIndex int) {
 		ind.Data = append(ind.Data, dataItem)
 	})
 
@@ -70,6 +106,14 @@ func NewObvForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Obv
 	return ind, err
 }
 
+// NewObvWithSmoothingForStream creates an On Balance Volume Indicator (Obv) for online usage with a source data stream
+// smoothed by an Ema of smoothingPeriod
+func NewObvWithSmoothingForStream(priceStream gotrade.DOHLCVStreamSubscriber, smoothingPeriod int) (indicator *Obv, err error) {
+	ind, err := NewObvWithSmoothing(smoothingPeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
 // NewObvForStreamWithSrcLen creates an On Balance Volume (Obv) for offline usage with a source data stream
 func NewObvForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Obv, err error) {
 	ind, err := NewObvWithSrcLen(sourceLength)
@@ -79,6 +123,11 @@ func NewObvForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStre
 
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *ObvWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// a gap beyond the maxGap of the policy starts the line again from the volume of this bar
+	if ind.gapExceeded(tickData) {
+		ind.periodCounter = -1
+	}
+
 	ind.periodCounter += 1
 
 	if ind.periodCounter <= 0 {
@@ -87,7 +136,7 @@ func (ind *ObvWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamB
 
 		result := ind.previousObv
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+		ind.emitResult(result, streamBarIndex)
 	}
 
 	if ind.periodCounter > 0 {
@@ -100,8 +149,30 @@ func (ind *ObvWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamB
 
 		result := ind.previousObv
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+		ind.emitResult(result, streamBarIndex)
 
 		ind.previousClose = tickData.C()
 	}
 }
+
+// emitResult passes the raw line through the smoothing Ema, when there is one
+func (ind *ObvWithoutStorage) emitResult(result float64, streamBarIndex int) {
+	if ind.smoothingEma != nil {
+		ind.smoothingEma.ReceiveTick(result, streamBarIndex)
+		return
+	}
+
+	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+}
+
+// ValuesInRange returns the Obv results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Obv) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Obv results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Obv) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
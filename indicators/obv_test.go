@@ -221,3 +221,83 @@ var _ = Describe("when calculating an on balance volume (obv) with DOHLCV source
 		})
 	})
 })
+
+var _ = Describe("when calculating a smoothed obv with DOHLCV source data", func() {
+	var (
+		indicator *indicators.Obv
+		raw       *indicators.Obv
+	)
+
+	BeforeEach(func() {
+		raw, _ = indicators.NewObv()
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			raw.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	Context("and the smoothingPeriod is 0", func() {
+		BeforeEach(func() {
+			indicator, _ = indicators.NewObvWithSmoothing(0)
+			for i := 0; i < len(sourceDOHLCVData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("should reproduce the raw line", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(raw.GetLookbackPeriod()))
+			Expect(indicator.Data).To(Equal(raw.Data))
+		})
+	})
+
+	Context("and the smoothingPeriod is positive", func() {
+		var (
+			period   int = 5
+			expected []float64
+		)
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewObvWithSmoothing(period)
+			for i := 0; i < len(sourceDOHLCVData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+
+			expected = []float64{}
+			ema, _ := indicators.NewEmaWithoutStorage(period, func(dataItem float64, streamBarIndex int) {
+				expected = append(expected, dataItem)
+			})
+			for i, value := range raw.Data {
+				ema.ReceiveTick(value, i+1)
+			}
+		})
+
+		It("should lag the raw line by the smoothing period", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(period - 1))
+			Expect(indicator.ValidFromBar()).To(Equal(raw.ValidFromBar() + period - 1))
+			Expect(len(indicator.Data)).To(Equal(len(raw.Data) - (period - 1)))
+		})
+
+		It("should be the ema of the raw line", func() {
+			Expect(indicator.Data).To(Equal(expected))
+		})
+	})
+
+	Context("and the smoothingPeriod is below the minimum", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			withoutStorage, err := indicators.NewObvWithSmoothingWithoutStorage(1, fakeFloatValAvailable)
+			Expect(withoutStorage).To(BeNil())
+			Expect(err).NotTo(BeNil())
+
+			withoutStorage, err = indicators.NewObvWithSmoothingWithoutStorage(-1, fakeFloatValAvailable)
+			Expect(withoutStorage).To(BeNil())
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Context("and the indicator is created for use with a price stream", func() {
+		It("should have requested to be attached to the stream", func() {
+			stream := newFakeDOHLCVStreamSubscriber()
+			streamIndicator, _ := indicators.NewObvWithSmoothingForStream(stream, 5)
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(streamIndicator))
+		})
+	})
+})
@@ -0,0 +1,205 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+	"time"
+)
+
+var (
+	ErrOscillatorOfStageIsNil = errors.New("An OscillatorOf requires a source and an oscillator stage")
+)
+
+// An OscillatorStage creates an indicator without storage that consumes DOHLCV data for an OscillatorOf, reporting
+// its results to the given value available action, e.g.
+//	func(valueAvailableAction ValueAvailableActionFloat) (DOHLCVFloatIndicatorWithoutStorage, error) {
+//		return NewWillRWithoutStorage(14, valueAvailableAction)
+//	}
+type OscillatorStage func(valueAvailableAction ValueAvailableActionFloat) (DOHLCVFloatIndicatorWithoutStorage, error)
+
+// An Oscillator of an Oscillator Indicator (OscillatorOf), no storage, for use in other indicators
+// each result of the source stage is received by the oscillator stage as a synthetic price bar, with an open,
+// high, low and close of the result and the date and volume of the source data tick, so that an indicator of
+// DOHLCV data, such as a WillR, can be applied to a bounded indicator, such as an Rsi. The results keep the stream
+// bar index of the source data and the oscillator lags the source stage by its own lookback period.
+// A saturated source stage gives a flat synthetic price without a range, the NaN of which is reported as 0.0
+type OscillatorOfWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	source        FloatIndicatorWithoutStorage
+	oscillator    DOHLCVFloatIndicatorWithoutStorage
+	currentDate   time.Time
+	currentVolume float64
+}
+
+// NewOscillatorOfWithoutStorage creates an Oscillator of an Oscillator Indicator (OscillatorOf) without storage
+func NewOscillatorOfWithoutStorage(source ChainStage, oscillator OscillatorStage, valueAvailableAction ValueAvailableActionFloat) (indicator *OscillatorOfWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	if source == nil || oscillator == nil {
+		return nil, ErrOscillatorOfStageIsNil
+	}
+
+	ind := OscillatorOfWithoutStorage{}
+
+	ind.oscillator, err = oscillator(func(dataItem float64, streamBarIndex int) {
+		if math.IsNaN(dataItem) {
+			dataItem = 0.0
+		}
+		ind.UpdateIndicatorWithNewValue(dataItem, streamBarIndex)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ind.source, err = source(func(dataItem float64, streamBarIndex int) {
+		bar := gotrade.NewDOHLCVDataItem(ind.currentDate, dataItem, dataItem, dataItem, dataItem, ind.currentVolume)
+		ind.oscillator.ReceiveDOHLCVTick(bar, streamBarIndex)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := ind.source.GetLookbackPeriod() + ind.oscillator.GetLookbackPeriod()
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction)
+
+	return &ind, nil
+}
+
+// NewWillROfRsiWithoutStorage creates an Oscillator of an Oscillator Indicator (OscillatorOf) without storage of
+// the WillR of the willRPeriod of the Rsi of the rsiPeriod
+func NewWillROfRsiWithoutStorage(rsiPeriod int, willRPeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *OscillatorOfWithoutStorage, err error) {
+	return NewOscillatorOfWithoutStorage(
+		func(valueAvailableAction ValueAvailableActionFloat) (FloatIndicatorWithoutStorage, error) {
+			return NewRsiWithoutStorage(rsiPeriod, valueAvailableAction)
+		},
+		func(valueAvailableAction ValueAvailableActionFloat) (DOHLCVFloatIndicatorWithoutStorage, error) {
+			return NewWillRWithoutStorage(willRPeriod, valueAvailableAction)
+		}, valueAvailableAction)
+}
+
+// receiveSelectedTick passes the selected price of the source data tick to the source stage, keeping the date and
+// volume of the tick for the synthetic price bar
+func (ind *OscillatorOfWithoutStorage) receiveSelectedTick(tickData gotrade.DOHLCV, selectedData float64, streamBarIndex int) {
+	ind.currentDate = tickData.D()
+	ind.currentVolume = tickData.V()
+	ind.source.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ReceiveTick consumes a source data float price tick, the synthetic price bars of which have no date or volume
+func (ind *OscillatorOfWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.currentDate = time.Time{}
+	ind.currentVolume = 0.0
+	ind.source.ReceiveTick(tickData, streamBarIndex)
+}
+
+// An Oscillator of an Oscillator Indicator (OscillatorOf)
+type OscillatorOf struct {
+	*OscillatorOfWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewOscillatorOf creates an Oscillator of an Oscillator Indicator (OscillatorOf) for online usage
+func NewOscillatorOf(source ChainStage, oscillator OscillatorStage, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *OscillatorOf, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := OscillatorOf{
+		selectData: selectData,
+	}
+
+	ind.OscillatorOfWithoutStorage, err = NewOscillatorOfWithoutStorage(source, oscillator,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ind, nil
+}
+
+// NewOscillatorOfWithSrcLen creates an Oscillator of an Oscillator Indicator (OscillatorOf) for offline usage
+func NewOscillatorOfWithSrcLen(sourceLength uint, source ChainStage, oscillator OscillatorStage, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *OscillatorOf, err error) {
+	ind, err := NewOscillatorOf(source, oscillator, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if err == nil && sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewOscillatorOfForStream creates an Oscillator of an Oscillator Indicator (OscillatorOf) for online usage with a source data stream
+func NewOscillatorOfForStream(priceStream gotrade.DOHLCVStreamSubscriber, source ChainStage, oscillator OscillatorStage, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *OscillatorOf, err error) {
+	ind, err := NewOscillatorOf(source, oscillator, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewOscillatorOfForStreamWithSrcLen creates an Oscillator of an Oscillator Indicator (OscillatorOf) for offline usage with a source data stream
+func NewOscillatorOfForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, source ChainStage, oscillator OscillatorStage, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *OscillatorOf, err error) {
+	ind, err := NewOscillatorOfWithSrcLen(sourceLength, source, oscillator, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewWillROfRsi creates an Oscillator of an Oscillator Indicator (OscillatorOf) for online usage of the WillR of
+// the willRPeriod of the Rsi of the rsiPeriod of the close
+func NewWillROfRsi(rsiPeriod int, willRPeriod int) (indicator *OscillatorOf, err error) {
+	ind := OscillatorOf{
+		selectData: gotrade.UseClosePrice,
+	}
+
+	ind.OscillatorOfWithoutStorage, err = NewWillROfRsiWithoutStorage(rsiPeriod, willRPeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ind, nil
+}
+
+// NewDefaultWillROfRsi creates an Oscillator of an Oscillator Indicator (OscillatorOf) for online usage of the WillR
+// of the Rsi of the close with default parameters
+//	- rsiPeriod: 14
+//	- willRPeriod: 14
+func NewDefaultWillROfRsi() (indicator *OscillatorOf, err error) {
+	rsiPeriod := 14
+	willRPeriod := 14
+	return NewWillROfRsi(rsiPeriod, willRPeriod)
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *OscillatorOf) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.receiveSelectedTick(tickData, selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the OscillatorOf results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *OscillatorOf) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the OscillatorOf results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *OscillatorOf) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,119 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+)
+
+var willROscillatorStage indicators.OscillatorStage = func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.DOHLCVFloatIndicatorWithoutStorage, error) {
+	return indicators.NewWillRWithoutStorage(14, valueAvailableAction)
+}
+
+var _ = Describe("when creating an oscillatorofwithoutstorage", func() {
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewOscillatorOfWithoutStorage(rsiChainStage, willROscillatorStage, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created without both stages", func() {
+		indicator, err := indicators.NewOscillatorOfWithoutStorage(nil, willROscillatorStage, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrOscillatorOfStageIsNil))
+
+		indicator, err = indicators.NewOscillatorOfWithoutStorage(rsiChainStage, nil, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrOscillatorOfStageIsNil))
+	})
+
+	It("the indicator should return the error of a stage", func() {
+		indicator, err := indicators.NewWillROfRsiWithoutStorage(14, 1, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+	})
+
+	It("the indicator should not be created with a nil data selection func", func() {
+		indicator, err := indicators.NewOscillatorOf(rsiChainStage, willROscillatorStage, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+})
+
+var _ = Describe("when calculating a williams %r of an rsi (oscillatorof) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.OscillatorOf
+		rsi       *indicators.Rsi
+		willR     *indicators.WillR
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultWillROfRsi()
+		rsi, _ = indicators.NewRsi(14, gotrade.UseClosePrice)
+		willR, _ = indicators.NewWillR(14)
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			rsi.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+
+		// the willr of the rsi results as synthetic price bars
+		for i := range rsi.Data {
+			value := rsi.Data[i]
+			willR.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(sourceDOHLCVData[0].D(), value, value, value, value, 0.0), rsi.ValidFromBar()+i)
+		}
+	})
+
+	It("should be valid from the bar after the sum of the two lookbacks", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(rsi.GetLookbackPeriod() + willR.GetLookbackPeriod()))
+		Expect(indicator.ValidFromBar()).To(Equal(rsi.GetLookbackPeriod() + willR.GetLookbackPeriod() + 1))
+		Expect(indicator.ValidFromBar()).To(Equal(willR.ValidFromBar()))
+		Expect(indicator.Data).To(HaveLen(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+	})
+
+	It("should be the willr of the rsi, with 0 where the rsi is saturated", func() {
+		expected := make([]float64, len(willR.Data))
+		for i := range willR.Data {
+			if !math.IsNaN(willR.Data[i]) {
+				expected[i] = willR.Data[i]
+			}
+		}
+		Expect(indicator.Data).To(Equal(expected))
+	})
+
+	It("should be bounded by the range of the willr", func() {
+		for i := range indicator.Data {
+			Expect(indicator.Data[i]).To(BeNumerically(">=", -100.0))
+			Expect(indicator.Data[i]).To(BeNumerically("<=", 0.0))
+		}
+		Expect(indicator.MaxValue()).To(Equal(GetFloatDataMax(indicator.Data)))
+		Expect(indicator.MinValue()).To(Equal(GetFloatDataMin(indicator.Data)))
+	})
+
+	It("should have the results in range of their bars", func() {
+		Expect(indicator.ValuesInRange(1, len(sourceDOHLCVData))).To(Equal(indicator.Data))
+	})
+})
+
+var _ = Describe("when creating an oscillatorof for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewOscillatorOfForStream(stream, rsiChainStage, willROscillatorStage, gotrade.UseClosePrice)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
+
+var _ = Describe("when calculating a williams %r of an rsi (oscillatorof) with flat source data", func() {
+	It("should be 0 rather than a NaN while the rsi is saturated", func() {
+		indicator, _ := indicators.NewWillROfRsi(2, 2)
+		for i := 0; i < 10; i++ {
+			indicator.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(sourceDOHLCVData[0].D(), 50.0, 50.0, 50.0, 50.0+float64(i), 0.0), i+1)
+		}
+
+		Expect(indicator.Data).To(HaveLen(10 - indicator.GetLookbackPeriod()))
+		for i := range indicator.Data {
+			Expect(indicator.Data[i]).To(Equal(0.0))
+		}
+	})
+})
@@ -0,0 +1,70 @@
+package indicators
+
+import (
+	"math"
+)
+
+// An OutputTransform is applied to each result of an indicator before the bounds are updated and the result
+// is made available
+type OutputTransform int
+
+const (
+	// OutputTransformNone makes the results available unchanged
+	OutputTransformNone OutputTransform = iota
+
+	// OutputTransformLog makes the natural log of each result available, 0.0 for a result that is not positive
+	OutputTransformLog
+
+	// OutputTransformPercentChange makes the percent change of each result from the prior result available,
+	// 0.0 if the prior result was 0. There is no prior for the first result so it is not made available
+	OutputTransformPercentChange
+)
+
+type baseOutputTransform struct {
+	outputTransform   OutputTransform
+	hasPreviousOutput bool
+	previousOutput    float64
+}
+
+func newBaseOutputTransform() *baseOutputTransform {
+	return &baseOutputTransform{outputTransform: OutputTransformNone}
+}
+
+// transform returns the transformed value, isAvailable is false if there is no transformed value to make available
+func (ind *baseOutputTransform) transform(value float64) (transformed float64, isAvailable bool) {
+	switch ind.outputTransform {
+	case OutputTransformLog:
+		if value <= 0.0 {
+			return 0.0, true
+		}
+		return math.Log(value), true
+	case OutputTransformPercentChange:
+		previousOutput, hasPreviousOutput := ind.previousOutput, ind.hasPreviousOutput
+		ind.previousOutput = value
+		ind.hasPreviousOutput = true
+
+		if !hasPreviousOutput {
+			return 0.0, false
+		}
+		if previousOutput == 0.0 {
+			return 0.0, true
+		}
+		return 100.0 * ((value / previousOutput) - 1), true
+	}
+
+	return value, true
+}
+
+// SetOutputTransform sets the transform applied to each result, it should be set before any ticks are received.
+// The OutputTransformPercentChange increases the lookback period by 1 as the first result is not made available
+func (ind *baseIndicatorWithFloatBounds) SetOutputTransform(outputTransform OutputTransform) {
+	if ind.outputTransform == OutputTransformPercentChange {
+		ind.lookbackPeriod -= 1
+	}
+
+	ind.outputTransform = outputTransform
+
+	if ind.outputTransform == OutputTransformPercentChange {
+		ind.lookbackPeriod += 1
+	}
+}
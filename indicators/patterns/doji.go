@@ -0,0 +1,142 @@
+package patterns
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// the body of a doji is at most this fraction of the average high low range
+const dojiBodyFactor float64 = 0.1
+
+// A Doji Candlestick Pattern (Doji), no storage, for use in other indicators
+// a doji has a tiny body relative to the average high low range of the prior timePeriod bars
+type DojiWithoutStorage struct {
+	*basePattern
+
+	// private variables
+	rangeAverage *candleAverage
+	timePeriod   int
+}
+
+// NewDojiWithoutStorage creates a Doji Candlestick Pattern (Doji) without storage
+func NewDojiWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionPattern) (indicator *DojiWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > indicators.MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod
+	ind := DojiWithoutStorage{
+		basePattern:  newBasePattern(lookback, valueAvailableAction),
+		rangeAverage: newCandleAverage(timePeriod),
+		timePeriod:   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// A Doji Candlestick Pattern (Doji)
+type Doji struct {
+	*DojiWithoutStorage
+
+	// public variables
+	Data []PatternResult
+}
+
+// NewDoji creates a Doji Candlestick Pattern (Doji) for online usage
+func NewDoji(timePeriod int) (indicator *Doji, err error) {
+	ind := Doji{}
+
+	ind.DojiWithoutStorage, err = NewDojiWithoutStorage(timePeriod,
+		func(dataItem PatternResult, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultDoji creates a Doji Candlestick Pattern (Doji) for online usage with default parameters
+//	- timePeriod: 10
+func NewDefaultDoji() (indicator *Doji, err error) {
+	timePeriod := 10
+	return NewDoji(timePeriod)
+}
+
+// NewDojiWithSrcLen creates a Doji Candlestick Pattern (Doji) for offline usage
+func NewDojiWithSrcLen(sourceLength uint, timePeriod int) (indicator *Doji, err error) {
+	ind, err := NewDoji(timePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultDojiWithSrcLen creates a Doji Candlestick Pattern (Doji) for offline usage with default parameters
+func NewDefaultDojiWithSrcLen(sourceLength uint) (indicator *Doji, err error) {
+	ind, err := NewDefaultDoji()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDojiForStream creates a Doji Candlestick Pattern (Doji) for online usage with a source data stream
+func NewDojiForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Doji, err error) {
+	ind, err := NewDoji(timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDojiForStream creates a Doji Candlestick Pattern (Doji) for online usage with a source data stream
+func NewDefaultDojiForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Doji, err error) {
+	ind, err := NewDefaultDoji()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDojiForStreamWithSrcLen creates a Doji Candlestick Pattern (Doji) for offline usage with a source data stream
+func NewDojiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Doji, err error) {
+	ind, err := NewDojiWithSrcLen(sourceLength, timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultDojiForStreamWithSrcLen creates a Doji Candlestick Pattern (Doji) for offline usage with a source data stream
+func NewDefaultDojiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Doji, err error) {
+	ind, err := NewDefaultDojiWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *DojiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// the baseline is the average range of the prior bars, excluding the current bar
+	if ind.rangeAverage.IsFull() {
+		result := PatternNone
+		if realBody(tickData) <= dojiBodyFactor*ind.rangeAverage.Average() {
+			result = PatternBullish
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+
+	ind.rangeAverage.Add(highLowRange(tickData))
+}
@@ -0,0 +1,156 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/indicators"
+	"github.com/thetruetrade/gotrade/indicators/patterns"
+)
+
+var _ = Describe("when creating a dojiwithoutstorage", func() {
+	var (
+		indicator      *patterns.DojiWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewDojiWithoutStorage(10, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewDojiWithoutStorage(0, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a timePeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewDojiWithoutStorage(indicators.MaximumLookbackPeriod+1, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when creating a doji", func() {
+	var (
+		indicator *patterns.Doji
+		stream    *fakeDOHLCVStreamSubscriber
+	)
+
+	Context("given the indicator is created via the constructor with defaulted parameters", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewDefaultDoji()
+		})
+
+		It("should have the default lookback period", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(10))
+		})
+
+		It("should not yet be valid", func() {
+			Expect(indicator.ValidFromBar()).To(Equal(-1))
+			Expect(indicator.Length()).To(Equal(0))
+		})
+	})
+
+	Context("given the indicator is created via the constructor with fixed source length", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewDojiWithSrcLen(100, 10)
+		})
+
+		It("should have pre-allocated storge for the output data", func() {
+			Expect(cap(indicator.Data)).To(Equal(100 - indicator.GetLookbackPeriod()))
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream", func() {
+		BeforeEach(func() {
+			stream = newFakeDOHLCVStreamSubscriber()
+			indicator, _ = patterns.NewDojiForStream(stream, 10)
+		})
+
+		It("should have requested to be attached to the stream", func() {
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+})
+
+var _ = Describe("when detecting a doji", func() {
+	var (
+		period    int = 10
+		indicator *patterns.Doji
+	)
+
+	BeforeEach(func() {
+		indicator, _ = patterns.NewDoji(period)
+		for i, bar := range baselineDOHLCVData(period) {
+			indicator.ReceiveDOHLCVTick(bar, i+1)
+		}
+	})
+
+	It("should not return any results until the average range baseline is available", func() {
+		Expect(indicator.Data).To(BeEmpty())
+		Expect(indicator.ValidFromBar()).To(Equal(-1))
+	})
+
+	Context("and the bar has a tiny body relative to the average range", func() {
+		BeforeEach(func() {
+			indicator.ReceiveDOHLCVTick(newBar(10.0, 11.0, 9.0, 10.05), period+1)
+		})
+
+		It("should return a bullish pattern result", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternBullish}))
+			Expect(indicator.ValidFromBar()).To(Equal(period + 1))
+		})
+	})
+
+	Context("and the bar has a wide body relative to the average range", func() {
+		BeforeEach(func() {
+			indicator.ReceiveDOHLCVTick(newBar(9.2, 11.0, 9.0, 10.8), period+1)
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and the bar has no body at all", func() {
+		BeforeEach(func() {
+			indicator.ReceiveDOHLCVTick(newBar(10.0, 10.0, 10.0, 10.0), period+1)
+		})
+
+		It("should return a bullish pattern result", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternBullish}))
+		})
+	})
+})
+
+var _ = Describe("when detecting a doji through an integer value available action", func() {
+	It("should emit the pattern result as an integer result", func() {
+		period := 10
+		results := []int64{}
+		var valueAvailableAction indicators.ValueAvailableActionInt = func(dataItem int64, streamBarIndex int) {
+			results = append(results, dataItem)
+		}
+		indicator, _ := patterns.NewDojiWithoutStorage(period, valueAvailableAction)
+		for i, bar := range baselineDOHLCVData(period) {
+			indicator.ReceiveDOHLCVTick(bar, i+1)
+		}
+		indicator.ReceiveDOHLCVTick(newBar(10.0, 11.0, 9.0, 10.05), period+1)
+
+		Expect(results).To(Equal([]int64{100}))
+	})
+})
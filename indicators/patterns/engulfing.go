@@ -0,0 +1,104 @@
+package patterns
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// An Engulfing Candlestick Pattern (Engulfing), no storage, for use in other indicators
+// the current body fully engulfs the prior body of the opposite color, +100 when bullish and -100 when bearish
+type EngulfingWithoutStorage struct {
+	*basePattern
+
+	// private variables
+	hasPreviousBar bool
+	previousOpen   float64
+	previousClose  float64
+}
+
+// NewEngulfingWithoutStorage creates an Engulfing Candlestick Pattern (Engulfing) without storage
+func NewEngulfingWithoutStorage(valueAvailableAction ValueAvailableActionPattern) (indicator *EngulfingWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	lookback := 1
+	ind := EngulfingWithoutStorage{
+		basePattern: newBasePattern(lookback, valueAvailableAction),
+	}
+
+	return &ind, nil
+}
+
+// An Engulfing Candlestick Pattern (Engulfing)
+type Engulfing struct {
+	*EngulfingWithoutStorage
+
+	// public variables
+	Data []PatternResult
+}
+
+// NewEngulfing creates an Engulfing Candlestick Pattern (Engulfing) for online usage
+func NewEngulfing() (indicator *Engulfing, err error) {
+	ind := Engulfing{}
+
+	ind.EngulfingWithoutStorage, err = NewEngulfingWithoutStorage(
+		func(dataItem PatternResult, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewEngulfingWithSrcLen creates an Engulfing Candlestick Pattern (Engulfing) for offline usage
+func NewEngulfingWithSrcLen(sourceLength uint) (indicator *Engulfing, err error) {
+	ind, err := NewEngulfing()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewEngulfingForStream creates an Engulfing Candlestick Pattern (Engulfing) for online usage with a source data stream
+func NewEngulfingForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Engulfing, err error) {
+	ind, err := NewEngulfing()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewEngulfingForStreamWithSrcLen creates an Engulfing Candlestick Pattern (Engulfing) for offline usage with a source data stream
+func NewEngulfingForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Engulfing, err error) {
+	ind, err := NewEngulfingWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *EngulfingWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if ind.hasPreviousBar {
+		result := PatternNone
+		previousIsWhite := ind.previousClose >= ind.previousOpen
+
+		// the engulf is strict, a body sharing either end of the prior body does not engulf it
+		if isWhite(tickData) && !previousIsWhite {
+			if tickData.C() > ind.previousOpen && tickData.O() < ind.previousClose {
+				result = PatternBullish
+			}
+		} else if !isWhite(tickData) && previousIsWhite {
+			if tickData.O() > ind.previousClose && tickData.C() < ind.previousOpen {
+				result = PatternBearish
+			}
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+
+	ind.hasPreviousBar = true
+	ind.previousOpen = tickData.O()
+	ind.previousClose = tickData.C()
+}
@@ -0,0 +1,142 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"github.com/thetruetrade/gotrade/indicators/patterns"
+)
+
+var _ = Describe("when creating an engulfingwithoutstorage", func() {
+	var (
+		indicator      *patterns.EngulfingWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewEngulfingWithoutStorage(nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when creating an engulfing", func() {
+	var (
+		indicator *patterns.Engulfing
+		stream    *fakeDOHLCVStreamSubscriber
+	)
+
+	Context("given the indicator is created via the standard constructor", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewEngulfing()
+		})
+
+		It("should have a lookback period of a single bar", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(1))
+		})
+	})
+
+	Context("given the indicator is created via the constructor with fixed source length", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewEngulfingWithSrcLen(100)
+		})
+
+		It("should have pre-allocated storge for the output data", func() {
+			Expect(cap(indicator.Data)).To(Equal(100 - indicator.GetLookbackPeriod()))
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream", func() {
+		BeforeEach(func() {
+			stream = newFakeDOHLCVStreamSubscriber()
+			indicator, _ = patterns.NewEngulfingForStream(stream)
+		})
+
+		It("should have requested to be attached to the stream", func() {
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+})
+
+var _ = Describe("when detecting an engulfing pattern", func() {
+	var (
+		indicator *patterns.Engulfing
+	)
+
+	feedBars := func(bars ...gotrade.DOHLCV) {
+		for i, bar := range bars {
+			indicator.ReceiveDOHLCVTick(bar, i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = patterns.NewEngulfing()
+	})
+
+	Context("and only a single bar has been received", func() {
+		BeforeEach(func() {
+			feedBars(newBar(10.5, 11.0, 9.5, 10.0))
+		})
+
+		It("should not return any results", func() {
+			Expect(indicator.Data).To(BeEmpty())
+		})
+	})
+
+	Context("and a white body engulfs the prior black body", func() {
+		BeforeEach(func() {
+			feedBars(newBar(10.5, 11.0, 9.5, 10.0), newBar(9.8, 11.2, 9.6, 10.8))
+		})
+
+		It("should return a bullish pattern result", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternBullish}))
+			Expect(indicator.ValidFromBar()).To(Equal(2))
+		})
+	})
+
+	Context("and a black body engulfs the prior white body", func() {
+		BeforeEach(func() {
+			feedBars(newBar(10.0, 11.0, 9.5, 10.5), newBar(10.8, 11.2, 9.6, 9.8))
+		})
+
+		It("should return a bearish pattern result", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternBearish}))
+		})
+	})
+
+	Context("and a white body engulfs a prior white body", func() {
+		BeforeEach(func() {
+			feedBars(newBar(10.0, 11.0, 9.5, 10.5), newBar(9.8, 11.2, 9.6, 10.8))
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and a white body opens at the prior close so only nearly engulfs the prior black body", func() {
+		BeforeEach(func() {
+			feedBars(newBar(10.5, 11.0, 9.5, 10.0), newBar(10.0, 11.2, 9.6, 10.8))
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and a black body closes at the prior open so only nearly engulfs the prior white body", func() {
+		BeforeEach(func() {
+			feedBars(newBar(10.0, 11.0, 9.5, 10.5), newBar(10.8, 11.2, 9.6, 10.0))
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+})
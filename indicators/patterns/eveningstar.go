@@ -0,0 +1,137 @@
+package patterns
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// An Evening Star Candlestick Pattern (EveningStar), no storage, for use in other indicators
+// a long white candle, a small body star gapping up above it, then a black candle closing at least penetration
+// of the way into the first body, emitting -100
+type EveningStarWithoutStorage struct {
+	*basePattern
+
+	// private variables
+	shape *starShape
+}
+
+// NewEveningStarWithoutStorage creates an Evening Star Candlestick Pattern (EveningStar) without storage
+func NewEveningStarWithoutStorage(timePeriod int, penetration float64, valueAvailableAction ValueAvailableActionPattern) (indicator *EveningStarWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	shape, err := newStarShape(timePeriod, penetration)
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := shape.lookback()
+	ind := EveningStarWithoutStorage{
+		basePattern: newBasePattern(lookback, valueAvailableAction),
+		shape:       shape,
+	}
+
+	return &ind, nil
+}
+
+// An Evening Star Candlestick Pattern (EveningStar)
+type EveningStar struct {
+	*EveningStarWithoutStorage
+
+	// public variables
+	Data []PatternResult
+}
+
+// NewEveningStar creates an Evening Star Candlestick Pattern (EveningStar) for online usage
+func NewEveningStar(timePeriod int, penetration float64) (indicator *EveningStar, err error) {
+	ind := EveningStar{}
+
+	ind.EveningStarWithoutStorage, err = NewEveningStarWithoutStorage(timePeriod, penetration,
+		func(dataItem PatternResult, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultEveningStar creates an Evening Star Candlestick Pattern (EveningStar) for online usage with default parameters
+//	- timePeriod: 10
+//	- penetration: 0.3
+func NewDefaultEveningStar() (indicator *EveningStar, err error) {
+	timePeriod := 10
+	penetration := 0.3
+	return NewEveningStar(timePeriod, penetration)
+}
+
+// NewEveningStarWithSrcLen creates an Evening Star Candlestick Pattern (EveningStar) for offline usage
+func NewEveningStarWithSrcLen(sourceLength uint, timePeriod int, penetration float64) (indicator *EveningStar, err error) {
+	ind, err := NewEveningStar(timePeriod, penetration)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultEveningStarWithSrcLen creates an Evening Star Candlestick Pattern (EveningStar) for offline usage with default parameters
+func NewDefaultEveningStarWithSrcLen(sourceLength uint) (indicator *EveningStar, err error) {
+	ind, err := NewDefaultEveningStar()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewEveningStarForStream creates an Evening Star Candlestick Pattern (EveningStar) for online usage with a source data stream
+func NewEveningStarForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, penetration float64) (indicator *EveningStar, err error) {
+	ind, err := NewEveningStar(timePeriod, penetration)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultEveningStarForStream creates an Evening Star Candlestick Pattern (EveningStar) for online usage with a source data stream
+func NewDefaultEveningStarForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *EveningStar, err error) {
+	ind, err := NewDefaultEveningStar()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewEveningStarForStreamWithSrcLen creates an Evening Star Candlestick Pattern (EveningStar) for offline usage with a source data stream
+func NewEveningStarForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, penetration float64) (indicator *EveningStar, err error) {
+	ind, err := NewEveningStarWithSrcLen(sourceLength, timePeriod, penetration)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultEveningStarForStreamWithSrcLen creates an Evening Star Candlestick Pattern (EveningStar) for offline usage with a source data stream
+func NewDefaultEveningStarForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *EveningStar, err error) {
+	ind, err := NewDefaultEveningStarWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *EveningStarWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ready, first, star, current := ind.shape.receive(tickData)
+	if ready {
+		result := PatternNone
+		firstClose := first.tickData.C()
+
+		if first.isLong && isWhite(first.tickData) &&
+			star.isShort && star.tickData.O() > firstClose && star.tickData.C() > firstClose &&
+			!current.isShort && !isWhite(current.tickData) &&
+			current.tickData.C() < firstClose-realBody(first.tickData)*ind.shape.penetration {
+			result = PatternBearish
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
@@ -0,0 +1,133 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"github.com/thetruetrade/gotrade/indicators/patterns"
+)
+
+var _ = Describe("when creating an eveningstarwithoutstorage", func() {
+	var (
+		indicator      *patterns.EveningStarWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewEveningStarWithoutStorage(10, 0.3, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewEveningStarWithoutStorage(0, 0.3, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a penetration below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewEveningStarWithoutStorage(10, -0.1, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when creating an evening star", func() {
+	var (
+		indicator *patterns.EveningStar
+		stream    *fakeDOHLCVStreamSubscriber
+	)
+
+	Context("given the indicator is created via the constructor with defaulted parameters", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewDefaultEveningStar()
+		})
+
+		It("should have a lookback period of the baseline and the first two bars of the pattern", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(12))
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream", func() {
+		BeforeEach(func() {
+			stream = newFakeDOHLCVStreamSubscriber()
+			indicator, _ = patterns.NewDefaultEveningStarForStream(stream)
+		})
+
+		It("should have requested to be attached to the stream", func() {
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+})
+
+var _ = Describe("when detecting an evening star", func() {
+	var (
+		indicator *patterns.EveningStar
+	)
+
+	feedBars := func(bars ...gotrade.DOHLCV) {
+		for i, bar := range append(baselineDOHLCVData(10), bars...) {
+			indicator.ReceiveDOHLCVTick(bar, i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = patterns.NewDefaultEveningStar()
+	})
+
+	Context("and the bars form a canonical evening star", func() {
+		BeforeEach(func() {
+			feedBars(newBar(10.0, 12.1, 9.9, 12.0), newBar(12.5, 12.7, 12.3, 12.4), newBar(12.2, 12.3, 10.4, 10.5))
+		})
+
+		It("should return a bearish pattern result", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternBearish}))
+			Expect(indicator.ValidFromBar()).To(Equal(13))
+		})
+	})
+
+	Context("and the star does not gap up from the first body", func() {
+		BeforeEach(func() {
+			feedBars(newBar(10.0, 12.1, 9.9, 12.0), newBar(11.8, 12.1, 11.6, 11.9), newBar(12.2, 12.3, 10.4, 10.5))
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and the bars form a morning star", func() {
+		BeforeEach(func() {
+			feedBars(newBar(12.0, 12.2, 9.9, 10.0), newBar(9.5, 9.8, 9.3, 9.6), newBar(9.8, 11.6, 9.7, 11.5))
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and the third bar does not close far enough into the first body for the configured penetration", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewEveningStar(10, 0.9)
+			feedBars(newBar(10.0, 12.1, 9.9, 12.0), newBar(12.5, 12.7, 12.3, 12.4), newBar(12.2, 12.3, 10.4, 10.5))
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+})
@@ -0,0 +1,232 @@
+package patterns
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// the upper shadow of a hammer shape is at most this fraction of the average high low range
+const hammerUpperShadowFactor float64 = 0.1
+
+// hammerShape detects the shape shared by the Hammer and Hanging Man, a small body near the top of the candle
+// with a long lower shadow, along with the trend of the prior bars
+type hammerShape struct {
+	rangeAverage *candleAverage
+	closeHistory *list.List
+	trendPeriod  int
+	shadowRatio  float64
+	bodyFactor   float64
+}
+
+func newHammerShape(timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64) (shape *hammerShape, err error) {
+
+	// the minimum timeperiod for this indicator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > indicators.MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum trendPeriod for this indicator is 2
+	if trendPeriod < 2 {
+		return nil, errors.New("trendPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum trendPeriod
+	if trendPeriod > indicators.MaximumLookbackPeriod {
+		return nil, errors.New("trendPeriod is greater than the maximum (100000)")
+	}
+
+	if shadowRatio <= 0.0 {
+		return nil, errors.New("shadowRatio must be greater than 0")
+	}
+
+	if bodyFactor <= 0.0 {
+		return nil, errors.New("bodyFactor must be greater than 0")
+	}
+
+	shape = &hammerShape{
+		rangeAverage: newCandleAverage(timePeriod),
+		closeHistory: list.New(),
+		trendPeriod:  trendPeriod,
+		shadowRatio:  shadowRatio,
+		bodyFactor:   bodyFactor,
+	}
+
+	return shape, nil
+}
+
+// lookback is the number of prior bars needed for both the average range baseline and the trend filter
+func (shape *hammerShape) lookback() int {
+	if shape.trendPeriod > shape.rangeAverage.timePeriod {
+		return shape.trendPeriod
+	}
+	return shape.rangeAverage.timePeriod
+}
+
+// receive consumes a bar, returning whether it has the hammer shape and the trend of the prior bars,
+// -1 for a downtrend, 1 for an uptrend, 0 otherwise, ready is false until there are enough prior bars
+func (shape *hammerShape) receive(tickData gotrade.DOHLCV) (ready bool, isShape bool, trend int) {
+	ready = shape.rangeAverage.IsFull() && shape.closeHistory.Len() >= shape.trendPeriod
+
+	if ready {
+		averageRange := shape.rangeAverage.Average()
+		body := realBody(tickData)
+		lower := lowerShadow(tickData)
+
+		isShape = body <= shape.bodyFactor*averageRange &&
+			upperShadow(tickData) <= hammerUpperShadowFactor*averageRange &&
+			lower > 0.0 && lower >= shape.shadowRatio*body
+
+		// compare the close of the prior bar with the close trendPeriod bars before the current bar
+		priorClose := shape.closeHistory.Back().Value.(float64)
+		earliestClose := shape.closeHistory.Front().Value.(float64)
+		if priorClose < earliestClose {
+			trend = -1
+		} else if priorClose > earliestClose {
+			trend = 1
+		}
+	}
+
+	shape.rangeAverage.Add(highLowRange(tickData))
+	shape.closeHistory.PushBack(tickData.C())
+	if shape.closeHistory.Len() > shape.trendPeriod {
+		shape.closeHistory.Remove(shape.closeHistory.Front())
+	}
+
+	return ready, isShape, trend
+}
+
+// A Hammer Candlestick Pattern (Hammer), no storage, for use in other indicators
+// a small body near the top of the candle with a long lower shadow following a downtrend, emitting +100
+type HammerWithoutStorage struct {
+	*basePattern
+
+	// private variables
+	shape *hammerShape
+}
+
+// NewHammerWithoutStorage creates a Hammer Candlestick Pattern (Hammer) without storage
+func NewHammerWithoutStorage(timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64, valueAvailableAction ValueAvailableActionPattern) (indicator *HammerWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	shape, err := newHammerShape(timePeriod, trendPeriod, shadowRatio, bodyFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := shape.lookback()
+	ind := HammerWithoutStorage{
+		basePattern: newBasePattern(lookback, valueAvailableAction),
+		shape:       shape,
+	}
+
+	return &ind, nil
+}
+
+// A Hammer Candlestick Pattern (Hammer)
+type Hammer struct {
+	*HammerWithoutStorage
+
+	// public variables
+	Data []PatternResult
+}
+
+// NewHammer creates a Hammer Candlestick Pattern (Hammer) for online usage
+func NewHammer(timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64) (indicator *Hammer, err error) {
+	ind := Hammer{}
+
+	ind.HammerWithoutStorage, err = NewHammerWithoutStorage(timePeriod, trendPeriod, shadowRatio, bodyFactor,
+		func(dataItem PatternResult, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultHammer creates a Hammer Candlestick Pattern (Hammer) for online usage with default parameters
+//	- timePeriod: 10
+//	- trendPeriod: 3
+//	- shadowRatio: 2.0
+//	- bodyFactor: 0.3
+func NewDefaultHammer() (indicator *Hammer, err error) {
+	timePeriod := 10
+	trendPeriod := 3
+	shadowRatio := 2.0
+	bodyFactor := 0.3
+	return NewHammer(timePeriod, trendPeriod, shadowRatio, bodyFactor)
+}
+
+// NewHammerWithSrcLen creates a Hammer Candlestick Pattern (Hammer) for offline usage
+func NewHammerWithSrcLen(sourceLength uint, timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64) (indicator *Hammer, err error) {
+	ind, err := NewHammer(timePeriod, trendPeriod, shadowRatio, bodyFactor)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultHammerWithSrcLen creates a Hammer Candlestick Pattern (Hammer) for offline usage with default parameters
+func NewDefaultHammerWithSrcLen(sourceLength uint) (indicator *Hammer, err error) {
+	ind, err := NewDefaultHammer()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewHammerForStream creates a Hammer Candlestick Pattern (Hammer) for online usage with a source data stream
+func NewHammerForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64) (indicator *Hammer, err error) {
+	ind, err := NewHammer(timePeriod, trendPeriod, shadowRatio, bodyFactor)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultHammerForStream creates a Hammer Candlestick Pattern (Hammer) for online usage with a source data stream
+func NewDefaultHammerForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Hammer, err error) {
+	ind, err := NewDefaultHammer()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewHammerForStreamWithSrcLen creates a Hammer Candlestick Pattern (Hammer) for offline usage with a source data stream
+func NewHammerForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64) (indicator *Hammer, err error) {
+	ind, err := NewHammerWithSrcLen(sourceLength, timePeriod, trendPeriod, shadowRatio, bodyFactor)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultHammerForStreamWithSrcLen creates a Hammer Candlestick Pattern (Hammer) for offline usage with a source data stream
+func NewDefaultHammerForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Hammer, err error) {
+	ind, err := NewDefaultHammerWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *HammerWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ready, isShape, trend := ind.shape.receive(tickData)
+	if ready {
+		result := PatternNone
+		if isShape && trend < 0 {
+			result = PatternBullish
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
@@ -0,0 +1,166 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/indicators"
+	"github.com/thetruetrade/gotrade/indicators/patterns"
+)
+
+var _ = Describe("when creating a hammerwithoutstorage", func() {
+	var (
+		indicator      *patterns.HammerWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHammerWithoutStorage(10, 3, 2.0, 0.3, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHammerWithoutStorage(0, 3, 2.0, 0.3, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a trendPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHammerWithoutStorage(10, 1, 2.0, 0.3, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a shadowRatio that is not greater than zero", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHammerWithoutStorage(10, 3, 0.0, 0.3, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a bodyFactor that is not greater than zero", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHammerWithoutStorage(10, 3, 2.0, 0.0, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when creating a hammer", func() {
+	var (
+		indicator *patterns.Hammer
+		stream    *fakeDOHLCVStreamSubscriber
+	)
+
+	Context("given the indicator is created via the constructor with defaulted parameters", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewDefaultHammer()
+		})
+
+		It("should have a lookback period of the longer of the baseline and trend periods", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(10))
+		})
+	})
+
+	Context("given the indicator is created with a trend period longer than the baseline period", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewHammer(5, 8, 2.0, 0.3)
+		})
+
+		It("should have a lookback period of the longer of the baseline and trend periods", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(8))
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream", func() {
+		BeforeEach(func() {
+			stream = newFakeDOHLCVStreamSubscriber()
+			indicator, _ = patterns.NewDefaultHammerForStream(stream)
+		})
+
+		It("should have requested to be attached to the stream", func() {
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+})
+
+var _ = Describe("when detecting a hammer", func() {
+	var (
+		indicator *patterns.Hammer
+	)
+
+	feedTrend := func(step float64) {
+		for i, bar := range trendingDOHLCVData(10, 15.0, step) {
+			indicator.ReceiveDOHLCVTick(bar, i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = patterns.NewDefaultHammer()
+	})
+
+	Context("and a canonical hammer follows a downtrend", func() {
+		BeforeEach(func() {
+			feedTrend(-1.0)
+			indicator.ReceiveDOHLCVTick(newBar(5.0, 5.25, 4.0, 5.2), 11)
+		})
+
+		It("should return a bullish pattern result", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternBullish}))
+			Expect(indicator.ValidFromBar()).To(Equal(11))
+		})
+	})
+
+	Context("and a canonical hammer follows an uptrend", func() {
+		BeforeEach(func() {
+			feedTrend(1.0)
+			indicator.ReceiveDOHLCVTick(newBar(25.0, 25.25, 24.0, 25.2), 11)
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and the lower shadow is shorter than the shadow ratio requires", func() {
+		BeforeEach(func() {
+			feedTrend(-1.0)
+			indicator.ReceiveDOHLCVTick(newBar(5.0, 5.55, 4.7, 5.5), 11)
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and a lower shadow ratio is configured that the shape satisfies", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewHammer(10, 3, 0.5, 0.3)
+			feedTrend(-1.0)
+			indicator.ReceiveDOHLCVTick(newBar(5.0, 5.55, 4.7, 5.5), 11)
+		})
+
+		It("should return a bullish pattern result", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternBullish}))
+		})
+	})
+})
@@ -0,0 +1,135 @@
+package patterns
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// A Hanging Man Candlestick Pattern (HangingMan), no storage, for use in other indicators
+// the hammer shape, a small body near the top of the candle with a long lower shadow, following an uptrend, emitting -100
+type HangingManWithoutStorage struct {
+	*basePattern
+
+	// private variables
+	shape *hammerShape
+}
+
+// NewHangingManWithoutStorage creates a Hanging Man Candlestick Pattern (HangingMan) without storage
+func NewHangingManWithoutStorage(timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64, valueAvailableAction ValueAvailableActionPattern) (indicator *HangingManWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	shape, err := newHammerShape(timePeriod, trendPeriod, shadowRatio, bodyFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := shape.lookback()
+	ind := HangingManWithoutStorage{
+		basePattern: newBasePattern(lookback, valueAvailableAction),
+		shape:       shape,
+	}
+
+	return &ind, nil
+}
+
+// A Hanging Man Candlestick Pattern (HangingMan)
+type HangingMan struct {
+	*HangingManWithoutStorage
+
+	// public variables
+	Data []PatternResult
+}
+
+// NewHangingMan creates a Hanging Man Candlestick Pattern (HangingMan) for online usage
+func NewHangingMan(timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64) (indicator *HangingMan, err error) {
+	ind := HangingMan{}
+
+	ind.HangingManWithoutStorage, err = NewHangingManWithoutStorage(timePeriod, trendPeriod, shadowRatio, bodyFactor,
+		func(dataItem PatternResult, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultHangingMan creates a Hanging Man Candlestick Pattern (HangingMan) for online usage with default parameters
+//	- timePeriod: 10
+//	- trendPeriod: 3
+//	- shadowRatio: 2.0
+//	- bodyFactor: 0.3
+func NewDefaultHangingMan() (indicator *HangingMan, err error) {
+	timePeriod := 10
+	trendPeriod := 3
+	shadowRatio := 2.0
+	bodyFactor := 0.3
+	return NewHangingMan(timePeriod, trendPeriod, shadowRatio, bodyFactor)
+}
+
+// NewHangingManWithSrcLen creates a Hanging Man Candlestick Pattern (HangingMan) for offline usage
+func NewHangingManWithSrcLen(sourceLength uint, timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64) (indicator *HangingMan, err error) {
+	ind, err := NewHangingMan(timePeriod, trendPeriod, shadowRatio, bodyFactor)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultHangingManWithSrcLen creates a Hanging Man Candlestick Pattern (HangingMan) for offline usage with default parameters
+func NewDefaultHangingManWithSrcLen(sourceLength uint) (indicator *HangingMan, err error) {
+	ind, err := NewDefaultHangingMan()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewHangingManForStream creates a Hanging Man Candlestick Pattern (HangingMan) for online usage with a source data stream
+func NewHangingManForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64) (indicator *HangingMan, err error) {
+	ind, err := NewHangingMan(timePeriod, trendPeriod, shadowRatio, bodyFactor)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultHangingManForStream creates a Hanging Man Candlestick Pattern (HangingMan) for online usage with a source data stream
+func NewDefaultHangingManForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HangingMan, err error) {
+	ind, err := NewDefaultHangingMan()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewHangingManForStreamWithSrcLen creates a Hanging Man Candlestick Pattern (HangingMan) for offline usage with a source data stream
+func NewHangingManForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, trendPeriod int, shadowRatio float64, bodyFactor float64) (indicator *HangingMan, err error) {
+	ind, err := NewHangingManWithSrcLen(sourceLength, timePeriod, trendPeriod, shadowRatio, bodyFactor)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultHangingManForStreamWithSrcLen creates a Hanging Man Candlestick Pattern (HangingMan) for offline usage with a source data stream
+func NewDefaultHangingManForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *HangingMan, err error) {
+	ind, err := NewDefaultHangingManWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *HangingManWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ready, isShape, trend := ind.shape.receive(tickData)
+	if ready {
+		result := PatternNone
+		if isShape && trend > 0 {
+			result = PatternBearish
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
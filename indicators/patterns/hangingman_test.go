@@ -0,0 +1,154 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade/indicators"
+	"github.com/thetruetrade/gotrade/indicators/patterns"
+)
+
+var _ = Describe("when creating a hangingmanwithoutstorage", func() {
+	var (
+		indicator      *patterns.HangingManWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHangingManWithoutStorage(10, 3, 2.0, 0.3, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHangingManWithoutStorage(0, 3, 2.0, 0.3, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a trendPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHangingManWithoutStorage(10, 1, 2.0, 0.3, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a shadowRatio that is not greater than zero", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHangingManWithoutStorage(10, 3, 0.0, 0.3, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a bodyFactor that is not greater than zero", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewHangingManWithoutStorage(10, 3, 2.0, 0.0, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when creating a hanging man", func() {
+	var (
+		indicator *patterns.HangingMan
+		stream    *fakeDOHLCVStreamSubscriber
+	)
+
+	Context("given the indicator is created via the constructor with defaulted parameters", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewDefaultHangingMan()
+		})
+
+		It("should have a lookback period of the longer of the baseline and trend periods", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(10))
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream", func() {
+		BeforeEach(func() {
+			stream = newFakeDOHLCVStreamSubscriber()
+			indicator, _ = patterns.NewDefaultHangingManForStream(stream)
+		})
+
+		It("should have requested to be attached to the stream", func() {
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+})
+
+var _ = Describe("when detecting a hanging man", func() {
+	var (
+		indicator *patterns.HangingMan
+	)
+
+	feedTrend := func(step float64) {
+		for i, bar := range trendingDOHLCVData(10, 15.0, step) {
+			indicator.ReceiveDOHLCVTick(bar, i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = patterns.NewDefaultHangingMan()
+	})
+
+	Context("and a canonical hammer shape follows an uptrend", func() {
+		BeforeEach(func() {
+			feedTrend(1.0)
+			indicator.ReceiveDOHLCVTick(newBar(25.0, 25.25, 24.0, 25.2), 11)
+		})
+
+		It("should return a bearish pattern result", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternBearish}))
+		})
+	})
+
+	Context("and a canonical hammer shape follows a downtrend", func() {
+		BeforeEach(func() {
+			feedTrend(-1.0)
+			indicator.ReceiveDOHLCVTick(newBar(5.0, 5.25, 4.0, 5.2), 11)
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and the lower shadow is shorter than the shadow ratio requires", func() {
+		BeforeEach(func() {
+			feedTrend(1.0)
+			indicator.ReceiveDOHLCVTick(newBar(25.0, 25.55, 24.7, 25.5), 11)
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and the upper shadow is too long for the body to be near the top", func() {
+		BeforeEach(func() {
+			feedTrend(1.0)
+			indicator.ReceiveDOHLCVTick(newBar(25.0, 25.8, 24.0, 25.2), 11)
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+})
@@ -0,0 +1,208 @@
+package patterns
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// starBar is a bar of a three bar star pattern, classified against the average body of the bars before it
+type starBar struct {
+	tickData gotrade.DOHLCV
+	isLong   bool
+	isShort  bool
+}
+
+// starShape keeps the two bar history and average body baseline shared by the Morning Star and Evening Star
+type starShape struct {
+	bodyAverage *candleAverage
+	history     []starBar
+	timePeriod  int
+	penetration float64
+}
+
+func newStarShape(timePeriod int, penetration float64) (shape *starShape, err error) {
+
+	// the minimum timeperiod for this indicator is 1
+	if timePeriod < 1 {
+		return nil, errors.New("timePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > indicators.MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum penetration for this indicator is 0
+	if penetration < 0.0 {
+		return nil, errors.New("penetration is less than the minimum (0)")
+	}
+
+	shape = &starShape{
+		bodyAverage: newCandleAverage(timePeriod),
+		history:     make([]starBar, 0, 3),
+		timePeriod:  timePeriod,
+		penetration: penetration,
+	}
+
+	return shape, nil
+}
+
+// lookback is the average body baseline for the first bar followed by the first and star bars
+func (shape *starShape) lookback() int {
+	return shape.timePeriod + 2
+}
+
+// receive consumes a bar, returning the first, star and current bars of the pattern,
+// ready is false until all three bars have been classified against the average body baseline
+func (shape *starShape) receive(tickData gotrade.DOHLCV) (ready bool, first starBar, star starBar, current starBar) {
+	if shape.bodyAverage.IsFull() {
+		averageBody := shape.bodyAverage.Average()
+		body := realBody(tickData)
+		current = starBar{tickData: tickData, isLong: body > averageBody, isShort: body < averageBody}
+
+		shape.history = append(shape.history, current)
+		if len(shape.history) > 3 {
+			shape.history = shape.history[1:]
+		}
+	}
+
+	shape.bodyAverage.Add(realBody(tickData))
+
+	if len(shape.history) < 3 {
+		return false, first, star, current
+	}
+
+	return true, shape.history[0], shape.history[1], shape.history[2]
+}
+
+// A Morning Star Candlestick Pattern (MorningStar), no storage, for use in other indicators
+// a long black candle, a small body star gapping down below it, then a white candle closing at least penetration
+// of the way into the first body, emitting +100
+type MorningStarWithoutStorage struct {
+	*basePattern
+
+	// private variables
+	shape *starShape
+}
+
+// NewMorningStarWithoutStorage creates a Morning Star Candlestick Pattern (MorningStar) without storage
+func NewMorningStarWithoutStorage(timePeriod int, penetration float64, valueAvailableAction ValueAvailableActionPattern) (indicator *MorningStarWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, indicators.ErrValueAvailableActionIsNil
+	}
+
+	shape, err := newStarShape(timePeriod, penetration)
+	if err != nil {
+		return nil, err
+	}
+
+	lookback := shape.lookback()
+	ind := MorningStarWithoutStorage{
+		basePattern: newBasePattern(lookback, valueAvailableAction),
+		shape:       shape,
+	}
+
+	return &ind, nil
+}
+
+// A Morning Star Candlestick Pattern (MorningStar)
+type MorningStar struct {
+	*MorningStarWithoutStorage
+
+	// public variables
+	Data []PatternResult
+}
+
+// NewMorningStar creates a Morning Star Candlestick Pattern (MorningStar) for online usage
+func NewMorningStar(timePeriod int, penetration float64) (indicator *MorningStar, err error) {
+	ind := MorningStar{}
+
+	ind.MorningStarWithoutStorage, err = NewMorningStarWithoutStorage(timePeriod, penetration,
+		func(dataItem PatternResult, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultMorningStar creates a Morning Star Candlestick Pattern (MorningStar) for online usage with default parameters
+//	- timePeriod: 10
+//	- penetration: 0.3
+func NewDefaultMorningStar() (indicator *MorningStar, err error) {
+	timePeriod := 10
+	penetration := 0.3
+	return NewMorningStar(timePeriod, penetration)
+}
+
+// NewMorningStarWithSrcLen creates a Morning Star Candlestick Pattern (MorningStar) for offline usage
+func NewMorningStarWithSrcLen(sourceLength uint, timePeriod int, penetration float64) (indicator *MorningStar, err error) {
+	ind, err := NewMorningStar(timePeriod, penetration)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultMorningStarWithSrcLen creates a Morning Star Candlestick Pattern (MorningStar) for offline usage with default parameters
+func NewDefaultMorningStarWithSrcLen(sourceLength uint) (indicator *MorningStar, err error) {
+	ind, err := NewDefaultMorningStar()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]PatternResult, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewMorningStarForStream creates a Morning Star Candlestick Pattern (MorningStar) for online usage with a source data stream
+func NewMorningStarForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, penetration float64) (indicator *MorningStar, err error) {
+	ind, err := NewMorningStar(timePeriod, penetration)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultMorningStarForStream creates a Morning Star Candlestick Pattern (MorningStar) for online usage with a source data stream
+func NewDefaultMorningStarForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *MorningStar, err error) {
+	ind, err := NewDefaultMorningStar()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewMorningStarForStreamWithSrcLen creates a Morning Star Candlestick Pattern (MorningStar) for offline usage with a source data stream
+func NewMorningStarForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, penetration float64) (indicator *MorningStar, err error) {
+	ind, err := NewMorningStarWithSrcLen(sourceLength, timePeriod, penetration)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultMorningStarForStreamWithSrcLen creates a Morning Star Candlestick Pattern (MorningStar) for offline usage with a source data stream
+func NewDefaultMorningStarForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *MorningStar, err error) {
+	ind, err := NewDefaultMorningStarWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *MorningStarWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ready, first, star, current := ind.shape.receive(tickData)
+	if ready {
+		result := PatternNone
+		firstClose := first.tickData.C()
+
+		if first.isLong && !isWhite(first.tickData) &&
+			star.isShort && star.tickData.O() < firstClose && star.tickData.C() < firstClose &&
+			!current.isShort && isWhite(current.tickData) &&
+			current.tickData.C() > firstClose+realBody(first.tickData)*ind.shape.penetration {
+			result = PatternBullish
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
@@ -0,0 +1,133 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"github.com/thetruetrade/gotrade/indicators/patterns"
+)
+
+var _ = Describe("when creating a morningstarwithoutstorage", func() {
+	var (
+		indicator      *patterns.MorningStarWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewMorningStarWithoutStorage(10, 0.3, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewMorningStarWithoutStorage(0, 0.3, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+
+	Context("and the indicator was given a penetration below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = patterns.NewMorningStarWithoutStorage(10, -0.1, fakePatternValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+		})
+	})
+})
+
+var _ = Describe("when creating a morning star", func() {
+	var (
+		indicator *patterns.MorningStar
+		stream    *fakeDOHLCVStreamSubscriber
+	)
+
+	Context("given the indicator is created via the constructor with defaulted parameters", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewDefaultMorningStar()
+		})
+
+		It("should have a lookback period of the baseline and the first two bars of the pattern", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(12))
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream", func() {
+		BeforeEach(func() {
+			stream = newFakeDOHLCVStreamSubscriber()
+			indicator, _ = patterns.NewDefaultMorningStarForStream(stream)
+		})
+
+		It("should have requested to be attached to the stream", func() {
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+})
+
+var _ = Describe("when detecting a morning star", func() {
+	var (
+		indicator *patterns.MorningStar
+	)
+
+	feedBars := func(bars ...gotrade.DOHLCV) {
+		for i, bar := range append(baselineDOHLCVData(10), bars...) {
+			indicator.ReceiveDOHLCVTick(bar, i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = patterns.NewDefaultMorningStar()
+	})
+
+	Context("and the bars form a canonical morning star", func() {
+		BeforeEach(func() {
+			feedBars(newBar(12.0, 12.2, 9.9, 10.0), newBar(9.5, 9.8, 9.3, 9.6), newBar(9.8, 11.6, 9.7, 11.5))
+		})
+
+		It("should return a bullish pattern result", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternBullish}))
+			Expect(indicator.ValidFromBar()).To(Equal(13))
+		})
+	})
+
+	Context("and the star does not gap down from the first body", func() {
+		BeforeEach(func() {
+			feedBars(newBar(12.0, 12.2, 9.9, 10.0), newBar(10.2, 10.4, 9.9, 10.1), newBar(9.8, 11.6, 9.7, 11.5))
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and the bars form an evening star", func() {
+		BeforeEach(func() {
+			feedBars(newBar(10.0, 12.1, 9.9, 12.0), newBar(12.5, 12.7, 12.3, 12.4), newBar(12.2, 12.3, 10.4, 10.5))
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+
+	Context("and the third bar does not close far enough into the first body for the configured penetration", func() {
+		BeforeEach(func() {
+			indicator, _ = patterns.NewMorningStar(10, 0.9)
+			feedBars(newBar(12.0, 12.2, 9.9, 10.0), newBar(9.5, 9.8, 9.3, 9.6), newBar(9.8, 11.6, 9.7, 11.5))
+		})
+
+		It("should return no pattern", func() {
+			Expect(indicator.Data).To(Equal([]patterns.PatternResult{patterns.PatternNone}))
+		})
+	})
+})
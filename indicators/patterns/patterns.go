@@ -0,0 +1,132 @@
+/*
+	import "github.com/thetruetrade/gotrade/indicators/patterns"
+
+	Package patterns provides candlestick pattern recognition.
+	All patterns follow the basic structure of the indicators package:
+		- receiving DOHLCV price data and emitting a PatternResult for each bar through the ValueAvailableActionInt.
+		- a PatternResult is +100 (bullish), -100 (bearish) or 0 (no pattern), as in TA-Lib.
+		- a lookback period indicating the lag between source data and the first result.
+		- the source data bar from which the pattern is valid
+
+	Non-directional patterns, such as the Doji, emit +100 when the pattern is present.
+*/
+package patterns
+
+import (
+	"container/list"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+)
+
+// A PatternResult is the outcome of a candlestick pattern detector for a bar, an integer result as carried by the
+// ValueAvailableActionInt of the indicators package
+type PatternResult = int64
+
+const (
+	PatternBearish PatternResult = -100
+	PatternNone    PatternResult = 0
+	PatternBullish PatternResult = 100
+)
+
+// A ValueAvailableActionPattern is the ValueAvailableActionInt through which a pattern emits its results
+type ValueAvailableActionPattern = indicators.ValueAvailableActionInt
+
+type basePattern struct {
+	validFromBar         int
+	dataLength           int
+	lookbackPeriod       int
+	valueAvailableAction ValueAvailableActionPattern
+}
+
+func newBasePattern(lookbackPeriod int, valueAvailableAction ValueAvailableActionPattern) *basePattern {
+	ind := basePattern{lookbackPeriod: lookbackPeriod, validFromBar: -1, valueAvailableAction: valueAvailableAction}
+	return &ind
+}
+
+func (ind *basePattern) ValidFromBar() int {
+	return ind.validFromBar
+}
+
+func (ind *basePattern) GetLookbackPeriod() int {
+	return ind.lookbackPeriod
+}
+
+// BarsUntilValid returns the number of source data bars consumed before the first result
+func (ind *basePattern) BarsUntilValid() int {
+	return ind.lookbackPeriod
+}
+
+func (ind *basePattern) Length() int {
+	return ind.dataLength
+}
+
+func (ind *basePattern) UpdateIndicatorWithNewValue(newValue PatternResult, streamBarIndex int) {
+	// increment the number of results this pattern can be expected to return
+	ind.dataLength += 1
+
+	// set the streamBarIndex from which this pattern returns valid results
+	if ind.validFromBar == -1 {
+		ind.validFromBar = streamBarIndex
+	}
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(newValue, streamBarIndex)
+}
+
+// candleAverage is the average of a candle measure, such as the high low range, over the prior bars
+// used as the baseline against which the size of a candle is judged
+type candleAverage struct {
+	periodTotal   float64
+	periodHistory *list.List
+	timePeriod    int
+}
+
+func newCandleAverage(timePeriod int) *candleAverage {
+	avg := candleAverage{periodHistory: list.New(), timePeriod: timePeriod}
+	return &avg
+}
+
+func (avg *candleAverage) Add(value float64) {
+	avg.periodHistory.PushBack(value)
+	avg.periodTotal += value
+
+	if avg.periodHistory.Len() > avg.timePeriod {
+		var first = avg.periodHistory.Front()
+		avg.periodTotal -= first.Value.(float64)
+		avg.periodHistory.Remove(first)
+	}
+}
+
+func (avg *candleAverage) IsFull() bool {
+	return avg.periodHistory.Len() >= avg.timePeriod
+}
+
+func (avg *candleAverage) Average() float64 {
+	return avg.periodTotal / float64(avg.timePeriod)
+}
+
+// realBody is the size of the candle body
+func realBody(tickData gotrade.DOHLCV) float64 {
+	return math.Abs(tickData.C() - tickData.O())
+}
+
+// highLowRange is the size of the whole candle
+func highLowRange(tickData gotrade.DOHLCV) float64 {
+	return tickData.H() - tickData.L()
+}
+
+// upperShadow is the size of the wick above the candle body
+func upperShadow(tickData gotrade.DOHLCV) float64 {
+	return tickData.H() - math.Max(tickData.O(), tickData.C())
+}
+
+// lowerShadow is the size of the wick below the candle body
+func lowerShadow(tickData gotrade.DOHLCV) float64 {
+	return math.Min(tickData.O(), tickData.C()) - tickData.L()
+}
+
+// isWhite is true for a candle that closed at or above its open
+func isWhite(tickData gotrade.DOHLCV) bool {
+	return tickData.C() >= tickData.O()
+}
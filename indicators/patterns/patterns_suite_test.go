@@ -0,0 +1,55 @@
+package patterns_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators/patterns"
+	"testing"
+	"time"
+)
+
+func TestPatterns(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Patterns Suite")
+}
+
+// baselineDOHLCVData is a run of neutral bars, each with a high low range of 2.0 and a body of 0.5,
+// giving the patterns a stable average range baseline
+func baselineDOHLCVData(count int) []gotrade.DOHLCV {
+	bars := []gotrade.DOHLCV{}
+	for i := 0; i < count; i++ {
+		bars = append(bars, newBar(10.0, 11.0, 9.0, 10.5))
+	}
+	return bars
+}
+
+// trendingDOHLCVData is a run of bars, each with a high low range of 2.0, whose open and close move by step each bar
+func trendingDOHLCVData(count int, start float64, step float64) []gotrade.DOHLCV {
+	bars := []gotrade.DOHLCV{}
+	for i := 0; i < count; i++ {
+		open := start + float64(i)*step
+		bars = append(bars, newBar(open, open+1.0, open-1.0, open+step/2.0))
+	}
+	return bars
+}
+
+func newBar(open float64, high float64, low float64, close float64) gotrade.DOHLCV {
+	return gotrade.NewDOHLCVDataItem(time.Now(), open, high, low, close, 0.0)
+}
+
+func fakePatternValAvailable(dataItem patterns.PatternResult, streamBarIndex int) {
+
+}
+
+type fakeDOHLCVStreamSubscriber struct {
+	lastCallToAddTickSubscriptionArg gotrade.DOHLCVTickReceiver
+}
+
+func newFakeDOHLCVStreamSubscriber() *fakeDOHLCVStreamSubscriber {
+	return &fakeDOHLCVStreamSubscriber{}
+}
+
+func (f *fakeDOHLCVStreamSubscriber) AddTickSubscription(subscriber gotrade.DOHLCVTickReceiver) {
+	f.lastCallToAddTickSubscriptionArg = subscriber
+}
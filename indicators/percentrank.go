@@ -0,0 +1,51 @@
+package indicators
+
+import (
+	"container/list"
+)
+
+// rollingPercentRank ranks each value among the last timePeriod values, including itself, as the percentage of the
+// other values in the window that are below it, with each other value equal to it counted as half below, from 0.0
+// for the lowest value to 100.0 for the highest and 50.0 within a flat window
+type rollingPercentRank struct {
+	periodHistory *list.List
+	timePeriod    int
+}
+
+func newRollingPercentRank(timePeriod int) *rollingPercentRank {
+	return &rollingPercentRank{
+		periodHistory: list.New(),
+		timePeriod:    timePeriod,
+	}
+}
+
+// percentRank adds the value to the window and returns its rank within the window
+func (r *rollingPercentRank) percentRank(value float64) float64 {
+	r.periodHistory.PushBack(value)
+	if r.periodHistory.Len() > r.timePeriod {
+		var first = r.periodHistory.Front()
+		r.periodHistory.Remove(first)
+	}
+
+	// the value itself is counted as equal, which is removed from the count of the others
+	var below float64 = -0.5
+	for e := r.periodHistory.Front(); e != nil; e = e.Next() {
+		if e.Value.(float64) < value {
+			below += 1.0
+		} else if e.Value.(float64) == value {
+			below += 0.5
+		}
+	}
+
+	others := r.periodHistory.Len() - 1
+	if others == 0 {
+		return 50.0
+	}
+
+	return 100.0 * below / float64(others)
+}
+
+// isFull returns whether the window holds timePeriod values
+func (r *rollingPercentRank) isFull() bool {
+	return r.periodHistory.Len() == r.timePeriod
+}
@@ -0,0 +1,165 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Pretty Good Oscillator (Pgo), no storage, for use in other indicators
+// the distance of the close from the Sma of the close of the timePeriod, in multiples of the Ema of the true range
+// of the timePeriod, (close - sma) / ema(trueRange). A result of 0.0 is given while the Ema of the true range is 0.0
+type PgoWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	sma          *SmaWithoutStorage
+	trueRange    *TrueRangeWithoutStorage
+	emaTrueRange *EmaWithoutStorage
+	currentClose float64
+	currentSma   float64
+	timePeriod   int
+}
+
+// NewPgoWithoutStorage creates a Pretty Good Oscillator (Pgo) without storage
+func NewPgoWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *PgoWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the true range needs a previous close, so the Ema of the true range is the last to warm up
+	lookback := timePeriod
+	ind := PgoWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		timePeriod:                   timePeriod,
+	}
+
+	ind.sma, err = NewSmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentSma = dataItem
+	})
+
+	ind.emaTrueRange, err = NewEmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		var result float64 = 0.0
+		if dataItem != 0.0 {
+			result = (ind.currentClose - ind.currentSma) / dataItem
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	})
+
+	ind.trueRange, err = NewTrueRangeWithoutStorage(func(dataItem float64, streamBarIndex int) {
+		ind.emaTrueRange.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	return &ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *PgoWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.currentClose = tickData.C()
+	ind.sma.ReceiveTick(tickData.C(), streamBarIndex)
+	ind.trueRange.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// A Pretty Good Oscillator (Pgo)
+type Pgo struct {
+	*PgoWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewPgo creates a Pretty Good Oscillator (Pgo) for online usage
+func NewPgo(timePeriod int) (indicator *Pgo, err error) {
+	ind := Pgo{}
+
+	ind.PgoWithoutStorage, err = NewPgoWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultPgo creates a Pretty Good Oscillator (Pgo) for online usage with default parameters
+//	- timePeriod: 14
+func NewDefaultPgo() (indicator *Pgo, err error) {
+	timePeriod := 14
+	return NewPgo(timePeriod)
+}
+
+// NewPgoWithSrcLen creates a Pretty Good Oscillator (Pgo) for offline usage
+func NewPgoWithSrcLen(sourceLength uint, timePeriod int) (indicator *Pgo, err error) {
+	ind, err := NewPgo(timePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultPgoWithSrcLen creates a Pretty Good Oscillator (Pgo) for offline usage with default parameters
+func NewDefaultPgoWithSrcLen(sourceLength uint) (indicator *Pgo, err error) {
+	ind, err := NewDefaultPgo()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewPgoForStream creates a Pretty Good Oscillator (Pgo) for online usage with a source data stream
+func NewPgoForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Pgo, err error) {
+	ind, err := NewPgo(timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultPgoForStream creates a Pretty Good Oscillator (Pgo) for online usage with a source data stream
+func NewDefaultPgoForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Pgo, err error) {
+	ind, err := NewDefaultPgo()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewPgoForStreamWithSrcLen creates a Pretty Good Oscillator (Pgo) for offline usage with a source data stream
+func NewPgoForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *Pgo, err error) {
+	ind, err := NewPgoWithSrcLen(sourceLength, timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultPgoForStreamWithSrcLen creates a Pretty Good Oscillator (Pgo) for offline usage with a source data stream
+func NewDefaultPgoForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Pgo, err error) {
+	ind, err := NewDefaultPgoWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ValuesInRange returns the Pgo results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Pgo) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Pgo results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Pgo) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
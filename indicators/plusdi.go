@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Plus Directional Indicator (PlusDi), no storage, for use in other indicators
@@ -194,3 +195,15 @@ func (ind *PlusDiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, stre
 	ind.previousHigh = high
 	ind.previousLow = low
 }
+
+// ValuesInRange returns the PlusDi results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *PlusDi) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the PlusDi results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *PlusDi) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -392,3 +392,30 @@ var _ = Describe("when calculating a plus directional indicator (PlusDi) with DO
 		})
 	})
 })
+
+var _ = Describe("when calculating a plus directional indicator (PlusDi) with directional source data", func() {
+	var (
+		period    int = 14
+		indicator *indicators.PlusDi
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewPlusDi(period)
+	})
+
+	It("should be the whole true range as a percentage when every bar moves up", func() {
+		receiveAllDOHLCVTicks(indicator, steppedDOHLCVData(1.0))
+		Expect(indicator.Data).NotTo(BeEmpty())
+		for i := range indicator.Data {
+			// each bar moves up by 1.0 over a true range of 2.0
+			Expect(indicator.Data[i]).To(BeNumerically("~", 50.0, 1e-9))
+		}
+	})
+
+	It("should be 0 when every bar moves down", func() {
+		receiveAllDOHLCVTicks(indicator, steppedDOHLCVData(-1.0))
+		for i := range indicator.Data {
+			Expect(indicator.Data[i]).To(Equal(0.0))
+		}
+	})
+})
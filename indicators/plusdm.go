@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Plus Directional Movement Indicator (PlusDm), no storage, for use in other indicators
@@ -178,3 +179,15 @@ func (ind *PlusDmWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, stre
 	ind.previousHigh = high
 	ind.previousLow = low
 }
+
+// ValuesInRange returns the PlusDm results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *PlusDm) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the PlusDm results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *PlusDm) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
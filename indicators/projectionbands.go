@@ -0,0 +1,183 @@
+// Projection Bands (ProjectionBands)
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Projection Bands Indicator (ProjectionBands), no storage, for use in other indicators
+// the bands of Mel Widner, each high of the last timePeriod bars is projected forward to the current bar along the
+// LinRegSlp of the highs, high + barsAgo * slope, and the upper band is the highest of the projections. The lower
+// band is the lowest of the lows projected along the LinRegSlp of the lows, and the middle band is half way between
+type ProjectionBandsWithoutStorage struct {
+	*baseIndicatorWithFloatBoundsBollinger
+
+	// private variables
+	highSlope        *LinRegWithoutStorage
+	lowSlope         *LinRegWithoutStorage
+	highs            *floatRing
+	lows             *floatRing
+	currentHighSlope float64
+	timePeriod       int
+}
+
+// NewProjectionBandsWithoutStorage creates a Projection Bands Indicator (ProjectionBands) without storage
+func NewProjectionBandsWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionBollinger) (indicator *ProjectionBandsWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := ProjectionBandsWithoutStorage{
+		baseIndicatorWithFloatBoundsBollinger: newBaseIndicatorWithFloatBoundsBollinger(lookback, valueAvailableAction),
+		highs:                                 newFloatRing(timePeriod),
+		lows:                                  newFloatRing(timePeriod),
+		timePeriod:                            timePeriod,
+	}
+
+	ind.highSlope, err = NewLinRegWithoutStorage(timePeriod,
+		func(dataItem float64, slope float64, intercept float64, streamBarIndex int) {
+			ind.currentHighSlope = slope
+		})
+
+	ind.lowSlope, err = NewLinRegWithoutStorage(timePeriod,
+		func(dataItem float64, slope float64, intercept float64, streamBarIndex int) {
+			upperBand := projectedExtreme(ind.highs.recent(), ind.currentHighSlope, true)
+			lowerBand := projectedExtreme(ind.lows.recent(), slope, false)
+			middleBand := (upperBand + lowerBand) / 2.0
+
+			ind.UpdateIndicatorWithNewValue(upperBand, middleBand, lowerBand, streamBarIndex)
+		})
+
+	return &ind, err
+}
+
+// projectedExtreme returns the highest, or the lowest, of the values, oldest first, each projected forward to the
+// newest along the slope
+func projectedExtreme(values []float64, slope float64, highest bool) float64 {
+	newest := len(values) - 1
+	extreme := values[newest]
+	for i := 0; i < newest; i++ {
+		projected := values[i] + float64(newest-i)*slope
+		if (highest && projected > extreme) || (!highest && projected < extreme) {
+			extreme = projected
+		}
+	}
+	return extreme
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *ProjectionBandsWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.highs.push(tickData.H())
+	ind.lows.push(tickData.L())
+
+	// the slope of the highs must be known before the slope of the lows completes the bands
+	ind.highSlope.ReceiveTick(tickData.H(), streamBarIndex)
+	ind.lowSlope.ReceiveTick(tickData.L(), streamBarIndex)
+}
+
+// A Projection Bands Indicator (ProjectionBands)
+type ProjectionBands struct {
+	*ProjectionBandsWithoutStorage
+
+	// public variables
+	UpperBand  []float64
+	MiddleBand []float64
+	LowerBand  []float64
+}
+
+// NewProjectionBands creates a Projection Bands Indicator (ProjectionBands) for online usage
+func NewProjectionBands(timePeriod int) (indicator *ProjectionBands, err error) {
+	ind := ProjectionBands{}
+	ind.ProjectionBandsWithoutStorage, err = NewProjectionBandsWithoutStorage(timePeriod,
+		func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
+			ind.UpperBand = append(ind.UpperBand, dataItemUpperBand)
+			ind.MiddleBand = append(ind.MiddleBand, dataItemMiddleBand)
+			ind.LowerBand = append(ind.LowerBand, dataItemLowerBand)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultProjectionBands creates a Projection Bands Indicator (ProjectionBands) for online usage with default parameters
+//	- timePeriod: 14
+func NewDefaultProjectionBands() (indicator *ProjectionBands, err error) {
+	timePeriod := 14
+	return NewProjectionBands(timePeriod)
+}
+
+// NewProjectionBandsWithSrcLen creates a Projection Bands Indicator (ProjectionBands) for offline usage
+func NewProjectionBandsWithSrcLen(sourceLength uint, timePeriod int) (indicator *ProjectionBands, err error) {
+	ind, err := NewProjectionBands(timePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.UpperBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.MiddleBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.LowerBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultProjectionBandsWithSrcLen creates a Projection Bands Indicator (ProjectionBands) for offline usage with default parameters
+func NewDefaultProjectionBandsWithSrcLen(sourceLength uint) (indicator *ProjectionBands, err error) {
+	ind, err := NewDefaultProjectionBands()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.UpperBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.MiddleBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.LowerBand = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewProjectionBandsForStream creates a Projection Bands Indicator (ProjectionBands) for online usage with a source data stream
+func NewProjectionBandsForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *ProjectionBands, err error) {
+	ind, err := NewProjectionBands(timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultProjectionBandsForStream creates a Projection Bands Indicator (ProjectionBands) for online usage with a source data stream
+func NewDefaultProjectionBandsForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ProjectionBands, err error) {
+	ind, err := NewDefaultProjectionBands()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewProjectionBandsForStreamWithSrcLen creates a Projection Bands Indicator (ProjectionBands) for offline usage with a source data stream
+func NewProjectionBandsForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *ProjectionBands, err error) {
+	ind, err := NewProjectionBandsWithSrcLen(sourceLength, timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultProjectionBandsForStreamWithSrcLen creates a Projection Bands Indicator (ProjectionBands) for offline usage with a source data stream
+func NewDefaultProjectionBandsForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ProjectionBands, err error) {
+	ind, err := NewDefaultProjectionBandsWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the ProjectionBands results as rows after a header of barIndex,upperBand,middleBand,lowerBand, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *ProjectionBands) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("upperBand", ind.UpperBand), floatCSVColumn("middleBand", ind.MiddleBand), floatCSVColumn("lowerBand", ind.LowerBand))
+}
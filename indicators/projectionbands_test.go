@@ -0,0 +1,113 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+)
+
+var _ = Describe("when creating a projectionbandswithoutstorage", func() {
+	var (
+		indicator      *indicators.ProjectionBandsWithoutStorage
+		indicatorError error
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewProjectionBandsWithoutStorage(14, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewProjectionBandsWithoutStorage(1, fakeBollingerBandsValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewProjectionBandsWithoutStorage(indicators.MaximumLookbackPeriod+1, fakeBollingerBandsValAvailable)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating projection bands (projectionbands) with DOHLCV source data", func() {
+	var (
+		period    int = 14
+		indicator *indicators.ProjectionBands
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultProjectionBands()
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should have a result for each bar once the timePeriod is full", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(period - 1))
+		Expect(indicator.ValidFromBar()).To(Equal(period))
+		Expect(indicator.UpperBand).To(HaveLen(len(sourceDOHLCVData) - period + 1))
+		Expect(indicator.LowerBand).To(HaveLen(len(sourceDOHLCVData) - period + 1))
+	})
+
+	It("should project the highs and lows of the window along their regression slopes", func() {
+		highSlope, _ := indicators.NewLinRegSlp(period, gotrade.UseHighPrice)
+		lowSlope, _ := indicators.NewLinRegSlp(period, gotrade.UseLowPrice)
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			highSlope.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			lowSlope.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+
+		for i := range indicator.UpperBand {
+			newest := i + period - 1
+			upperBand, lowerBand := -math.MaxFloat64, math.MaxFloat64
+			for barsAgo := 0; barsAgo < period; barsAgo++ {
+				bar := sourceDOHLCVData[newest-barsAgo]
+				upperBand = math.Max(upperBand, bar.H()+float64(barsAgo)*highSlope.Data[i])
+				lowerBand = math.Min(lowerBand, bar.L()+float64(barsAgo)*lowSlope.Data[i])
+			}
+			Expect(indicator.UpperBand[i]).To(BeNumerically("~", upperBand, 0.0000001))
+			Expect(indicator.LowerBand[i]).To(BeNumerically("~", lowerBand, 0.0000001))
+			Expect(indicator.MiddleBand[i]).To(BeNumerically("~", (upperBand+lowerBand)/2.0, 0.0000001))
+		}
+	})
+
+	It("should enclose the high and low of each bar", func() {
+		for i := range indicator.UpperBand {
+			bar := sourceDOHLCVData[i+period-1]
+			Expect(indicator.UpperBand[i]).To(BeNumerically(">=", bar.H()))
+			Expect(indicator.LowerBand[i]).To(BeNumerically("<=", bar.L()))
+		}
+	})
+
+	It("should have the bounds of the bands", func() {
+		Expect(indicator.MaxValue()).To(Equal(GetFloatDataMax(indicator.UpperBand)))
+		Expect(indicator.MinValue()).To(Equal(GetFloatDataMin(indicator.LowerBand)))
+	})
+})
+
+var _ = Describe("when creating projection bands for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewDefaultProjectionBandsForStream(stream)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
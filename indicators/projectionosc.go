@@ -0,0 +1,153 @@
+// Projection Oscillator (ProjectionOsc)
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Projection Oscillator Indicator (ProjectionOsc), no storage, for use in other indicators
+// the position of the close within the ProjectionBands of the timePeriod, 100 * (close - lowerBand) / (upperBand -
+// lowerBand). As the bands enclose the high and low of the bar the result is bounded by 0 and 100, a result of 50
+// is given while the bands have no width
+type ProjectionOscWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	bands        *ProjectionBandsWithoutStorage
+	currentClose float64
+}
+
+// NewProjectionOscWithoutStorage creates a Projection Oscillator Indicator (ProjectionOsc) without storage
+func NewProjectionOscWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *ProjectionOscWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	ind := ProjectionOscWithoutStorage{}
+
+	ind.bands, err = NewProjectionBandsWithoutStorage(timePeriod,
+		func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
+			result := 50.0
+			width := dataItemUpperBand - dataItemLowerBand
+			if width > 0.0 {
+				result = 100.0 * (ind.currentClose - dataItemLowerBand) / width
+			}
+
+			ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+		})
+
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(ind.bands.GetLookbackPeriod(), valueAvailableAction)
+
+	return &ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *ProjectionOscWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.currentClose = tickData.C()
+	ind.bands.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// A Projection Oscillator Indicator (ProjectionOsc)
+type ProjectionOsc struct {
+	*ProjectionOscWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewProjectionOsc creates a Projection Oscillator Indicator (ProjectionOsc) for online usage
+func NewProjectionOsc(timePeriod int) (indicator *ProjectionOsc, err error) {
+	ind := ProjectionOsc{}
+
+	ind.ProjectionOscWithoutStorage, err = NewProjectionOscWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultProjectionOsc creates a Projection Oscillator Indicator (ProjectionOsc) for online usage with default parameters
+//	- timePeriod: 14
+func NewDefaultProjectionOsc() (indicator *ProjectionOsc, err error) {
+	timePeriod := 14
+	return NewProjectionOsc(timePeriod)
+}
+
+// NewProjectionOscWithSrcLen creates a Projection Oscillator Indicator (ProjectionOsc) for offline usage
+func NewProjectionOscWithSrcLen(sourceLength uint, timePeriod int) (indicator *ProjectionOsc, err error) {
+	ind, err := NewProjectionOsc(timePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultProjectionOscWithSrcLen creates a Projection Oscillator Indicator (ProjectionOsc) for offline usage with default parameters
+func NewDefaultProjectionOscWithSrcLen(sourceLength uint) (indicator *ProjectionOsc, err error) {
+	ind, err := NewDefaultProjectionOsc()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewProjectionOscForStream creates a Projection Oscillator Indicator (ProjectionOsc) for online usage with a source data stream
+func NewProjectionOscForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *ProjectionOsc, err error) {
+	ind, err := NewProjectionOsc(timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultProjectionOscForStream creates a Projection Oscillator Indicator (ProjectionOsc) for online usage with a source data stream
+func NewDefaultProjectionOscForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ProjectionOsc, err error) {
+	ind, err := NewDefaultProjectionOsc()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewProjectionOscForStreamWithSrcLen creates a Projection Oscillator Indicator (ProjectionOsc) for offline usage with a source data stream
+func NewProjectionOscForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int) (indicator *ProjectionOsc, err error) {
+	ind, err := NewProjectionOscWithSrcLen(sourceLength, timePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultProjectionOscForStreamWithSrcLen creates a Projection Oscillator Indicator (ProjectionOsc) for offline usage with a source data stream
+func NewDefaultProjectionOscForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ProjectionOsc, err error) {
+	ind, err := NewDefaultProjectionOscWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ValuesInRange returns the ProjectionOsc results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *ProjectionOsc) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the ProjectionOsc results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *ProjectionOsc) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,223 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Percentage Volume Oscillator (Pvo), no storage, for use in other indicators
+// the Macd of the volume as a percentage of the slow ema, 100 * (fast ema - slow ema) / slow ema, with a signal
+// line ema of the oscillator and a histogram of the oscillator less the signal. The oscillator is 0.0 while the
+// slow ema of the volume is 0.0
+type PvoWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionMacd
+	emaFast              *EmaWithoutStorage
+	emaSlow              *EmaWithoutStorage
+	emaSignal            *EmaWithoutStorage
+	currentFastEma       float64
+	currentPvo           float64
+	emaSlowSkip          int
+}
+
+// NewPvoWithoutStorage creates a Percentage Volume Oscillator (Pvo) without storage
+func NewPvoWithoutStorage(fastTimePeriod int, slowTimePeriod int, signalTimePeriod int, valueAvailableAction ValueAvailableActionMacd) (indicator *PvoWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum fastTimePeriod for this indicator is 2
+	if fastTimePeriod < 2 {
+		return nil, errors.New("fastTimePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum fastTimePeriod
+	if fastTimePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("fastTimePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum slowTimePeriod for this indicator is 2
+	if slowTimePeriod < 2 {
+		return nil, errors.New("slowTimePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum slowTimePeriod
+	if slowTimePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("slowTimePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum signalTimePeriod for this indicator is 1
+	if signalTimePeriod < 1 {
+		return nil, errors.New("signalTimePeriod is less than the minimum (1)")
+	}
+
+	// check the maximum signalTimePeriod
+	if signalTimePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("signalTimePeriod is greater than the maximum (100000)")
+	}
+
+	// the fast ema must not be slower than the slow ema
+	if fastTimePeriod > slowTimePeriod {
+		return nil, errors.New("fastTimePeriod is greater than the slowTimePeriod")
+	}
+
+	lookback := slowTimePeriod + signalTimePeriod - 2
+	ind := PvoWithoutStorage{
+		baseIndicator:        newBaseIndicator(lookback),
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+	}
+
+	// shift the fast ema up so that it has valid data at the same time as the slow ema
+	ind.emaSlowSkip = slowTimePeriod - fastTimePeriod
+	ind.emaFast, err = NewEmaWithoutStorage(fastTimePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentFastEma = dataItem
+	})
+
+	ind.emaSlow, err = NewEmaWithoutStorage(slowTimePeriod, func(dataItem float64, streamBarIndex int) {
+
+		// guard against a slow ema of 0, no volume
+		ind.currentPvo = 0.0
+		if dataItem != 0.0 {
+			ind.currentPvo = 100.0 * (ind.currentFastEma - dataItem) / dataItem
+		}
+
+		ind.emaSignal.ReceiveTick(ind.currentPvo, streamBarIndex)
+	})
+
+	ind.emaSignal, err = NewEmaWithoutStorage(signalTimePeriod, func(dataItem float64, streamBarIndex int) {
+		pvo := ind.quantize(ind.currentPvo)
+		signal := ind.quantize(dataItem)
+		histogram := ind.quantize(pvo - signal)
+
+		ind.UpdateMinMax(pvo, pvo)
+		ind.UpdateMinMax(signal, signal)
+		ind.UpdateMinMax(histogram, histogram)
+
+		ind.IncDataLength()
+
+		ind.SetValidFromBar(streamBarIndex)
+
+		// notify of a new result value though the value available action
+		ind.valueAvailableAction(pvo, signal, histogram, streamBarIndex)
+	})
+
+	return &ind, err
+}
+
+// A Percentage Volume Oscillator (Pvo)
+type Pvo struct {
+	*PvoWithoutStorage
+
+	// public variables
+	Pvo       []float64
+	Signal    []float64
+	Histogram []float64
+}
+
+// NewPvo creates a Percentage Volume Oscillator (Pvo) for online usage
+func NewPvo(fastTimePeriod int, slowTimePeriod int, signalTimePeriod int) (indicator *Pvo, err error) {
+	ind := Pvo{}
+	ind.PvoWithoutStorage, err = NewPvoWithoutStorage(fastTimePeriod, slowTimePeriod, signalTimePeriod,
+		func(dataItemPvo float64, dataItemSignal float64, dataItemHistogram float64, streamBarIndex int) {
+			ind.Pvo = append(ind.Pvo, dataItemPvo)
+			ind.Signal = append(ind.Signal, dataItemSignal)
+			ind.Histogram = append(ind.Histogram, dataItemHistogram)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultPvo creates a Percentage Volume Oscillator (Pvo) for online usage with default parameters
+//	- fastTimePeriod: 12
+//	- slowTimePeriod: 26
+//	- signalTimePeriod: 9
+func NewDefaultPvo() (indicator *Pvo, err error) {
+	fastTimePeriod := 12
+	slowTimePeriod := 26
+	signalTimePeriod := 9
+	return NewPvo(fastTimePeriod, slowTimePeriod, signalTimePeriod)
+}
+
+// NewPvoWithSrcLen creates a Percentage Volume Oscillator (Pvo) for offline usage
+func NewPvoWithSrcLen(sourceLength uint, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int) (indicator *Pvo, err error) {
+	ind, err := NewPvo(fastTimePeriod, slowTimePeriod, signalTimePeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Pvo = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Signal = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Histogram = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultPvoWithSrcLen creates a Percentage Volume Oscillator (Pvo) for offline usage with default parameters
+func NewDefaultPvoWithSrcLen(sourceLength uint) (indicator *Pvo, err error) {
+	ind, err := NewDefaultPvo()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Pvo = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Signal = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Histogram = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewPvoForStream creates a Percentage Volume Oscillator (Pvo) for online usage with a source data stream
+func NewPvoForStream(priceStream gotrade.DOHLCVStreamSubscriber, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int) (indicator *Pvo, err error) {
+	ind, err := NewPvo(fastTimePeriod, slowTimePeriod, signalTimePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultPvoForStream creates a Percentage Volume Oscillator (Pvo) for online usage with a source data stream
+func NewDefaultPvoForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Pvo, err error) {
+	ind, err := NewDefaultPvo()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewPvoForStreamWithSrcLen creates a Percentage Volume Oscillator (Pvo) for offline usage with a source data stream
+func NewPvoForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, fastTimePeriod int, slowTimePeriod int, signalTimePeriod int) (indicator *Pvo, err error) {
+	ind, err := NewPvoWithSrcLen(sourceLength, fastTimePeriod, slowTimePeriod, signalTimePeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultPvoForStreamWithSrcLen creates a Percentage Volume Oscillator (Pvo) for offline usage with a source data stream
+func NewDefaultPvoForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Pvo, err error) {
+	ind, err := NewDefaultPvoWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick, the volume is selected
+func (ind *PvoWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.ReceiveTick(gotrade.UseVolume(tickData), streamBarIndex)
+}
+
+// ReceiveTick consumes a source data float volume tick
+func (ind *PvoWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if streamBarIndex > ind.emaSlowSkip {
+		ind.emaFast.ReceiveTick(tickData, streamBarIndex)
+	}
+	ind.emaSlow.ReceiveTick(tickData, streamBarIndex)
+}
+
+// WriteCSV writes the Pvo results as rows after a header of barIndex,pvo,signal,histogram, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *Pvo) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("pvo", ind.Pvo), floatCSVColumn("signal", ind.Signal), floatCSVColumn("histogram", ind.Histogram))
+}
@@ -0,0 +1,142 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a pvowithoutstorage", func() {
+	var (
+		indicator      *indicators.PvoWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemPvo float64, dataItemSignal float64, dataItemHistogram float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewPvoWithoutStorage(12, 26, 9, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a fastTimePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewPvoWithoutStorage(1, 26, 9, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a slowTimePeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewPvoWithoutStorage(12, indicators.MaximumLookbackPeriod+1, 9, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+
+	Context("and the indicator was given a signalTimePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewPvoWithoutStorage(12, 26, 0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a percentage volume oscillator (pvo) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.Pvo
+		bars      []gotrade.DOHLCV
+	)
+
+	// bars of a constant price whose volume is given by volumeAt
+	volumeBars := func(count int, volumeAt func(i int) float64) []gotrade.DOHLCV {
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		result := []gotrade.DOHLCV{}
+		for i := 0; i < count; i++ {
+			result = append(result, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), 100.0, 101.0, 99.0, 100.0, volumeAt(i)))
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultPvo()
+	})
+
+	It("the defaulted parameters should be applied", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(26 + 9 - 2))
+	})
+
+	Context("and the volume surges after a steady period", func() {
+		surgeBar := 50
+
+		BeforeEach(func() {
+			bars = volumeBars(60, func(i int) float64 {
+				if i >= surgeBar {
+					return 5000.0
+				}
+				return 1000.0
+			})
+			for i := range bars {
+				indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			}
+		})
+
+		It("should produce a result for each bar after the lookback period", func() {
+			Expect(len(indicator.Pvo)).To(Equal(len(bars) - indicator.GetLookbackPeriod()))
+			Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		})
+
+		It("the oscillator should be 0 during the steady volume and rise during the surge", func() {
+			firstSurgeResult := surgeBar - indicator.GetLookbackPeriod()
+			for i := 0; i < firstSurgeResult; i++ {
+				Expect(indicator.Pvo[i]).To(BeNumerically("~", 0.0, 1e-9))
+			}
+			Expect(indicator.Pvo[firstSurgeResult]).To(BeNumerically(">", 0.0))
+			Expect(indicator.Histogram[firstSurgeResult]).To(BeNumerically(">", 0.0))
+			Expect(indicator.Signal[len(indicator.Signal)-1]).To(BeNumerically(">", 0.0))
+		})
+
+		It("the histogram should be the oscillator less the signal", func() {
+			for i := range indicator.Pvo {
+				Expect(indicator.Histogram[i]).To(BeNumerically("~", indicator.Pvo[i]-indicator.Signal[i], 1e-9))
+			}
+		})
+	})
+
+	Context("and there is no volume", func() {
+		BeforeEach(func() {
+			bars = volumeBars(40, func(i int) float64 {
+				return 0.0
+			})
+			for i := range bars {
+				indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			}
+		})
+
+		It("the oscillator should be 0", func() {
+			Expect(indicator.Pvo).NotTo(BeEmpty())
+			for i := range indicator.Pvo {
+				Expect(indicator.Pvo[i]).To(Equal(0.0))
+				Expect(indicator.Signal[i]).To(Equal(0.0))
+			}
+		})
+	})
+})
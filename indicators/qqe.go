@@ -0,0 +1,274 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionQqe func(dataItemSmoothedRsi float64, dataItemTrailingLine float64, streamBarIndex int)
+
+// A Quantitative Qualitative Estimation Indicator (Qqe), no storage, for use in other indicators
+// the Rsi of the rsiPeriod smoothed by an Ema of the smoothingFactor, with a trailing line that follows it at a
+// distance of multiplier times the "Atr of the Rsi", the absolute change in the smoothed Rsi smoothed twice by an
+// Rma of the rsiPeriod. While the trend is up the trailing line is the long band below the smoothed Rsi, which only
+// rises, and while the trend is down it is the short band above the smoothed Rsi, which only falls. The trend
+// turns when the smoothed Rsi crosses the prior band, starting up
+type QqeWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionQqe
+	multiplier           float64
+	rsi                  *RsiWithoutStorage
+	smoothedRsi          *EmaWithoutStorage
+	atrRsi               *RmaWithoutStorage
+	smoothedAtrRsi       *RmaWithoutStorage
+	hasPreviousSmoothed  bool
+	previousSmoothedRsi  float64
+	currentSmoothedRsi   float64
+	hasBands             bool
+	longBand             float64
+	shortBand            float64
+	isUptrend            bool
+}
+
+// NewQqeWithoutStorage creates a Quantitative Qualitative Estimation Indicator (Qqe) without storage
+func NewQqeWithoutStorage(rsiPeriod int, smoothingFactor int, multiplier float64, valueAvailableAction ValueAvailableActionQqe) (indicator *QqeWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum rsiPeriod for this indicator is 2
+	if rsiPeriod < 2 {
+		return nil, errors.New("rsiPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum rsiPeriod
+	if rsiPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("rsiPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum smoothingFactor for this indicator is 2
+	if smoothingFactor < 2 {
+		return nil, errors.New("smoothingFactor is less than the minimum (2)")
+	}
+
+	// check the maximum smoothingFactor
+	if smoothingFactor > MaximumLookbackPeriod {
+		return nil, errors.New("smoothingFactor is greater than the maximum (100000)")
+	}
+
+	// the minimum multiplier for this indicator is 0
+	if multiplier < 0.0 {
+		return nil, errors.New("multiplier is less than the minimum (0)")
+	}
+
+	// check the maximum multiplier
+	if multiplier > math.MaxFloat64 {
+		return nil, errors.New("multiplier is greater than the maximum float64 size")
+	}
+
+	ind := QqeWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		multiplier:           multiplier,
+		isUptrend:            true,
+	}
+
+	ind.smoothedAtrRsi, err = NewRmaWithoutStorage(rsiPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.updateBands(dataItem*ind.multiplier, streamBarIndex)
+	})
+
+	ind.atrRsi, err = NewRmaWithoutStorage(rsiPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.smoothedAtrRsi.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	ind.smoothedRsi, err = NewEmaWithoutStorage(smoothingFactor, func(dataItem float64, streamBarIndex int) {
+		ind.currentSmoothedRsi = dataItem
+		if ind.hasPreviousSmoothed {
+			ind.atrRsi.ReceiveTick(math.Abs(dataItem-ind.previousSmoothedRsi), streamBarIndex)
+		}
+
+		ind.previousSmoothedRsi = dataItem
+		ind.hasPreviousSmoothed = true
+	})
+
+	ind.rsi, err = NewRsiWithoutStorage(rsiPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.smoothedRsi.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	// the change in the smoothed Rsi needs a previous smoothed Rsi
+	lookback := ind.rsi.GetLookbackPeriod() + ind.smoothedRsi.GetLookbackPeriod() + 1 +
+		ind.atrRsi.GetLookbackPeriod() + ind.smoothedAtrRsi.GetLookbackPeriod()
+	ind.baseIndicator = newBaseIndicator(lookback)
+
+	return &ind, err
+}
+
+// updateBands trails the bands by the distance from the current smoothed Rsi and makes the result available, the
+// previous smoothed Rsi is still that of the prior bar
+func (ind *QqeWithoutStorage) updateBands(distance float64, streamBarIndex int) {
+	newLongBand := ind.currentSmoothedRsi - distance
+	newShortBand := ind.currentSmoothedRsi + distance
+
+	if ind.hasBands {
+		// the smoothed Rsi crossing the prior band turns the trend
+		if ind.isUptrend && ind.currentSmoothedRsi < ind.longBand {
+			ind.isUptrend = false
+		} else if !ind.isUptrend && ind.currentSmoothedRsi > ind.shortBand {
+			ind.isUptrend = true
+		}
+
+		if ind.previousSmoothedRsi > ind.longBand && ind.currentSmoothedRsi > ind.longBand {
+			newLongBand = math.Max(ind.longBand, newLongBand)
+		}
+		if ind.previousSmoothedRsi < ind.shortBand && ind.currentSmoothedRsi < ind.shortBand {
+			newShortBand = math.Min(ind.shortBand, newShortBand)
+		}
+	}
+
+	ind.longBand = newLongBand
+	ind.shortBand = newShortBand
+	ind.hasBands = true
+
+	trailingLine := ind.shortBand
+	if ind.isUptrend {
+		trailingLine = ind.longBand
+	}
+
+	smoothedRsi := ind.quantize(ind.currentSmoothedRsi)
+	trailingLine = ind.quantize(trailingLine)
+
+	ind.UpdateMinMax(math.Min(smoothedRsi, trailingLine), math.Max(smoothedRsi, trailingLine))
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(smoothedRsi, trailingLine, streamBarIndex)
+}
+
+// CurrentUptrend returns whether the trend was up as of the last result, the trailing line is then the long band
+func (ind *QqeWithoutStorage) CurrentUptrend() bool {
+	return ind.isUptrend
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *QqeWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.rsi.ReceiveTick(tickData, streamBarIndex)
+}
+
+// A Quantitative Qualitative Estimation Indicator (Qqe)
+type Qqe struct {
+	*QqeWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	SmoothedRsi  []float64
+	TrailingLine []float64
+}
+
+// NewQqe creates a Quantitative Qualitative Estimation Indicator (Qqe) for online usage
+func NewQqe(rsiPeriod int, smoothingFactor int, multiplier float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Qqe, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Qqe{
+		selectData: selectData,
+	}
+
+	ind.QqeWithoutStorage, err = NewQqeWithoutStorage(rsiPeriod, smoothingFactor, multiplier,
+		func(dataItemSmoothedRsi float64, dataItemTrailingLine float64, streamBarIndex int) {
+			ind.SmoothedRsi = append(ind.SmoothedRsi, dataItemSmoothedRsi)
+			ind.TrailingLine = append(ind.TrailingLine, dataItemTrailingLine)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultQqe creates a Quantitative Qualitative Estimation Indicator (Qqe) for online usage with default parameters
+//	- rsiPeriod: 14
+//	- smoothingFactor: 5
+//	- multiplier: 4.236
+func NewDefaultQqe() (indicator *Qqe, err error) {
+	rsiPeriod := 14
+	smoothingFactor := 5
+	multiplier := 4.236
+	return NewQqe(rsiPeriod, smoothingFactor, multiplier, gotrade.UseClosePrice)
+}
+
+// NewQqeWithSrcLen creates a Quantitative Qualitative Estimation Indicator (Qqe) for offline usage
+func NewQqeWithSrcLen(sourceLength uint, rsiPeriod int, smoothingFactor int, multiplier float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Qqe, err error) {
+	ind, err := NewQqe(rsiPeriod, smoothingFactor, multiplier, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.SmoothedRsi = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.TrailingLine = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultQqeWithSrcLen creates a Quantitative Qualitative Estimation Indicator (Qqe) for offline usage with default parameters
+func NewDefaultQqeWithSrcLen(sourceLength uint) (indicator *Qqe, err error) {
+	ind, err := NewDefaultQqe()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.SmoothedRsi = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.TrailingLine = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewQqeForStream creates a Quantitative Qualitative Estimation Indicator (Qqe) for online usage with a source data stream
+func NewQqeForStream(priceStream gotrade.DOHLCVStreamSubscriber, rsiPeriod int, smoothingFactor int, multiplier float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Qqe, err error) {
+	ind, err := NewQqe(rsiPeriod, smoothingFactor, multiplier, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultQqeForStream creates a Quantitative Qualitative Estimation Indicator (Qqe) for online usage with a source data stream
+func NewDefaultQqeForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Qqe, err error) {
+	ind, err := NewDefaultQqe()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewQqeForStreamWithSrcLen creates a Quantitative Qualitative Estimation Indicator (Qqe) for offline usage with a source data stream
+func NewQqeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, rsiPeriod int, smoothingFactor int, multiplier float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Qqe, err error) {
+	ind, err := NewQqeWithSrcLen(sourceLength, rsiPeriod, smoothingFactor, multiplier, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultQqeForStreamWithSrcLen creates a Quantitative Qualitative Estimation Indicator (Qqe) for offline usage with a source data stream
+func NewDefaultQqeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Qqe, err error) {
+	ind, err := NewDefaultQqeWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Qqe) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// WriteCSV writes the Qqe results as rows after a header of barIndex,smoothedRsi,trailingLine, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *Qqe) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("smoothedRsi", ind.SmoothedRsi),
+		floatCSVColumn("trailingLine", ind.TrailingLine))
+}
@@ -0,0 +1,166 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"math/rand"
+)
+
+var _ = Describe("when creating a qqewithoutstorage", func() {
+	var (
+		indicator      *indicators.QqeWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemSmoothedRsi float64, dataItemTrailingLine float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewQqeWithoutStorage(14, 5, 4.236, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a rsiPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewQqeWithoutStorage(1, 5, 4.236, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a rsiPeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewQqeWithoutStorage(indicators.MaximumLookbackPeriod+1, 5, 4.236, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+
+	Context("and the indicator was given a smoothingFactor below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewQqeWithoutStorage(14, 1, 4.236, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a multiplier below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewQqeWithoutStorage(14, 5, -1.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a quantitative qualitative estimation (qqe) with source data", func() {
+	var (
+		indicator *indicators.Qqe
+		values    []float64
+	)
+
+	// an oscillating series with noise so that the trend turns several times
+	random := rand.New(rand.NewSource(3))
+	for i := 0; i < 400; i++ {
+		values = append(values, 100.0+10.0*math.Sin(float64(i)/15.0)+random.NormFloat64())
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultQqe()
+		for i := range values {
+			indicator.ReceiveTick(values[i], i+1)
+		}
+	})
+
+	It("should have a result for each bar after the lookback period", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(14 + 4 + 1 + 13 + 13))
+		Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		Expect(len(indicator.SmoothedRsi)).To(Equal(len(values) - indicator.GetLookbackPeriod()))
+		Expect(len(indicator.TrailingLine)).To(Equal(len(values) - indicator.GetLookbackPeriod()))
+	})
+
+	It("the smoothed rsi should be an ema of the rsi", func() {
+		rsi, _ := indicators.NewRsi(14, gotrade.UseClosePrice)
+		for i := range values {
+			rsi.ReceiveTick(values[i], i+1)
+		}
+		ema, _ := indicators.NewEma(5, gotrade.UseClosePrice)
+		for i := range rsi.Data {
+			ema.ReceiveTick(rsi.Data[i], rsi.ValidFromBar()+i)
+		}
+
+		expected := ema.ValuesInRange(indicator.ValidFromBar(), len(values))
+		Expect(len(expected)).To(Equal(len(indicator.SmoothedRsi)))
+		for i := range expected {
+			Expect(indicator.SmoothedRsi[i]).To(BeNumerically("~", expected[i], 1e-9))
+		}
+	})
+
+	It("the trailing line should bracket the smoothed rsi from both sides as the trend turns", func() {
+		below, above := 0, 0
+		for i := range indicator.TrailingLine {
+			if indicator.TrailingLine[i] < indicator.SmoothedRsi[i] {
+				below++
+			} else if indicator.TrailingLine[i] > indicator.SmoothedRsi[i] {
+				above++
+			}
+		}
+		Expect(below).To(BeNumerically(">", 0))
+		Expect(above).To(BeNumerically(">", 0))
+		Expect(below + above).To(Equal(len(indicator.TrailingLine)))
+	})
+
+	It("the trailing line should only rise below the smoothed rsi and only fall above it", func() {
+		for i := 1; i < len(indicator.TrailingLine); i++ {
+			wasBelow := indicator.TrailingLine[i-1] < indicator.SmoothedRsi[i-1]
+			isBelow := indicator.TrailingLine[i] < indicator.SmoothedRsi[i]
+			if wasBelow && isBelow {
+				Expect(indicator.TrailingLine[i]).To(BeNumerically(">=", indicator.TrailingLine[i-1]))
+			} else if !wasBelow && !isBelow {
+				Expect(indicator.TrailingLine[i]).To(BeNumerically("<=", indicator.TrailingLine[i-1]))
+			}
+		}
+	})
+
+	It("the trailing line should be below the smoothed rsi in a rise and above it through a sharp fall", func() {
+		trend, _ := indicators.NewDefaultQqe()
+		noise := rand.New(rand.NewSource(3))
+		price := 100.0
+		for i := 0; i < 160; i++ {
+			if i < 100 {
+				price += 0.5 + noise.NormFloat64()*0.5
+			} else {
+				price -= 0.5 + noise.NormFloat64()*0.5
+			}
+			trend.ReceiveTick(price, i+1)
+
+			last := len(trend.TrailingLine) - 1
+			if i == 50 {
+				Expect(trend.CurrentUptrend()).To(BeTrue())
+				Expect(trend.TrailingLine[last]).To(BeNumerically("<", trend.SmoothedRsi[last]))
+			} else if i >= 108 {
+				Expect(trend.CurrentUptrend()).To(BeFalse())
+				Expect(trend.TrailingLine[last]).To(BeNumerically(">", trend.SmoothedRsi[last]))
+			}
+		}
+	})
+})
@@ -0,0 +1,204 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+	"strconv"
+)
+
+// the number of recursively smoothed Sma levels of a Rainbow
+const RainbowLevels int = 10
+
+type ValueAvailableActionRainbow func(dataItemLevels []float64, streamBarIndex int)
+
+// A Rainbow Moving Average Indicator (Rainbow), no storage, for use in other indicators
+// ten recursively smoothed Sma levels of the timePeriod, the first an Sma of the price and each subsequent level an Sma
+// of the level before it, the results are the ten levels once the tenth is available. The levels passed to the value
+// available action are only valid for the duration of the action
+type RainbowWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionRainbow
+	levels               []*SmaWithoutStorage
+	currentLevels        []float64
+}
+
+// NewRainbowWithoutStorage creates a Rainbow Moving Average Indicator (Rainbow) without storage
+func NewRainbowWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionRainbow) (indicator *RainbowWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	ind := RainbowWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		levels:               make([]*SmaWithoutStorage, RainbowLevels),
+		currentLevels:        make([]float64, RainbowLevels),
+	}
+
+	// each level passes its results on to the next, the last level notifies of the results
+	lookback := 0
+	for level := RainbowLevels - 1; level >= 0; level-- {
+		level := level
+		ind.levels[level], err = NewSmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+			ind.currentLevels[level] = ind.quantize(dataItem)
+			if level < RainbowLevels-1 {
+				ind.levels[level+1].ReceiveTick(dataItem, streamBarIndex)
+				return
+			}
+
+			lowest, highest := ind.currentLevels[0], ind.currentLevels[0]
+			for _, levelValue := range ind.currentLevels {
+				lowest = math.Min(lowest, levelValue)
+				highest = math.Max(highest, levelValue)
+			}
+
+			ind.UpdateMinMax(lowest, highest)
+
+			ind.IncDataLength()
+
+			ind.SetValidFromBar(streamBarIndex)
+
+			// notify of a new result value though the value available action
+			ind.valueAvailableAction(ind.currentLevels, streamBarIndex)
+		})
+		lookback += ind.levels[level].GetLookbackPeriod()
+	}
+	ind.baseIndicator = newBaseIndicator(lookback)
+
+	return &ind, err
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *RainbowWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.levels[0].ReceiveTick(tickData, streamBarIndex)
+}
+
+// A Rainbow Moving Average Indicator (Rainbow)
+type Rainbow struct {
+	*RainbowWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables, the results of each level, Levels[0] the first Sma through Levels[9] the tenth
+	Levels [][]float64
+}
+
+// NewRainbow creates a Rainbow Moving Average Indicator (Rainbow) for online usage
+func NewRainbow(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Rainbow, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Rainbow{
+		selectData: selectData,
+		Levels:     make([][]float64, RainbowLevels),
+	}
+
+	ind.RainbowWithoutStorage, err = NewRainbowWithoutStorage(timePeriod,
+		func(dataItemLevels []float64, streamBarIndex int) {
+			for level := range dataItemLevels {
+				ind.Levels[level] = append(ind.Levels[level], dataItemLevels[level])
+			}
+		})
+
+	return &ind, err
+}
+
+// NewDefaultRainbow creates a Rainbow Moving Average Indicator (Rainbow) for online usage with default parameters
+//	- timePeriod: 2
+func NewDefaultRainbow() (indicator *Rainbow, err error) {
+	timePeriod := 2
+	return NewRainbow(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewRainbowWithSrcLen creates a Rainbow Moving Average Indicator (Rainbow) for offline usage
+func NewRainbowWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Rainbow, err error) {
+	ind, err := NewRainbow(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if err == nil && sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.allocate(sourceLength - uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultRainbowWithSrcLen creates a Rainbow Moving Average Indicator (Rainbow) for offline usage with default parameters
+func NewDefaultRainbowWithSrcLen(sourceLength uint) (indicator *Rainbow, err error) {
+	ind, err := NewDefaultRainbow()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.allocate(sourceLength - uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewRainbowForStream creates a Rainbow Moving Average Indicator (Rainbow) for online usage with a source data stream
+func NewRainbowForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Rainbow, err error) {
+	ind, err := NewRainbow(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRainbowForStream creates a Rainbow Moving Average Indicator (Rainbow) for online usage with a source data stream
+func NewDefaultRainbowForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Rainbow, err error) {
+	ind, err := NewDefaultRainbow()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewRainbowForStreamWithSrcLen creates a Rainbow Moving Average Indicator (Rainbow) for offline usage with a source data stream
+func NewRainbowForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Rainbow, err error) {
+	ind, err := NewRainbowWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRainbowForStreamWithSrcLen creates a Rainbow Moving Average Indicator (Rainbow) for offline usage with a source data stream
+func NewDefaultRainbowForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Rainbow, err error) {
+	ind, err := NewDefaultRainbowWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Rainbow) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *Rainbow) allocate(capacity uint) {
+	for level := range ind.Levels {
+		ind.Levels[level] = make([]float64, 0, capacity)
+	}
+}
+
+// WriteCSV writes the Rainbow results as rows after a header of barIndex,level1,...,level10, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *Rainbow) WriteCSV(w io.Writer, startBarOffset int) error {
+	columns := make([]csvColumn, len(ind.Levels))
+	for level := range ind.Levels {
+		columns[level] = floatCSVColumn("level"+strconv.Itoa(level+1), ind.Levels[level])
+	}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), columns...)
+}
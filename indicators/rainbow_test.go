@@ -0,0 +1,117 @@
+package indicators_test
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"strings"
+)
+
+var _ = Describe("when creating a rainbowwithoutstorage", func() {
+	var (
+		indicator      *indicators.RainbowWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemLevels []float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewRainbowWithoutStorage(2, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewRainbowWithoutStorage(1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewRainbowWithoutStorage(indicators.MaximumLookbackPeriod+1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a rainbow moving average (rainbow) with DOHLCV source data", func() {
+	var (
+		period    int = 3
+		indicator *indicators.Rainbow
+	)
+
+	// the Sma of the timePeriod of each value of the series from the first full period
+	smaOf := func(series []float64, timePeriod int) []float64 {
+		var results []float64
+		for i := timePeriod - 1; i < len(series); i++ {
+			var total float64
+			for j := i - timePeriod + 1; j <= i; j++ {
+				total += series[j]
+			}
+			results = append(results, total/float64(timePeriod))
+		}
+		return results
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewRainbow(period, gotrade.UseClosePrice)
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should not be created with a nil data selection func", func() {
+		nilSelect, err := indicators.NewRainbow(period, nil)
+		Expect(nilSelect).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+
+	It("should produce the ten levels once the tenth level is available", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(indicators.RainbowLevels * (period - 1)))
+		Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		Expect(len(indicator.Levels)).To(Equal(indicators.RainbowLevels))
+		for level := range indicator.Levels {
+			Expect(len(indicator.Levels[level])).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+		}
+		Expect(indicator.Length()).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+	})
+
+	It("each level should be the sma of the level before it", func() {
+		var series []float64
+		for i := range sourceDOHLCVData {
+			series = append(series, sourceDOHLCVData[i].C())
+		}
+
+		for level := range indicator.Levels {
+			series = smaOf(series, period)
+			offset := len(series) - len(indicator.Levels[level])
+			for i := range indicator.Levels[level] {
+				Expect(indicator.Levels[level][i]).To(BeNumerically("~", series[offset+i], 1e-9))
+			}
+		}
+	})
+
+	It("should write a column for each level", func() {
+		var buffer bytes.Buffer
+		Expect(indicator.WriteCSV(&buffer, 0)).To(Succeed())
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		Expect(lines[0]).To(Equal("barIndex,level1,level2,level3,level4,level5,level6,level7,level8,level9,level10"))
+		Expect(len(lines)).To(Equal(len(indicator.Levels[0]) + 1))
+	})
+})
@@ -0,0 +1,214 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionRainbowOsc func(dataItemOscillator float64, dataItemBandwidth float64, streamBarIndex int)
+
+// A Rainbow Oscillator Indicator (RainbowOsc), no storage, for use in other indicators
+// the distance of the close from the average of the ten levels of a Rainbow of the timePeriod, as a percentage of the
+// range of the highest high and lowest low of the highLowPeriod, 100 * (close - average) / (highestHigh - lowestLow).
+// The bandwidth is the spread of the levels, highest level less lowest level, as a percentage of the same range,
+// both are 0.0 while the range is flat
+type RainbowOscWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionRainbowOsc
+	rainbow              *RainbowWithoutStorage
+	highestHigh          *HhvWithoutStorage
+	lowestLow            *LlvWithoutStorage
+	currentClose         float64
+	currentHighestHigh   float64
+	currentLowestLow     float64
+}
+
+// NewRainbowOscWithoutStorage creates a Rainbow Oscillator Indicator (RainbowOsc) without storage
+func NewRainbowOscWithoutStorage(timePeriod int, highLowPeriod int, valueAvailableAction ValueAvailableActionRainbowOsc) (indicator *RainbowOscWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum highLowPeriod for this indicator is 1
+	if highLowPeriod < 1 {
+		return nil, errors.New("highLowPeriod is less than the minimum (1)")
+	}
+
+	// check the maximum highLowPeriod
+	if highLowPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("highLowPeriod is greater than the maximum (100000)")
+	}
+
+	ind := RainbowOscWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+	}
+
+	ind.highestHigh, err = NewHhvWithoutStorage(highLowPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentHighestHigh = dataItem
+	})
+
+	ind.lowestLow, err = NewLlvWithoutStorage(highLowPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentLowestLow = dataItem
+	})
+
+	ind.rainbow, err = NewRainbowWithoutStorage(timePeriod, func(dataItemLevels []float64, streamBarIndex int) {
+		// the highest high and lowest low are received ahead of the rainbow, but may have a longer lookback
+		if ind.highestHigh.Length() == 0 {
+			return
+		}
+
+		var total float64 = 0.0
+		lowestLevel, highestLevel := dataItemLevels[0], dataItemLevels[0]
+		for _, level := range dataItemLevels {
+			total += level
+			lowestLevel = math.Min(lowestLevel, level)
+			highestLevel = math.Max(highestLevel, level)
+		}
+
+		var oscillator float64 = 0.0
+		var bandwidth float64 = 0.0
+		highLowRange := ind.currentHighestHigh - ind.currentLowestLow
+		if highLowRange != 0.0 {
+			oscillator = 100.0 * (ind.currentClose - total/float64(len(dataItemLevels))) / highLowRange
+			bandwidth = 100.0 * (highestLevel - lowestLevel) / highLowRange
+		}
+
+		oscillator = ind.quantize(oscillator)
+		bandwidth = ind.quantize(bandwidth)
+
+		ind.UpdateMinMax(math.Min(oscillator, bandwidth), math.Max(oscillator, bandwidth))
+
+		ind.IncDataLength()
+
+		ind.SetValidFromBar(streamBarIndex)
+
+		// notify of a new result value though the value available action
+		ind.valueAvailableAction(oscillator, bandwidth, streamBarIndex)
+	})
+
+	lookback := ind.rainbow.GetLookbackPeriod()
+	if ind.highestHigh.GetLookbackPeriod() > lookback {
+		lookback = ind.highestHigh.GetLookbackPeriod()
+	}
+	ind.baseIndicator = newBaseIndicator(lookback)
+
+	return &ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *RainbowOscWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.currentClose = tickData.C()
+	ind.highestHigh.ReceiveTick(tickData.H(), streamBarIndex)
+	ind.lowestLow.ReceiveTick(tickData.L(), streamBarIndex)
+	ind.rainbow.ReceiveTick(tickData.C(), streamBarIndex)
+}
+
+// A Rainbow Oscillator Indicator (RainbowOsc)
+type RainbowOsc struct {
+	*RainbowOscWithoutStorage
+
+	// public variables
+	Oscillator []float64
+	Bandwidth  []float64
+}
+
+// NewRainbowOsc creates a Rainbow Oscillator Indicator (RainbowOsc) for online usage
+func NewRainbowOsc(timePeriod int, highLowPeriod int) (indicator *RainbowOsc, err error) {
+	ind := RainbowOsc{}
+	ind.RainbowOscWithoutStorage, err = NewRainbowOscWithoutStorage(timePeriod, highLowPeriod,
+		func(dataItemOscillator float64, dataItemBandwidth float64, streamBarIndex int) {
+			ind.Oscillator = append(ind.Oscillator, dataItemOscillator)
+			ind.Bandwidth = append(ind.Bandwidth, dataItemBandwidth)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultRainbowOsc creates a Rainbow Oscillator Indicator (RainbowOsc) for online usage with default parameters
+//	- timePeriod: 2
+//	- highLowPeriod: 10
+func NewDefaultRainbowOsc() (indicator *RainbowOsc, err error) {
+	timePeriod := 2
+	highLowPeriod := 10
+	return NewRainbowOsc(timePeriod, highLowPeriod)
+}
+
+// NewRainbowOscWithSrcLen creates a Rainbow Oscillator Indicator (RainbowOsc) for offline usage
+func NewRainbowOscWithSrcLen(sourceLength uint, timePeriod int, highLowPeriod int) (indicator *RainbowOsc, err error) {
+	ind, err := NewRainbowOsc(timePeriod, highLowPeriod)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Oscillator = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Bandwidth = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultRainbowOscWithSrcLen creates a Rainbow Oscillator Indicator (RainbowOsc) for offline usage with default parameters
+func NewDefaultRainbowOscWithSrcLen(sourceLength uint) (indicator *RainbowOsc, err error) {
+	ind, err := NewDefaultRainbowOsc()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Oscillator = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Bandwidth = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewRainbowOscForStream creates a Rainbow Oscillator Indicator (RainbowOsc) for online usage with a source data stream
+func NewRainbowOscForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, highLowPeriod int) (indicator *RainbowOsc, err error) {
+	ind, err := NewRainbowOsc(timePeriod, highLowPeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRainbowOscForStream creates a Rainbow Oscillator Indicator (RainbowOsc) for online usage with a source data stream
+func NewDefaultRainbowOscForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *RainbowOsc, err error) {
+	ind, err := NewDefaultRainbowOsc()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewRainbowOscForStreamWithSrcLen creates a Rainbow Oscillator Indicator (RainbowOsc) for offline usage with a source data stream
+func NewRainbowOscForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, highLowPeriod int) (indicator *RainbowOsc, err error) {
+	ind, err := NewRainbowOscWithSrcLen(sourceLength, timePeriod, highLowPeriod)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRainbowOscForStreamWithSrcLen creates a Rainbow Oscillator Indicator (RainbowOsc) for offline usage with a source data stream
+func NewDefaultRainbowOscForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *RainbowOsc, err error) {
+	ind, err := NewDefaultRainbowOscWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the RainbowOsc results as rows after a header of barIndex,oscillator,bandwidth, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *RainbowOsc) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("oscillator", ind.Oscillator), floatCSVColumn("bandwidth", ind.Bandwidth))
+}
@@ -0,0 +1,119 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var _ = Describe("when creating a rainbowoscwithoutstorage", func() {
+	var (
+		indicator      *indicators.RainbowOscWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemOscillator float64, dataItemBandwidth float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewRainbowOscWithoutStorage(2, 10, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewRainbowOscWithoutStorage(1, 10, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a highLowPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewRainbowOscWithoutStorage(2, 0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a rainbow oscillator (rainbowosc) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.RainbowOsc
+		bars      []gotrade.DOHLCV
+	)
+
+	// a cycling close within the range of each bar
+	start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 100; i++ {
+		price := 100.0 + 10.0*math.Sin(float64(i)/5.0)
+		bars = append(bars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price+1.0, price-1.0, price, 1000.0))
+	}
+
+	receiveAll := func(bars []gotrade.DOHLCV) {
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultRainbowOsc()
+	})
+
+	It("should have a result for each bar after the longer of the lookback periods", func() {
+		receiveAll(bars)
+		Expect(indicator.GetLookbackPeriod()).To(Equal(indicators.RainbowLevels))
+		Expect(len(indicator.Oscillator)).To(Equal(len(bars) - indicator.GetLookbackPeriod()))
+		Expect(len(indicator.Bandwidth)).To(Equal(len(bars) - indicator.GetLookbackPeriod()))
+
+		longer, _ := indicators.NewRainbowOsc(2, 30)
+		for i := range bars {
+			longer.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+		Expect(longer.GetLookbackPeriod()).To(Equal(29))
+		Expect(longer.ValidFromBar()).To(Equal(30))
+	})
+
+	// with a highLowPeriod spanning the bars behind the levels, the levels are within the range of the period
+	It("should be bounded by the range of the highest high and lowest low", func() {
+		indicator, _ = indicators.NewRainbowOsc(2, 20)
+		receiveAll(bars)
+		Expect(indicator.Oscillator).NotTo(BeEmpty())
+		for i := range indicator.Oscillator {
+			Expect(math.Abs(indicator.Oscillator[i])).To(BeNumerically("<=", 100.0))
+			Expect(indicator.Bandwidth[i]).To(BeNumerically(">=", 0.0))
+			Expect(indicator.Bandwidth[i]).To(BeNumerically("<=", 100.0))
+		}
+	})
+
+	It("should be positive as the close rises above the rainbow and negative as it falls below", func() {
+		receiveAll(bars)
+		Expect(indicator.Oscillator).To(ContainElement(BeNumerically(">", 0.0)))
+		Expect(indicator.Oscillator).To(ContainElement(BeNumerically("<", 0.0)))
+	})
+
+	It("should be 0 while the range is flat", func() {
+		var flat []gotrade.DOHLCV
+		for i := 0; i < 30; i++ {
+			flat = append(flat, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), 100.0, 100.0, 100.0, 100.0, 1000.0))
+		}
+		receiveAll(flat)
+		for i := range indicator.Oscillator {
+			Expect(indicator.Oscillator[i]).To(Equal(0.0))
+			Expect(indicator.Bandwidth[i]).To(Equal(0.0))
+		}
+	})
+})
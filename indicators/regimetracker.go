@@ -0,0 +1,174 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"strconv"
+)
+
+var (
+	ErrRegimeTrackerHasNoClassifier = errors.New("A RegimeTracker requires a RegimeClassifier")
+)
+
+// A RegimeInput is an indicator consumed by the classifier of a RegimeTracker, such as an Adx
+type RegimeInput interface {
+	Indicator
+	// consumes a source data DOHLCV tick
+	ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
+}
+
+// A RegimeClassifier returns the regime of a bar, e.g. a trend while the Adx of the inputs is above 25 and a range
+// otherwise. The inputs have received the bar and are all valid
+type RegimeClassifier func(tickData gotrade.DOHLCV, inputs []RegimeInput) int
+
+// A RegimeChangeAction is notified of each change of the regime with the regime it changed from, the number of
+// bars that regime persisted and the regime it changed to
+type RegimeChangeAction func(previousRegime int, previousBarsInRegime int, regime int, streamBarIndex int)
+
+type ValueAvailableActionRegimeTracker func(dataItemRegime int, dataItemBarsInRegime int, streamBarIndex int)
+
+// A Regime Tracker (RegimeTracker), no storage, for use in other indicators
+// the regime of each bar given by the classifier and the number of consecutive bars, including the bar, it has
+// persisted. Each tick is received by the inputs before the bar is classified, the first result is given once all
+// of the inputs are valid, and a change of the regime is also notified to the regime change action, if any
+type RegimeTrackerWithoutStorage struct {
+	*baseIndicator
+
+	// private variables
+	valueAvailableAction ValueAvailableActionRegimeTracker
+	regimeChangeAction   RegimeChangeAction
+	classify             RegimeClassifier
+	inputs               []RegimeInput
+	currentRegime        int
+	barsInRegime         int
+}
+
+// NewRegimeTrackerWithoutStorage creates a Regime Tracker (RegimeTracker) without storage, the inputs should not
+// also receive the ticks from elsewhere
+func NewRegimeTrackerWithoutStorage(classify RegimeClassifier, inputs []RegimeInput, valueAvailableAction ValueAvailableActionRegimeTracker) (indicator *RegimeTrackerWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	if classify == nil {
+		return nil, ErrRegimeTrackerHasNoClassifier
+	}
+
+	// the regime is given once the input with the longest lookback period is valid
+	lookback := 0
+	for i := range inputs {
+		if inputs[i].GetLookbackPeriod() > lookback {
+			lookback = inputs[i].GetLookbackPeriod()
+		}
+	}
+
+	ind := RegimeTrackerWithoutStorage{
+		baseIndicator:        newBaseIndicator(lookback),
+		valueAvailableAction: valueAvailableAction,
+		classify:             classify,
+		inputs:               append([]RegimeInput{}, inputs...),
+	}
+
+	return &ind, nil
+}
+
+// SetRegimeChangeAction sets the action notified of each change of the regime, nil for none
+func (ind *RegimeTrackerWithoutStorage) SetRegimeChangeAction(regimeChangeAction RegimeChangeAction) {
+	ind.regimeChangeAction = regimeChangeAction
+}
+
+// CurrentRegime returns the regime of the last result
+func (ind *RegimeTrackerWithoutStorage) CurrentRegime() int {
+	return ind.currentRegime
+}
+
+// CurrentBarsInRegime returns the number of consecutive bars the regime of the last result has persisted, 0
+// before the first result
+func (ind *RegimeTrackerWithoutStorage) CurrentBarsInRegime() int {
+	return ind.barsInRegime
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *RegimeTrackerWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	isValid := true
+	for i := range ind.inputs {
+		ind.inputs[i].ReceiveDOHLCVTick(tickData, streamBarIndex)
+		isValid = isValid && ind.inputs[i].ValidFromBar() != -1
+	}
+
+	if !isValid {
+		return
+	}
+
+	regime := ind.classify(tickData, ind.inputs)
+
+	if ind.barsInRegime > 0 && regime != ind.currentRegime {
+		if ind.regimeChangeAction != nil {
+			ind.regimeChangeAction(ind.currentRegime, ind.barsInRegime, regime, streamBarIndex)
+		}
+		ind.barsInRegime = 0
+	}
+
+	ind.currentRegime = regime
+	ind.barsInRegime++
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(ind.currentRegime, ind.barsInRegime, streamBarIndex)
+}
+
+// A Regime Tracker (RegimeTracker)
+type RegimeTracker struct {
+	*RegimeTrackerWithoutStorage
+
+	// public variables
+	Regime       []int
+	BarsInRegime []int
+}
+
+// NewRegimeTracker creates a Regime Tracker (RegimeTracker) for online usage, the inputs should not also receive
+// the ticks from elsewhere
+func NewRegimeTracker(classify RegimeClassifier, inputs []RegimeInput) (indicator *RegimeTracker, err error) {
+	ind := RegimeTracker{}
+	ind.RegimeTrackerWithoutStorage, err = NewRegimeTrackerWithoutStorage(classify, inputs,
+		func(dataItemRegime int, dataItemBarsInRegime int, streamBarIndex int) {
+			ind.Regime = append(ind.Regime, dataItemRegime)
+			ind.BarsInRegime = append(ind.BarsInRegime, dataItemBarsInRegime)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ind, nil
+}
+
+// NewRegimeTrackerForStream creates a Regime Tracker (RegimeTracker) for online usage with a source data stream,
+// the inputs should not also be attached to the stream
+func NewRegimeTrackerForStream(priceStream gotrade.DOHLCVStreamSubscriber, classify RegimeClassifier, inputs []RegimeInput) (indicator *RegimeTracker, err error) {
+	ind, err := NewRegimeTracker(classify, inputs)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// WriteCSV writes the RegimeTracker results as barIndex,regime,barsInRegime rows after a header, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *RegimeTracker) WriteCSV(w io.Writer, startBarOffset int) error {
+	regimeColumn := csvColumn{name: "regime", length: len(ind.Regime), format: func(index int) string {
+		return strconv.Itoa(ind.Regime[index])
+	}}
+	barsInRegimeColumn := csvColumn{name: "barsInRegime", length: len(ind.BarsInRegime), format: func(index int) string {
+		return strconv.Itoa(ind.BarsInRegime[index])
+	}}
+
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), regimeColumn, barsInRegimeColumn)
+}
@@ -0,0 +1,138 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+// scriptedAdx is a regime input giving a scripted Adx value for each bar after its lookback period
+type scriptedAdx struct {
+	values       []float64
+	lookback     int
+	validFromBar int
+	length       int
+	latest       float64
+}
+
+func newScriptedAdx(lookback int, values []float64) *scriptedAdx {
+	return &scriptedAdx{values: values, lookback: lookback, validFromBar: -1}
+}
+
+func (ind *scriptedAdx) ValidFromBar() int      { return ind.validFromBar }
+func (ind *scriptedAdx) GetLookbackPeriod() int { return ind.lookback }
+func (ind *scriptedAdx) Length() int            { return ind.length }
+
+func (ind *scriptedAdx) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if streamBarIndex <= ind.lookback {
+		return
+	}
+	if ind.validFromBar == -1 {
+		ind.validFromBar = streamBarIndex
+	}
+	ind.latest = ind.values[ind.length]
+	ind.length++
+}
+
+const (
+	regimeRange = iota
+	regimeTrend
+)
+
+// a trend while the Adx is at or above 25, a range otherwise
+var adxRegimeClassifier indicators.RegimeClassifier = func(tickData gotrade.DOHLCV, inputs []indicators.RegimeInput) int {
+	if inputs[0].(*scriptedAdx).latest >= 25.0 {
+		return regimeTrend
+	}
+	return regimeRange
+}
+
+type regimeChange struct {
+	previousRegime       int
+	previousBarsInRegime int
+	regime               int
+	streamBarIndex       int
+}
+
+var _ = Describe("when creating a regimetrackerwithoutstorage", func() {
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewRegimeTrackerWithoutStorage(adxRegimeClassifier, nil, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created without a classifier", func() {
+		indicator, err := indicators.NewRegimeTracker(nil, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrRegimeTrackerHasNoClassifier))
+	})
+})
+
+var _ = Describe("when tracking the regime of a scripted adx transitioning from trend to range and back", func() {
+	var (
+		indicator *indicators.RegimeTracker
+		changes   []regimeChange
+		adx       []float64 = []float64{30.0, 32.0, 35.0, 31.0, 22.0, 18.0, 15.0, 20.0, 24.0, 26.0, 29.0}
+	)
+
+	BeforeEach(func() {
+		changes = []regimeChange{}
+		indicator, _ = indicators.NewRegimeTracker(adxRegimeClassifier, []indicators.RegimeInput{newScriptedAdx(2, adx)})
+		indicator.SetRegimeChangeAction(func(previousRegime int, previousBarsInRegime int, regime int, streamBarIndex int) {
+			changes = append(changes, regimeChange{previousRegime, previousBarsInRegime, regime, streamBarIndex})
+		})
+
+		for bar := 1; bar <= len(adx)+2; bar++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[bar-1], bar)
+		}
+	})
+
+	It("should have results once the inputs are valid", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(2))
+		Expect(indicator.ValidFromBar()).To(Equal(3))
+		Expect(indicator.Length()).To(Equal(len(adx)))
+	})
+
+	It("should give the regime and the bars it has persisted for each bar", func() {
+		Expect(indicator.Regime).To(Equal([]int{regimeTrend, regimeTrend, regimeTrend, regimeTrend,
+			regimeRange, regimeRange, regimeRange, regimeRange, regimeRange,
+			regimeTrend, regimeTrend}))
+		Expect(indicator.BarsInRegime).To(Equal([]int{1, 2, 3, 4, 1, 2, 3, 4, 5, 1, 2}))
+		Expect(indicator.CurrentRegime()).To(Equal(regimeTrend))
+		Expect(indicator.CurrentBarsInRegime()).To(Equal(2))
+	})
+
+	It("should notify each change of the regime with the duration of the previous regime", func() {
+		Expect(changes).To(Equal([]regimeChange{
+			{previousRegime: regimeTrend, previousBarsInRegime: 4, regime: regimeRange, streamBarIndex: 7},
+			{previousRegime: regimeRange, previousBarsInRegime: 5, regime: regimeTrend, streamBarIndex: 12},
+		}))
+	})
+})
+
+var _ = Describe("when tracking the regime given by an adx", func() {
+	It("should have the lookback period of the adx and a result for every bar after it", func() {
+		adx, _ := indicators.NewAdx(14)
+		indicator, _ := indicators.NewRegimeTracker(func(tickData gotrade.DOHLCV, inputs []indicators.RegimeInput) int {
+			if inputs[0].(*indicators.Adx).LatestValue() >= 25.0 {
+				return regimeTrend
+			}
+			return regimeRange
+		}, []indicators.RegimeInput{adx})
+
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+
+		Expect(indicator.GetLookbackPeriod()).To(Equal(adx.GetLookbackPeriod()))
+		Expect(indicator.ValidFromBar()).To(Equal(adx.ValidFromBar()))
+		Expect(indicator.Regime).To(HaveLen(len(adx.Data)))
+	})
+
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewRegimeTrackerForStream(stream, adxRegimeClassifier, []indicators.RegimeInput{newScriptedAdx(2, nil)})
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
@@ -0,0 +1,275 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"math"
+	"sort"
+)
+
+var (
+	ErrIndicatorFactoryIsNil = errors.New("An IndicatorFactory is required")
+	ErrStrNotRegistered      = "is not a registered indicator"
+	ErrStrAlreadyRegistered  = "is already a registered indicator"
+	ErrStrParamIsRequired    = "is a required param"
+)
+
+// A DOHLCVIndicator is an indicator that consumes source data DOHLCV price ticks, the common interface of the
+// indicators created by a Registry
+type DOHLCVIndicator interface {
+	Indicator
+	gotrade.DOHLCVTickReceiver
+}
+
+// An IndicatorFactory creates an indicator from its named params
+type IndicatorFactory func(params map[string]interface{}) (indicator DOHLCVIndicator, err error)
+
+// A Registry creates indicators by name, for pipelines where the indicators are given by configuration. A Registry
+// is not safe for concurrent registration, the factories are expected to be registered before use
+type Registry struct {
+	factories map[string]IndicatorFactory
+}
+
+// NewRegistry creates a Registry without any registered indicators
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]IndicatorFactory)}
+}
+
+// Register registers the factory of the indicator with the name
+func (r *Registry) Register(name string, factory IndicatorFactory) error {
+	if factory == nil {
+		return ErrIndicatorFactoryIsNil
+	}
+
+	if _, ok := r.factories[name]; ok {
+		return errors.New("\"" + name + "\" " + ErrStrAlreadyRegistered)
+	}
+
+	r.factories[name] = factory
+	return nil
+}
+
+// New creates the indicator registered with the name from its params
+func (r *Registry) New(name string, params map[string]interface{}) (indicator DOHLCVIndicator, err error) {
+	factory, ok := r.factories[name]
+	if !ok {
+		return nil, errors.New("\"" + name + "\" " + ErrStrNotRegistered)
+	}
+
+	indicator, err = factory(params)
+	if err != nil {
+		return nil, errors.New(name + ": " + err.Error())
+	}
+
+	return indicator, nil
+}
+
+// Names returns the names of the registered indicators in order
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// the registry of the built in indicators, used by Register and New
+var defaultRegistry = NewRegistry()
+
+// Register registers the factory of the indicator with the name in the registry of the built in indicators
+func Register(name string, factory IndicatorFactory) error {
+	return defaultRegistry.Register(name, factory)
+}
+
+// New creates the indicator registered with the name in the registry of the built in indicators from its params
+//	- the built in indicators of a single time period are registered by their lower case name, e.g. sma or rsi,
+//	  with a required "timePeriod" param and, for those of a selected price, an optional "selectData" param
+func New(name string, params map[string]interface{}) (indicator DOHLCVIndicator, err error) {
+	return defaultRegistry.New(name, params)
+}
+
+// RegisteredNames returns the names of the indicators in the registry of the built in indicators in order
+func RegisteredNames() []string {
+	return defaultRegistry.Names()
+}
+
+// IntParam returns the required int param of the name, a float64 with no fractional part is accepted so that
+// params decoded from JSON can be used
+func IntParam(params map[string]interface{}, name string) (int, error) {
+	value, ok := params[name]
+	if !ok {
+		return 0, errors.New("\"" + name + "\" " + ErrStrParamIsRequired)
+	}
+
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= float64(math.MaxInt32) {
+			return int(v), nil
+		}
+	}
+
+	return 0, errors.New("\"" + name + "\" is not an int param")
+}
+
+// SelectDataParam returns the optional data selection param of the name, either a DOHLCVDataSelectionFunc or one
+// of the names "open", "high", "low", "close" or "volume", the close price is used when it is not given
+func SelectDataParam(params map[string]interface{}, name string) (gotrade.DOHLCVDataSelectionFunc, error) {
+	value, ok := params[name]
+	if !ok {
+		return gotrade.UseClosePrice, nil
+	}
+
+	switch v := value.(type) {
+	case gotrade.DOHLCVDataSelectionFunc:
+		if v != nil {
+			return v, nil
+		}
+	case func(dataItem gotrade.DOHLCV) float64:
+		if v != nil {
+			return v, nil
+		}
+	case string:
+		switch v {
+		case "open":
+			return gotrade.UseOpenPrice, nil
+		case "high":
+			return gotrade.UseHighPrice, nil
+		case "low":
+			return gotrade.UseLowPrice, nil
+		case "close":
+			return gotrade.UseClosePrice, nil
+		case "volume":
+			return gotrade.UseVolume, nil
+		}
+	}
+
+	return nil, errors.New("\"" + name + "\" is not a data selection param")
+}
+
+// asDOHLCVIndicator converts the result of an indicator constructor, so that a nil indicator is returned as a
+// nil interface
+func asDOHLCVIndicator(indicator DOHLCVIndicator, err error) (DOHLCVIndicator, error) {
+	if err != nil {
+		return nil, err
+	}
+	return indicator, nil
+}
+
+// timePeriodFactory creates the factory of an indicator of a timePeriod
+func timePeriodFactory(newIndicator func(timePeriod int) (DOHLCVIndicator, error)) IndicatorFactory {
+	return func(params map[string]interface{}) (indicator DOHLCVIndicator, err error) {
+		timePeriod, err := IntParam(params, "timePeriod")
+		if err != nil {
+			return nil, err
+		}
+		return newIndicator(timePeriod)
+	}
+}
+
+// timePeriodSelectDataFactory creates the factory of an indicator of a timePeriod and a selected price
+func timePeriodSelectDataFactory(newIndicator func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error)) IndicatorFactory {
+	return func(params map[string]interface{}) (indicator DOHLCVIndicator, err error) {
+		timePeriod, err := IntParam(params, "timePeriod")
+		if err != nil {
+			return nil, err
+		}
+		selectData, err := SelectDataParam(params, "selectData")
+		if err != nil {
+			return nil, err
+		}
+		return newIndicator(timePeriod, selectData)
+	}
+}
+
+func init() {
+	timePeriodIndicators := map[string]func(timePeriod int) (DOHLCVIndicator, error){
+		"adx":           func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewAdx(timePeriod)) },
+		"adxr":          func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewAdxr(timePeriod)) },
+		"aroonosc":      func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewAroonOsc(timePeriod)) },
+		"atr":           func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewAtr(timePeriod)) },
+		"cci":           func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewCci(timePeriod)) },
+		"dx":            func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewDx(timePeriod)) },
+		"mfi":           func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewMfi(timePeriod)) },
+		"minusdi":       func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewMinusDi(timePeriod)) },
+		"minusdm":       func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewMinusDm(timePeriod)) },
+		"pgo":           func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewPgo(timePeriod)) },
+		"plusdi":        func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewPlusDi(timePeriod)) },
+		"plusdm":        func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewPlusDm(timePeriod)) },
+		"willr":         func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewWillR(timePeriod)) },
+		"donchianwidth": func(timePeriod int) (DOHLCVIndicator, error) { return asDOHLCVIndicator(NewDonchianWidth(timePeriod)) },
+	}
+
+	timePeriodSelectDataIndicators := map[string]func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error){
+		"cog": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewCog(timePeriod, selectData))
+		},
+		"dema": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewDema(timePeriod, selectData))
+		},
+		"ema": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewEma(timePeriod, selectData))
+		},
+		"hhv": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewHhv(timePeriod, selectData))
+		},
+		"kama": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewKama(timePeriod, selectData))
+		},
+		"linreg": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewLinReg(timePeriod, selectData))
+		},
+		"llv": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewLlv(timePeriod, selectData))
+		},
+		"mom": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewMom(timePeriod, selectData))
+		},
+		"rma": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewRma(timePeriod, selectData))
+		},
+		"roc": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewRoc(timePeriod, selectData))
+		},
+		"rsi": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewRsi(timePeriod, selectData))
+		},
+		"sma": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewSma(timePeriod, selectData))
+		},
+		"stddev": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewStdDev(timePeriod, selectData))
+		},
+		"sum": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewSum(timePeriod, selectData))
+		},
+		"tema": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewTema(timePeriod, selectData))
+		},
+		"trima": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewTrima(timePeriod, selectData))
+		},
+		"tsf": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewTsf(timePeriod, selectData))
+		},
+		"var": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewVar(timePeriod, selectData))
+		},
+		"wma": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewWma(timePeriod, selectData))
+		},
+		"zscore": func(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (DOHLCVIndicator, error) {
+			return asDOHLCVIndicator(NewZScore(timePeriod, selectData))
+		},
+	}
+
+	for name, newIndicator := range timePeriodIndicators {
+		Register(name, timePeriodFactory(newIndicator))
+	}
+
+	for name, newIndicator := range timePeriodSelectDataIndicators {
+		Register(name, timePeriodSelectDataFactory(newIndicator))
+	}
+}
@@ -0,0 +1,115 @@
+package indicators_test
+
+import (
+	"encoding/json"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when creating an indicator by name", func() {
+	It("should create an sma with the same results as the sma", func() {
+		indicator, err := indicators.New("sma", map[string]interface{}{"timePeriod": 4})
+		Expect(err).To(BeNil())
+		direct, _ := indicators.NewSma(4, gotrade.UseClosePrice)
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			direct.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+
+		sma, ok := indicator.(*indicators.Sma)
+		Expect(ok).To(BeTrue())
+		Expect(sma.GetLookbackPeriod()).To(Equal(direct.GetLookbackPeriod()))
+		Expect(sma.Data).To(Equal(direct.Data))
+	})
+
+	It("should create an rsi of the selected price from params decoded from JSON", func() {
+		var params map[string]interface{}
+		json.Unmarshal([]byte(`{"timePeriod": 14, "selectData": "high"}`), &params)
+
+		indicator, err := indicators.New("rsi", params)
+		Expect(err).To(BeNil())
+		direct, _ := indicators.NewRsi(14, gotrade.UseHighPrice)
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			direct.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+
+		rsi, ok := indicator.(*indicators.Rsi)
+		Expect(ok).To(BeTrue())
+		Expect(rsi.Data).To(Equal(direct.Data))
+	})
+
+	It("should accept a data selection func as the selectData param", func() {
+		indicator, err := indicators.New("ema", map[string]interface{}{"timePeriod": 10, "selectData": gotrade.UseLowPrice})
+		Expect(err).To(BeNil())
+		Expect(indicator.GetLookbackPeriod()).To(Equal(9))
+	})
+
+	It("should not create an indicator that is not registered", func() {
+		indicator, err := indicators.New("unknown", map[string]interface{}{"timePeriod": 4})
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrNotRegistered))
+		Expect(err.Error()).To(ContainSubstring("unknown"))
+	})
+
+	It("should not create an indicator without a required param", func() {
+		indicator, err := indicators.New("sma", map[string]interface{}{})
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrParamIsRequired))
+		Expect(err.Error()).To(ContainSubstring("timePeriod"))
+	})
+
+	It("should not create an indicator with a param of the wrong type", func() {
+		indicator, err := indicators.New("sma", map[string]interface{}{"timePeriod": 4.5})
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring("timePeriod"))
+
+		indicator, err = indicators.New("rsi", map[string]interface{}{"timePeriod": 14, "selectData": "median"})
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring("selectData"))
+	})
+
+	It("should return the error of the constructor for an invalid param", func() {
+		indicator, err := indicators.New("atr", map[string]interface{}{"timePeriod": 0})
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+	})
+
+	It("should have registered the built in indicators", func() {
+		Expect(indicators.RegisteredNames()).To(ContainElement("sma"))
+		Expect(indicators.RegisteredNames()).To(ContainElement("rsi"))
+		Expect(indicators.RegisteredNames()).To(ContainElement("atr"))
+	})
+})
+
+var _ = Describe("when registering an indicator", func() {
+	var registry *indicators.Registry
+
+	newSma := func(params map[string]interface{}) (indicators.DOHLCVIndicator, error) {
+		return indicators.NewDefaultSma()
+	}
+
+	BeforeEach(func() {
+		registry = indicators.NewRegistry()
+	})
+
+	It("should create the registered indicator by name", func() {
+		Expect(registry.Register("defaultsma", newSma)).To(BeNil())
+		indicator, err := registry.New("defaultsma", nil)
+		Expect(err).To(BeNil())
+		Expect(indicator.GetLookbackPeriod()).To(Equal(9))
+		Expect(registry.Names()).To(Equal([]string{"defaultsma"}))
+	})
+
+	It("should not register a name that is already registered", func() {
+		registry.Register("defaultsma", newSma)
+		err := registry.Register("defaultsma", newSma)
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrAlreadyRegistered))
+	})
+
+	It("should not register a nil factory", func() {
+		Expect(registry.Register("defaultsma", nil)).To(Equal(indicators.ErrIndicatorFactoryIsNil))
+	})
+})
@@ -0,0 +1,175 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Relative Volatility Index Indicator (RelVolIndex), no storage, for use in other indicators
+// the Rsi formula of the rsiPeriod applied to the StdDev of the stdDevPeriod instead of the price, as given by
+// Dorsey. The changes of the StdDev are split into rises and falls and Wilder smoothed, 100 * rises / (rises + falls),
+// so that a result above 50 is given while the volatility is rising. As for the Rsi a result of 0 is given while the
+// StdDev has not changed. Dorsey averages the results of the high and the low prices
+type RelVolIndexWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	stdDev *StdDevWithoutStorage
+	rsi    *RsiWithoutStorage
+}
+
+// NewRelVolIndexWithoutStorage creates a Relative Volatility Index Indicator (RelVolIndex) without storage
+func NewRelVolIndexWithoutStorage(stdDevPeriod int, rsiPeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *RelVolIndexWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum stdDevPeriod for this indicator is 2
+	if stdDevPeriod < 2 {
+		return nil, errors.New("stdDevPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum stdDevPeriod
+	if stdDevPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("stdDevPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum rsiPeriod for this indicator is 2
+	if rsiPeriod < 2 {
+		return nil, errors.New("rsiPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum rsiPeriod
+	if rsiPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("rsiPeriod is greater than the maximum (100000)")
+	}
+
+	ind := RelVolIndexWithoutStorage{}
+
+	ind.rsi, err = NewRsiWithoutStorage(rsiPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.UpdateIndicatorWithNewValue(dataItem, streamBarIndex)
+	})
+
+	ind.stdDev, err = NewStdDevWithoutStorage(stdDevPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.rsi.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	lookback := ind.stdDev.GetLookbackPeriod() + ind.rsi.GetLookbackPeriod()
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction)
+
+	return &ind, err
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *RelVolIndexWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.stdDev.ReceiveTick(tickData, streamBarIndex)
+}
+
+// A Relative Volatility Index Indicator (RelVolIndex)
+type RelVolIndex struct {
+	*RelVolIndexWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewRelVolIndex creates a Relative Volatility Index Indicator (RelVolIndex) for online usage
+func NewRelVolIndex(stdDevPeriod int, rsiPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *RelVolIndex, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := RelVolIndex{
+		selectData: selectData,
+	}
+
+	ind.RelVolIndexWithoutStorage, err = NewRelVolIndexWithoutStorage(stdDevPeriod, rsiPeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultRelVolIndex creates a Relative Volatility Index Indicator (RelVolIndex) for online usage with default parameters
+//	- stdDevPeriod: 10
+//	- rsiPeriod: 14
+func NewDefaultRelVolIndex() (indicator *RelVolIndex, err error) {
+	stdDevPeriod := 10
+	rsiPeriod := 14
+	return NewRelVolIndex(stdDevPeriod, rsiPeriod, gotrade.UseClosePrice)
+}
+
+// NewRelVolIndexWithSrcLen creates a Relative Volatility Index Indicator (RelVolIndex) for offline usage
+func NewRelVolIndexWithSrcLen(sourceLength uint, stdDevPeriod int, rsiPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *RelVolIndex, err error) {
+	ind, err := NewRelVolIndex(stdDevPeriod, rsiPeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultRelVolIndexWithSrcLen creates a Relative Volatility Index Indicator (RelVolIndex) for offline usage with default parameters
+func NewDefaultRelVolIndexWithSrcLen(sourceLength uint) (indicator *RelVolIndex, err error) {
+	ind, err := NewDefaultRelVolIndex()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewRelVolIndexForStream creates a Relative Volatility Index Indicator (RelVolIndex) for online usage with a source data stream
+func NewRelVolIndexForStream(priceStream gotrade.DOHLCVStreamSubscriber, stdDevPeriod int, rsiPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *RelVolIndex, err error) {
+	ind, err := NewRelVolIndex(stdDevPeriod, rsiPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRelVolIndexForStream creates a Relative Volatility Index Indicator (RelVolIndex) for online usage with a source data stream
+func NewDefaultRelVolIndexForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *RelVolIndex, err error) {
+	ind, err := NewDefaultRelVolIndex()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewRelVolIndexForStreamWithSrcLen creates a Relative Volatility Index Indicator (RelVolIndex) for offline usage with a source data stream
+func NewRelVolIndexForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, stdDevPeriod int, rsiPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *RelVolIndex, err error) {
+	ind, err := NewRelVolIndexWithSrcLen(sourceLength, stdDevPeriod, rsiPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRelVolIndexForStreamWithSrcLen creates a Relative Volatility Index Indicator (RelVolIndex) for offline usage with a source data stream
+func NewDefaultRelVolIndexForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *RelVolIndex, err error) {
+	ind, err := NewDefaultRelVolIndexWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *RelVolIndex) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the RelVolIndex results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *RelVolIndex) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the RelVolIndex results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *RelVolIndex) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,46 @@
+package indicators
+
+// floatRing keeps the most recent capacity values received, each value pushed once the ring is full overwrites
+// the oldest value
+type floatRing struct {
+	values []float64
+	start  int
+	count  int
+}
+
+func newFloatRing(capacity int) *floatRing {
+	return &floatRing{values: make([]float64, capacity)}
+}
+
+// push adds the value as the newest, overwriting the oldest when the ring is full
+func (r *floatRing) push(value float64) {
+	if r.count < len(r.values) {
+		r.values[(r.start+r.count)%len(r.values)] = value
+		r.count++
+		return
+	}
+
+	r.values[r.start] = value
+	r.start = (r.start + 1) % len(r.values)
+}
+
+// dropNewest removes the newest value, the oldest value overwritten by it is not restored
+func (r *floatRing) dropNewest() {
+	if r.count > 0 {
+		r.count--
+	}
+}
+
+// len returns the number of values held
+func (r *floatRing) len() int {
+	return r.count
+}
+
+// recent returns a copy of the values held, oldest first
+func (r *floatRing) recent() []float64 {
+	recent := make([]float64, r.count)
+	for i := range recent {
+		recent[i] = r.values[(r.start+i)%len(r.values)]
+	}
+	return recent
+}
@@ -0,0 +1,175 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Wilder's Moving Average Indicator (Rma), no storage, for use in other indicators
+// also known as the smoothed moving average (Smma), the smoothing Welles Wilder used within the Rsi and the Atr.
+// Seeded with the Sma of the first timePeriod prices, each subsequent result is rma = (rma' * (timePeriod - 1) + price) / timePeriod,
+// an Ema with a multiplier of 1 / timePeriod
+type RmaWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodTotal   float64
+	periodCounter int
+	previousRma   float64
+	timePeriod    int
+}
+
+// NewRmaWithoutStorage creates a Wilder's Moving Average Indicator (Rma) without storage
+func NewRmaWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *RmaWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := RmaWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodCounter:                timePeriod * -1,
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *RmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.periodCounter += 1
+	if ind.periodCounter < 0 {
+		ind.periodTotal += tickData
+	} else if ind.periodCounter == 0 {
+
+		ind.periodTotal += tickData
+		result := ind.periodTotal / float64(ind.timePeriod)
+		ind.previousRma = result
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+
+	} else if ind.periodCounter > 0 {
+
+		result := (ind.previousRma*float64(ind.timePeriod-1) + tickData) / float64(ind.timePeriod)
+		ind.previousRma = result
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// A Wilder's Moving Average Indicator (Rma)
+type Rma struct {
+	*RmaWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewRma creates a Wilder's Moving Average Indicator (Rma) for online usage
+func NewRma(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Rma, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Rma{
+		selectData: selectData,
+	}
+
+	ind.RmaWithoutStorage, err = NewRmaWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultRma creates a Wilder's Moving Average Indicator (Rma) for online usage with default parameters
+//	- timePeriod: 14
+func NewDefaultRma() (indicator *Rma, err error) {
+	timePeriod := 14
+	return NewRma(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewRmaWithSrcLen creates a Wilder's Moving Average Indicator (Rma) for offline usage
+func NewRmaWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Rma, err error) {
+	ind, err := NewRma(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultRmaWithSrcLen creates a Wilder's Moving Average Indicator (Rma) for offline usage with default parameters
+func NewDefaultRmaWithSrcLen(sourceLength uint) (indicator *Rma, err error) {
+	ind, err := NewDefaultRma()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewRmaForStream creates a Wilder's Moving Average Indicator (Rma) for online usage with a source data stream
+func NewRmaForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Rma, err error) {
+	ind, err := NewRma(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRmaForStream creates a Wilder's Moving Average Indicator (Rma) for online usage with a source data stream
+func NewDefaultRmaForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Rma, err error) {
+	ind, err := NewDefaultRma()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewRmaForStreamWithSrcLen creates a Wilder's Moving Average Indicator (Rma) for offline usage with a source data stream
+func NewRmaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Rma, err error) {
+	ind, err := NewRmaWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRmaForStreamWithSrcLen creates a Wilder's Moving Average Indicator (Rma) for offline usage with a source data stream
+func NewDefaultRmaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Rma, err error) {
+	ind, err := NewDefaultRmaWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Rma) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Rma results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Rma) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Rma results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Rma) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
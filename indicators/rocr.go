@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Rate of Change Ratio Indicator (RocR), no storage, for use in other indicators
@@ -161,3 +162,15 @@ func (ind *RocRWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 		ind.periodHistory.Remove(first)
 	}
 }
+
+// ValuesInRange returns the RocR results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *RocR) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the RocR results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *RocR) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
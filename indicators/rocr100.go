@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Rate of Change Ratio 100 Scale Indicator (RocR100), no storage, for use in other indicators
@@ -162,3 +163,15 @@ func (ind *RocR100WithoutStorage) ReceiveTick(tickData float64, streamBarIndex i
 		ind.periodHistory.Remove(first)
 	}
 }
+
+// ValuesInRange returns the RocR100 results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *RocR100) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the RocR100 results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *RocR100) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,62 @@
+package indicators
+
+import (
+	"container/list"
+	"math"
+)
+
+// the largest magnitude of a normalized value passed to the Fisher transform, which is infinite at -1 and 1
+const fisherTransformLimit float64 = 0.999
+
+// rollingNormalizer normalizes each value to -1 at the low and 1 at the high of the last timePeriod values, the
+// values received so far until there are timePeriod of them, and smooths the normalized values by an exponential
+// smoothing of the smoothingFactor, 1 for none. A value within a flat window is normalized to 0
+type rollingNormalizer struct {
+	periodHistory   *list.List
+	timePeriod      int
+	smoothingFactor float64
+	previous        float64
+}
+
+func newRollingNormalizer(timePeriod int, smoothingFactor float64) *rollingNormalizer {
+	return &rollingNormalizer{
+		periodHistory:   list.New(),
+		timePeriod:      timePeriod,
+		smoothingFactor: smoothingFactor,
+	}
+}
+
+// rollingNormalize adds the value to the window and returns it normalized to the high and low of the window
+func (n *rollingNormalizer) rollingNormalize(value float64) float64 {
+	n.periodHistory.PushBack(value)
+	if n.periodHistory.Len() > n.timePeriod {
+		var first = n.periodHistory.Front()
+		n.periodHistory.Remove(first)
+	}
+
+	high, low := value, value
+	for e := n.periodHistory.Front(); e != nil; e = e.Next() {
+		high = math.Max(high, e.Value.(float64))
+		low = math.Min(low, e.Value.(float64))
+	}
+
+	var normalized float64 = 0.0
+	if high > low {
+		normalized = 2.0*(value-low)/(high-low) - 1.0
+	}
+
+	n.previous = n.smoothingFactor*normalized + (1.0-n.smoothingFactor)*n.previous
+	return n.previous
+}
+
+// isFull returns whether the window holds timePeriod values
+func (n *rollingNormalizer) isFull() bool {
+	return n.periodHistory.Len() == n.timePeriod
+}
+
+// fisherTransform returns the Fisher transform of a value normalized to -1 to 1, 0.5 * ln((1 + x) / (1 - x)), the
+// value is limited to within the fisherTransformLimit so that the result is finite
+func fisherTransform(value float64) float64 {
+	value = math.Max(math.Min(value, fisherTransformLimit), -fisherTransformLimit)
+	return 0.5 * math.Log((1.0+value)/(1.0-value))
+}
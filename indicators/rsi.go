@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Relative Strength Indicator (Rsi), no storage, for use in other indicators
@@ -196,3 +197,15 @@ func (ind *RsiWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 	}
 	ind.previousClose = tickData
 }
+
+// ValuesInRange returns the Rsi results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Rsi) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Rsi results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Rsi) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,205 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// the variance, relative to the square of the mean, below which a series is taken to be flat
+// so that rounding in the running sums does not produce a fit for a flat period
+const rSquaredFlatVarianceTolerance float64 = 0.000000000001
+
+// An R-Squared Indicator (RSquared), no storage, for use in other indicators
+// the coefficient of determination of the linear regression of the last timePeriod values against the bar, the
+// square of their Pearson correlation, from 0.0 for no linear trend to 1.0 for a perfectly linear series. The sums
+// of the regression are updated incrementally as the values enter and leave the period, a result of 0.0 is given
+// while the period is flat
+type RSquaredWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodHistory *list.List
+	sumX          float64
+	sumXSquare    float64
+	sumY          float64
+	sumYSquare    float64
+	sumXY         float64
+	timePeriod    int
+}
+
+// NewRSquaredWithoutStorage creates an R-Squared Indicator (RSquared) without storage
+func NewRSquaredWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *RSquaredWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := RSquaredWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		timePeriod:                   timePeriod,
+	}
+
+	// the bars of the period are numbered 0, the oldest, to timePeriod - 1, the newest
+	timePeriodF := float64(timePeriod)
+	ind.sumX = timePeriodF * (timePeriodF - 1.0) * 0.5
+	ind.sumXSquare = timePeriodF * (timePeriodF - 1.0) * (2.0*timePeriodF - 1.0) / 6.0
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *RSquaredWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if ind.periodHistory.Len() < ind.timePeriod {
+		ind.sumXY += float64(ind.periodHistory.Len()) * tickData
+	} else {
+		// the oldest value leaves the period and the bar numbers of the others fall by one
+		var first = ind.periodHistory.Front()
+		oldest := first.Value.(float64)
+		ind.periodHistory.Remove(first)
+
+		ind.sumXY -= ind.sumY - oldest
+		ind.sumXY += float64(ind.timePeriod-1) * tickData
+		ind.sumY -= oldest
+		ind.sumYSquare -= oldest * oldest
+	}
+
+	ind.periodHistory.PushBack(tickData)
+	ind.sumY += tickData
+	ind.sumYSquare += tickData * tickData
+
+	if ind.periodHistory.Len() == ind.timePeriod {
+		n := float64(ind.timePeriod)
+		mean := ind.sumY / n
+		variance := ind.sumYSquare/n - mean*mean
+
+		var result float64 = 0.0
+		if variance > rSquaredFlatVarianceTolerance*mean*mean {
+			covarianceTerm := n*ind.sumXY - ind.sumX*ind.sumY
+			varianceXTerm := n*ind.sumXSquare - ind.sumX*ind.sumX
+			varianceYTerm := n*ind.sumYSquare - ind.sumY*ind.sumY
+			result = math.Min(covarianceTerm*covarianceTerm/(varianceXTerm*varianceYTerm), 1.0)
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// An R-Squared Indicator (RSquared)
+type RSquared struct {
+	*RSquaredWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewRSquared creates an R-Squared Indicator (RSquared) for online usage
+func NewRSquared(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *RSquared, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := RSquared{
+		selectData: selectData,
+	}
+
+	ind.RSquaredWithoutStorage, err = NewRSquaredWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultRSquared creates an R-Squared Indicator (RSquared) for online usage with default parameters
+//	- timePeriod: 14
+func NewDefaultRSquared() (indicator *RSquared, err error) {
+	timePeriod := 14
+	return NewRSquared(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewRSquaredWithSrcLen creates an R-Squared Indicator (RSquared) for offline usage
+func NewRSquaredWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *RSquared, err error) {
+	ind, err := NewRSquared(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultRSquaredWithSrcLen creates an R-Squared Indicator (RSquared) for offline usage with default parameters
+func NewDefaultRSquaredWithSrcLen(sourceLength uint) (indicator *RSquared, err error) {
+	ind, err := NewDefaultRSquared()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewRSquaredForStream creates an R-Squared Indicator (RSquared) for online usage with a source data stream
+func NewRSquaredForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *RSquared, err error) {
+	ind, err := NewRSquared(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRSquaredForStream creates an R-Squared Indicator (RSquared) for online usage with a source data stream
+func NewDefaultRSquaredForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *RSquared, err error) {
+	ind, err := NewDefaultRSquared()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewRSquaredForStreamWithSrcLen creates an R-Squared Indicator (RSquared) for offline usage with a source data stream
+func NewRSquaredForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *RSquared, err error) {
+	ind, err := NewRSquaredWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultRSquaredForStreamWithSrcLen creates an R-Squared Indicator (RSquared) for offline usage with a source data stream
+func NewDefaultRSquaredForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *RSquared, err error) {
+	ind, err := NewDefaultRSquaredWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *RSquared) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the RSquared results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *RSquared) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the RSquared results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *RSquared) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,276 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+	"strconv"
+)
+
+type ValueAvailableActionSafeZone func(dataItemStop float64, dataItemDirection int, streamBarIndex int)
+
+// the directions of the trend protected by a SafeZone stop
+const (
+	SafeZoneLong  int = 1
+	SafeZoneShort int = -1
+)
+
+// the penetrations of a bar beyond the range of the previous bar
+type safeZonePenetration struct {
+	downside float64
+	upside   float64
+}
+
+// A SafeZone Stop Indicator (SafeZone), no storage, for use in other indicators
+// the trend is up while the close is at or above the close timePeriod bars ago. In an uptrend the downside
+// penetrations, the falls of the low below the previous low, are averaged over the timePeriod and the long stop
+// is placed below the low by multiplier times the average, symmetrically in a downtrend the short stop is placed
+// above the high using the upside penetrations. The stop only trails in the direction of the trend, it is never
+// loosened while the trend is unchanged
+type SafeZoneWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionSafeZone
+	timePeriod           int
+	multiplier           float64
+	hasPreviousBar       bool
+	previousHigh         float64
+	previousLow          float64
+	periodHistory        *list.List
+	closeHistory         *list.List
+	downsideTotal        float64
+	downsideCount        int
+	upsideTotal          float64
+	upsideCount          int
+	previousStop         float64
+	previousDirection    int
+}
+
+// NewSafeZoneWithoutStorage creates a SafeZone Stop Indicator (SafeZone) without storage
+func NewSafeZoneWithoutStorage(timePeriod int, multiplier float64, valueAvailableAction ValueAvailableActionSafeZone) (indicator *SafeZoneWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum multiplier for this indicator is 0
+	if multiplier < 0.0 {
+		return nil, errors.New("multiplier is less than the minimum (0)")
+	}
+
+	// check the maximum multiplier
+	if multiplier > math.MaxFloat64 {
+		return nil, errors.New("multiplier is greater than the maximum float64 size")
+	}
+
+	lookback := timePeriod
+	ind := SafeZoneWithoutStorage{
+		baseIndicator:        newBaseIndicator(lookback),
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		timePeriod:           timePeriod,
+		multiplier:           multiplier,
+		periodHistory:        list.New(),
+		closeHistory:         list.New(),
+	}
+
+	return &ind, nil
+}
+
+// A SafeZone Stop Indicator (SafeZone)
+type SafeZone struct {
+	*SafeZoneWithoutStorage
+
+	// public variables
+	Stop      []float64
+	Direction []int
+}
+
+// NewSafeZone creates a SafeZone Stop Indicator (SafeZone) for online usage
+func NewSafeZone(timePeriod int, multiplier float64) (indicator *SafeZone, err error) {
+	ind := SafeZone{}
+	ind.SafeZoneWithoutStorage, err = NewSafeZoneWithoutStorage(timePeriod, multiplier, func(dataItemStop float64, dataItemDirection int, streamBarIndex int) {
+		ind.Stop = append(ind.Stop, dataItemStop)
+		ind.Direction = append(ind.Direction, dataItemDirection)
+	})
+
+	return &ind, err
+}
+
+// NewDefaultSafeZone creates a SafeZone Stop Indicator (SafeZone) for online usage with default parameters
+//	- timePeriod: 22
+//	- multiplier: 2.0
+func NewDefaultSafeZone() (indicator *SafeZone, err error) {
+	timePeriod := 22
+	multiplier := 2.0
+	return NewSafeZone(timePeriod, multiplier)
+}
+
+// NewSafeZoneWithSrcLen creates a SafeZone Stop Indicator (SafeZone) for offline usage
+func NewSafeZoneWithSrcLen(sourceLength uint, timePeriod int, multiplier float64) (indicator *SafeZone, err error) {
+	ind, err := NewSafeZone(timePeriod, multiplier)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Stop = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Direction = make([]int, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultSafeZoneWithSrcLen creates a SafeZone Stop Indicator (SafeZone) for offline usage with default parameters
+func NewDefaultSafeZoneWithSrcLen(sourceLength uint) (indicator *SafeZone, err error) {
+	ind, err := NewDefaultSafeZone()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Stop = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Direction = make([]int, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewSafeZoneForStream creates a SafeZone Stop Indicator (SafeZone) for online usage with a source data stream
+func NewSafeZoneForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, multiplier float64) (indicator *SafeZone, err error) {
+	ind, err := NewSafeZone(timePeriod, multiplier)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultSafeZoneForStream creates a SafeZone Stop Indicator (SafeZone) for online usage with a source data stream
+func NewDefaultSafeZoneForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *SafeZone, err error) {
+	ind, err := NewDefaultSafeZone()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewSafeZoneForStreamWithSrcLen creates a SafeZone Stop Indicator (SafeZone) for offline usage with a source data stream
+func NewSafeZoneForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, multiplier float64) (indicator *SafeZone, err error) {
+	ind, err := NewSafeZoneWithSrcLen(sourceLength, timePeriod, multiplier)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultSafeZoneForStreamWithSrcLen creates a SafeZone Stop Indicator (SafeZone) for offline usage with a source data stream
+func NewDefaultSafeZoneForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *SafeZone, err error) {
+	ind, err := NewDefaultSafeZoneWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the SafeZone results as rows after a header of barIndex,stop,direction, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *SafeZone) WriteCSV(w io.Writer, startBarOffset int) error {
+	direction := csvColumn{name: "direction", length: len(ind.Direction), format: func(index int) string {
+		return strconv.Itoa(ind.Direction[index])
+	}}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("stop", ind.Stop), direction)
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *SafeZoneWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.closeHistory.PushBack(tickData.C())
+	if ind.closeHistory.Len() > ind.timePeriod+1 {
+		ind.closeHistory.Remove(ind.closeHistory.Front())
+	}
+
+	if !ind.hasPreviousBar {
+		ind.hasPreviousBar = true
+		ind.previousHigh = tickData.H()
+		ind.previousLow = tickData.L()
+		return
+	}
+
+	penetration := safeZonePenetration{
+		downside: math.Max(ind.previousLow-tickData.L(), 0.0),
+		upside:   math.Max(tickData.H()-ind.previousHigh, 0.0),
+	}
+	ind.addPenetration(penetration, 1)
+	ind.periodHistory.PushBack(penetration)
+
+	if ind.periodHistory.Len() > ind.timePeriod {
+		var first = ind.periodHistory.Front()
+		ind.addPenetration(first.Value.(safeZonePenetration), -1)
+		ind.periodHistory.Remove(first)
+	}
+
+	ind.previousHigh = tickData.H()
+	ind.previousLow = tickData.L()
+
+	if ind.periodHistory.Len() < ind.timePeriod {
+		return
+	}
+
+	var stop float64
+	var direction int
+	if tickData.C() >= ind.closeHistory.Front().Value.(float64) {
+		direction = SafeZoneLong
+		stop = tickData.L() - ind.multiplier*safeZoneAverage(ind.downsideTotal, ind.downsideCount)
+
+		// a long stop is only ever raised while the trend is up
+		if ind.previousDirection == SafeZoneLong && stop < ind.previousStop {
+			stop = ind.previousStop
+		}
+	} else {
+		direction = SafeZoneShort
+		stop = tickData.H() + ind.multiplier*safeZoneAverage(ind.upsideTotal, ind.upsideCount)
+
+		// a short stop is only ever lowered while the trend is down
+		if ind.previousDirection == SafeZoneShort && stop > ind.previousStop {
+			stop = ind.previousStop
+		}
+	}
+
+	stop = ind.quantize(stop)
+	ind.previousStop = stop
+	ind.previousDirection = direction
+
+	ind.UpdateMinMax(stop, stop)
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(stop, direction, streamBarIndex)
+}
+
+// addPenetration adds, sign 1, or removes, sign -1, a penetration from the period totals, only the bars with a
+// penetration are counted in the averages
+func (ind *SafeZoneWithoutStorage) addPenetration(penetration safeZonePenetration, sign int) {
+	if penetration.downside > 0.0 {
+		ind.downsideTotal += float64(sign) * penetration.downside
+		ind.downsideCount += sign
+	}
+
+	if penetration.upside > 0.0 {
+		ind.upsideTotal += float64(sign) * penetration.upside
+		ind.upsideCount += sign
+	}
+}
+
+func safeZoneAverage(total float64, count int) float64 {
+	if count == 0 {
+		return 0.0
+	}
+	return total / float64(count)
+}
@@ -0,0 +1,154 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a safezonewithoutstorage", func() {
+	var (
+		indicator      *indicators.SafeZoneWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemStop float64, dataItemDirection int, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewSafeZoneWithoutStorage(22, 2.0, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewSafeZoneWithoutStorage(1, 2.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewSafeZoneWithoutStorage(indicators.MaximumLookbackPeriod+1, 2.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+
+	Context("and the indicator was given a multiplier below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewSafeZoneWithoutStorage(22, -1.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a safezone stop (safezone) with DOHLCV source data", func() {
+	var (
+		period    int = 5
+		indicator *indicators.SafeZone
+		bars      []gotrade.DOHLCV
+	)
+
+	// a trending series that pulls back against the trend every third bar
+	trendingBars := func(step float64) []gotrade.DOHLCV {
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		result := []gotrade.DOHLCV{}
+		price := 100.0
+		for i := 0; i < 40; i++ {
+			if i%3 == 2 {
+				price -= step
+			} else {
+				price += 2.0 * step
+			}
+			result = append(result, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price+1.0, price-1.0, price, 1000.0))
+		}
+		return result
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSafeZone(period, 2.0)
+	})
+
+	It("the defaulted parameters should be applied", func() {
+		indicator, _ = indicators.NewDefaultSafeZone()
+		Expect(indicator.GetLookbackPeriod()).To(Equal(22))
+	})
+
+	Context("and the source data is an uptrend", func() {
+		BeforeEach(func() {
+			bars = trendingBars(1.0)
+			for i := range bars {
+				indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			}
+		})
+
+		It("should produce a result for each bar after the lookback period", func() {
+			Expect(len(indicator.Stop)).To(Equal(len(bars) - period))
+			Expect(len(indicator.Direction)).To(Equal(len(bars) - period))
+			Expect(indicator.ValidFromBar()).To(Equal(period + 1))
+		})
+
+		It("the direction should be long", func() {
+			for i := range indicator.Direction {
+				Expect(indicator.Direction[i]).To(Equal(indicators.SafeZoneLong))
+			}
+		})
+
+		It("the stop should trail upward below the lows", func() {
+			for i := range indicator.Stop {
+				Expect(indicator.Stop[i]).To(BeNumerically("<", bars[i+period].L()))
+				if i > 0 {
+					Expect(indicator.Stop[i]).To(BeNumerically(">=", indicator.Stop[i-1]))
+				}
+			}
+			Expect(indicator.Stop[len(indicator.Stop)-1]).To(BeNumerically(">", indicator.Stop[0]))
+		})
+
+		It("the first stop should be below the low by the multiple of the average downside penetration", func() {
+			// a single pullback of 1.0 in the first period
+			Expect(indicator.Stop[0]).To(BeNumerically("~", bars[period].L()-2.0*1.0, 1e-9))
+		})
+
+		It("the bounds should be the bounds of the stops", func() {
+			Expect(indicator.MaxValue()).To(Equal(GetFloatDataMax(indicator.Stop)))
+			Expect(indicator.MinValue()).To(Equal(GetFloatDataMin(indicator.Stop)))
+		})
+	})
+
+	Context("and the source data is a downtrend", func() {
+		BeforeEach(func() {
+			bars = trendingBars(-1.0)
+			for i := range bars {
+				indicator.ReceiveDOHLCVTick(bars[i], i+1)
+			}
+		})
+
+		It("the stop should trail downward above the highs", func() {
+			for i := range indicator.Stop {
+				Expect(indicator.Direction[i]).To(Equal(indicators.SafeZoneShort))
+				Expect(indicator.Stop[i]).To(BeNumerically(">", bars[i+period].H()))
+				if i > 0 {
+					Expect(indicator.Stop[i]).To(BeNumerically("<=", indicator.Stop[i-1]))
+				}
+			}
+		})
+	})
+})
@@ -0,0 +1,130 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionSessionRange func(dataItemHigh float64, dataItemLow float64, dataItemRange float64, streamBarIndex int)
+
+// A Session Range Indicator (SessionRange), no storage, for use in other indicators
+// the highest high and lowest low of the session so far, and the range between them, high - low. The session
+// starts with the first tick received and restarts with the first tick after the session is reset
+type SessionRangeWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionSessionRange
+	hasSessionTick       bool
+	sessionHigh          float64
+	sessionLow           float64
+}
+
+// NewSessionRangeWithoutStorage creates a Session Range Indicator (SessionRange) without storage
+func NewSessionRangeWithoutStorage(valueAvailableAction ValueAvailableActionSessionRange) (indicator *SessionRangeWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	lookback := 0
+	ind := SessionRangeWithoutStorage{
+		baseIndicator:        newBaseIndicator(lookback),
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+	}
+
+	return &ind, nil
+}
+
+// ResetSession resets the session high and low, the next tick received is the first of a new session
+func (ind *SessionRangeWithoutStorage) ResetSession() {
+	ind.hasSessionTick = false
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *SessionRangeWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if !ind.hasSessionTick {
+		ind.hasSessionTick = true
+		ind.sessionHigh = tickData.H()
+		ind.sessionLow = tickData.L()
+	} else {
+		ind.sessionHigh = math.Max(ind.sessionHigh, tickData.H())
+		ind.sessionLow = math.Min(ind.sessionLow, tickData.L())
+	}
+
+	high := ind.quantize(ind.sessionHigh)
+	low := ind.quantize(ind.sessionLow)
+	sessionRange := ind.quantize(ind.sessionHigh - ind.sessionLow)
+
+	ind.UpdateMinMax(math.Min(low, sessionRange), math.Max(high, sessionRange))
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(high, low, sessionRange, streamBarIndex)
+}
+
+// A Session Range Indicator (SessionRange)
+type SessionRange struct {
+	*SessionRangeWithoutStorage
+
+	// public variables
+	High  []float64
+	Low   []float64
+	Range []float64
+}
+
+// NewSessionRange creates a Session Range Indicator (SessionRange) for online usage
+func NewSessionRange() (indicator *SessionRange, err error) {
+	ind := SessionRange{}
+	ind.SessionRangeWithoutStorage, err = NewSessionRangeWithoutStorage(
+		func(dataItemHigh float64, dataItemLow float64, dataItemRange float64, streamBarIndex int) {
+			ind.High = append(ind.High, dataItemHigh)
+			ind.Low = append(ind.Low, dataItemLow)
+			ind.Range = append(ind.Range, dataItemRange)
+		})
+
+	return &ind, err
+}
+
+// NewSessionRangeWithSrcLen creates a Session Range Indicator (SessionRange) for offline usage
+func NewSessionRangeWithSrcLen(sourceLength uint) (indicator *SessionRange, err error) {
+	ind, err := NewSessionRange()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.High = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Low = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Range = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewSessionRangeForStream creates a Session Range Indicator (SessionRange) for online usage with a source data stream
+func NewSessionRangeForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *SessionRange, err error) {
+	ind, err := NewSessionRange()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewSessionRangeForStreamWithSrcLen creates a Session Range Indicator (SessionRange) for offline usage with a source data stream
+func NewSessionRangeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *SessionRange, err error) {
+	ind, err := NewSessionRangeWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the SessionRange results as rows after a header of barIndex,high,low,range, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *SessionRange) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("high", ind.High), floatCSVColumn("low", ind.Low), floatCSVColumn("range", ind.Range))
+}
@@ -0,0 +1,123 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a sessionrangewithoutstorage", func() {
+	Context("and the indicator was not given a value available action", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			indicator, indicatorError := indicators.NewSessionRangeWithoutStorage(nil)
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating a session range (sessionrange) with DOHLCV source data", func() {
+	var (
+		barsPerSession int = 6
+		indicator      *indicators.SessionRange
+		sessions       [][]gotrade.DOHLCV
+	)
+
+	// two sessions of hourly bars, the second opening with a gap below the first
+	start := time.Date(2014, 1, 6, 9, 0, 0, 0, time.UTC)
+	for session, open := range []float64{100.0, 90.0} {
+		var bars []gotrade.DOHLCV
+		for i := 0; i < barsPerSession; i++ {
+			price := open + float64(i%3) - float64(i/3)
+			bars = append(bars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, session).Add(time.Duration(i)*time.Hour), price, price+1.0, price-0.5, price, 1000.0))
+		}
+		sessions = append(sessions, bars)
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSessionRange()
+	})
+
+	// the cumulative high and low of the bars of a session
+	expectSession := func(bars []gotrade.DOHLCV, offset int) {
+		high, low := bars[0].H(), bars[0].L()
+		for i := range bars {
+			if bars[i].H() > high {
+				high = bars[i].H()
+			}
+			if bars[i].L() < low {
+				low = bars[i].L()
+			}
+			Expect(indicator.High[offset+i]).To(Equal(high))
+			Expect(indicator.Low[offset+i]).To(Equal(low))
+			Expect(indicator.Range[offset+i]).To(Equal(high - low))
+		}
+	}
+
+	Context("and the indicator has received the ticks of a single session", func() {
+		BeforeEach(func() {
+			for i := range sessions[0] {
+				indicator.ReceiveDOHLCVTick(sessions[0][i], i+1)
+			}
+		})
+
+		It("should have a result for every bar", func() {
+			Expect(indicator.ValidFromBar()).To(Equal(1))
+			Expect(len(indicator.High)).To(Equal(barsPerSession))
+			Expect(indicator.Length()).To(Equal(barsPerSession))
+		})
+
+		It("should track the running session high, low and range", func() {
+			expectSession(sessions[0], 0)
+		})
+
+		It("the range should never shrink during the session", func() {
+			for i := 1; i < len(indicator.Range); i++ {
+				Expect(indicator.Range[i]).To(BeNumerically(">=", indicator.Range[i-1]))
+			}
+		})
+	})
+
+	Context("and the indicator has received the ticks of two sessions, reset at the boundary", func() {
+		BeforeEach(func() {
+			streamBarIndex := 0
+			for s := range sessions {
+				if s > 0 {
+					indicator.ResetSession()
+				}
+				for i := range sessions[s] {
+					streamBarIndex++
+					indicator.ReceiveDOHLCVTick(sessions[s][i], streamBarIndex)
+				}
+			}
+		})
+
+		It("should keep the results of both sessions", func() {
+			Expect(len(indicator.High)).To(Equal(2 * barsPerSession))
+		})
+
+		It("the range should restart from the first bar of the second session", func() {
+			Expect(indicator.Range[barsPerSession]).To(Equal(sessions[1][0].H() - sessions[1][0].L()))
+			Expect(indicator.Range[barsPerSession]).To(BeNumerically("<", indicator.Range[barsPerSession-1]))
+			expectSession(sessions[1], barsPerSession)
+		})
+	})
+
+	Context("and the indicator has received the ticks of two sessions without a reset", func() {
+		BeforeEach(func() {
+			streamBarIndex := 0
+			for s := range sessions {
+				for i := range sessions[s] {
+					streamBarIndex++
+					indicator.ReceiveDOHLCVTick(sessions[s][i], streamBarIndex)
+				}
+			}
+		})
+
+		It("should track the high and low of both sessions as one", func() {
+			expectSession(append(append([]gotrade.DOHLCV{}, sessions[0]...), sessions[1]...), 0)
+		})
+	})
+})
@@ -0,0 +1,229 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// the second central moment, relative to the square of the mean, below which a period is taken to be flat
+// so that rounding in the running power sums does not produce a skewness or kurtosis for a flat period
+const momentsFlatVarianceTolerance float64 = 0.000000000001
+
+// rollingMoments keeps the running power sums of the values in a period, giving its central moments in O(1)
+type rollingMoments struct {
+	periodHistory *list.List
+	sum1          float64
+	sum2          float64
+	sum3          float64
+	sum4          float64
+	timePeriod    int
+}
+
+func newRollingMoments(timePeriod int) *rollingMoments {
+	return &rollingMoments{periodHistory: list.New(), timePeriod: timePeriod}
+}
+
+// Add adds the value to the period, removing the oldest value once the period is full
+func (m *rollingMoments) Add(value float64) {
+	m.periodHistory.PushBack(value)
+	m.addPowers(value, 1.0)
+
+	if m.periodHistory.Len() > m.timePeriod {
+		var first = m.periodHistory.Front()
+		m.addPowers(first.Value.(float64), -1.0)
+		m.periodHistory.Remove(first)
+	}
+}
+
+func (m *rollingMoments) addPowers(value float64, sign float64) {
+	valueSq := value * value
+	m.sum1 += sign * value
+	m.sum2 += sign * valueSq
+	m.sum3 += sign * valueSq * value
+	m.sum4 += sign * valueSq * valueSq
+}
+
+func (m *rollingMoments) IsFull() bool {
+	return m.periodHistory.Len() == m.timePeriod
+}
+
+// CentralMoments returns the second, third and fourth central moments of the period, isFlat is true
+// if the period has no variance
+func (m *rollingMoments) CentralMoments() (m2 float64, m3 float64, m4 float64, isFlat bool) {
+	n := float64(m.timePeriod)
+	mean := m.sum1 / n
+	meanSq := mean * mean
+	s2 := m.sum2 / n
+	s3 := m.sum3 / n
+	s4 := m.sum4 / n
+
+	m2 = s2 - meanSq
+	m3 = s3 - 3.0*mean*s2 + 2.0*meanSq*mean
+	m4 = s4 - 4.0*mean*s3 + 6.0*meanSq*s2 - 3.0*meanSq*meanSq
+	isFlat = m2 <= momentsFlatVarianceTolerance*meanSq || m2 <= 0.0
+
+	return m2, m3, m4, isFlat
+}
+
+// A Skewness Indicator (Skewness), no storage, for use in other indicators
+// the adjusted Fisher-Pearson sample skewness of the values in the period, a result of 0.0 is
+// given for a period with no variance
+type SkewnessWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	moments    *rollingMoments
+	timePeriod int
+}
+
+// NewSkewnessWithoutStorage creates a Skewness Indicator (Skewness) without storage
+func NewSkewnessWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *SkewnessWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 3
+	if timePeriod < 3 {
+		return nil, errors.New("timePeriod is less than the minimum (3)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := SkewnessWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		moments:                      newRollingMoments(timePeriod),
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *SkewnessWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.moments.Add(tickData)
+
+	if ind.moments.IsFull() {
+		m2, m3, _, isFlat := ind.moments.CentralMoments()
+
+		var result float64 = 0.0
+		if !isFlat {
+			n := float64(ind.timePeriod)
+			result = m3 / math.Pow(m2, 1.5) * math.Sqrt(n*(n-1.0)) / (n - 2.0)
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// A Skewness Indicator (Skewness)
+type Skewness struct {
+	*SkewnessWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewSkewness creates a Skewness Indicator (Skewness) for online usage
+func NewSkewness(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Skewness, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Skewness{
+		selectData: selectData,
+	}
+
+	ind.SkewnessWithoutStorage, err = NewSkewnessWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultSkewness creates a Skewness Indicator (Skewness) for online usage with default parameters
+//	- timePeriod: 30
+func NewDefaultSkewness() (indicator *Skewness, err error) {
+	timePeriod := 30
+	return NewSkewness(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewSkewnessWithSrcLen creates a Skewness Indicator (Skewness) for offline usage
+func NewSkewnessWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Skewness, err error) {
+	ind, err := NewSkewness(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultSkewnessWithSrcLen creates a Skewness Indicator (Skewness) for offline usage with default parameters
+func NewDefaultSkewnessWithSrcLen(sourceLength uint) (indicator *Skewness, err error) {
+	ind, err := NewDefaultSkewness()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewSkewnessForStream creates a Skewness Indicator (Skewness) for online usage with a source data stream
+func NewSkewnessForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Skewness, err error) {
+	ind, err := NewSkewness(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultSkewnessForStream creates a Skewness Indicator (Skewness) for online usage with a source data stream
+func NewDefaultSkewnessForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Skewness, err error) {
+	ind, err := NewDefaultSkewness()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewSkewnessForStreamWithSrcLen creates a Skewness Indicator (Skewness) for offline usage with a source data stream
+func NewSkewnessForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Skewness, err error) {
+	ind, err := NewSkewnessWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultSkewnessForStreamWithSrcLen creates a Skewness Indicator (Skewness) for offline usage with a source data stream
+func NewDefaultSkewnessForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Skewness, err error) {
+	ind, err := NewDefaultSkewnessWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Skewness) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Skewness results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Skewness) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Skewness results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Skewness) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
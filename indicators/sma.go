@@ -2,9 +2,9 @@
 package indicators
 
 import (
-	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Simple Moving Average Indicator (Sma), no storage, for use in other indicators
@@ -12,10 +12,7 @@ type SmaWithoutStorage struct {
 	*baseIndicatorWithFloatBounds
 
 	// private variables
-	periodTotal   float64
-	periodHistory *list.List
-	periodCounter int
-	timePeriod    int
+	core *smaCore[NumericFloat64]
 }
 
 // NewSmaWithoutStorage creates a Simple Moving Average Indicator (Sma) without storage
@@ -39,14 +36,28 @@ func NewSmaWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableAct
 	lookback := timePeriod - 1
 	ind := SmaWithoutStorage{
 		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
-		periodCounter:                timePeriod * -1,
-		periodHistory:                list.New(),
-		timePeriod:                   timePeriod,
+		core:                         newSmaCore[NumericFloat64](timePeriod),
 	}
 
 	return &ind, nil
 }
 
+// NewSmaWithOffsetWithoutStorage creates a Simple Moving Average Indicator (Sma) without storage
+// displaced by offset bars, a positive offset plots the results into the future and a negative one into the past
+func NewSmaWithOffsetWithoutStorage(timePeriod int, offset int, valueAvailableAction ValueAvailableActionFloat) (indicator *SmaWithoutStorage, err error) {
+	ind, err := NewSmaWithoutStorage(timePeriod, valueAvailableAction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ind.setOffset(offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return ind, nil
+}
+
 // A Simple Moving Average Indicator (Sma)
 type Sma struct {
 	*SmaWithoutStorage
@@ -74,6 +85,25 @@ func NewSma(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicat
 	return &ind, err
 }
 
+// NewSmaWithOffset creates a Simple Moving Average Indicator (Sma) for online usage displaced by offset bars
+func NewSmaWithOffset(timePeriod int, offset int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sma, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Sma{
+		selectData: selectData,
+	}
+	ind.SmaWithoutStorage, err = NewSmaWithOffsetWithoutStorage(
+		timePeriod,
+		offset,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
 // NewDefaultSma creates a Simple Moving Average Indicator (Sma) for online usage with default parameters
 //	- timePeriod: 10
 func NewDefaultSma() (indicator *Sma, err error) {
@@ -112,6 +142,14 @@ func NewSmaForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int,
 	return ind, err
 }
 
+// NewSmaWithOffsetForStream creates a Simple Moving Average Indicator (Sma) for online usage with a source data stream
+// displaced by offset bars
+func NewSmaWithOffsetForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, offset int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sma, err error) {
+	ind, err := NewSmaWithOffset(timePeriod, offset, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
 // NewDefaultSmaForStream creates a Simple Moving Average Indicator (Sma) for online usage with a source data stream
 func NewDefaultSmaForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Sma, err error) {
 	ind, err := NewDefaultSma()
@@ -133,6 +171,54 @@ func NewDefaultSmaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOH
 	return ind, err
 }
 
+// NewSmaWithCentering creates a Simple Moving Average Indicator (Sma) for online usage, a centered Sma requires future data and
+// so ErrCenteringRequiresSourceLength is returned when centered
+func NewSmaWithCentering(timePeriod int, centered bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sma, err error) {
+	if centered {
+		return nil, ErrCenteringRequiresSourceLength
+	}
+	return NewSma(timePeriod, selectData)
+}
+
+// NewSmaWithCenteringWithSrcLen creates a Simple Moving Average Indicator (Sma) for offline usage, when centered each
+// result is displaced back by half the timePeriod onto the bar at the center of its window, for research rather
+// than trading as the result of a bar then depends on later bars
+func NewSmaWithCenteringWithSrcLen(sourceLength uint, timePeriod int, centered bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sma, err error) {
+	ind, err := NewSmaWithSrcLen(sourceLength, timePeriod, selectData)
+	if err != nil || !centered {
+		return ind, err
+	}
+
+	err = ind.setCentered(timePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	return ind, nil
+}
+
+// NewSmaWithCenteringForStream creates a Simple Moving Average Indicator (Sma) for online usage with a source data stream,
+// a centered Sma requires future data and so ErrCenteringRequiresSourceLength is returned when centered
+func NewSmaWithCenteringForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, centered bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sma, err error) {
+	ind, err := NewSmaWithCentering(timePeriod, centered, selectData)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewSmaWithCenteringForStreamWithSrcLen creates a Simple Moving Average Indicator (Sma) for offline usage with a source
+// data stream, centered on the bar at the center of the window of each result when centered
+func NewSmaWithCenteringForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, centered bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sma, err error) {
+	ind, err := NewSmaWithCenteringWithSrcLen(sourceLength, timePeriod, centered, selectData)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (ind *Sma) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	var selectedData = ind.selectData(tickData)
@@ -140,21 +226,19 @@ func (ind *Sma) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 }
 
 func (ind *SmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
-	ind.periodCounter += 1
-	ind.periodHistory.PushBack(tickData)
-
-	if ind.periodCounter > 0 {
-		var valueToRemove = ind.periodHistory.Front()
-		ind.periodTotal -= valueToRemove.Value.(float64)
-	}
-	if ind.periodHistory.Len() > ind.timePeriod {
-		var first = ind.periodHistory.Front()
-		ind.periodHistory.Remove(first)
+	if result, isAvailable := ind.core.receive(NumericFloat64(tickData)); isAvailable {
+		ind.UpdateIndicatorWithNewValue(float64(result), streamBarIndex)
 	}
-	ind.periodTotal += tickData
-	var result float64 = ind.periodTotal / float64(ind.timePeriod)
-	if ind.periodCounter >= 0 {
+}
 
-		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
-	}
+// ValuesInRange returns the Sma results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Sma) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Sma results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Sma) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
 }
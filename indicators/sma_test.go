@@ -5,6 +5,9 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/thetruetrade/gotrade"
 	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"strconv"
+	"time"
 )
 
 var _ = Describe("when creating an smawithoutstorage", func() {
@@ -405,3 +408,479 @@ var _ = Describe("when calculating a simple moving average (sma) with DOHLCV sou
 		})
 	})
 })
+
+var _ = Describe("when querying a range of values from a simple moving average (sma)", func() {
+	var (
+		period    int = 3
+		indicator *indicators.Sma
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+	})
+
+	Context("and the indicator has not yet received any ticks", func() {
+		It("should return an empty slice", func() {
+			values := indicator.ValuesInRange(1, 10)
+			Expect(values).NotTo(BeNil())
+			Expect(values).To(BeEmpty())
+		})
+	})
+
+	Context("and the indicator has received ticks", func() {
+		BeforeEach(func() {
+			for i := 0; i < 10; i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("should return the values for a range within the valid region", func() {
+			Expect(indicator.ValidFromBar()).To(Equal(3))
+			Expect(indicator.ValuesInRange(4, 6)).To(Equal(indicator.Data[1:4]))
+		})
+
+		It("should return the values for a single bar", func() {
+			Expect(indicator.ValuesInRange(3, 3)).To(Equal(indicator.Data[0:1]))
+		})
+
+		It("should clamp a range that starts before the valid region", func() {
+			Expect(indicator.ValuesInRange(1, 4)).To(Equal(indicator.Data[0:2]))
+		})
+
+		It("should clamp a range that ends after the valid region", func() {
+			Expect(indicator.ValuesInRange(9, 20)).To(Equal(indicator.Data[6:8]))
+		})
+
+		It("should return all of the values for a range covering the whole valid region", func() {
+			Expect(indicator.ValuesInRange(0, 100)).To(Equal(indicator.Data))
+		})
+
+		It("should return an empty slice for a range entirely before the valid region", func() {
+			values := indicator.ValuesInRange(0, 2)
+			Expect(values).NotTo(BeNil())
+			Expect(values).To(BeEmpty())
+		})
+
+		It("should return an empty slice for a range entirely after the valid region", func() {
+			Expect(indicator.ValuesInRange(11, 15)).To(BeEmpty())
+		})
+
+		It("should return an empty slice for a reversed range", func() {
+			Expect(indicator.ValuesInRange(6, 4)).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("when calculating a simple moving average (sma) quantized to a tick size", func() {
+	var (
+		period    int     = 3
+		tickSize  float64 = 0.25
+		indicator *indicators.Sma
+		unrounded *indicators.Sma
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+		indicator.Quantize(tickSize)
+		unrounded, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			unrounded.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("all stored values should be multiples of the tick size", func() {
+		Expect(len(indicator.Data)).To(Equal(len(unrounded.Data)))
+		for i := range indicator.Data {
+			ticks := indicator.Data[i] / tickSize
+			Expect(ticks).To(BeNumerically("~", math.Floor(ticks+0.5), 1e-9))
+		}
+	})
+
+	It("all stored values should be the nearest multiple of the tick size to the unrounded values", func() {
+		for i := range indicator.Data {
+			Expect(math.Abs(indicator.Data[i] - unrounded.Data[i])).To(BeNumerically("<=", tickSize/2.0+1e-9))
+		}
+	})
+
+	It("the bounds should be the bounds of the quantized values", func() {
+		Expect(indicator.MaxValue()).To(Equal(GetFloatDataMax(indicator.Data)))
+		Expect(indicator.MinValue()).To(Equal(GetFloatDataMin(indicator.Data)))
+	})
+})
+
+var _ = Describe("when calculating a simple moving average (sma) with an output transform", func() {
+	var (
+		period    int = 3
+		indicator *indicators.Sma
+		plain     *indicators.Sma
+	)
+
+	receiveAll := func() {
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			plain.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+		plain, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+	})
+
+	Context("and the transform is a percent change", func() {
+		BeforeEach(func() {
+			indicator.SetOutputTransform(indicators.OutputTransformPercentChange)
+			receiveAll()
+		})
+
+		It("the first result should be suppressed", func() {
+			Expect(len(indicator.Data)).To(Equal(len(plain.Data) - 1))
+			Expect(indicator.Length()).To(Equal(len(plain.Data) - 1))
+			Expect(indicator.GetLookbackPeriod()).To(Equal(plain.GetLookbackPeriod() + 1))
+			Expect(indicator.ValidFromBar()).To(Equal(plain.ValidFromBar() + 1))
+		})
+
+		It("the results should equal the percent changes of the untransformed results", func() {
+			for i := range indicator.Data {
+				expected := 100.0 * (plain.Data[i+1] - plain.Data[i]) / plain.Data[i]
+				Expect(indicator.Data[i]).To(BeNumerically("~", expected, 1e-9))
+			}
+		})
+
+		It("the bounds should be the bounds of the transformed results", func() {
+			Expect(indicator.MaxValue()).To(Equal(GetFloatDataMax(indicator.Data)))
+			Expect(indicator.MinValue()).To(Equal(GetFloatDataMin(indicator.Data)))
+		})
+	})
+
+	Context("and the transform is a log", func() {
+		BeforeEach(func() {
+			indicator.SetOutputTransform(indicators.OutputTransformLog)
+			receiveAll()
+		})
+
+		It("the results should equal the log of the untransformed results", func() {
+			Expect(len(indicator.Data)).To(Equal(len(plain.Data)))
+			for i := range indicator.Data {
+				Expect(indicator.Data[i]).To(BeNumerically("~", math.Log(plain.Data[i]), 1e-12))
+			}
+		})
+	})
+
+	Context("and the transform is reset to none", func() {
+		BeforeEach(func() {
+			indicator.SetOutputTransform(indicators.OutputTransformPercentChange)
+			indicator.SetOutputTransform(indicators.OutputTransformNone)
+			receiveAll()
+		})
+
+		It("the results should be untransformed", func() {
+			Expect(indicator.GetLookbackPeriod()).To(Equal(plain.GetLookbackPeriod()))
+			Expect(indicator.Data).To(Equal(plain.Data))
+		})
+	})
+})
+
+var _ = Describe("when calculating a simple moving average (sma) with the warm-up fill set", func() {
+	var (
+		period    int = 3
+		indicator *indicators.Sma
+		plain     *indicators.Sma
+	)
+
+	receiveAll := func() {
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			plain.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+		plain, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+		indicator.SetWarmupFill(true)
+	})
+
+	Context("and the indicator has received fewer ticks than its lookback period", func() {
+		BeforeEach(func() {
+			for i := 0; i < indicator.GetLookbackPeriod(); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("should have no results", func() {
+			Expect(indicator.Data).To(BeEmpty())
+			Expect(indicator.ValidFromBar()).To(Equal(-1))
+		})
+	})
+
+	Context("and the indicator has received all of its ticks", func() {
+		BeforeEach(func() {
+			receiveAll()
+		})
+
+		It("should have a result for every source data bar", func() {
+			Expect(len(indicator.Data)).To(Equal(len(sourceDOHLCVData)))
+			Expect(indicator.Length()).To(Equal(len(sourceDOHLCVData)))
+			Expect(indicator.ValidFromBar()).To(Equal(1))
+		})
+
+		It("the lookback period should be filled with NaNs", func() {
+			for i := 0; i < indicator.GetLookbackPeriod(); i++ {
+				Expect(math.IsNaN(indicator.Data[i])).To(BeTrue())
+			}
+		})
+
+		It("the results after the lookback period should be the unfilled results", func() {
+			Expect(indicator.Data[indicator.GetLookbackPeriod():]).To(Equal(plain.Data))
+		})
+
+		It("the result of a bar should be its value in range", func() {
+			bar := indicator.GetLookbackPeriod() + 5
+			Expect(indicator.ValuesInRange(bar, bar)).To(Equal([]float64{indicator.Data[bar-1]}))
+		})
+
+		It("the bounds should be the bounds of the unfilled results", func() {
+			Expect(indicator.MaxValue()).To(Equal(plain.MaxValue()))
+			Expect(indicator.MinValue()).To(Equal(plain.MinValue()))
+		})
+	})
+
+	It("should leave the results unfilled when not set", func() {
+		indicator.SetWarmupFill(false)
+		receiveAll()
+		Expect(indicator.Data).To(Equal(plain.Data))
+	})
+})
+
+var _ = Describe("when calculating a simple moving average (sma) with an offset", func() {
+	var (
+		period    int = 3
+		indicator *indicators.Sma
+		plain     *indicators.Sma
+	)
+
+	receiveAll := func() {
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			plain.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	}
+
+	Context("given the indicator is created with an offset above the maximum", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			_, err := indicators.NewSmaWithOffset(period, indicators.MaximumLookbackPeriod+1, gotrade.UseClosePrice)
+			Expect(err).To(MatchError(ContainSubstring(indicators.ErrStrAboveMaximum)))
+		})
+	})
+
+	Context("given the indicator is created with an offset below the minimum", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			_, err := indicators.NewSmaWithOffset(period, -indicators.MaximumLookbackPeriod-1, gotrade.UseClosePrice)
+			Expect(err).To(MatchError(ContainSubstring(indicators.ErrStrBelowMinimum)))
+		})
+	})
+
+	for _, offset := range []int{3, -2} {
+		offset := offset
+
+		Context("given the indicator is created with an offset of "+strconv.Itoa(offset)+" and has received all of its ticks", func() {
+			BeforeEach(func() {
+				indicator, _ = indicators.NewSmaWithOffset(period, offset, gotrade.UseClosePrice)
+				plain, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+				receiveAll()
+			})
+
+			It("should report the offset", func() {
+				Expect(indicator.Offset()).To(Equal(offset))
+			})
+
+			It("the results should be unchanged", func() {
+				Expect(indicator.Data).To(Equal(plain.Data))
+				Expect(indicator.MaxValue()).To(Equal(plain.MaxValue()))
+				Expect(indicator.MinValue()).To(Equal(plain.MinValue()))
+			})
+
+			It("the results should be valid from the bar shifted by the offset", func() {
+				Expect(indicator.ValidFromBar()).To(Equal(plain.ValidFromBar() + offset))
+			})
+
+			It("the result of each bar should be the unshifted result of the bar offset bars earlier", func() {
+				for bar := plain.ValidFromBar(); bar <= len(sourceDOHLCVData); bar++ {
+					Expect(indicator.ValuesInRange(bar+offset, bar+offset)).To(Equal(plain.ValuesInRange(bar, bar)))
+				}
+			})
+		})
+	}
+})
+
+var _ = Describe("when calculating a simple moving average (sma) with a bounds filter", func() {
+	var (
+		period    int = 3
+		indicator *indicators.Sma
+		plain     *indicators.Sma
+	)
+
+	// a steady series with an injected outlier in each direction
+	values := []float64{10.0, 11.0, 12.0, 1000.0, 13.0, 14.0, 15.0, 16.0, -1000.0, 17.0, 18.0, 19.0}
+
+	// the bounds of the results within the filter
+	filteredBounds := func(data []float64, min float64, max float64) (float64, float64) {
+		filteredMin, filteredMax := math.MaxFloat64, -math.MaxFloat64
+		for _, value := range data {
+			if value >= min && value <= max {
+				filteredMin = math.Min(filteredMin, value)
+				filteredMax = math.Max(filteredMax, value)
+			}
+		}
+		return filteredMin, filteredMax
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+		indicator.SetBoundsFilter(0.0, 100.0)
+		plain, _ = indicators.NewSma(period, gotrade.UseClosePrice)
+		for i := range values {
+			indicator.ReceiveTick(values[i], i+1)
+			plain.ReceiveTick(values[i], i+1)
+		}
+	})
+
+	It("the outliers should still be made available", func() {
+		Expect(indicator.Data).To(Equal(plain.Data))
+		Expect(GetFloatDataMax(indicator.Data)).To(BeNumerically(">", 100.0))
+		Expect(GetFloatDataMin(indicator.Data)).To(BeNumerically("<", 0.0))
+	})
+
+	It("the outliers should be excluded from the bounds", func() {
+		filteredMin, filteredMax := filteredBounds(indicator.Data, 0.0, 100.0)
+		Expect(indicator.MaxValue()).To(Equal(filteredMax))
+		Expect(indicator.MinValue()).To(Equal(filteredMin))
+		Expect(plain.MaxValue()).To(Equal(GetFloatDataMax(plain.Data)))
+		Expect(plain.MinValue()).To(Equal(GetFloatDataMin(plain.Data)))
+	})
+})
+
+var _ = Describe("when calculating a centered simple moving average (sma)", func() {
+	var (
+		period   int = 5
+		peakBar  int = 50
+		bars     int = 99
+		centered *indicators.Sma
+		trailing *indicators.Sma
+	)
+
+	// a series that rises to a peak at the peakBar and falls away symmetrically
+	newClose := func(bar int) gotrade.DOHLCV {
+		closePrice := 100.0 - math.Abs(float64(bar-peakBar))
+		return gotrade.NewDOHLCVDataItem(time.Now(), closePrice, closePrice, closePrice, closePrice, 0.0)
+	}
+
+	// the bar of the highest result of the indicator
+	barOfMax := func(indicator *indicators.Sma) int {
+		maxIndex := 0
+		for i := range indicator.Data {
+			if indicator.Data[i] > indicator.Data[maxIndex] {
+				maxIndex = i
+			}
+		}
+		return indicator.ValidFromBar() + maxIndex
+	}
+
+	BeforeEach(func() {
+		centered, _ = indicators.NewSmaWithCenteringWithSrcLen(uint(bars), period, true, gotrade.UseClosePrice)
+		trailing, _ = indicators.NewSmaWithCenteringWithSrcLen(uint(bars), period, false, gotrade.UseClosePrice)
+		for bar := 1; bar <= bars; bar++ {
+			centered.ReceiveDOHLCVTick(newClose(bar), bar)
+			trailing.ReceiveDOHLCVTick(newClose(bar), bar)
+		}
+	})
+
+	It("should align the highest result with the peak, unlike the trailing sma", func() {
+		Expect(barOfMax(centered)).To(Equal(peakBar))
+		Expect(barOfMax(trailing)).To(Equal(peakBar + period/2))
+	})
+
+	It("should have the results of the trailing sma shifted back by half the period", func() {
+		Expect(centered.Data).To(Equal(trailing.Data))
+		Expect(centered.ValidFromBar()).To(Equal(trailing.ValidFromBar() - period/2))
+		Expect(centered.Offset()).To(Equal(-(period / 2)))
+	})
+
+	It("should not be created for online usage", func() {
+		indicator, err := indicators.NewSmaWithCentering(period, true, gotrade.UseClosePrice)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrCenteringRequiresSourceLength))
+
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, err = indicators.NewSmaWithCenteringForStream(stream, period, true, gotrade.UseClosePrice)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrCenteringRequiresSourceLength))
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(BeNil())
+	})
+
+	It("should be created for offline usage with a source data stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, err := indicators.NewSmaWithCenteringForStreamWithSrcLen(uint(bars), stream, period, true, gotrade.UseClosePrice)
+		Expect(err).To(BeNil())
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
+
+var _ = Describe("when calculating a simple moving average (sma) with a value available action that panics", func() {
+	var (
+		period       int = 3
+		panicBar     int = 10
+		indicator    *indicators.SmaWithoutStorage
+		results      []int
+		actionErrors []error
+		errorBars    []int
+		receiveAll   func()
+	)
+
+	BeforeEach(func() {
+		results, actionErrors, errorBars = nil, nil, nil
+		indicator, _ = indicators.NewSmaWithoutStorage(period, func(dataItem float64, streamBarIndex int) {
+			if streamBarIndex == panicBar {
+				panic("consumer failed")
+			}
+			results = append(results, streamBarIndex)
+		})
+
+		receiveAll = func() {
+			for i := range sourceDOHLCVData {
+				indicator.ReceiveTick(sourceDOHLCVData[i].C(), i+1)
+			}
+		}
+	})
+
+	It("should pass the panic up to the source of the tick by default", func() {
+		Expect(receiveAll).To(Panic())
+		Expect(results).To(HaveLen(panicBar - period))
+	})
+
+	Context("and the indicator was given an action error handler", func() {
+		BeforeEach(func() {
+			indicator.SetActionErrorHandler(func(err error, barIndex int) {
+				actionErrors = append(actionErrors, err)
+				errorBars = append(errorBars, barIndex)
+			})
+			receiveAll()
+		})
+
+		It("should report the panic as an error of the bar", func() {
+			Expect(errorBars).To(Equal([]int{panicBar}))
+			Expect(actionErrors[0].Error()).To(ContainSubstring("consumer failed"))
+		})
+
+		It("should continue to process the ticks after the panic", func() {
+			// every result but that of the bar that panicked
+			Expect(results).To(HaveLen(len(sourceDOHLCVData) - indicator.GetLookbackPeriod() - 1))
+			Expect(results[len(results)-1]).To(Equal(len(sourceDOHLCVData)))
+			Expect(results).NotTo(ContainElement(panicBar))
+			Expect(indicator.Length()).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+		})
+	})
+})
@@ -0,0 +1,173 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+	"strconv"
+)
+
+var (
+	ErrSmaFanHasNoTimePeriods = errors.New("A SmaFan requires at least one time period")
+)
+
+// A Simple Moving Average Fan Indicator (SmaFan), no storage, for use in other indicators
+// the smas of each of the time periods are made available together for each bar once the sma of the shortest
+// time period is valid, in the order of the time periods. The value of an sma that is not yet valid is a NaN, so
+// that each series becomes valid at the bar of its own lookback period, SeriesValidFromBar
+type SmaFanWithoutStorage struct {
+	*baseIndicatorWithFloatBoundsMulti
+
+	// private variables
+	smas               []*SmaWithoutStorage
+	timePeriods        []int
+	currentValues      []float64
+	seriesValidFromBar []int
+}
+
+// NewSmaFanWithoutStorage creates a Simple Moving Average Fan Indicator (SmaFan) without storage
+func NewSmaFanWithoutStorage(timePeriods []int, valueAvailableAction ValueAvailableActionMulti) (indicator *SmaFanWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	if len(timePeriods) == 0 {
+		return nil, ErrSmaFanHasNoTimePeriods
+	}
+
+	ind := SmaFanWithoutStorage{
+		smas:               make([]*SmaWithoutStorage, len(timePeriods)),
+		timePeriods:        append([]int{}, timePeriods...),
+		currentValues:      make([]float64, len(timePeriods)),
+		seriesValidFromBar: make([]int, len(timePeriods)),
+	}
+
+	lookback := MaximumLookbackPeriod
+	for i := range timePeriods {
+		ind.currentValues[i] = math.NaN()
+		ind.seriesValidFromBar[i] = -1
+
+		// each sma updates its own slot of the current values
+		seriesIndex := i
+		ind.smas[i], err = NewSmaWithoutStorage(timePeriods[i], func(dataItem float64, streamBarIndex int) {
+			ind.currentValues[seriesIndex] = dataItem
+			if ind.seriesValidFromBar[seriesIndex] == -1 {
+				ind.seriesValidFromBar[seriesIndex] = streamBarIndex
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if ind.smas[i].GetLookbackPeriod() < lookback {
+			lookback = ind.smas[i].GetLookbackPeriod()
+		}
+	}
+
+	ind.baseIndicatorWithFloatBoundsMulti = newBaseIndicatorWithFloatBoundsMulti(lookback, valueAvailableAction)
+
+	return &ind, nil
+}
+
+// TimePeriods returns the time periods of the smas, in the order of their series
+func (ind *SmaFanWithoutStorage) TimePeriods() []int {
+	return append([]int{}, ind.timePeriods...)
+}
+
+// SeriesValidFromBar returns the stream bar index from which the series of the sma at seriesIndex is valid, -1
+// while it is not yet valid
+func (ind *SmaFanWithoutStorage) SeriesValidFromBar(seriesIndex int) int {
+	return ind.seriesValidFromBar[seriesIndex]
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *SmaFanWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	hasValue := false
+	for i := range ind.smas {
+		ind.smas[i].ReceiveTick(tickData, streamBarIndex)
+		hasValue = hasValue || ind.smas[i].Length() > 0
+	}
+
+	// the results are available once the sma of the shortest time period has a value
+	if hasValue {
+		ind.UpdateIndicatorWithNewValue(ind.currentValues, streamBarIndex)
+	}
+}
+
+// A Simple Moving Average Fan Indicator (SmaFan)
+type SmaFan struct {
+	*SmaFanWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	// the results of each sma, in the order of the time periods
+	Series [][]float64
+}
+
+// NewSmaFan creates a Simple Moving Average Fan Indicator (SmaFan) for online usage
+func NewSmaFan(timePeriods []int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SmaFan, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := SmaFan{
+		selectData: selectData,
+		Series:     make([][]float64, len(timePeriods)),
+	}
+
+	ind.SmaFanWithoutStorage, err = NewSmaFanWithoutStorage(timePeriods,
+		func(dataItems []float64, streamBarIndex int) {
+			for i := range dataItems {
+				ind.Series[i] = append(ind.Series[i], dataItems[i])
+			}
+		})
+
+	return &ind, err
+}
+
+// NewSmaFanWithSrcLen creates a Simple Moving Average Fan Indicator (SmaFan) for offline usage
+func NewSmaFanWithSrcLen(sourceLength uint, timePeriods []int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SmaFan, err error) {
+	ind, err := NewSmaFan(timePeriods, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if err == nil && sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		for i := range ind.Series {
+			ind.Series[i] = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		}
+	}
+
+	return ind, err
+}
+
+// NewSmaFanForStream creates a Simple Moving Average Fan Indicator (SmaFan) for online usage with a source data stream
+func NewSmaFanForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriods []int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SmaFan, err error) {
+	ind, err := NewSmaFan(timePeriods, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewSmaFanForStreamWithSrcLen creates a Simple Moving Average Fan Indicator (SmaFan) for offline usage with a source data stream
+func NewSmaFanForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriods []int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SmaFan, err error) {
+	ind, err := NewSmaFanWithSrcLen(sourceLength, timePeriods, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *SmaFan) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// WriteCSV writes the SmaFan results as rows after a header of barIndex followed by an smaN column for each time
+// period, the bar index of each result is its stream bar index plus the startBarOffset
+func (ind *SmaFan) WriteCSV(w io.Writer, startBarOffset int) error {
+	columns := make([]csvColumn, len(ind.timePeriods))
+	for i := range ind.timePeriods {
+		columns[i] = floatCSVColumn("sma"+strconv.Itoa(ind.timePeriods[i]), ind.Series[i])
+	}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), columns...)
+}
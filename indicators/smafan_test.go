@@ -0,0 +1,117 @@
+package indicators_test
+
+import (
+	"bytes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"strings"
+)
+
+var _ = Describe("when creating a smafanwithoutstorage", func() {
+	fakeAction := func(dataItems []float64, streamBarIndex int) {}
+
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewSmaFanWithoutStorage([]int{5, 10, 20}, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created without any time periods", func() {
+		indicator, err := indicators.NewSmaFanWithoutStorage([]int{}, fakeAction)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrSmaFanHasNoTimePeriods))
+	})
+
+	It("the indicator should not be created with a time period below the minimum", func() {
+		indicator, err := indicators.NewSmaFanWithoutStorage([]int{5, 1, 20}, fakeAction)
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+	})
+
+	It("the indicator should not be created with a nil data selection func", func() {
+		indicator, err := indicators.NewSmaFan([]int{5, 10, 20}, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+})
+
+var _ = Describe("when calculating a simple moving average fan (smafan) with DOHLCV source data", func() {
+	var (
+		timePeriods = []int{5, 10, 20}
+		indicator   *indicators.SmaFan
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSmaFanWithSrcLen(uint(len(sourceDOHLCVData)), timePeriods, gotrade.UseClosePrice)
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("the lookback period should be that of the shortest sma", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(4))
+		Expect(indicator.ValidFromBar()).To(Equal(5))
+		Expect(indicator.TimePeriods()).To(Equal(timePeriods))
+	})
+
+	It("should have a series of the same length for each time period", func() {
+		Expect(indicator.Series).To(HaveLen(3))
+		for i := range indicator.Series {
+			Expect(indicator.Series[i]).To(HaveLen(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+		}
+		Expect(cap(indicator.Series[0])).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+	})
+
+	It("each series should be valid from the bar of its own time period", func() {
+		for i := range timePeriods {
+			Expect(indicator.SeriesValidFromBar(i)).To(Equal(timePeriods[i]))
+
+			// the values before the series is valid are NaNs
+			firstValid := indicator.SeriesValidFromBar(i) - indicator.ValidFromBar()
+			for j := 0; j < firstValid; j++ {
+				Expect(math.IsNaN(indicator.Series[i][j])).To(BeTrue())
+			}
+			Expect(math.IsNaN(indicator.Series[i][firstValid])).To(BeFalse())
+		}
+	})
+
+	It("each series should equal the sma of its time period", func() {
+		for i := range timePeriods {
+			sma, _ := indicators.NewSma(timePeriods[i], gotrade.UseClosePrice)
+			for j := range sourceDOHLCVData {
+				sma.ReceiveDOHLCVTick(sourceDOHLCVData[j], j+1)
+			}
+
+			firstValid := indicator.SeriesValidFromBar(i) - indicator.ValidFromBar()
+			Expect(indicator.Series[i][firstValid:]).To(Equal(sma.Data))
+		}
+	})
+
+	It("should have the bounds of the valid values of every series", func() {
+		max, min := -math.MaxFloat64, math.MaxFloat64
+		for i := range indicator.Series {
+			firstValid := indicator.SeriesValidFromBar(i) - indicator.ValidFromBar()
+			max = math.Max(max, GetFloatDataMax(indicator.Series[i][firstValid:]))
+			min = math.Min(min, GetFloatDataMin(indicator.Series[i][firstValid:]))
+		}
+		Expect(indicator.MaxValue()).To(Equal(max))
+		Expect(indicator.MinValue()).To(Equal(min))
+	})
+
+	It("should write a column for each time period", func() {
+		var buffer bytes.Buffer
+		Expect(indicator.WriteCSV(&buffer, 0)).To(BeNil())
+		Expect(strings.SplitN(buffer.String(), "\n", 2)[0]).To(Equal("barIndex,sma5,sma10,sma20"))
+	})
+})
+
+var _ = Describe("when creating a smafan for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewSmaFanForStream(stream, []int{5, 10, 20}, gotrade.UseClosePrice)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
@@ -0,0 +1,139 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+type ValueAvailableActionSmiErgodic func(dataItemSmi float64, dataItemSignal float64, streamBarIndex int)
+
+// An SMI Ergodic Indicator (SmiErgodic), no storage, for use in other indicators
+// the Tsi of the price, the change in price double smoothed by an Ema of the longPeriod and then an Ema of the
+// shortPeriod as a percentage of the absolute change in price smoothed the same way, as the smi with its signal line
+// of an Ema of the signalPeriod of the smi. The smi is bounded by -100.0 and 100.0 and is 0.0 while the smoothed
+// absolute change is 0
+type SmiErgodicWithoutStorage struct {
+	*TsiWithoutStorage
+}
+
+// NewSmiErgodicWithoutStorage creates an SMI Ergodic Indicator (SmiErgodic) without storage
+func NewSmiErgodicWithoutStorage(longPeriod int, shortPeriod int, signalPeriod int, valueAvailableAction ValueAvailableActionSmiErgodic) (indicator *SmiErgodicWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	tsi, err := NewTsiWithoutStorage(longPeriod, shortPeriod, signalPeriod, ValueAvailableActionTsi(valueAvailableAction))
+	if err != nil {
+		return nil, err
+	}
+
+	return &SmiErgodicWithoutStorage{TsiWithoutStorage: tsi}, nil
+}
+
+// An SMI Ergodic Indicator (SmiErgodic)
+type SmiErgodic struct {
+	*SmiErgodicWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Smi    []float64
+	Signal []float64
+}
+
+// NewSmiErgodic creates an SMI Ergodic Indicator (SmiErgodic) for online usage
+func NewSmiErgodic(longPeriod int, shortPeriod int, signalPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SmiErgodic, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := SmiErgodic{
+		selectData: selectData,
+	}
+
+	ind.SmiErgodicWithoutStorage, err = NewSmiErgodicWithoutStorage(longPeriod, shortPeriod, signalPeriod,
+		func(dataItemSmi float64, dataItemSignal float64, streamBarIndex int) {
+			ind.Smi = append(ind.Smi, dataItemSmi)
+			ind.Signal = append(ind.Signal, dataItemSignal)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultSmiErgodic creates an SMI Ergodic Indicator (SmiErgodic) for online usage with default parameters
+//	- longPeriod: 20
+//	- shortPeriod: 5
+//	- signalPeriod: 5
+func NewDefaultSmiErgodic() (indicator *SmiErgodic, err error) {
+	longPeriod := 20
+	shortPeriod := 5
+	signalPeriod := 5
+	return NewSmiErgodic(longPeriod, shortPeriod, signalPeriod, gotrade.UseClosePrice)
+}
+
+// NewSmiErgodicWithSrcLen creates an SMI Ergodic Indicator (SmiErgodic) for offline usage
+func NewSmiErgodicWithSrcLen(sourceLength uint, longPeriod int, shortPeriod int, signalPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SmiErgodic, err error) {
+	ind, err := NewSmiErgodic(longPeriod, shortPeriod, signalPeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Smi = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Signal = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultSmiErgodicWithSrcLen creates an SMI Ergodic Indicator (SmiErgodic) for offline usage with default parameters
+func NewDefaultSmiErgodicWithSrcLen(sourceLength uint) (indicator *SmiErgodic, err error) {
+	ind, err := NewDefaultSmiErgodic()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Smi = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Signal = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewSmiErgodicForStream creates an SMI Ergodic Indicator (SmiErgodic) for online usage with a source data stream
+func NewSmiErgodicForStream(priceStream gotrade.DOHLCVStreamSubscriber, longPeriod int, shortPeriod int, signalPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SmiErgodic, err error) {
+	ind, err := NewSmiErgodic(longPeriod, shortPeriod, signalPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultSmiErgodicForStream creates an SMI Ergodic Indicator (SmiErgodic) for online usage with a source data stream
+func NewDefaultSmiErgodicForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *SmiErgodic, err error) {
+	ind, err := NewDefaultSmiErgodic()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewSmiErgodicForStreamWithSrcLen creates an SMI Ergodic Indicator (SmiErgodic) for offline usage with a source data stream
+func NewSmiErgodicForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, longPeriod int, shortPeriod int, signalPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SmiErgodic, err error) {
+	ind, err := NewSmiErgodicWithSrcLen(sourceLength, longPeriod, shortPeriod, signalPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultSmiErgodicForStreamWithSrcLen creates an SMI Ergodic Indicator (SmiErgodic) for offline usage with a source data stream
+func NewDefaultSmiErgodicForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *SmiErgodic, err error) {
+	ind, err := NewDefaultSmiErgodicWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *SmiErgodic) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// WriteCSV writes the SmiErgodic results as rows after a header of barIndex,smi,signal, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *SmiErgodic) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("smi", ind.Smi), floatCSVColumn("signal", ind.Signal))
+}
@@ -0,0 +1,158 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var _ = Describe("when creating a smiergodicwithoutstorage", func() {
+	var (
+		indicator      *indicators.SmiErgodicWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemSmi float64, dataItemSignal float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewSmiErgodicWithoutStorage(20, 5, 5, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a longPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewSmiErgodicWithoutStorage(1, 5, 5, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a shortPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewSmiErgodicWithoutStorage(20, 1, 5, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a signalPeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewSmiErgodicWithoutStorage(20, 5, indicators.MaximumLookbackPeriod+1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+
+	Context("and the indicator was not given a data selection func", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			indicator, err := indicators.NewSmiErgodic(20, 5, 5, nil)
+			Expect(indicator).To(BeNil())
+			Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating an smi ergodic (smiergodic) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.SmiErgodic
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultSmiErgodicWithSrcLen(uint(len(sourceDOHLCVData)))
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should have a result once the signal is available", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(1 + 19 + 4 + 4))
+		Expect(indicator.ValidFromBar()).To(Equal(29))
+		Expect(indicator.Smi).To(HaveLen(len(sourceDOHLCVData) - 28))
+		Expect(indicator.Signal).To(HaveLen(len(sourceDOHLCVData) - 28))
+	})
+
+	It("should be bounded by -100 and 100", func() {
+		for i := range indicator.Smi {
+			Expect(math.Abs(indicator.Smi[i])).To(BeNumerically("<=", 100.0))
+			Expect(math.Abs(indicator.Signal[i])).To(BeNumerically("<=", 100.0))
+		}
+		Expect(indicator.MaxValue()).To(Equal(math.Max(GetFloatDataMax(indicator.Smi), GetFloatDataMax(indicator.Signal))))
+		Expect(indicator.MinValue()).To(Equal(math.Min(GetFloatDataMin(indicator.Smi), GetFloatDataMin(indicator.Signal))))
+	})
+
+	It("the signal should be an ema of the smi", func() {
+		multiplier := 2.0 / 6.0
+		for i := 1; i < len(indicator.Signal); i++ {
+			expected := indicator.Signal[i-1] + multiplier*(indicator.Smi[i]-indicator.Signal[i-1])
+			Expect(indicator.Signal[i]).To(BeNumerically("~", expected, 0.0000001))
+		}
+	})
+})
+
+var _ = Describe("when calculating an smi ergodic (smiergodic) of a fall and then a rise", func() {
+	It("the signal should lag the smi", func() {
+		indicator, _ := indicators.NewDefaultSmiErgodic()
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		price := 100.0
+		for i := 0; i < 80; i++ {
+			if i < 40 {
+				price -= 1.0
+			} else {
+				price += 1.0
+			}
+			indicator.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price, price, price, 1000.0), i+1)
+		}
+
+		firstPositive := func(values []float64) int {
+			for i := range values {
+				if values[i] > 0.0 {
+					return i
+				}
+			}
+			return -1
+		}
+
+		// the smi turns up from the bottom of the fall before the signal
+		Expect(indicator.Smi[0]).To(Equal(-100.0))
+		Expect(firstPositive(indicator.Smi)).To(BeNumerically(">", 0))
+		Expect(firstPositive(indicator.Signal)).To(BeNumerically(">", firstPositive(indicator.Smi)))
+	})
+
+	It("should be 0 while the price is unchanged", func() {
+		indicator, _ := indicators.NewDefaultSmiErgodic()
+		for i := 0; i < 40; i++ {
+			indicator.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(time.Time{}, 50.0, 50.0, 50.0, 50.0, 1000.0), i+1)
+		}
+
+		Expect(indicator.Smi).NotTo(BeEmpty())
+		for i := range indicator.Smi {
+			Expect(indicator.Smi[i]).To(Equal(0.0))
+			Expect(indicator.Signal[i]).To(Equal(0.0))
+		}
+	})
+})
+
+var _ = Describe("when creating an smiergodic for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewDefaultSmiErgodicForStream(stream)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
@@ -0,0 +1,165 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+)
+
+var (
+	ErrSnapshotOutputNameIsDuplicate = errors.New("A snapshot output with the same name has already been added")
+)
+
+// A SnapshotRow is the values of the named outputs of a SnapshotCollector for a bar. The outputs are in the
+// order they were added, an output that did not report for the bar, such as one still in its lookback
+// period, is marked as not valid and has a value of 0.0
+type SnapshotRow struct {
+	StreamBarIndex int
+	Names          []string
+	Values         []float64
+	IsValid        []bool
+}
+
+// Value returns the value of the named output and whether it is valid for the bar
+func (row SnapshotRow) Value(name string) (value float64, isValid bool) {
+	for i := range row.Names {
+		if row.Names[i] == name {
+			return row.Values[i], row.IsValid[i]
+		}
+	}
+	return 0.0, false
+}
+
+type ValueAvailableActionSnapshot func(row SnapshotRow, streamBarIndex int)
+
+// snapshotReceiver feeds the source data ticks of a float indicator added to a snapshot collector
+type snapshotReceiver struct {
+	indicator  FloatIndicatorWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (receiver *snapshotReceiver) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	receiver.indicator.ReceiveTick(receiver.selectData(tickData), streamBarIndex)
+}
+
+// A Snapshot Collector (SnapshotCollector), no storage
+// assembles the current values of many named indicator outputs into a single row per bar. The collector feeds
+// each source data tick to its indicators in the order they were added, so that every indicator has reported
+// for the bar, and then makes the row available. The indicators should be fed only through the collector
+type SnapshotCollectorWithoutStorage struct {
+	// private variables
+	valueAvailableAction ValueAvailableActionSnapshot
+	receivers            []gotrade.DOHLCVTickReceiver
+	names                []string
+	currentValues        []float64
+	currentIsValid       []bool
+}
+
+// NewSnapshotCollectorWithoutStorage creates a Snapshot Collector (SnapshotCollector) without storage
+func NewSnapshotCollectorWithoutStorage(valueAvailableAction ValueAvailableActionSnapshot) (collector *SnapshotCollectorWithoutStorage, err error) {
+
+	// a collector without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	ind := SnapshotCollectorWithoutStorage{
+		valueAvailableAction: valueAvailableAction,
+	}
+
+	return &ind, nil
+}
+
+// Output adds a named output to the rows, returning the value available action through which an indicator
+// reports the output, e.g. one band of a BollingerBandsWithoutStorage. The indicator must also be added with
+// AddTickReceiver so that the collector can feed it
+func (ind *SnapshotCollectorWithoutStorage) Output(name string) (valueAvailableAction ValueAvailableActionFloat, err error) {
+	for i := range ind.names {
+		if ind.names[i] == name {
+			return nil, ErrSnapshotOutputNameIsDuplicate
+		}
+	}
+
+	outputIndex := len(ind.names)
+	ind.names = append(ind.names, name)
+	ind.currentValues = append(ind.currentValues, 0.0)
+	ind.currentIsValid = append(ind.currentIsValid, false)
+
+	return func(dataItem float64, streamBarIndex int) {
+		ind.currentValues[outputIndex] = dataItem
+		ind.currentIsValid[outputIndex] = true
+	}, nil
+}
+
+// AddTickReceiver adds an indicator that the collector feeds with each source data tick
+func (ind *SnapshotCollectorWithoutStorage) AddTickReceiver(receiver gotrade.DOHLCVTickReceiver) {
+	ind.receivers = append(ind.receivers, receiver)
+}
+
+// AddIndicator adds a float indicator, created by the stage, as a named output fed with the selected source data
+func (ind *SnapshotCollectorWithoutStorage) AddIndicator(name string, selectData gotrade.DOHLCVDataSelectionFunc, stage ChainStage) (indicator FloatIndicatorWithoutStorage, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	valueAvailableAction, err := ind.Output(name)
+	if err != nil {
+		return nil, err
+	}
+
+	indicator, err = stage(valueAvailableAction)
+	if err != nil {
+		return nil, err
+	}
+
+	ind.AddTickReceiver(&snapshotReceiver{indicator: indicator, selectData: selectData})
+	return indicator, nil
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *SnapshotCollectorWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	for i := range ind.currentIsValid {
+		ind.currentValues[i] = 0.0
+		ind.currentIsValid[i] = false
+	}
+
+	for i := range ind.receivers {
+		ind.receivers[i].ReceiveDOHLCVTick(tickData, streamBarIndex)
+	}
+
+	row := SnapshotRow{
+		StreamBarIndex: streamBarIndex,
+		Names:          ind.names,
+		Values:         make([]float64, len(ind.currentValues)),
+		IsValid:        make([]bool, len(ind.currentIsValid)),
+	}
+	copy(row.Values, ind.currentValues)
+	copy(row.IsValid, ind.currentIsValid)
+
+	ind.valueAvailableAction(row, streamBarIndex)
+}
+
+// A Snapshot Collector (SnapshotCollector)
+type SnapshotCollector struct {
+	*SnapshotCollectorWithoutStorage
+
+	// public variables
+	Rows []SnapshotRow
+}
+
+// NewSnapshotCollector creates a Snapshot Collector (SnapshotCollector) for online usage
+func NewSnapshotCollector() (collector *SnapshotCollector, err error) {
+	ind := SnapshotCollector{}
+	ind.SnapshotCollectorWithoutStorage, err = NewSnapshotCollectorWithoutStorage(func(row SnapshotRow, streamBarIndex int) {
+		ind.Rows = append(ind.Rows, row)
+	})
+
+	return &ind, err
+}
+
+// NewSnapshotCollectorForStream creates a Snapshot Collector (SnapshotCollector) for online usage with a source data stream
+func NewSnapshotCollectorForStream(priceStream gotrade.DOHLCVStreamSubscriber) (collector *SnapshotCollector, err error) {
+	ind, err := NewSnapshotCollector()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
@@ -0,0 +1,99 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when creating a snapshotcollectorwithoutstorage", func() {
+	Context("and the collector was not given a value available action", func() {
+		It("the collector should not be created and return the appropriate error message", func() {
+			collector, err := indicators.NewSnapshotCollectorWithoutStorage(nil)
+			Expect(collector).To(BeNil())
+			Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+})
+
+var _ = Describe("when collecting snapshots of many indicators with DOHLCV source data", func() {
+	var (
+		collector *indicators.SnapshotCollector
+		stream    *fakeDOHLCVStreamSubscriber
+		sma       *indicators.Sma
+		ema       *indicators.Ema
+		rsi       *indicators.Rsi
+	)
+
+	BeforeEach(func() {
+		stream = newFakeDOHLCVStreamSubscriber()
+		collector, _ = indicators.NewSnapshotCollectorForStream(stream)
+		collector.AddIndicator("sma", gotrade.UseClosePrice, func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+			return indicators.NewSmaWithoutStorage(5, valueAvailableAction)
+		})
+		collector.AddIndicator("ema", gotrade.UseClosePrice, func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+			return indicators.NewEmaWithoutStorage(10, valueAvailableAction)
+		})
+		collector.AddIndicator("rsi", gotrade.UseClosePrice, func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+			return indicators.NewRsiWithoutStorage(14, valueAvailableAction)
+		})
+
+		sma, _ = indicators.NewSma(5, gotrade.UseClosePrice)
+		ema, _ = indicators.NewEma(10, gotrade.UseClosePrice)
+		rsi, _ = indicators.NewRsi(14, gotrade.UseClosePrice)
+		for i := range sourceDOHLCVData {
+			collector.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			sma.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			ema.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			rsi.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should have requested to be attached to the stream", func() {
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(collector))
+	})
+
+	It("should produce a row for each bar with the outputs in the order they were added", func() {
+		Expect(collector.Rows).To(HaveLen(len(sourceDOHLCVData)))
+		for i := range collector.Rows {
+			Expect(collector.Rows[i].StreamBarIndex).To(Equal(i + 1))
+			Expect(collector.Rows[i].Names).To(Equal([]string{"sma", "ema", "rsi"}))
+		}
+	})
+
+	It("the outputs should be marked as not valid until the end of their lookback periods", func() {
+		for _, row := range collector.Rows {
+			_, smaIsValid := row.Value("sma")
+			_, emaIsValid := row.Value("ema")
+			_, rsiIsValid := row.Value("rsi")
+			Expect(smaIsValid).To(Equal(row.StreamBarIndex >= sma.ValidFromBar()))
+			Expect(emaIsValid).To(Equal(row.StreamBarIndex >= ema.ValidFromBar()))
+			Expect(rsiIsValid).To(Equal(row.StreamBarIndex >= rsi.ValidFromBar()))
+		}
+	})
+
+	It("the valid outputs should be aligned with the results of each indicator", func() {
+		for _, row := range collector.Rows {
+			if value, isValid := row.Value("sma"); isValid {
+				Expect(value).To(Equal(sma.Data[row.StreamBarIndex-sma.ValidFromBar()]))
+			}
+			if value, isValid := row.Value("ema"); isValid {
+				Expect(value).To(Equal(ema.Data[row.StreamBarIndex-ema.ValidFromBar()]))
+			}
+			if value, isValid := row.Value("rsi"); isValid {
+				Expect(value).To(Equal(rsi.Data[row.StreamBarIndex-rsi.ValidFromBar()]))
+			}
+		}
+	})
+
+	It("an output that was not added should not be valid", func() {
+		_, isValid := collector.Rows[len(collector.Rows)-1].Value("wma")
+		Expect(isValid).To(BeFalse())
+	})
+
+	It("adding an output with a duplicate name should return the appropriate error", func() {
+		_, err := collector.Output("sma")
+		Expect(err).To(Equal(indicators.ErrSnapshotOutputNameIsDuplicate))
+	})
+})
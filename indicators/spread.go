@@ -0,0 +1,283 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// a pair of aligned leg values in the regression period of a spread
+type spreadPair struct {
+	a float64
+	b float64
+}
+
+// A Spread Indicator (Spread), no storage, for use in other indicators
+// the spread between two legs, a - hedgeRatio * b, for each bar received by both legs. The ticks of the
+// legs are aligned by their streamBarIndex, so either leg may lag the other. The hedge ratio is either fixed,
+// or estimated as the regression slope of a on b over the regressionPeriod, the previous hedge ratio being
+// kept while b is flat over the period
+type SpreadWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	hedgeRatio       float64
+	intercept        float64
+	regressionPeriod int
+	pendingA         map[int]float64
+	pendingB         map[int]float64
+	periodHistory    *list.List
+	periodTotalA     float64
+	periodTotalB     float64
+	periodTotalBB    float64
+	periodTotalAB    float64
+}
+
+// NewSpreadWithoutStorage creates a Spread Indicator (Spread) without storage with a fixed hedge ratio
+func NewSpreadWithoutStorage(hedgeRatio float64, valueAvailableAction ValueAvailableActionFloat) (indicator *SpreadWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	return newSpreadWithoutStorage(hedgeRatio, 0, valueAvailableAction), nil
+}
+
+// NewRegressionSpreadWithoutStorage creates a Spread Indicator (Spread) without storage with a hedge ratio
+// estimated by a rolling regression over the regressionPeriod
+func NewRegressionSpreadWithoutStorage(regressionPeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *SpreadWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum regressionPeriod for this indicator is 2
+	if regressionPeriod < 2 {
+		return nil, errors.New("regressionPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum regressionPeriod
+	if regressionPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("regressionPeriod is greater than the maximum (100000)")
+	}
+
+	return newSpreadWithoutStorage(0.0, regressionPeriod, valueAvailableAction), nil
+}
+
+func newSpreadWithoutStorage(hedgeRatio float64, regressionPeriod int, valueAvailableAction ValueAvailableActionFloat) *SpreadWithoutStorage {
+	lookback := 0
+	if regressionPeriod > 0 {
+		lookback = regressionPeriod - 1
+	}
+
+	ind := SpreadWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		hedgeRatio:                   hedgeRatio,
+		regressionPeriod:             regressionPeriod,
+		pendingA:                     make(map[int]float64),
+		pendingB:                     make(map[int]float64),
+		periodHistory:                list.New(),
+	}
+
+	return &ind
+}
+
+// HedgeRatio returns the hedge ratio applied to the last spread
+func (ind *SpreadWithoutStorage) HedgeRatio() float64 {
+	return ind.hedgeRatio
+}
+
+// Intercept returns the intercept of the regression of a on b for the last spread, mean a - hedgeRatio * mean b,
+// which is 0.0 for a fixed hedge ratio
+func (ind *SpreadWithoutStorage) Intercept() float64 {
+	return ind.intercept
+}
+
+// ReceiveTickA consumes a source data float price tick of leg a
+func (ind *SpreadWithoutStorage) ReceiveTickA(tickData float64, streamBarIndex int) {
+	if b, ok := ind.pendingB[streamBarIndex]; ok {
+		ind.receivePair(tickData, b, streamBarIndex)
+		return
+	}
+	ind.pendingA[streamBarIndex] = tickData
+}
+
+// ReceiveTickB consumes a source data float price tick of leg b
+func (ind *SpreadWithoutStorage) ReceiveTickB(tickData float64, streamBarIndex int) {
+	if a, ok := ind.pendingA[streamBarIndex]; ok {
+		ind.receivePair(a, tickData, streamBarIndex)
+		return
+	}
+	ind.pendingB[streamBarIndex] = tickData
+}
+
+func (ind *SpreadWithoutStorage) receivePair(a float64, b float64, streamBarIndex int) {
+	// any bar pending from before this bar was missed by the other leg and can never be aligned
+	for barIndex := range ind.pendingA {
+		if barIndex <= streamBarIndex {
+			delete(ind.pendingA, barIndex)
+		}
+	}
+	for barIndex := range ind.pendingB {
+		if barIndex <= streamBarIndex {
+			delete(ind.pendingB, barIndex)
+		}
+	}
+
+	if ind.regressionPeriod > 0 {
+		if !ind.updateRegression(a, b) {
+			return
+		}
+	}
+
+	result := a - ind.hedgeRatio*b
+
+	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+}
+
+// updateRegression adds the pair to the regression period, returning true once the period is full
+func (ind *SpreadWithoutStorage) updateRegression(a float64, b float64) bool {
+	ind.periodHistory.PushBack(spreadPair{a: a, b: b})
+	ind.periodTotalA += a
+	ind.periodTotalB += b
+	ind.periodTotalBB += b * b
+	ind.periodTotalAB += a * b
+
+	if ind.periodHistory.Len() > ind.regressionPeriod {
+		var first = ind.periodHistory.Front()
+		var pair = first.Value.(spreadPair)
+		ind.periodTotalA -= pair.a
+		ind.periodTotalB -= pair.b
+		ind.periodTotalBB -= pair.b * pair.b
+		ind.periodTotalAB -= pair.a * pair.b
+		ind.periodHistory.Remove(first)
+	}
+
+	if ind.periodHistory.Len() < ind.regressionPeriod {
+		return false
+	}
+
+	n := float64(ind.regressionPeriod)
+	covariance := ind.periodTotalAB/n - (ind.periodTotalA/n)*(ind.periodTotalB/n)
+	varianceB := ind.periodTotalBB/n - (ind.periodTotalB/n)*(ind.periodTotalB/n)
+	if varianceB > 0.0 {
+		ind.hedgeRatio = covariance / varianceB
+		ind.intercept = ind.periodTotalA/n - ind.hedgeRatio*ind.periodTotalB/n
+	}
+
+	return true
+}
+
+// spreadLeg receives the source data DOHLCV price ticks of one leg of a spread
+type spreadLeg struct {
+	receiveTick func(tickData float64, streamBarIndex int)
+	selectData  gotrade.DOHLCVDataSelectionFunc
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (leg *spreadLeg) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	leg.receiveTick(leg.selectData(tickData), streamBarIndex)
+}
+
+// A Spread Indicator (Spread)
+type Spread struct {
+	*SpreadWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+	legA       *spreadLeg
+	legB       *spreadLeg
+
+	// public variables
+	Data []float64
+}
+
+// NewSpread creates a Spread Indicator (Spread) for online usage with a fixed hedge ratio
+func NewSpread(hedgeRatio float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Spread, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := newSpread(selectData)
+	ind.SpreadWithoutStorage, err = NewSpreadWithoutStorage(hedgeRatio,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return ind, err
+}
+
+// NewRegressionSpread creates a Spread Indicator (Spread) for online usage with a hedge ratio
+// estimated by a rolling regression over the regressionPeriod
+func NewRegressionSpread(regressionPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Spread, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := newSpread(selectData)
+	ind.SpreadWithoutStorage, err = NewRegressionSpreadWithoutStorage(regressionPeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return ind, err
+}
+
+func newSpread(selectData gotrade.DOHLCVDataSelectionFunc) *Spread {
+	ind := Spread{
+		selectData: selectData,
+	}
+	ind.legA = &spreadLeg{selectData: selectData, receiveTick: func(tickData float64, streamBarIndex int) {
+		ind.ReceiveTickA(tickData, streamBarIndex)
+	}}
+	ind.legB = &spreadLeg{selectData: selectData, receiveTick: func(tickData float64, streamBarIndex int) {
+		ind.ReceiveTickB(tickData, streamBarIndex)
+	}}
+
+	return &ind
+}
+
+// NewSpreadForStreams creates a Spread Indicator (Spread) for online usage with a source data stream for each leg
+func NewSpreadForStreams(priceStreamA gotrade.DOHLCVStreamSubscriber, priceStreamB gotrade.DOHLCVStreamSubscriber, hedgeRatio float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Spread, err error) {
+	ind, err := NewSpread(hedgeRatio, selectData)
+	if err != nil {
+		return ind, err
+	}
+	priceStreamA.AddTickSubscription(ind.LegA())
+	priceStreamB.AddTickSubscription(ind.LegB())
+	return ind, err
+}
+
+// NewRegressionSpreadForStreams creates a Spread Indicator (Spread) for online usage with a source data stream for each leg
+func NewRegressionSpreadForStreams(priceStreamA gotrade.DOHLCVStreamSubscriber, priceStreamB gotrade.DOHLCVStreamSubscriber, regressionPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Spread, err error) {
+	ind, err := NewRegressionSpread(regressionPeriod, selectData)
+	if err != nil {
+		return ind, err
+	}
+	priceStreamA.AddTickSubscription(ind.LegA())
+	priceStreamB.AddTickSubscription(ind.LegB())
+	return ind, err
+}
+
+// LegA returns the receiver of the source data DOHLCV price ticks of leg a
+func (ind *Spread) LegA() gotrade.DOHLCVTickReceiver {
+	return ind.legA
+}
+
+// LegB returns the receiver of the source data DOHLCV price ticks of leg b
+func (ind *Spread) LegB() gotrade.DOHLCVTickReceiver {
+	return ind.legB
+}
+
+// ValuesInRange returns the Spread results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Spread) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Spread results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Spread) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
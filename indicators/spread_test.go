@@ -0,0 +1,180 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a spreadwithoutstorage", func() {
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewSpreadWithoutStorage(1.0, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+
+		indicator, err = indicators.NewRegressionSpreadWithoutStorage(5, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created with a regressionPeriod below the minimum", func() {
+		indicator, err := indicators.NewRegressionSpreadWithoutStorage(1, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("the indicator should not be created with a regressionPeriod above the maximum", func() {
+		indicator, err := indicators.NewRegressionSpreadWithoutStorage(indicators.MaximumLookbackPeriod+1, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("the indicator should not be created with a nil data selection func", func() {
+		indicator, err := indicators.NewSpread(1.0, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+})
+
+var _ = Describe("when calculating a spread with a fixed hedge ratio", func() {
+	var (
+		indicator  *indicators.Spread
+		legA       []gotrade.DOHLCV
+		legB       []gotrade.DOHLCV
+		newClose   func(closePrice float64) gotrade.DOHLCV
+		resultBars []int
+	)
+
+	newClose = func(closePrice float64) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(time.Now(), closePrice, closePrice, closePrice, closePrice, 0.0)
+	}
+
+	BeforeEach(func() {
+		legA = []gotrade.DOHLCV{newClose(10.0), newClose(11.0), newClose(13.0), newClose(12.0), newClose(15.0)}
+		legB = []gotrade.DOHLCV{newClose(5.0), newClose(5.0), newClose(6.0), newClose(6.5), newClose(7.0)}
+		indicator, _ = indicators.NewSpread(2.0, gotrade.UseClosePrice)
+		resultBars = []int{}
+	})
+
+	Context("and the legs receive their ticks together", func() {
+		BeforeEach(func() {
+			for i := range legA {
+				indicator.LegA().ReceiveDOHLCVTick(legA[i], i+1)
+				indicator.LegB().ReceiveDOHLCVTick(legB[i], i+1)
+			}
+		})
+
+		It("should be the difference of leg a and the hedged leg b for each bar", func() {
+			Expect(indicator.Data).To(Equal([]float64{0.0, 1.0, 1.0, -1.0, 1.0}))
+			Expect(indicator.GetLookbackPeriod()).To(Equal(0))
+			Expect(indicator.ValidFromBar()).To(Equal(1))
+			Expect(indicator.HedgeRatio()).To(Equal(2.0))
+		})
+	})
+
+	Context("and leg b lags leg a", func() {
+		var (
+			noLag          *indicators.Spread
+			dataBeforeLegB []float64
+		)
+
+		BeforeEach(func() {
+			noLag, _ = indicators.NewSpread(2.0, gotrade.UseClosePrice)
+			for i := range legA {
+				noLag.LegA().ReceiveDOHLCVTick(legA[i], i+1)
+				noLag.LegB().ReceiveDOHLCVTick(legB[i], i+1)
+			}
+
+			withoutStorage, _ := indicators.NewSpreadWithoutStorage(2.0, func(dataItem float64, streamBarIndex int) {
+				resultBars = append(resultBars, streamBarIndex)
+			})
+			for i := range legA {
+				indicator.LegA().ReceiveDOHLCVTick(legA[i], i+1)
+				withoutStorage.ReceiveTickA(legA[i].C(), i+1)
+			}
+			dataBeforeLegB = append([]float64{}, indicator.Data...)
+
+			for i := range legB {
+				indicator.LegB().ReceiveDOHLCVTick(legB[i], i+1)
+				withoutStorage.ReceiveTickB(legB[i].C(), i+1)
+			}
+		})
+
+		It("should not have a spread until leg b has received its ticks", func() {
+			Expect(dataBeforeLegB).To(BeEmpty())
+		})
+
+		It("should align the legs by their stream bar index", func() {
+			Expect(indicator.Data).To(Equal(noLag.Data))
+			Expect(indicator.Data).To(Equal([]float64{0.0, 1.0, 1.0, -1.0, 1.0}))
+			Expect(resultBars).To(Equal([]int{1, 2, 3, 4, 5}))
+		})
+	})
+
+	Context("and leg a misses a bar", func() {
+		BeforeEach(func() {
+			for i := range legA {
+				if i != 2 {
+					indicator.LegA().ReceiveDOHLCVTick(legA[i], i+1)
+				}
+				indicator.LegB().ReceiveDOHLCVTick(legB[i], i+1)
+			}
+		})
+
+		It("should not have a spread for the missed bar", func() {
+			Expect(indicator.Data).To(Equal([]float64{0.0, 1.0, -1.0, 1.0}))
+		})
+	})
+})
+
+var _ = Describe("when calculating a spread with a hedge ratio estimated by regression", func() {
+	var (
+		period    int = 4
+		indicator *indicators.SpreadWithoutStorage
+		results   []float64
+	)
+
+	BeforeEach(func() {
+		results = []float64{}
+		indicator, _ = indicators.NewRegressionSpreadWithoutStorage(period, func(dataItem float64, streamBarIndex int) {
+			results = append(results, dataItem)
+		})
+
+		// leg a is exactly 3 times leg b plus 1
+		for i := 0; i < 10; i++ {
+			b := 5.0 + float64(i%3) + float64(i)*0.5
+			indicator.ReceiveTickA(3.0*b+1.0, i+1)
+			indicator.ReceiveTickB(b, i+1)
+		}
+	})
+
+	It("should have a lookback period of the regression period", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(period - 1))
+		Expect(indicator.ValidFromBar()).To(Equal(period))
+		Expect(results).To(HaveLen(10 - (period - 1)))
+	})
+
+	It("should estimate the hedge ratio and leave the constant as the spread", func() {
+		Expect(indicator.HedgeRatio()).To(BeNumerically("~", 3.0, 0.0000001))
+		Expect(indicator.Intercept()).To(BeNumerically("~", 1.0, 0.0000001))
+		for _, result := range results {
+			Expect(result).To(BeNumerically("~", 1.0, 0.0000001))
+		}
+	})
+})
+
+var _ = Describe("when creating a spread for use with two price streams", func() {
+	It("should have requested each leg be attached to its stream", func() {
+		streamA := newFakeDOHLCVStreamSubscriber()
+		streamB := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewSpreadForStreams(streamA, streamB, 1.5, gotrade.UseClosePrice)
+		Expect(streamA.lastCallToAddTickSubscriptionArg).To(Equal(indicator.LegA()))
+		Expect(streamB.lastCallToAddTickSubscriptionArg).To(Equal(indicator.LegB()))
+
+		regression, _ := indicators.NewRegressionSpreadForStreams(streamA, streamB, 5, gotrade.UseClosePrice)
+		Expect(streamA.lastCallToAddTickSubscriptionArg).To(Equal(regression.LegA()))
+		Expect(streamB.lastCallToAddTickSubscriptionArg).To(Equal(regression.LegB()))
+	})
+})
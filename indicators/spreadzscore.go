@@ -0,0 +1,155 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Spread Z-Score Indicator (SpreadZScore), no storage, for use in other indicators
+// the z-score over the zScorePeriod of the residual spread between two legs, a - (intercept + hedgeRatio * b), of
+// the regression of a on b over the regressionPeriod, a mean reversion signal for a pair of cointegrated legs. The
+// ticks of the legs are aligned by their streamBarIndex as for a Spread, a result of 0.0 is given while the
+// residual spread has no deviation over the zScorePeriod
+type SpreadZScoreWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	spread        *SpreadWithoutStorage
+	zScore        *ZScoreWithoutStorage
+	currentSpread float64
+}
+
+// NewSpreadZScoreWithoutStorage creates a Spread Z-Score Indicator (SpreadZScore) without storage
+func NewSpreadZScoreWithoutStorage(regressionPeriod int, zScorePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *SpreadZScoreWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum regressionPeriod for this indicator is 2
+	if regressionPeriod < 2 {
+		return nil, errors.New("regressionPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum regressionPeriod
+	if regressionPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("regressionPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum zScorePeriod for this indicator is 2
+	if zScorePeriod < 2 {
+		return nil, errors.New("zScorePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum zScorePeriod
+	if zScorePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("zScorePeriod is greater than the maximum (100000)")
+	}
+
+	ind := SpreadZScoreWithoutStorage{}
+
+	ind.zScore, err = NewZScoreWithoutStorage(zScorePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.UpdateIndicatorWithNewValue(dataItem, streamBarIndex)
+	})
+
+	ind.spread, err = NewRegressionSpreadWithoutStorage(regressionPeriod, func(dataItem float64, streamBarIndex int) {
+		// the intercept is removed so that a drift in the hedge ratio does not move the level of the spread
+		ind.currentSpread = dataItem - ind.spread.Intercept()
+		ind.zScore.ReceiveTick(ind.currentSpread, streamBarIndex)
+	})
+
+	lookback := ind.spread.GetLookbackPeriod() + ind.zScore.GetLookbackPeriod()
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction)
+
+	return &ind, err
+}
+
+// HedgeRatio returns the hedge ratio applied to the last spread
+func (ind *SpreadZScoreWithoutStorage) HedgeRatio() float64 {
+	return ind.spread.HedgeRatio()
+}
+
+// CurrentSpread returns the last residual spread, available before the first result
+func (ind *SpreadZScoreWithoutStorage) CurrentSpread() float64 {
+	return ind.currentSpread
+}
+
+// ReceiveTickA consumes a source data float price tick of leg a
+func (ind *SpreadZScoreWithoutStorage) ReceiveTickA(tickData float64, streamBarIndex int) {
+	ind.spread.ReceiveTickA(tickData, streamBarIndex)
+}
+
+// ReceiveTickB consumes a source data float price tick of leg b
+func (ind *SpreadZScoreWithoutStorage) ReceiveTickB(tickData float64, streamBarIndex int) {
+	ind.spread.ReceiveTickB(tickData, streamBarIndex)
+}
+
+// A Spread Z-Score Indicator (SpreadZScore)
+type SpreadZScore struct {
+	*SpreadZScoreWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+	legA       *spreadLeg
+	legB       *spreadLeg
+
+	// public variables
+	Data []float64
+}
+
+// NewSpreadZScore creates a Spread Z-Score Indicator (SpreadZScore) for online usage
+func NewSpreadZScore(regressionPeriod int, zScorePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SpreadZScore, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := SpreadZScore{
+		selectData: selectData,
+	}
+	ind.legA = &spreadLeg{selectData: selectData, receiveTick: func(tickData float64, streamBarIndex int) {
+		ind.ReceiveTickA(tickData, streamBarIndex)
+	}}
+	ind.legB = &spreadLeg{selectData: selectData, receiveTick: func(tickData float64, streamBarIndex int) {
+		ind.ReceiveTickB(tickData, streamBarIndex)
+	}}
+
+	ind.SpreadZScoreWithoutStorage, err = NewSpreadZScoreWithoutStorage(regressionPeriod, zScorePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewSpreadZScoreForStreams creates a Spread Z-Score Indicator (SpreadZScore) for online usage with a source data stream for each leg
+func NewSpreadZScoreForStreams(priceStreamA gotrade.DOHLCVStreamSubscriber, priceStreamB gotrade.DOHLCVStreamSubscriber, regressionPeriod int, zScorePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *SpreadZScore, err error) {
+	ind, err := NewSpreadZScore(regressionPeriod, zScorePeriod, selectData)
+	if err != nil {
+		return ind, err
+	}
+	priceStreamA.AddTickSubscription(ind.LegA())
+	priceStreamB.AddTickSubscription(ind.LegB())
+	return ind, err
+}
+
+// LegA returns the receiver of the source data DOHLCV price ticks of leg a
+func (ind *SpreadZScore) LegA() gotrade.DOHLCVTickReceiver {
+	return ind.legA
+}
+
+// LegB returns the receiver of the source data DOHLCV price ticks of leg b
+func (ind *SpreadZScore) LegB() gotrade.DOHLCVTickReceiver {
+	return ind.legB
+}
+
+// ValuesInRange returns the SpreadZScore results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *SpreadZScore) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the SpreadZScore results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *SpreadZScore) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
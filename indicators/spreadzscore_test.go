@@ -0,0 +1,141 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"math/rand"
+	"time"
+)
+
+var _ = Describe("when creating a spreadzscorewithoutstorage", func() {
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewSpreadZScoreWithoutStorage(60, 30, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created with a regressionPeriod below the minimum", func() {
+		indicator, err := indicators.NewSpreadZScoreWithoutStorage(1, 30, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+	})
+
+	It("the indicator should not be created with a zScorePeriod above the maximum", func() {
+		indicator, err := indicators.NewSpreadZScoreWithoutStorage(60, indicators.MaximumLookbackPeriod+1, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+		Expect(err.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+	})
+
+	It("the indicator should not be created with a nil data selection func", func() {
+		indicator, err := indicators.NewSpreadZScore(60, 30, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+})
+
+var _ = Describe("when calculating a spread z-score of two cointegrated legs", func() {
+	var (
+		regressionPeriod int = 60
+		zScorePeriod     int = 30
+		bars             int = 300
+		divergeFrom      int = 250
+		indicator        *indicators.SpreadZScore
+		hedgeRatio       float64
+	)
+
+	newClose := func(closePrice float64) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(time.Now(), closePrice, closePrice, closePrice, closePrice, 0.0)
+	}
+
+	// the z-score of the result for the stream bar index
+	zScoreAt := func(streamBarIndex int) float64 {
+		return indicator.Data[streamBarIndex-indicator.ValidFromBar()]
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewSpreadZScore(regressionPeriod, zScorePeriod, gotrade.UseClosePrice)
+
+		// leg b is a random walk and leg a is twice leg b plus a mean reverting residual, until leg a diverges
+		random := rand.New(rand.NewSource(13))
+		b := 0.0
+		residual := 0.0
+		for i := 1; i <= bars; i++ {
+			b += random.NormFloat64()
+			residual = 0.5*residual + random.NormFloat64()
+			a := 2.0*b + residual
+			if i >= divergeFrom {
+				a += 8.0
+			}
+			indicator.LegA().ReceiveDOHLCVTick(newClose(a), i)
+			indicator.LegB().ReceiveDOHLCVTick(newClose(b), i)
+
+			if i == divergeFrom-1 {
+				hedgeRatio = indicator.HedgeRatio()
+			}
+		}
+	})
+
+	It("should have a result once both the regression and the z-score periods are full", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(regressionPeriod - 1 + zScorePeriod - 1))
+		Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		Expect(indicator.Data).To(HaveLen(bars - indicator.GetLookbackPeriod()))
+	})
+
+	It("should oscillate around zero while the legs are cointegrated", func() {
+		var total float64
+		crossings := 0
+		for bar := indicator.ValidFromBar(); bar < divergeFrom; bar++ {
+			total += zScoreAt(bar)
+			Expect(math.Abs(zScoreAt(bar))).To(BeNumerically("<", 3.5))
+			if bar > indicator.ValidFromBar() && (zScoreAt(bar) > 0.0) != (zScoreAt(bar-1) > 0.0) {
+				crossings++
+			}
+		}
+		Expect(total / float64(divergeFrom-indicator.ValidFromBar())).To(BeNumerically("~", 0.0, 0.3))
+		Expect(crossings).To(BeNumerically(">", 20))
+	})
+
+	It("should spike when leg a diverges", func() {
+		Expect(zScoreAt(divergeFrom)).To(BeNumerically(">", 3.0))
+	})
+
+	It("should estimate the hedge ratio of the legs while they are cointegrated", func() {
+		Expect(hedgeRatio).To(BeNumerically("~", 2.0, 0.1))
+	})
+})
+
+var _ = Describe("when calculating a spread z-score of legs with a constant spread", func() {
+	It("should be 0 while the spread has no deviation", func() {
+		results := []float64{}
+		indicator, _ := indicators.NewSpreadZScoreWithoutStorage(4, 3, func(dataItem float64, streamBarIndex int) {
+			results = append(results, dataItem)
+		})
+
+		// leg a is exactly 3 times leg b plus 1, which leaves no residual spread
+		for i := 0; i < 12; i++ {
+			b := 5.0 + float64(i%3) + float64(i)*0.5
+			indicator.ReceiveTickA(3.0*b+1.0, i+1)
+			indicator.ReceiveTickB(b, i+1)
+		}
+
+		Expect(indicator.HedgeRatio()).To(BeNumerically("~", 3.0, 0.0000001))
+		Expect(indicator.CurrentSpread()).To(BeNumerically("~", 0.0, 0.0000001))
+		Expect(results).To(HaveLen(12 - indicator.GetLookbackPeriod()))
+		for _, result := range results {
+			Expect(result).To(Equal(0.0))
+		}
+	})
+})
+
+var _ = Describe("when creating a spread z-score for use with two price streams", func() {
+	It("should have requested each leg be attached to its stream", func() {
+		streamA := newFakeDOHLCVStreamSubscriber()
+		streamB := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewSpreadZScoreForStreams(streamA, streamB, 60, 30, gotrade.UseClosePrice)
+		Expect(streamA.lastCallToAddTickSubscriptionArg).To(Equal(indicator.LegA()))
+		Expect(streamB.lastCallToAddTickSubscriptionArg).To(Equal(indicator.LegB()))
+	})
+})
@@ -0,0 +1,261 @@
+// Schaff Trend Cycle (Stc)
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Schaff Trend Cycle Indicator (Stc), no storage, for use in other indicators
+// The Macd line is passed through two stochastic stages over the cycle period,
+// each stage being smoothed by an Ema with a factor of 0.5.
+type StcWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	emaFast          *EmaWithoutStorage
+	emaSlow          *EmaWithoutStorage
+	macdHhv          *HhvWithoutStorage
+	macdLlv          *LlvWithoutStorage
+	frameHhv         *HhvWithoutStorage
+	frameLlv         *LlvWithoutStorage
+	currentFastEma   float64
+	currentMacd      float64
+	currentMacdHigh  float64
+	currentFrameHigh float64
+	previousFastK    float64
+	previousFrame    float64
+	previousStc      float64
+	hasPreviousFrame bool
+	hasPreviousStc   bool
+	smoothingFactor  float64
+	fastTimePeriod   int
+	slowTimePeriod   int
+	cyclePeriod      int
+}
+
+// NewStcWithoutStorage creates a Schaff Trend Cycle Indicator (Stc) without storage
+func NewStcWithoutStorage(fastTimePeriod int, slowTimePeriod int, cyclePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *StcWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum fastTimePeriod for this indicator is 2
+	if fastTimePeriod < 2 {
+		return nil, errors.New("fastTimePeriod is less than the minimum (2)")
+	}
+
+	// the slowTimePeriod must be greater than the fastTimePeriod
+	if slowTimePeriod <= fastTimePeriod {
+		return nil, errors.New("slowTimePeriod must be greater than the fastTimePeriod")
+	}
+
+	// check the maximum slowTimePeriod
+	if slowTimePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("slowTimePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum cyclePeriod for this indicator is 2
+	if cyclePeriod < 2 {
+		return nil, errors.New("cyclePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum cyclePeriod
+	if cyclePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("cyclePeriod is greater than the maximum (100000)")
+	}
+
+	// the macd line is valid after the slow ema lookback, each stochastic stage adds a further cycle
+	lookback := (slowTimePeriod - 1) + 2*(cyclePeriod-1)
+	ind := StcWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		smoothingFactor:              0.5,
+		fastTimePeriod:               fastTimePeriod,
+		slowTimePeriod:               slowTimePeriod,
+		cyclePeriod:                  cyclePeriod,
+	}
+
+	ind.emaFast, err = NewEmaWithoutStorage(fastTimePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentFastEma = dataItem
+	})
+
+	// the fast ema is always valid by the time the slow ema produces its first value
+	ind.emaSlow, err = NewEmaWithoutStorage(slowTimePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentMacd = ind.currentFastEma - dataItem
+
+		ind.macdHhv.ReceiveTick(ind.currentMacd, streamBarIndex)
+		ind.macdLlv.ReceiveTick(ind.currentMacd, streamBarIndex)
+	})
+
+	ind.macdHhv, err = NewHhvWithoutStorage(cyclePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentMacdHigh = dataItem
+	})
+
+	// first stochastic stage, the stochastic of the macd line
+	ind.macdLlv, err = NewLlvWithoutStorage(cyclePeriod, func(dataItem float64, streamBarIndex int) {
+		var fastK float64
+		periodRange := ind.currentMacdHigh - dataItem
+		if periodRange > 0.0 {
+			fastK = 100.0 * (ind.currentMacd - dataItem) / periodRange
+		} else {
+			fastK = ind.previousFastK
+		}
+		ind.previousFastK = fastK
+
+		if ind.hasPreviousFrame {
+			ind.previousFrame = ind.previousFrame + ind.smoothingFactor*(fastK-ind.previousFrame)
+		} else {
+			ind.previousFrame = fastK
+			ind.hasPreviousFrame = true
+		}
+
+		ind.frameHhv.ReceiveTick(ind.previousFrame, streamBarIndex)
+		ind.frameLlv.ReceiveTick(ind.previousFrame, streamBarIndex)
+	})
+
+	ind.frameHhv, err = NewHhvWithoutStorage(cyclePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentFrameHigh = dataItem
+	})
+
+	// second stochastic stage, the stochastic of the smoothed first stage
+	ind.frameLlv, err = NewLlvWithoutStorage(cyclePeriod, func(dataItem float64, streamBarIndex int) {
+		var frameK float64
+		periodRange := ind.currentFrameHigh - dataItem
+		if periodRange > 0.0 {
+			frameK = 100.0 * (ind.previousFrame - dataItem) / periodRange
+		} else if ind.hasPreviousStc {
+			frameK = ind.previousStc
+		} else {
+			frameK = ind.previousFrame
+		}
+
+		var result float64
+		if ind.hasPreviousStc {
+			result = ind.previousStc + ind.smoothingFactor*(frameK-ind.previousStc)
+		} else {
+			result = frameK
+			ind.hasPreviousStc = true
+		}
+		ind.previousStc = result
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	})
+
+	return &ind, err
+}
+
+// A Schaff Trend Cycle Indicator (Stc)
+type Stc struct {
+	*StcWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewStc creates a Schaff Trend Cycle Indicator (Stc) for online usage
+func NewStc(fastTimePeriod int, slowTimePeriod int, cyclePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Stc, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Stc{
+		selectData: selectData,
+	}
+
+	ind.StcWithoutStorage, err = NewStcWithoutStorage(fastTimePeriod, slowTimePeriod, cyclePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultStc creates a Schaff Trend Cycle Indicator (Stc) for online usage with default parameters
+//	- fastTimePeriod: 23
+//	- slowTimePeriod: 50
+//	- cyclePeriod: 10
+func NewDefaultStc() (indicator *Stc, err error) {
+	fastTimePeriod := 23
+	slowTimePeriod := 50
+	cyclePeriod := 10
+	return NewStc(fastTimePeriod, slowTimePeriod, cyclePeriod, gotrade.UseClosePrice)
+}
+
+// NewStcWithSrcLen creates a Schaff Trend Cycle Indicator (Stc) for offline usage
+func NewStcWithSrcLen(sourceLength uint, fastTimePeriod int, slowTimePeriod int, cyclePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Stc, err error) {
+	ind, err := NewStc(fastTimePeriod, slowTimePeriod, cyclePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultStcWithSrcLen creates a Schaff Trend Cycle Indicator (Stc) for offline usage with default parameters
+func NewDefaultStcWithSrcLen(sourceLength uint) (indicator *Stc, err error) {
+	ind, err := NewDefaultStc()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewStcForStream creates a Schaff Trend Cycle Indicator (Stc) for online usage with a source data stream
+func NewStcForStream(priceStream gotrade.DOHLCVStreamSubscriber, fastTimePeriod int, slowTimePeriod int, cyclePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Stc, err error) {
+	ind, err := NewStc(fastTimePeriod, slowTimePeriod, cyclePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultStcForStream creates a Schaff Trend Cycle Indicator (Stc) for online usage with a source data stream
+func NewDefaultStcForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Stc, err error) {
+	ind, err := NewDefaultStc()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewStcForStreamWithSrcLen creates a Schaff Trend Cycle Indicator (Stc) for offline usage with a source data stream
+func NewStcForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, fastTimePeriod int, slowTimePeriod int, cyclePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Stc, err error) {
+	ind, err := NewStcWithSrcLen(sourceLength, fastTimePeriod, slowTimePeriod, cyclePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultStcForStreamWithSrcLen creates a Schaff Trend Cycle Indicator (Stc) for offline usage with a source data stream
+func NewDefaultStcForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Stc, err error) {
+	ind, err := NewDefaultStcWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Stc) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *StcWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.emaFast.ReceiveTick(tickData, streamBarIndex)
+	ind.emaSlow.ReceiveTick(tickData, streamBarIndex)
+}
+
+// ValuesInRange returns the Stc results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Stc) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Stc results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Stc) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 	"math"
 )
 
@@ -43,7 +44,8 @@ func NewStdDevWithoutStorage(timePeriod int, valueAvailableAction ValueAvailable
 
 	ind.variance, err = NewVarWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
 
-		result := math.Sqrt(dataItem)
+		// the rolling variance of a flat period can round to just below 0.0, which has no square root
+		result := math.Sqrt(math.Max(dataItem, 0.0))
 
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	})
@@ -146,3 +148,15 @@ func (stdDev *StdDev) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex
 func (stdDev *StdDevWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
 	stdDev.variance.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the StdDev results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *StdDev) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the StdDev results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *StdDev) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
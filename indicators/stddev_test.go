@@ -405,3 +405,25 @@ var _ = Describe("when calculating a standard deviation (stdev) with DOHLCV sour
 		})
 	})
 })
+
+var _ = Describe("when calculating a standard deviation (stddev) of constant prices", func() {
+	It("should be 0 rather than a NaN once the period is flat", func() {
+		results := []float64{}
+		indicator, _ := indicators.NewStdDevWithoutStorage(5, func(dataItem float64, streamBarIndex int) {
+			results = append(results, dataItem)
+		})
+
+		// a varied period leaves a rolling variance that rounds to just below 0.0 once the prices are constant
+		for i := 0; i < 5; i++ {
+			indicator.ReceiveTick(91.5*float64(i+1), i+1)
+		}
+		for i := 5; i < 20; i++ {
+			indicator.ReceiveTick(91.5, i+1)
+		}
+
+		Expect(results).To(HaveLen(16))
+		for _, result := range results[5:] {
+			Expect(result).To(Equal(0.0))
+		}
+	})
+})
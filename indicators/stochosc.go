@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Stochastic Oscillator Indicator (StochOsc), no storage, for use in other indicators
@@ -190,3 +191,9 @@ func (ind *StochOscWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, st
 		ind.slowKMA.ReceiveTick(ind.currentFastK, streamBarIndex)
 	}
 }
+
+// WriteCSV writes the StochOsc results as rows after a header of barIndex,slowK,slowD, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *StochOsc) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("slowK", ind.SlowK), floatCSVColumn("slowD", ind.SlowD))
+}
@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Stochastic Relative Strength Indicator (StochRsi), no storage, for use in other indicators
@@ -195,3 +196,9 @@ func (ind *StochRsiWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, st
 
 	ind.rsi.ReceiveTick(tickData.C(), streamBarIndex)
 }
+
+// WriteCSV writes the StochRsi results as rows after a header of barIndex,slowK,slowD, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *StochRsi) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("slowK", ind.SlowK), floatCSVColumn("slowD", ind.SlowD))
+}
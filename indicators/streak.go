@@ -0,0 +1,122 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Streak Indicator (Streak), no storage, for use in other indicators
+// counts the consecutive bars the source data has risen, as a positive value, or fallen, as a negative value,
+// an unchanged bar resets the streak to 0
+type StreakWithoutStorage struct {
+	*baseIndicatorWithIntBounds
+
+	// private variables
+	hasPreviousValue bool
+	previousValue    float64
+	streak           int64
+}
+
+// NewStreakWithoutStorage creates a Streak Indicator (Streak) without storage
+func NewStreakWithoutStorage(valueAvailableAction ValueAvailableActionInt) (indicator *StreakWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	lookback := 1
+	ind := StreakWithoutStorage{
+		baseIndicatorWithIntBounds: newBaseIndicatorWithIntBounds(lookback, valueAvailableAction),
+	}
+
+	return &ind, nil
+}
+
+// A Streak Indicator (Streak)
+type Streak struct {
+	*StreakWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []int64
+}
+
+// NewStreak creates a Streak Indicator (Streak) for online usage
+func NewStreak(selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Streak, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Streak{
+		selectData: selectData,
+	}
+
+	ind.StreakWithoutStorage, err = NewStreakWithoutStorage(
+		func(dataItem int64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewStreakWithSrcLen creates a Streak Indicator (Streak) for offline usage
+func NewStreakWithSrcLen(sourceLength uint, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Streak, err error) {
+	ind, err := NewStreak(selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]int64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewStreakForStream creates a Streak Indicator (Streak) for online usage with a source data stream
+func NewStreakForStream(priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Streak, err error) {
+	ind, err := NewStreak(selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewStreakForStreamWithSrcLen creates a Streak Indicator (Streak) for offline usage with a source data stream
+func NewStreakForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Streak, err error) {
+	ind, err := NewStreakWithSrcLen(sourceLength, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Streak) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+func (ind *StreakWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if ind.hasPreviousValue {
+		if tickData > ind.previousValue {
+			if ind.streak < 0 {
+				ind.streak = 0
+			}
+			ind.streak += 1
+		} else if tickData < ind.previousValue {
+			if ind.streak > 0 {
+				ind.streak = 0
+			}
+			ind.streak -= 1
+		} else {
+			ind.streak = 0
+		}
+
+		ind.UpdateIndicatorWithNewValue(ind.streak, streamBarIndex)
+	}
+
+	ind.hasPreviousValue = true
+	ind.previousValue = tickData
+}
+
+// WriteCSV writes the Streak results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Streak) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), intCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,167 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Rolling Sum Indicator (Sum), no storage, for use in other indicators
+type SumWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodTotal   float64
+	periodHistory *list.List
+	timePeriod    int
+}
+
+// NewSumWithoutStorage creates a Rolling Sum Indicator (Sum) without storage
+func NewSumWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *SumWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := SumWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *SumWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.periodHistory.PushBack(tickData)
+	ind.periodTotal += tickData
+
+	// slide the window, dropping the oldest value from the total
+	if ind.periodHistory.Len() > ind.timePeriod {
+		var first = ind.periodHistory.Front()
+		ind.periodTotal -= first.Value.(float64)
+		ind.periodHistory.Remove(first)
+	}
+
+	if ind.periodHistory.Len() == ind.timePeriod {
+		ind.UpdateIndicatorWithNewValue(ind.periodTotal, streamBarIndex)
+	}
+}
+
+// A Rolling Sum Indicator (Sum)
+type Sum struct {
+	*SumWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewSum creates a Rolling Sum Indicator (Sum) for online usage
+func NewSum(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sum, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Sum{
+		selectData: selectData,
+	}
+
+	ind.SumWithoutStorage, err = NewSumWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultSum creates a Rolling Sum Indicator (Sum) for online usage with default parameters
+//	- timePeriod: 30
+func NewDefaultSum() (indicator *Sum, err error) {
+	timePeriod := 30
+	return NewSum(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewSumWithSrcLen creates a Rolling Sum Indicator (Sum) for offline usage
+func NewSumWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sum, err error) {
+	ind, err := NewSum(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultSumWithSrcLen creates a Rolling Sum Indicator (Sum) for offline usage with default parameters
+func NewDefaultSumWithSrcLen(sourceLength uint) (indicator *Sum, err error) {
+	ind, err := NewDefaultSum()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewSumForStream creates a Rolling Sum Indicator (Sum) for online usage with a source data stream
+func NewSumForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sum, err error) {
+	ind, err := NewSum(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultSumForStream creates a Rolling Sum Indicator (Sum) for online usage with a source data stream
+func NewDefaultSumForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Sum, err error) {
+	ind, err := NewDefaultSum()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewSumForStreamWithSrcLen creates a Rolling Sum Indicator (Sum) for offline usage with a source data stream
+func NewSumForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Sum, err error) {
+	ind, err := NewSumWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultSumForStreamWithSrcLen creates a Rolling Sum Indicator (Sum) for offline usage with a source data stream
+func NewDefaultSumForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Sum, err error) {
+	ind, err := NewDefaultSumWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Sum) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Sum results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Sum) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Sum results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Sum) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
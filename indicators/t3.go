@@ -0,0 +1,218 @@
+package indicators
+
+// T3(X) = c1 * e6 + c2 * e5 + c3 * e4 + c4 * e3
+// where e1..e6 are six chained EMA(X) and a is the volume factor
+//	c1 = -a^3
+//	c2 = 3a^2 + 3a^3
+//	c3 = -6a^2 - 3a - 3a^3
+//	c4 = 1 + 3a + a^3 + 3a^2
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// A Tillson T3 Moving Average Indicator (T3), no storage, for use in other indicators
+type T3WithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	ema1         *EmaWithoutStorage
+	ema2         *EmaWithoutStorage
+	ema3         *EmaWithoutStorage
+	ema4         *EmaWithoutStorage
+	ema5         *EmaWithoutStorage
+	ema6         *EmaWithoutStorage
+	currentEMA3  float64
+	currentEMA4  float64
+	currentEMA5  float64
+	c1           float64
+	c2           float64
+	c3           float64
+	c4           float64
+	timePeriod   int
+	volumeFactor float64
+}
+
+// NewT3WithoutStorage creates a Tillson T3 Moving Average Indicator (T3) without storage
+func NewT3WithoutStorage(timePeriod int, volumeFactor float64, valueAvailableAction ValueAvailableActionFloat) (indicator *T3WithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the volume factor ranges from 0, a Tema like response, to 1, a Dema of a Dema
+	if volumeFactor < 0.0 {
+		return nil, errors.New("volumeFactor is less than the minimum (0)")
+	}
+
+	if volumeFactor > 1.0 {
+		return nil, errors.New("volumeFactor is greater than the maximum (1)")
+	}
+
+	a := volumeFactor
+	lookback := 6 * (timePeriod - 1)
+	ind := T3WithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		c1:                           -a * a * a,
+		c2:                           3*a*a + 3*a*a*a,
+		c3:                           -6*a*a - 3*a - 3*a*a*a,
+		c4:                           1 + 3*a + a*a*a + 3*a*a,
+		timePeriod:                   timePeriod,
+		volumeFactor:                 volumeFactor,
+	}
+
+	ind.ema1, err = NewEmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.ema2.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	ind.ema2, _ = NewEmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.ema3.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	ind.ema3, _ = NewEmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentEMA3 = dataItem
+		ind.ema4.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	ind.ema4, _ = NewEmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentEMA4 = dataItem
+		ind.ema5.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	ind.ema5, _ = NewEmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentEMA5 = dataItem
+		ind.ema6.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	ind.ema6, _ = NewEmaWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		result := ind.c1*dataItem + ind.c2*ind.currentEMA5 + ind.c3*ind.currentEMA4 + ind.c4*ind.currentEMA3
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	})
+
+	return &ind, err
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *T3WithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.ema1.ReceiveTick(tickData, streamBarIndex)
+}
+
+// A Tillson T3 Moving Average Indicator (T3)
+type T3 struct {
+	*T3WithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewT3 creates a Tillson T3 Moving Average Indicator (T3) for online usage
+func NewT3(timePeriod int, volumeFactor float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *T3, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := T3{
+		selectData: selectData,
+	}
+
+	ind.T3WithoutStorage, err = NewT3WithoutStorage(timePeriod, volumeFactor,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultT3 creates a Tillson T3 Moving Average Indicator (T3) for online usage with default parameters
+//	- timePeriod: 5
+//	- volumeFactor: 0.7
+func NewDefaultT3() (indicator *T3, err error) {
+	timePeriod := 5
+	volumeFactor := 0.7
+	return NewT3(timePeriod, volumeFactor, gotrade.UseClosePrice)
+}
+
+// NewT3WithSrcLen creates a Tillson T3 Moving Average Indicator (T3) for offline usage
+func NewT3WithSrcLen(sourceLength uint, timePeriod int, volumeFactor float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *T3, err error) {
+	ind, err := NewT3(timePeriod, volumeFactor, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultT3WithSrcLen creates a Tillson T3 Moving Average Indicator (T3) for offline usage with default parameters
+func NewDefaultT3WithSrcLen(sourceLength uint) (indicator *T3, err error) {
+	ind, err := NewDefaultT3()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewT3ForStream creates a Tillson T3 Moving Average Indicator (T3) for online usage with a source data stream
+func NewT3ForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, volumeFactor float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *T3, err error) {
+	ind, err := NewT3(timePeriod, volumeFactor, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultT3ForStream creates a Tillson T3 Moving Average Indicator (T3) for online usage with a source data stream
+func NewDefaultT3ForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *T3, err error) {
+	ind, err := NewDefaultT3()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewT3ForStreamWithSrcLen creates a Tillson T3 Moving Average Indicator (T3) for offline usage with a source data stream
+func NewT3ForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, volumeFactor float64, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *T3, err error) {
+	ind, err := NewT3WithSrcLen(sourceLength, timePeriod, volumeFactor, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultT3ForStreamWithSrcLen creates a Tillson T3 Moving Average Indicator (T3) for offline usage with a source data stream
+func NewDefaultT3ForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *T3, err error) {
+	ind, err := NewDefaultT3WithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *T3) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the T3 results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *T3) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the T3 results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *T3) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -5,6 +5,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Tripple Exponential Moving Average Indicator (Tema), no storage, for use in other indicators
@@ -158,3 +159,15 @@ func (ind *Tema) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int)
 func (ind *TemaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
 	ind.ema1.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the Tema results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Tema) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Tema results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Tema) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
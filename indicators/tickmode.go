@@ -0,0 +1,13 @@
+package indicators
+
+// A TickMode selects when an indicator that supports intrabar updates advances its results
+type TickMode int
+
+const (
+	// TickModeOnClose treats each tick received as a closed bar
+	TickModeOnClose TickMode = iota
+
+	// TickModeOnEveryTick treats each tick received as a provisional update of the forming bar, the result of
+	// which is recomputed by every update for the bar until the bar is committed with CommitBar
+	TickModeOnEveryTick
+)
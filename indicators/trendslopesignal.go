@@ -0,0 +1,224 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"strconv"
+)
+
+// A TrendSlope is the direction and strength of the trend given by a TrendSlopeSignal
+type TrendSlope int
+
+const (
+	// the normalized slope is between the lower and upper thresholds
+	TrendSlopeWeak TrendSlope = iota
+	// the normalized slope is at or above the upper threshold
+	TrendSlopeStrongUp
+	// the normalized slope is at or below the lower threshold
+	TrendSlopeStrongDown
+)
+
+type ValueAvailableActionTrendSlopeSignal func(dataItemSignal TrendSlope, dataItemNormalizedSlope float64, streamBarIndex int)
+
+// A Trend Slope Signal Indicator (TrendSlopeSignal), no storage, for use in other indicators
+// the LinRegSlp of the close over the timePeriod normalized by the Atr of the same timePeriod, the change of the
+// regression per bar as a proportion of the average true range, classified as a strong up, weak or strong down
+// trend by the upper and lower thresholds of the normalized slope. The normalization makes the signal comparable
+// across instruments of different prices and volatilities, a normalized slope of 0.0 is given while the Atr is 0
+type TrendSlopeSignalWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionTrendSlopeSignal
+	linReg               *LinRegWithoutStorage
+	atr                  *AtrWithoutStorage
+	hasAtr               bool
+	currentAtr           float64
+	upperThreshold       float64
+	lowerThreshold       float64
+	currentSignal        TrendSlope
+}
+
+// NewTrendSlopeSignalWithoutStorage creates a Trend Slope Signal Indicator (TrendSlopeSignal) without storage
+func NewTrendSlopeSignalWithoutStorage(timePeriod int, upperThreshold float64, lowerThreshold float64, valueAvailableAction ValueAvailableActionTrendSlopeSignal) (indicator *TrendSlopeSignalWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the thresholds must not overlap
+	if lowerThreshold >= upperThreshold {
+		return nil, errors.New("lowerThreshold is greater than the maximum (the upperThreshold)")
+	}
+
+	ind := TrendSlopeSignalWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		upperThreshold:       upperThreshold,
+		lowerThreshold:       lowerThreshold,
+	}
+
+	ind.atr, err = NewAtrWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.hasAtr = true
+		ind.currentAtr = dataItem
+	})
+
+	ind.linReg, err = NewLinRegWithoutStorage(timePeriod,
+		func(dataItem float64, slope float64, intercept float64, streamBarIndex int) {
+			// the slope is only normalized once the Atr is available
+			if !ind.hasAtr {
+				return
+			}
+
+			normalizedSlope := 0.0
+			if ind.currentAtr > 0.0 {
+				normalizedSlope = slope / ind.currentAtr
+			}
+
+			signal := TrendSlopeWeak
+			if normalizedSlope >= ind.upperThreshold {
+				signal = TrendSlopeStrongUp
+			} else if normalizedSlope <= ind.lowerThreshold {
+				signal = TrendSlopeStrongDown
+			}
+			ind.currentSignal = signal
+
+			normalizedSlope = ind.quantize(normalizedSlope)
+
+			ind.UpdateMinMax(normalizedSlope, normalizedSlope)
+
+			ind.IncDataLength()
+
+			ind.SetValidFromBar(streamBarIndex)
+
+			// notify of a new result value though the value available action
+			ind.valueAvailableAction(signal, normalizedSlope, streamBarIndex)
+		})
+
+	// the Atr has the longer lookback of the two
+	ind.baseIndicator = newBaseIndicator(ind.atr.GetLookbackPeriod())
+
+	return &ind, err
+}
+
+// CurrentSignal returns the trend signal of the last result
+func (ind *TrendSlopeSignalWithoutStorage) CurrentSignal() TrendSlope {
+	return ind.currentSignal
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *TrendSlopeSignalWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// the Atr of the bar is received before its slope
+	ind.atr.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	ind.linReg.ReceiveTick(tickData.C(), streamBarIndex)
+}
+
+// A Trend Slope Signal Indicator (TrendSlopeSignal)
+type TrendSlopeSignal struct {
+	*TrendSlopeSignalWithoutStorage
+
+	// public variables
+	Signal          []TrendSlope
+	NormalizedSlope []float64
+}
+
+// NewTrendSlopeSignal creates a Trend Slope Signal Indicator (TrendSlopeSignal) for online usage
+func NewTrendSlopeSignal(timePeriod int, upperThreshold float64, lowerThreshold float64) (indicator *TrendSlopeSignal, err error) {
+	ind := TrendSlopeSignal{}
+	ind.TrendSlopeSignalWithoutStorage, err = NewTrendSlopeSignalWithoutStorage(timePeriod, upperThreshold, lowerThreshold,
+		func(dataItemSignal TrendSlope, dataItemNormalizedSlope float64, streamBarIndex int) {
+			ind.Signal = append(ind.Signal, dataItemSignal)
+			ind.NormalizedSlope = append(ind.NormalizedSlope, dataItemNormalizedSlope)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultTrendSlopeSignal creates a Trend Slope Signal Indicator (TrendSlopeSignal) for online usage with default parameters
+//	- timePeriod: 20
+//	- upperThreshold: 0.1
+//	- lowerThreshold: -0.1
+func NewDefaultTrendSlopeSignal() (indicator *TrendSlopeSignal, err error) {
+	timePeriod := 20
+	upperThreshold := 0.1
+	lowerThreshold := -0.1
+	return NewTrendSlopeSignal(timePeriod, upperThreshold, lowerThreshold)
+}
+
+// NewTrendSlopeSignalWithSrcLen creates a Trend Slope Signal Indicator (TrendSlopeSignal) for offline usage
+func NewTrendSlopeSignalWithSrcLen(sourceLength uint, timePeriod int, upperThreshold float64, lowerThreshold float64) (indicator *TrendSlopeSignal, err error) {
+	ind, err := NewTrendSlopeSignal(timePeriod, upperThreshold, lowerThreshold)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Signal = make([]TrendSlope, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.NormalizedSlope = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultTrendSlopeSignalWithSrcLen creates a Trend Slope Signal Indicator (TrendSlopeSignal) for offline usage with default parameters
+func NewDefaultTrendSlopeSignalWithSrcLen(sourceLength uint) (indicator *TrendSlopeSignal, err error) {
+	ind, err := NewDefaultTrendSlopeSignal()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Signal = make([]TrendSlope, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.NormalizedSlope = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewTrendSlopeSignalForStream creates a Trend Slope Signal Indicator (TrendSlopeSignal) for online usage with a source data stream
+func NewTrendSlopeSignalForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, upperThreshold float64, lowerThreshold float64) (indicator *TrendSlopeSignal, err error) {
+	ind, err := NewTrendSlopeSignal(timePeriod, upperThreshold, lowerThreshold)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultTrendSlopeSignalForStream creates a Trend Slope Signal Indicator (TrendSlopeSignal) for online usage with a source data stream
+func NewDefaultTrendSlopeSignalForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *TrendSlopeSignal, err error) {
+	ind, err := NewDefaultTrendSlopeSignal()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewTrendSlopeSignalForStreamWithSrcLen creates a Trend Slope Signal Indicator (TrendSlopeSignal) for offline usage with a source data stream
+func NewTrendSlopeSignalForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, upperThreshold float64, lowerThreshold float64) (indicator *TrendSlopeSignal, err error) {
+	ind, err := NewTrendSlopeSignalWithSrcLen(sourceLength, timePeriod, upperThreshold, lowerThreshold)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultTrendSlopeSignalForStreamWithSrcLen creates a Trend Slope Signal Indicator (TrendSlopeSignal) for offline usage with a source data stream
+func NewDefaultTrendSlopeSignalForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *TrendSlopeSignal, err error) {
+	ind, err := NewDefaultTrendSlopeSignalWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the TrendSlopeSignal results as rows after a header of barIndex,signal,normalizedSlope, the bar
+// index of each result is its stream bar index plus the startBarOffset
+func (ind *TrendSlopeSignal) WriteCSV(w io.Writer, startBarOffset int) error {
+	signal := csvColumn{name: "signal", length: len(ind.Signal), format: func(index int) string {
+		return strconv.Itoa(int(ind.Signal[index]))
+	}}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), signal, floatCSVColumn("normalizedSlope", ind.NormalizedSlope))
+}
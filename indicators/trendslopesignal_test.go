@@ -0,0 +1,151 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a trendslopesignalwithoutstorage", func() {
+	var (
+		indicator      *indicators.TrendSlopeSignalWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemSignal indicators.TrendSlope, dataItemNormalizedSlope float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTrendSlopeSignalWithoutStorage(20, 0.1, -0.1, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTrendSlopeSignalWithoutStorage(1, 0.1, -0.1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTrendSlopeSignalWithoutStorage(indicators.MaximumLookbackPeriod+1, 0.1, -0.1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+
+	Context("and the indicator was given a lowerThreshold above the upperThreshold", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTrendSlopeSignalWithoutStorage(20, -0.1, 0.1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a trend slope signal (trendslopesignal) with DOHLCV source data", func() {
+	var (
+		period    int = 20
+		segment   int = 40
+		indicator *indicators.TrendSlopeSignal
+		bars      []gotrade.DOHLCV
+	)
+
+	// bars of an uptrend, then a flat section, then a downtrend, of the given price level
+	newBars := func(level float64) []gotrade.DOHLCV {
+		shape := []gotrade.DOHLCV{}
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		price := 1.0
+		for i := 0; i < 3*segment; i++ {
+			if i < segment {
+				price += 0.01
+			} else if i >= 2*segment {
+				price -= 0.01
+			}
+			wiggle := 0.005 * float64(i%3)
+			closePrice := (price + wiggle) * level
+			shape = append(shape, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), closePrice, closePrice+0.01*level, closePrice-0.01*level, closePrice, 1000.0))
+		}
+		return shape
+	}
+
+	// the signal of the result for the stream bar index
+	signalAt := func(streamBarIndex int) indicators.TrendSlope {
+		return indicator.Signal[streamBarIndex-indicator.ValidFromBar()]
+	}
+
+	BeforeEach(func() {
+		bars = newBars(10.0)
+		indicator, _ = indicators.NewDefaultTrendSlopeSignal()
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+	})
+
+	It("should have a result once both the slope and the atr are available", func() {
+		atr, _ := indicators.NewAtr(period)
+		Expect(indicator.GetLookbackPeriod()).To(Equal(atr.GetLookbackPeriod()))
+		Expect(indicator.ValidFromBar()).To(Equal(period + 1))
+		Expect(indicator.Signal).To(HaveLen(len(bars) - period))
+		Expect(indicator.NormalizedSlope).To(HaveLen(len(bars) - period))
+	})
+
+	It("should be the slope relative to the atr", func() {
+		linRegSlp, _ := indicators.NewLinRegSlp(period, gotrade.UseClosePrice)
+		atr, _ := indicators.NewAtr(period)
+		for i := range bars {
+			linRegSlp.ReceiveDOHLCVTick(bars[i], i+1)
+			atr.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+
+		slopes := linRegSlp.ValuesInRange(indicator.ValidFromBar(), len(bars))
+		for i := range indicator.NormalizedSlope {
+			Expect(indicator.NormalizedSlope[i]).To(BeNumerically("~", slopes[i]/atr.Data[i], 0.0000001))
+		}
+	})
+
+	It("should be a strong up signal, then a weak signal, then a strong down signal", func() {
+		Expect(signalAt(segment)).To(Equal(indicators.TrendSlopeStrongUp))
+		Expect(signalAt(2 * segment)).To(Equal(indicators.TrendSlopeWeak))
+		Expect(signalAt(3 * segment)).To(Equal(indicators.TrendSlopeStrongDown))
+		Expect(indicator.CurrentSignal()).To(Equal(indicators.TrendSlopeStrongDown))
+	})
+
+	It("should give the same signal for a high priced and a low priced version of the same shape", func() {
+		highPriced, _ := indicators.NewDefaultTrendSlopeSignal()
+		highPricedBars := newBars(1000.0)
+		for i := range highPricedBars {
+			highPriced.ReceiveDOHLCVTick(highPricedBars[i], i+1)
+		}
+
+		Expect(highPriced.Signal).To(Equal(indicator.Signal))
+		for i := range indicator.NormalizedSlope {
+			Expect(highPriced.NormalizedSlope[i]).To(BeNumerically("~", indicator.NormalizedSlope[i], 0.0000001))
+		}
+	})
+})
+
+var _ = Describe("when creating a trendslopesignal for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewDefaultTrendSlopeSignalForStream(stream)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
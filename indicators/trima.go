@@ -3,6 +3,7 @@ package indicators
 import (
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Triangular Moving Average Indicator (Trima), no storage, for use in other indicators
@@ -152,6 +153,54 @@ func NewDefaultTrimaForStreamWithSrcLen(sourceLength uint, priceStream gotrade.D
 	return ind, err
 }
 
+// NewTrimaWithCentering creates a Triangular Moving Average Indicator (Trima) for online usage, a centered Trima requires future data and
+// so ErrCenteringRequiresSourceLength is returned when centered
+func NewTrimaWithCentering(timePeriod int, centered bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Trima, err error) {
+	if centered {
+		return nil, ErrCenteringRequiresSourceLength
+	}
+	return NewTrima(timePeriod, selectData)
+}
+
+// NewTrimaWithCenteringWithSrcLen creates a Triangular Moving Average Indicator (Trima) for offline usage, when centered each
+// result is displaced back by half the timePeriod onto the bar at the center of its window, for research rather
+// than trading as the result of a bar then depends on later bars
+func NewTrimaWithCenteringWithSrcLen(sourceLength uint, timePeriod int, centered bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Trima, err error) {
+	ind, err := NewTrimaWithSrcLen(sourceLength, timePeriod, selectData)
+	if err != nil || !centered {
+		return ind, err
+	}
+
+	err = ind.setCentered(timePeriod)
+	if err != nil {
+		return nil, err
+	}
+
+	return ind, nil
+}
+
+// NewTrimaWithCenteringForStream creates a Triangular Moving Average Indicator (Trima) for online usage with a source data stream,
+// a centered Trima requires future data and so ErrCenteringRequiresSourceLength is returned when centered
+func NewTrimaWithCenteringForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, centered bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Trima, err error) {
+	ind, err := NewTrimaWithCentering(timePeriod, centered, selectData)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewTrimaWithCenteringForStreamWithSrcLen creates a Triangular Moving Average Indicator (Trima) for offline usage with a source
+// data stream, centered on the bar at the center of the window of each result when centered
+func NewTrimaWithCenteringForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, centered bool, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Trima, err error) {
+	ind, err := NewTrimaWithCenteringWithSrcLen(sourceLength, timePeriod, centered, selectData)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
 // ReceiveDOHLCVTick consumes a source data DOHLCV price tick
 func (tema *Trima) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
 	var selectedData = tema.selectData(tickData)
@@ -161,3 +210,15 @@ func (tema *Trima) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int
 func (tema *TrimaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
 	tema.sma1.ReceiveTick(tickData, streamBarIndex)
 }
+
+// ValuesInRange returns the Trima results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Trima) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Trima results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Trima) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -5,6 +5,8 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/thetruetrade/gotrade"
 	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
 )
 
 var _ = Describe("when creating an trimawithoutstorage", func() {
@@ -405,3 +407,69 @@ var _ = Describe("when calculating a triangular moving average (trima)", func()
 		})
 	})
 })
+
+var _ = Describe("when calculating a centered triangular moving average (trima)", func() {
+	var (
+		period   int = 5
+		peakBar  int = 50
+		bars     int = 99
+		centered *indicators.Trima
+		trailing *indicators.Trima
+	)
+
+	// a series that rises to a peak at the peakBar and falls away symmetrically
+	newClose := func(bar int) gotrade.DOHLCV {
+		closePrice := 100.0 - math.Abs(float64(bar-peakBar))
+		return gotrade.NewDOHLCVDataItem(time.Now(), closePrice, closePrice, closePrice, closePrice, 0.0)
+	}
+
+	// the bar of the highest result of the indicator
+	barOfMax := func(indicator *indicators.Trima) int {
+		maxIndex := 0
+		for i := range indicator.Data {
+			if indicator.Data[i] > indicator.Data[maxIndex] {
+				maxIndex = i
+			}
+		}
+		return indicator.ValidFromBar() + maxIndex
+	}
+
+	BeforeEach(func() {
+		centered, _ = indicators.NewTrimaWithCenteringWithSrcLen(uint(bars), period, true, gotrade.UseClosePrice)
+		trailing, _ = indicators.NewTrimaWithCenteringWithSrcLen(uint(bars), period, false, gotrade.UseClosePrice)
+		for bar := 1; bar <= bars; bar++ {
+			centered.ReceiveDOHLCVTick(newClose(bar), bar)
+			trailing.ReceiveDOHLCVTick(newClose(bar), bar)
+		}
+	})
+
+	It("should align the highest result with the peak, unlike the trailing trima", func() {
+		Expect(barOfMax(centered)).To(Equal(peakBar))
+		Expect(barOfMax(trailing)).To(Equal(peakBar + period/2))
+	})
+
+	It("should have the results of the trailing trima shifted back by half the period", func() {
+		Expect(centered.Data).To(Equal(trailing.Data))
+		Expect(centered.ValidFromBar()).To(Equal(trailing.ValidFromBar() - period/2))
+		Expect(centered.Offset()).To(Equal(-(period / 2)))
+	})
+
+	It("should not be created for online usage", func() {
+		indicator, err := indicators.NewTrimaWithCentering(period, true, gotrade.UseClosePrice)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrCenteringRequiresSourceLength))
+
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, err = indicators.NewTrimaWithCenteringForStream(stream, period, true, gotrade.UseClosePrice)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrCenteringRequiresSourceLength))
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(BeNil())
+	})
+
+	It("should be created for offline usage with a source data stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, err := indicators.NewTrimaWithCenteringForStreamWithSrcLen(uint(bars), stream, period, true, gotrade.UseClosePrice)
+		Expect(err).To(BeNil())
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
@@ -0,0 +1,249 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionTsi func(dataItemTsi float64, dataItemSignal float64, streamBarIndex int)
+
+// A True Strength Index Indicator (Tsi), no storage, for use in other indicators
+// the momentum, the change in price from the previous bar, double smoothed by an Ema of the longPeriod and then an
+// Ema of the shortPeriod, as a percentage of the absolute momentum smoothed the same way, with a signal line of an
+// Ema of the signalPeriod of the tsi, a signalPeriod of 0 gives a signal of the tsi itself. The tsi is bounded by
+// -100.0 and 100.0 and is 0.0 while the smoothed absolute momentum is 0
+type TsiWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionTsi
+	momentum             *ChainWithoutStorage
+	absMomentum          *ChainWithoutStorage
+	signal               *EmaWithoutStorage
+	hasPreviousPrice     bool
+	previousPrice        float64
+	currentMomentum      float64
+	currentTsi           float64
+}
+
+// NewTsiWithoutStorage creates a True Strength Index Indicator (Tsi) without storage
+func NewTsiWithoutStorage(longPeriod int, shortPeriod int, signalPeriod int, valueAvailableAction ValueAvailableActionTsi) (indicator *TsiWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum longPeriod for this indicator is 2
+	if longPeriod < 2 {
+		return nil, errors.New("longPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum longPeriod
+	if longPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("longPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum shortPeriod for this indicator is 2
+	if shortPeriod < 2 {
+		return nil, errors.New("shortPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum shortPeriod
+	if shortPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("shortPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum signalPeriod for the Ema is 2, 0 disables the signal Ema
+	if signalPeriod < 0 || signalPeriod == 1 {
+		return nil, errors.New("signalPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum signalPeriod
+	if signalPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("signalPeriod is greater than the maximum (100000)")
+	}
+
+	ind := TsiWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+	}
+
+	if signalPeriod > 0 {
+		ind.signal, err = NewEmaWithoutStorage(signalPeriod, func(dataItem float64, streamBarIndex int) {
+			ind.emitResult(dataItem, streamBarIndex)
+		})
+	}
+
+	// the momentum and the absolute momentum are each double smoothed by a chain of the long and then the short Ema
+	longEma := func(valueAvailableAction ValueAvailableActionFloat) (FloatIndicatorWithoutStorage, error) {
+		return NewEmaWithoutStorage(longPeriod, valueAvailableAction)
+	}
+	shortEma := func(valueAvailableAction ValueAvailableActionFloat) (FloatIndicatorWithoutStorage, error) {
+		return NewEmaWithoutStorage(shortPeriod, valueAvailableAction)
+	}
+
+	ind.momentum, err = NewChainWithoutStorage(func(dataItem float64, streamBarIndex int) {
+		ind.currentMomentum = dataItem
+	}, longEma, shortEma)
+
+	ind.absMomentum, err = NewChainWithoutStorage(func(dataItem float64, streamBarIndex int) {
+		ind.currentTsi = 0.0
+		if dataItem != 0.0 {
+			ind.currentTsi = 100.0 * ind.currentMomentum / dataItem
+		}
+		if ind.signal != nil {
+			ind.signal.ReceiveTick(ind.currentTsi, streamBarIndex)
+			return
+		}
+		ind.emitResult(ind.currentTsi, streamBarIndex)
+	}, longEma, shortEma)
+
+	// the momentum needs a previous price
+	lookback := 1 + ind.momentum.GetLookbackPeriod()
+	if ind.signal != nil {
+		lookback += ind.signal.GetLookbackPeriod()
+	}
+	ind.baseIndicator = newBaseIndicator(lookback)
+
+	return &ind, err
+}
+
+// emitResult makes the current tsi available with its signal
+func (ind *TsiWithoutStorage) emitResult(signal float64, streamBarIndex int) {
+	tsi := ind.quantize(ind.currentTsi)
+	signal = ind.quantize(signal)
+
+	ind.UpdateMinMax(math.Min(tsi, signal), math.Max(tsi, signal))
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(tsi, signal, streamBarIndex)
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *TsiWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	if ind.hasPreviousPrice {
+		// the smoothed momentum is received before the smoothed absolute momentum of the same bar
+		momentum := tickData - ind.previousPrice
+		ind.momentum.ReceiveTick(momentum, streamBarIndex)
+		ind.absMomentum.ReceiveTick(math.Abs(momentum), streamBarIndex)
+	}
+
+	ind.previousPrice = tickData
+	ind.hasPreviousPrice = true
+}
+
+// A True Strength Index Indicator (Tsi)
+type Tsi struct {
+	*TsiWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Tsi    []float64
+	Signal []float64
+}
+
+// NewTsi creates a True Strength Index Indicator (Tsi) for online usage
+func NewTsi(longPeriod int, shortPeriod int, signalPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Tsi, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Tsi{
+		selectData: selectData,
+	}
+
+	ind.TsiWithoutStorage, err = NewTsiWithoutStorage(longPeriod, shortPeriod, signalPeriod,
+		func(dataItemTsi float64, dataItemSignal float64, streamBarIndex int) {
+			ind.Tsi = append(ind.Tsi, dataItemTsi)
+			ind.Signal = append(ind.Signal, dataItemSignal)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultTsi creates a True Strength Index Indicator (Tsi) for online usage with default parameters
+//	- longPeriod: 25
+//	- shortPeriod: 13
+//	- signalPeriod: 13
+func NewDefaultTsi() (indicator *Tsi, err error) {
+	longPeriod := 25
+	shortPeriod := 13
+	signalPeriod := 13
+	return NewTsi(longPeriod, shortPeriod, signalPeriod, gotrade.UseClosePrice)
+}
+
+// NewTsiWithSrcLen creates a True Strength Index Indicator (Tsi) for offline usage
+func NewTsiWithSrcLen(sourceLength uint, longPeriod int, shortPeriod int, signalPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Tsi, err error) {
+	ind, err := NewTsi(longPeriod, shortPeriod, signalPeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Tsi = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Signal = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultTsiWithSrcLen creates a True Strength Index Indicator (Tsi) for offline usage with default parameters
+func NewDefaultTsiWithSrcLen(sourceLength uint) (indicator *Tsi, err error) {
+	ind, err := NewDefaultTsi()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Tsi = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Signal = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewTsiForStream creates a True Strength Index Indicator (Tsi) for online usage with a source data stream
+func NewTsiForStream(priceStream gotrade.DOHLCVStreamSubscriber, longPeriod int, shortPeriod int, signalPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Tsi, err error) {
+	ind, err := NewTsi(longPeriod, shortPeriod, signalPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultTsiForStream creates a True Strength Index Indicator (Tsi) for online usage with a source data stream
+func NewDefaultTsiForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Tsi, err error) {
+	ind, err := NewDefaultTsi()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewTsiForStreamWithSrcLen creates a True Strength Index Indicator (Tsi) for offline usage with a source data stream
+func NewTsiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, longPeriod int, shortPeriod int, signalPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Tsi, err error) {
+	ind, err := NewTsiWithSrcLen(sourceLength, longPeriod, shortPeriod, signalPeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultTsiForStreamWithSrcLen creates a True Strength Index Indicator (Tsi) for offline usage with a source data stream
+func NewDefaultTsiForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Tsi, err error) {
+	ind, err := NewDefaultTsiWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Tsi) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// WriteCSV writes the Tsi results as rows after a header of barIndex,tsi,signal, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *Tsi) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("tsi", ind.Tsi), floatCSVColumn("signal", ind.Signal))
+}
@@ -0,0 +1,184 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var _ = Describe("when creating a tsiwithoutstorage", func() {
+	var (
+		indicator      *indicators.TsiWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemTsi float64, dataItemSignal float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTsiWithoutStorage(25, 13, 13, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a longPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTsiWithoutStorage(1, 13, 13, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a shortPeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTsiWithoutStorage(25, indicators.MaximumLookbackPeriod+1, 13, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+
+	Context("and the indicator was given a signalPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTsiWithoutStorage(25, 13, 1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was not given a data selection func", func() {
+		It("the indicator should not be created and return the appropriate error message", func() {
+			indicator, err := indicators.NewTsi(25, 13, 13, nil)
+			Expect(indicator).To(BeNil())
+			Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+		})
+	})
+})
+
+var _ = Describe("when calculating a true strength index (tsi) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.Tsi
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultTsiWithSrcLen(uint(len(sourceDOHLCVData)))
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should have a result once the signal is available", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(1 + 24 + 12 + 12))
+		Expect(indicator.ValidFromBar()).To(Equal(50))
+		Expect(indicator.Tsi).To(HaveLen(len(sourceDOHLCVData) - 49))
+		Expect(indicator.Signal).To(HaveLen(len(sourceDOHLCVData) - 49))
+	})
+
+	It("should be bounded by -100 and 100", func() {
+		for i := range indicator.Tsi {
+			Expect(math.Abs(indicator.Tsi[i])).To(BeNumerically("<=", 100.0))
+			Expect(math.Abs(indicator.Signal[i])).To(BeNumerically("<=", 100.0))
+		}
+		Expect(indicator.MaxValue()).To(Equal(math.Max(GetFloatDataMax(indicator.Tsi), GetFloatDataMax(indicator.Signal))))
+		Expect(indicator.MinValue()).To(Equal(math.Min(GetFloatDataMin(indicator.Tsi), GetFloatDataMin(indicator.Signal))))
+	})
+
+	It("should be the double smoothed momentum as a percentage of the double smoothed absolute momentum", func() {
+		momentum, absMomentum := []float64{}, []float64{}
+		smooth := func(values *[]float64) *indicators.ChainWithoutStorage {
+			chain, _ := indicators.NewChainWithoutStorage(func(dataItem float64, streamBarIndex int) {
+				*values = append(*values, dataItem)
+			}, func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+				return indicators.NewEmaWithoutStorage(25, valueAvailableAction)
+			}, func(valueAvailableAction indicators.ValueAvailableActionFloat) (indicators.FloatIndicatorWithoutStorage, error) {
+				return indicators.NewEmaWithoutStorage(13, valueAvailableAction)
+			})
+			return chain
+		}
+		momentumChain, absMomentumChain := smooth(&momentum), smooth(&absMomentum)
+		for i := 1; i < len(sourceDOHLCVData); i++ {
+			change := sourceDOHLCVData[i].C() - sourceDOHLCVData[i-1].C()
+			momentumChain.ReceiveTick(change, i+1)
+			absMomentumChain.ReceiveTick(math.Abs(change), i+1)
+		}
+
+		// the signal lookback lags the tsi behind the smoothed momentum
+		offset := len(momentum) - len(indicator.Tsi)
+		for i := range indicator.Tsi {
+			Expect(indicator.Tsi[i]).To(BeNumerically("~", 100.0*momentum[i+offset]/absMomentum[i+offset], 0.0000001))
+		}
+	})
+})
+
+var _ = Describe("when calculating a true strength index (tsi) of a rise and then a fall", func() {
+	var (
+		indicator *indicators.Tsi
+		segment   int = 60
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewTsi(25, 13, 0, gotrade.UseClosePrice)
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		price := 100.0
+		for i := 0; i < 2*segment; i++ {
+			if i < segment {
+				price += 1.0
+			} else {
+				price -= 1.0
+			}
+			indicator.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price, price, price, 1000.0), i+1)
+		}
+	})
+
+	It("should track the momentum, 100 throughout the rise and falling toward -100 after it", func() {
+		riseBars := segment - indicator.ValidFromBar() + 1
+		for i := 0; i < riseBars; i++ {
+			Expect(indicator.Tsi[i]).To(BeNumerically("~", 100.0, 0.0000001))
+		}
+		for i := riseBars + 1; i < len(indicator.Tsi); i++ {
+			Expect(indicator.Tsi[i]).To(BeNumerically("<", indicator.Tsi[i-1]))
+		}
+		Expect(indicator.Tsi[len(indicator.Tsi)-1]).To(BeNumerically("<", -90.0))
+	})
+
+	It("should give a signal of the tsi itself for a signalPeriod of 0", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(1 + 24 + 12))
+		Expect(indicator.Signal).To(Equal(indicator.Tsi))
+	})
+})
+
+var _ = Describe("when calculating a true strength index (tsi) with flat source data", func() {
+	It("should be 0 while the price is unchanged", func() {
+		indicator, _ := indicators.NewDefaultTsi()
+		for i := 0; i < 60; i++ {
+			indicator.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(time.Time{}, 50.0, 50.0, 50.0, 50.0, 1000.0), i+1)
+		}
+
+		Expect(indicator.Tsi).NotTo(BeEmpty())
+		for i := range indicator.Tsi {
+			Expect(indicator.Tsi[i]).To(Equal(0.0))
+		}
+	})
+})
+
+var _ = Describe("when creating a tsi for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewDefaultTsiForStream(stream)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
@@ -0,0 +1,219 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+	"strconv"
+)
+
+type ValueAvailableActionTtmSqueeze func(dataItemSqueezeOn bool, dataItemMomentum float64, streamBarIndex int)
+
+// A TTM Squeeze Indicator (TtmSqueeze), no storage, for use in other indicators
+// the squeeze is on while the Bollinger Bands of the timePeriod, 2 standard deviations about an Sma of the close,
+// are inside the Keltner Channel of the timePeriod, keltnerMultiplier times the Atr about the same Sma. The momentum
+// is the linear regression of the timePeriod of the close less the midline, the average of the Sma and the mid
+// point of the highest high and lowest low of the timePeriod
+type TtmSqueezeWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionTtmSqueeze
+	keltnerMultiplier    float64
+	bollinger            *BollingerBandsWithoutStorage
+	atr                  *AtrWithoutStorage
+	highestHigh          *HhvWithoutStorage
+	lowestLow            *LlvWithoutStorage
+	momentum             *LinRegWithoutStorage
+	currentClose         float64
+	currentAtr           float64
+	currentHighestHigh   float64
+	currentLowestLow     float64
+	currentSqueezeOn     bool
+}
+
+// NewTtmSqueezeWithoutStorage creates a TTM Squeeze Indicator (TtmSqueeze) without storage
+func NewTtmSqueezeWithoutStorage(timePeriod int, keltnerMultiplier float64, valueAvailableAction ValueAvailableActionTtmSqueeze) (indicator *TtmSqueezeWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum keltnerMultiplier for this indicator is 0
+	if keltnerMultiplier < 0.0 {
+		return nil, errors.New("keltnerMultiplier is less than the minimum (0)")
+	}
+
+	// check the maximum keltnerMultiplier
+	if keltnerMultiplier > math.MaxFloat64 {
+		return nil, errors.New("keltnerMultiplier is greater than the maximum float64 size")
+	}
+
+	ind := TtmSqueezeWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		keltnerMultiplier:    keltnerMultiplier,
+	}
+
+	ind.highestHigh, err = NewHhvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentHighestHigh = dataItem
+	})
+
+	ind.lowestLow, err = NewLlvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentLowestLow = dataItem
+	})
+
+	ind.atr, err = NewAtrWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.currentAtr = dataItem
+	})
+
+	// the Bollinger Bands are the last of the period indicators to receive the tick, the others are current
+	ind.bollinger, err = NewBollingerBandsWithoutStorage(timePeriod, func(dataItemUpperBand float64, dataItemMiddleBand float64, dataItemLowerBand float64, streamBarIndex int) {
+		keltnerWidth := ind.keltnerMultiplier * ind.currentAtr
+		ind.currentSqueezeOn = dataItemUpperBand < dataItemMiddleBand+keltnerWidth && dataItemLowerBand > dataItemMiddleBand-keltnerWidth
+
+		midline := ((ind.currentHighestHigh+ind.currentLowestLow)/2.0 + dataItemMiddleBand) / 2.0
+		ind.momentum.ReceiveTick(ind.currentClose-midline, streamBarIndex)
+	})
+
+	ind.momentum, err = NewLinRegWithoutStorage(timePeriod, func(dataItem float64, slope float64, intercept float64, streamBarIndex int) {
+		momentum := ind.quantize(dataItem)
+
+		ind.UpdateMinMax(momentum, momentum)
+
+		ind.IncDataLength()
+
+		ind.SetValidFromBar(streamBarIndex)
+
+		// notify of a new result value though the value available action
+		ind.valueAvailableAction(ind.currentSqueezeOn, momentum, streamBarIndex)
+	})
+
+	// the Atr lookback of timePeriod is within that of the momentum
+	lookback := ind.bollinger.GetLookbackPeriod() + ind.momentum.GetLookbackPeriod()
+	ind.baseIndicator = newBaseIndicator(lookback)
+
+	return &ind, err
+}
+
+// CurrentSqueezeOn returns whether the squeeze was on as of the last tick received, available before the first result
+func (ind *TtmSqueezeWithoutStorage) CurrentSqueezeOn() bool {
+	return ind.currentSqueezeOn
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *TtmSqueezeWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.currentClose = tickData.C()
+	ind.highestHigh.ReceiveTick(tickData.H(), streamBarIndex)
+	ind.lowestLow.ReceiveTick(tickData.L(), streamBarIndex)
+	ind.atr.ReceiveDOHLCVTick(tickData, streamBarIndex)
+	ind.bollinger.RecieveTick(tickData.C(), streamBarIndex)
+}
+
+// A TTM Squeeze Indicator (TtmSqueeze)
+type TtmSqueeze struct {
+	*TtmSqueezeWithoutStorage
+
+	// public variables
+	SqueezeOn []bool
+	Momentum  []float64
+}
+
+// NewTtmSqueeze creates a TTM Squeeze Indicator (TtmSqueeze) for online usage
+func NewTtmSqueeze(timePeriod int, keltnerMultiplier float64) (indicator *TtmSqueeze, err error) {
+	ind := TtmSqueeze{}
+	ind.TtmSqueezeWithoutStorage, err = NewTtmSqueezeWithoutStorage(timePeriod, keltnerMultiplier,
+		func(dataItemSqueezeOn bool, dataItemMomentum float64, streamBarIndex int) {
+			ind.SqueezeOn = append(ind.SqueezeOn, dataItemSqueezeOn)
+			ind.Momentum = append(ind.Momentum, dataItemMomentum)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultTtmSqueeze creates a TTM Squeeze Indicator (TtmSqueeze) for online usage with default parameters
+//	- timePeriod: 20
+//	- keltnerMultiplier: 1.5
+func NewDefaultTtmSqueeze() (indicator *TtmSqueeze, err error) {
+	timePeriod := 20
+	keltnerMultiplier := 1.5
+	return NewTtmSqueeze(timePeriod, keltnerMultiplier)
+}
+
+// NewTtmSqueezeWithSrcLen creates a TTM Squeeze Indicator (TtmSqueeze) for offline usage
+func NewTtmSqueezeWithSrcLen(sourceLength uint, timePeriod int, keltnerMultiplier float64) (indicator *TtmSqueeze, err error) {
+	ind, err := NewTtmSqueeze(timePeriod, keltnerMultiplier)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.SqueezeOn = make([]bool, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Momentum = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultTtmSqueezeWithSrcLen creates a TTM Squeeze Indicator (TtmSqueeze) for offline usage with default parameters
+func NewDefaultTtmSqueezeWithSrcLen(sourceLength uint) (indicator *TtmSqueeze, err error) {
+	ind, err := NewDefaultTtmSqueeze()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.SqueezeOn = make([]bool, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Momentum = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewTtmSqueezeForStream creates a TTM Squeeze Indicator (TtmSqueeze) for online usage with a source data stream
+func NewTtmSqueezeForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, keltnerMultiplier float64) (indicator *TtmSqueeze, err error) {
+	ind, err := NewTtmSqueeze(timePeriod, keltnerMultiplier)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultTtmSqueezeForStream creates a TTM Squeeze Indicator (TtmSqueeze) for online usage with a source data stream
+func NewDefaultTtmSqueezeForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *TtmSqueeze, err error) {
+	ind, err := NewDefaultTtmSqueeze()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewTtmSqueezeForStreamWithSrcLen creates a TTM Squeeze Indicator (TtmSqueeze) for offline usage with a source data stream
+func NewTtmSqueezeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, keltnerMultiplier float64) (indicator *TtmSqueeze, err error) {
+	ind, err := NewTtmSqueezeWithSrcLen(sourceLength, timePeriod, keltnerMultiplier)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultTtmSqueezeForStreamWithSrcLen creates a TTM Squeeze Indicator (TtmSqueeze) for offline usage with a source data stream
+func NewDefaultTtmSqueezeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *TtmSqueeze, err error) {
+	ind, err := NewDefaultTtmSqueezeWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the TtmSqueeze results as rows after a header of barIndex,squeezeOn,momentum, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *TtmSqueeze) WriteCSV(w io.Writer, startBarOffset int) error {
+	squeezeOn := csvColumn{name: "squeezeOn", length: len(ind.SqueezeOn), format: func(index int) string {
+		return strconv.FormatBool(ind.SqueezeOn[index])
+	}}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), squeezeOn, floatCSVColumn("momentum", ind.Momentum))
+}
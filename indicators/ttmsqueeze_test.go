@@ -0,0 +1,143 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a ttmsqueezewithoutstorage", func() {
+	var (
+		indicator      *indicators.TtmSqueezeWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemSqueezeOn bool, dataItemMomentum float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTtmSqueezeWithoutStorage(20, 1.5, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTtmSqueezeWithoutStorage(1, 1.5, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a timePeriod above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTtmSqueezeWithoutStorage(indicators.MaximumLookbackPeriod+1, 1.5, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+
+	Context("and the indicator was given a keltnerMultiplier below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewTtmSqueezeWithoutStorage(20, -1.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a ttm squeeze (ttmsqueeze) with DOHLCV source data", func() {
+	var (
+		period    int = 20
+		quiet     int = 60
+		breakout  int = 30
+		indicator *indicators.TtmSqueeze
+		bars      []gotrade.DOHLCV
+	)
+
+	// a quiet section of a constant range about an almost flat close, then a breakout trending steadily upward
+	start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	price := 100.0
+	for i := 0; i < quiet+breakout; i++ {
+		closePrice := price + 0.1*float64(i%2)
+		if i >= quiet {
+			price += 2.0
+			closePrice = price
+		}
+		bars = append(bars, gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), closePrice, closePrice+1.0, closePrice-1.0, closePrice, 1000.0))
+	}
+
+	// the squeeze state of the result for the stream bar index
+	squeezeOnAt := func(streamBarIndex int) bool {
+		return indicator.SqueezeOn[streamBarIndex-indicator.ValidFromBar()]
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultTtmSqueeze()
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+	})
+
+	It("should have a result for each bar after the lookback period", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(2 * (period - 1)))
+		Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		Expect(len(indicator.SqueezeOn)).To(Equal(len(bars) - indicator.GetLookbackPeriod()))
+		Expect(len(indicator.Momentum)).To(Equal(len(bars) - indicator.GetLookbackPeriod()))
+		Expect(indicator.Length()).To(Equal(len(bars) - indicator.GetLookbackPeriod()))
+	})
+
+	It("the squeeze should be on during the quiet section", func() {
+		for bar := indicator.ValidFromBar(); bar <= quiet; bar++ {
+			Expect(squeezeOnAt(bar)).To(BeTrue())
+		}
+	})
+
+	It("the squeeze should be off once the breakout widens the bollinger bands beyond the keltner channel", func() {
+		Expect(squeezeOnAt(quiet + period/2)).To(BeFalse())
+		Expect(squeezeOnAt(quiet + breakout)).To(BeFalse())
+		Expect(indicator.CurrentSqueezeOn()).To(BeFalse())
+	})
+
+	It("the momentum should be positive during the breakout", func() {
+		for bar := quiet + period/2; bar <= quiet+breakout; bar++ {
+			Expect(indicator.Momentum[bar-indicator.ValidFromBar()]).To(BeNumerically(">", 0.0))
+		}
+	})
+
+	It("the bounds should be the bounds of the momentum", func() {
+		maxMomentum, minMomentum := indicator.Momentum[0], indicator.Momentum[0]
+		for _, momentum := range indicator.Momentum {
+			if momentum > maxMomentum {
+				maxMomentum = momentum
+			}
+			if momentum < minMomentum {
+				minMomentum = momentum
+			}
+		}
+		Expect(indicator.MaxValue()).To(Equal(maxMomentum))
+		Expect(indicator.MinValue()).To(Equal(minMomentum))
+	})
+
+	It("a keltnerMultiplier of 0 should never be in a squeeze", func() {
+		never, _ := indicators.NewTtmSqueeze(period, 0.0)
+		for i := range bars {
+			never.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+		Expect(never.SqueezeOn).NotTo(ContainElement(true))
+	})
+})
@@ -2,6 +2,7 @@ package indicators
 
 import (
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 type TypPriceWithoutStorage struct {
@@ -74,3 +75,15 @@ func (ind *TypPriceWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, st
 
 	ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 }
+
+// ValuesInRange returns the TypPrice results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *TypPrice) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the TypPrice results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *TypPrice) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Variance Indicator (Var), no storage, for use in other indicators
@@ -176,3 +177,15 @@ func (ind *VarWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
 }
+
+// ValuesInRange returns the Var results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Var) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Var results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Var) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,185 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// A Vertical Horizontal Filter Indicator (Vhf), no storage, for use in other indicators
+// the range of the highest and lowest of the last timePeriod values relative to the sum of the last timePeriod
+// absolute changes from one value to the next, (highest - lowest) / sum(abs(value - previousValue)). The result is
+// higher for a trending market and lower for a congested one. A window without any change gives 0
+type VhfWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	hhv              *HhvWithoutStorage
+	llv              *LlvWithoutStorage
+	sum              *SumWithoutStorage
+	highest          float64
+	lowest           float64
+	hasPreviousValue bool
+	previousValue    float64
+}
+
+// NewVhfWithoutStorage creates a Vertical Horizontal Filter Indicator (Vhf) without storage
+func NewVhfWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *VhfWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod
+	ind := VhfWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+	}
+
+	ind.hhv, err = NewHhvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.highest = dataItem
+	})
+
+	ind.llv, err = NewLlvWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		ind.lowest = dataItem
+	})
+
+	ind.sum, err = NewSumWithoutStorage(timePeriod, func(dataItem float64, streamBarIndex int) {
+		result := 0.0
+		if dataItem > 0.0 {
+			result = (ind.highest - ind.lowest) / dataItem
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	})
+
+	return &ind, err
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *VhfWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	// the highest and lowest of the bar are received before its change
+	ind.hhv.ReceiveTick(tickData, streamBarIndex)
+	ind.llv.ReceiveTick(tickData, streamBarIndex)
+
+	if ind.hasPreviousValue {
+		ind.sum.ReceiveTick(math.Abs(tickData-ind.previousValue), streamBarIndex)
+	}
+	ind.hasPreviousValue = true
+	ind.previousValue = tickData
+}
+
+// A Vertical Horizontal Filter Indicator (Vhf)
+type Vhf struct {
+	*VhfWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewVhf creates a Vertical Horizontal Filter Indicator (Vhf) for online usage
+func NewVhf(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Vhf, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := Vhf{
+		selectData: selectData,
+	}
+
+	ind.VhfWithoutStorage, err = NewVhfWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultVhf creates a Vertical Horizontal Filter Indicator (Vhf) for online usage with default parameters
+//	- timePeriod: 28
+func NewDefaultVhf() (indicator *Vhf, err error) {
+	timePeriod := 28
+	return NewVhf(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewVhfWithSrcLen creates a Vertical Horizontal Filter Indicator (Vhf) for offline usage
+func NewVhfWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Vhf, err error) {
+	ind, err := NewVhf(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultVhfWithSrcLen creates a Vertical Horizontal Filter Indicator (Vhf) for offline usage with default parameters
+func NewDefaultVhfWithSrcLen(sourceLength uint) (indicator *Vhf, err error) {
+	ind, err := NewDefaultVhf()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewVhfForStream creates a Vertical Horizontal Filter Indicator (Vhf) for online usage with a source data stream
+func NewVhfForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Vhf, err error) {
+	ind, err := NewVhf(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultVhfForStream creates a Vertical Horizontal Filter Indicator (Vhf) for online usage with a source data stream
+func NewDefaultVhfForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Vhf, err error) {
+	ind, err := NewDefaultVhf()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewVhfForStreamWithSrcLen creates a Vertical Horizontal Filter Indicator (Vhf) for offline usage with a source data stream
+func NewVhfForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *Vhf, err error) {
+	ind, err := NewVhfWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultVhfForStreamWithSrcLen creates a Vertical Horizontal Filter Indicator (Vhf) for offline usage with a source data stream
+func NewDefaultVhfForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *Vhf, err error) {
+	ind, err := NewDefaultVhfWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *Vhf) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the Vhf results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Vhf) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Vhf results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Vhf) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -0,0 +1,232 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"strconv"
+)
+
+// A VolatilityRegime is the level of the volatility given by a VolRegime
+type VolatilityRegime int
+
+const (
+	// the percentile of the Atr is between the low and high cutoffs
+	VolatilityRegimeNormal VolatilityRegime = iota
+	// the percentile of the Atr is at or below the low cutoff
+	VolatilityRegimeLow
+	// the percentile of the Atr is at or above the high cutoff
+	VolatilityRegimeHigh
+)
+
+type ValueAvailableActionVolRegime func(dataItemPercentile float64, dataItemRegime VolatilityRegime, streamBarIndex int)
+
+// A Volatility Regime Indicator (VolRegime), no storage, for use in other indicators
+// the percentile rank, 0 to 100, of each Atr of the atrPeriod among the last rankPeriod Atr results, classified as
+// a low, normal or high volatility regime by the low and high cutoffs of the percentile. An Atr equal to others
+// in the window is ranked in the middle of them, so that a flat window gives a percentile of 50
+type VolRegimeWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionVolRegime
+	atr                  *AtrWithoutStorage
+	rank                 *rollingPercentRank
+	lowCutoff            float64
+	highCutoff           float64
+	currentRegime        VolatilityRegime
+}
+
+// NewVolRegimeWithoutStorage creates a Volatility Regime Indicator (VolRegime) without storage
+func NewVolRegimeWithoutStorage(atrPeriod int, rankPeriod int, lowCutoff float64, highCutoff float64, valueAvailableAction ValueAvailableActionVolRegime) (indicator *VolRegimeWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum atrPeriod for this indicator is 1
+	if atrPeriod < 1 {
+		return nil, errors.New("atrPeriod is less than the minimum (1)")
+	}
+
+	// check the maximum atrPeriod
+	if atrPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("atrPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum rankPeriod for this indicator is 2
+	if rankPeriod < 2 {
+		return nil, errors.New("rankPeriod is less than the minimum (2)")
+	}
+
+	// check the maximum rankPeriod
+	if rankPeriod > MaximumLookbackPeriod {
+		return nil, errors.New("rankPeriod is greater than the maximum (100000)")
+	}
+
+	// the minimum lowCutoff for this indicator is 0
+	if lowCutoff < 0.0 {
+		return nil, errors.New("lowCutoff is less than the minimum (0)")
+	}
+
+	// the maximum highCutoff for this indicator is 100
+	if highCutoff > 100.0 {
+		return nil, errors.New("highCutoff is greater than the maximum (100)")
+	}
+
+	// the cutoffs must not overlap
+	if lowCutoff >= highCutoff {
+		return nil, errors.New("lowCutoff is greater than the maximum (the highCutoff)")
+	}
+
+	ind := VolRegimeWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+		rank:                 newRollingPercentRank(rankPeriod),
+		lowCutoff:            lowCutoff,
+		highCutoff:           highCutoff,
+	}
+
+	ind.atr, err = NewAtrWithoutStorage(atrPeriod, func(dataItem float64, streamBarIndex int) {
+		percentile := ind.rank.percentRank(dataItem)
+
+		// the percentile is only available once the window is full
+		if !ind.rank.isFull() {
+			return
+		}
+
+		regime := VolatilityRegimeNormal
+		if percentile <= ind.lowCutoff {
+			regime = VolatilityRegimeLow
+		} else if percentile >= ind.highCutoff {
+			regime = VolatilityRegimeHigh
+		}
+		ind.currentRegime = regime
+
+		percentile = ind.quantize(percentile)
+
+		ind.UpdateMinMax(percentile, percentile)
+
+		ind.IncDataLength()
+
+		ind.SetValidFromBar(streamBarIndex)
+
+		// notify of a new result value though the value available action
+		ind.valueAvailableAction(percentile, regime, streamBarIndex)
+	})
+
+	ind.baseIndicator = newBaseIndicator(ind.atr.GetLookbackPeriod() + rankPeriod - 1)
+
+	return &ind, err
+}
+
+// CurrentRegime returns the volatility regime of the last result
+func (ind *VolRegimeWithoutStorage) CurrentRegime() VolatilityRegime {
+	return ind.currentRegime
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *VolRegimeWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.atr.ReceiveDOHLCVTick(tickData, streamBarIndex)
+}
+
+// A Volatility Regime Indicator (VolRegime)
+type VolRegime struct {
+	*VolRegimeWithoutStorage
+
+	// public variables
+	Percentile []float64
+	Regime     []VolatilityRegime
+}
+
+// NewVolRegime creates a Volatility Regime Indicator (VolRegime) for online usage
+func NewVolRegime(atrPeriod int, rankPeriod int, lowCutoff float64, highCutoff float64) (indicator *VolRegime, err error) {
+	ind := VolRegime{}
+	ind.VolRegimeWithoutStorage, err = NewVolRegimeWithoutStorage(atrPeriod, rankPeriod, lowCutoff, highCutoff,
+		func(dataItemPercentile float64, dataItemRegime VolatilityRegime, streamBarIndex int) {
+			ind.Percentile = append(ind.Percentile, dataItemPercentile)
+			ind.Regime = append(ind.Regime, dataItemRegime)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultVolRegime creates a Volatility Regime Indicator (VolRegime) for online usage with default parameters
+//	- atrPeriod: 14
+//	- rankPeriod: 100
+//	- lowCutoff: 25.0
+//	- highCutoff: 75.0
+func NewDefaultVolRegime() (indicator *VolRegime, err error) {
+	atrPeriod := 14
+	rankPeriod := 100
+	lowCutoff := 25.0
+	highCutoff := 75.0
+	return NewVolRegime(atrPeriod, rankPeriod, lowCutoff, highCutoff)
+}
+
+// NewVolRegimeWithSrcLen creates a Volatility Regime Indicator (VolRegime) for offline usage
+func NewVolRegimeWithSrcLen(sourceLength uint, atrPeriod int, rankPeriod int, lowCutoff float64, highCutoff float64) (indicator *VolRegime, err error) {
+	ind, err := NewVolRegime(atrPeriod, rankPeriod, lowCutoff, highCutoff)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Percentile = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Regime = make([]VolatilityRegime, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultVolRegimeWithSrcLen creates a Volatility Regime Indicator (VolRegime) for offline usage with default parameters
+func NewDefaultVolRegimeWithSrcLen(sourceLength uint) (indicator *VolRegime, err error) {
+	ind, err := NewDefaultVolRegime()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Percentile = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Regime = make([]VolatilityRegime, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewVolRegimeForStream creates a Volatility Regime Indicator (VolRegime) for online usage with a source data stream
+func NewVolRegimeForStream(priceStream gotrade.DOHLCVStreamSubscriber, atrPeriod int, rankPeriod int, lowCutoff float64, highCutoff float64) (indicator *VolRegime, err error) {
+	ind, err := NewVolRegime(atrPeriod, rankPeriod, lowCutoff, highCutoff)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultVolRegimeForStream creates a Volatility Regime Indicator (VolRegime) for online usage with a source data stream
+func NewDefaultVolRegimeForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *VolRegime, err error) {
+	ind, err := NewDefaultVolRegime()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewVolRegimeForStreamWithSrcLen creates a Volatility Regime Indicator (VolRegime) for offline usage with a source data stream
+func NewVolRegimeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, atrPeriod int, rankPeriod int, lowCutoff float64, highCutoff float64) (indicator *VolRegime, err error) {
+	ind, err := NewVolRegimeWithSrcLen(sourceLength, atrPeriod, rankPeriod, lowCutoff, highCutoff)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultVolRegimeForStreamWithSrcLen creates a Volatility Regime Indicator (VolRegime) for offline usage with a source data stream
+func NewDefaultVolRegimeForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *VolRegime, err error) {
+	ind, err := NewDefaultVolRegimeWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the VolRegime results as rows after a header of barIndex,percentile,regime, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *VolRegime) WriteCSV(w io.Writer, startBarOffset int) error {
+	regime := csvColumn{name: "regime", length: len(ind.Regime), format: func(index int) string {
+		return strconv.Itoa(int(ind.Regime[index]))
+	}}
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("percentile", ind.Percentile), regime)
+}
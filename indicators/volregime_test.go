@@ -0,0 +1,159 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math/rand"
+	"time"
+)
+
+var _ = Describe("when creating a volregimewithoutstorage", func() {
+	var (
+		indicator      *indicators.VolRegimeWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemPercentile float64, dataItemRegime indicators.VolatilityRegime, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewVolRegimeWithoutStorage(14, 100, 25.0, 75.0, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a rankPeriod below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewVolRegimeWithoutStorage(14, 1, 25.0, 75.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given a highCutoff above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewVolRegimeWithoutStorage(14, 100, 25.0, 101.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+
+	Context("and the indicator was given a lowCutoff above the highCutoff", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewVolRegimeWithoutStorage(14, 100, 75.0, 25.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a volatility regime (volregime) with DOHLCV source data", func() {
+	var (
+		atrPeriod  int = 14
+		rankPeriod int = 100
+		bars       int = 250
+		spikeFrom  int = 240
+		indicator  *indicators.VolRegime
+	)
+
+	// a bar around the price with the range
+	newBar := func(price float64, barRange float64) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(time.Now(), price, price+barRange/2.0, price-barRange/2.0, price, 0.0)
+	}
+
+	// the result index for the stream bar index
+	indexAt := func(streamBarIndex int) int {
+		return streamBarIndex - indicator.ValidFromBar()
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultVolRegime()
+
+		// bars of a range of about 1, until the range spikes to 5 from the spikeFrom bar
+		random := rand.New(rand.NewSource(21))
+		price := 100.0
+		for i := 1; i <= bars; i++ {
+			price += random.NormFloat64() * 0.2
+			barRange := 0.8 + random.Float64()*0.4
+			if i >= spikeFrom {
+				barRange = 5.0
+			}
+			indicator.ReceiveDOHLCVTick(newBar(price, barRange), i)
+		}
+	})
+
+	It("should have a result once the rank period of the atr is full", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(atrPeriod + rankPeriod - 1))
+		Expect(indicator.ValidFromBar()).To(Equal(indicator.GetLookbackPeriod() + 1))
+		Expect(indicator.Percentile).To(HaveLen(bars - indicator.GetLookbackPeriod()))
+		Expect(indicator.Regime).To(HaveLen(len(indicator.Percentile)))
+	})
+
+	It("should have percentiles within 0 to 100 classified by the cutoffs", func() {
+		for i, percentile := range indicator.Percentile {
+			Expect(percentile).To(BeNumerically(">=", 0.0))
+			Expect(percentile).To(BeNumerically("<=", 100.0))
+			switch {
+			case percentile <= 25.0:
+				Expect(indicator.Regime[i]).To(Equal(indicators.VolatilityRegimeLow))
+			case percentile >= 75.0:
+				Expect(indicator.Regime[i]).To(Equal(indicators.VolatilityRegimeHigh))
+			default:
+				Expect(indicator.Regime[i]).To(Equal(indicators.VolatilityRegimeNormal))
+			}
+		}
+	})
+
+	It("should not be in a high regime throughout the calm bars", func() {
+		regimes := map[indicators.VolatilityRegime]int{}
+		for bar := indicator.ValidFromBar(); bar < spikeFrom; bar++ {
+			regimes[indicator.Regime[indexAt(bar)]]++
+		}
+		Expect(regimes[indicators.VolatilityRegimeNormal]).To(BeNumerically(">", regimes[indicators.VolatilityRegimeHigh]))
+	})
+
+	It("should push the percentile to 100 and flip to a high regime on a volatility spike", func() {
+		for bar := spikeFrom; bar <= bars; bar++ {
+			Expect(indicator.Percentile[indexAt(bar)]).To(Equal(100.0))
+			Expect(indicator.Regime[indexAt(bar)]).To(Equal(indicators.VolatilityRegimeHigh))
+		}
+		Expect(indicator.CurrentRegime()).To(Equal(indicators.VolatilityRegimeHigh))
+	})
+})
+
+var _ = Describe("when calculating a volatility regime (volregime) of bars of a constant range", func() {
+	It("should rank each atr in the middle of the window as a normal regime", func() {
+		indicator, _ := indicators.NewVolRegime(3, 5, 25.0, 75.0)
+		for i := 1; i <= 20; i++ {
+			indicator.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(time.Now(), 10.0, 11.0, 9.0, 10.0, 0.0), i)
+		}
+
+		Expect(indicator.Percentile).To(HaveLen(20 - indicator.GetLookbackPeriod()))
+		for i := range indicator.Percentile {
+			Expect(indicator.Percentile[i]).To(Equal(50.0))
+			Expect(indicator.Regime[i]).To(Equal(indicators.VolatilityRegimeNormal))
+		}
+	})
+})
+
+var _ = Describe("when creating a volatility regime (volregime) for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewDefaultVolRegimeForStream(stream)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
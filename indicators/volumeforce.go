@@ -0,0 +1,126 @@
+package indicators
+
+import (
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+// Trend = +1 if High + Low + Close > High[-1] + Low[-1] + Close[-1], otherwise -1
+// dm = High - Low
+// VolumeForce = Trend * dm * Volume
+
+// volumeTrend returns the Klinger trend of a bar, +1 when the sum of its high, low and close is above that of the
+// previous bar, otherwise -1
+func volumeTrend(hlc float64, previousHlc float64) float64 {
+	if hlc > previousHlc {
+		return 1.0
+	}
+	return -1.0
+}
+
+// volumeForce returns the Klinger style volume force of a bar, the volume signed by the trend and weighted by the
+// dm (daily measurement), the high - low range of the bar. A bar without a positive dm, e.g. an invalid bar with
+// the low above the high, has no force rather than a force of the opposite sign
+func volumeForce(trend float64, dm float64, volume float64) float64 {
+	if !(dm > 0.0) {
+		return 0.0
+	}
+	return trend * dm * volume
+}
+
+// A Volume Force Indicator (VolumeForce), no storage, for use in other indicators
+type VolumeForceWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodCounter int
+	previousHlc   float64
+}
+
+// NewVolumeForceWithoutStorage creates a Volume Force Indicator (VolumeForce) without storage
+func NewVolumeForceWithoutStorage(valueAvailableAction ValueAvailableActionFloat) (indicator *VolumeForceWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	lookback := 1
+	ind := VolumeForceWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodCounter:                -1,
+		previousHlc:                  0.0,
+	}
+	return &ind, nil
+}
+
+// A Volume Force Indicator (VolumeForce)
+type VolumeForce struct {
+	*VolumeForceWithoutStorage
+
+	// public variables
+	Data []float64
+}
+
+// NewVolumeForce creates a Volume Force Indicator (VolumeForce) for online usage
+func NewVolumeForce() (indicator *VolumeForce, err error) {
+	ind := VolumeForce{}
+	ind.VolumeForceWithoutStorage, err = NewVolumeForceWithoutStorage(func(dataItem float64, streamBarIndex int) {
+		ind.Data = append(ind.Data, dataItem)
+	})
+	return &ind, err
+}
+
+// NewVolumeForceWithSrcLen creates a Volume Force Indicator (VolumeForce) for offline usage
+func NewVolumeForceWithSrcLen(sourceLength uint) (indicator *VolumeForce, err error) {
+	ind, err := NewVolumeForce()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewVolumeForceForStream creates a Volume Force Indicator (VolumeForce) for online usage with a source data stream
+func NewVolumeForceForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *VolumeForce, err error) {
+	ind, err := NewVolumeForce()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewVolumeForceForStreamWithSrcLen creates a Volume Force Indicator (VolumeForce) for offline usage with a source data stream
+func NewVolumeForceForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *VolumeForce, err error) {
+	ind, err := NewVolumeForceWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *VolumeForceWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.periodCounter += 1
+
+	hlc := tickData.H() + tickData.L() + tickData.C()
+
+	if ind.periodCounter > 0 {
+		trend := volumeTrend(hlc, ind.previousHlc)
+		result := volumeForce(trend, tickData.H()-tickData.L(), tickData.V())
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+
+	ind.previousHlc = hlc
+}
+
+// ValuesInRange returns the VolumeForce results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *VolumeForce) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the VolumeForce results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *VolumeForce) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
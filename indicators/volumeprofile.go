@@ -0,0 +1,157 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"math"
+)
+
+// A Volume Profile (VolumeProfile)
+// accumulates the volume traded at each price, the volume of each bar is distributed across the price buckets of
+// bucketSize spanning its low to its high in proportion to the part of the range of the bar within each bucket, a
+// bar with no range puts all of its volume in the bucket of its close. The profile accumulates from the first tick
+// received until it is reset, for example at the start of each session
+type VolumeProfile struct {
+	// private variables
+	bucketSize  float64
+	volumes     map[int64]float64
+	lowBucket   int64
+	highBucket  int64
+	totalVolume float64
+}
+
+// NewVolumeProfile creates a Volume Profile (VolumeProfile)
+func NewVolumeProfile(bucketSize float64) (profile *VolumeProfile, err error) {
+
+	// bucketSize must be above 0
+	if bucketSize <= 0.0 {
+		return nil, errors.New("bucketSize is less than the minimum (above 0)")
+	}
+
+	// check the maximum bucketSize
+	if bucketSize > math.MaxFloat64 {
+		return nil, errors.New("bucketSize is greater than the maximum float64 size")
+	}
+
+	ind := VolumeProfile{
+		bucketSize: bucketSize,
+		volumes:    make(map[int64]float64),
+	}
+
+	return &ind, nil
+}
+
+// NewVolumeProfileForStream creates a Volume Profile (VolumeProfile) with a source data stream
+func NewVolumeProfileForStream(priceStream gotrade.DOHLCVStreamSubscriber, bucketSize float64) (profile *VolumeProfile, err error) {
+	ind, err := NewVolumeProfile(bucketSize)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// Reset clears the profile, the next tick received is the first of a new profile
+func (ind *VolumeProfile) Reset() {
+	ind.volumes = make(map[int64]float64)
+	ind.totalVolume = 0.0
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *VolumeProfile) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	if tickData.V() <= 0.0 {
+		return
+	}
+
+	barRange := tickData.H() - tickData.L()
+	if barRange <= 0.0 {
+		ind.addVolume(ind.bucket(tickData.C()), tickData.V())
+		return
+	}
+
+	for bucket := ind.bucket(tickData.L()); bucket <= ind.bucket(tickData.H()); bucket++ {
+		bucketLow := float64(bucket) * ind.bucketSize
+		overlap := math.Min(tickData.H(), bucketLow+ind.bucketSize) - math.Max(tickData.L(), bucketLow)
+		if overlap > 0.0 {
+			ind.addVolume(bucket, tickData.V()*overlap/barRange)
+		}
+	}
+}
+
+// TotalVolume returns the volume accumulated since the profile was created or last reset
+func (ind *VolumeProfile) TotalVolume() float64 {
+	return ind.totalVolume
+}
+
+// VolumeAt returns the volume accumulated in the price bucket containing the price
+func (ind *VolumeProfile) VolumeAt(price float64) float64 {
+	return ind.volumes[ind.bucket(price)]
+}
+
+// POC returns the point of control, the middle price of the bucket with the most volume, the lowest such bucket
+// on a tie, or 0.0 while the profile is empty
+func (ind *VolumeProfile) POC() float64 {
+	if ind.totalVolume == 0.0 {
+		return 0.0
+	}
+
+	return (float64(ind.pocBucket()) + 0.5) * ind.bucketSize
+}
+
+// ValueArea returns the bounds of the value area, the prices about the point of control containing at least pct
+// percent of the volume, clamped to 0 to 100. The value area starts at the bucket of the point of control and is
+// extended by the adjacent bucket with more volume, by both on a tie, until it contains enough of the volume. The
+// bounds are the high of its highest bucket and the low of its lowest bucket, or 0.0 while the profile is empty
+func (ind *VolumeProfile) ValueArea(pct float64) (high float64, low float64) {
+	if ind.totalVolume == 0.0 {
+		return 0.0, 0.0
+	}
+
+	targetVolume := ind.totalVolume * math.Max(math.Min(pct, 100.0), 0.0) / 100.0
+	lowBucket := ind.pocBucket()
+	highBucket := lowBucket
+	areaVolume := ind.volumes[lowBucket]
+	for areaVolume < targetVolume && (lowBucket > ind.lowBucket || highBucket < ind.highBucket) {
+		var belowVolume, aboveVolume float64 = -1.0, -1.0
+		if lowBucket > ind.lowBucket {
+			belowVolume = ind.volumes[lowBucket-1]
+		}
+		if highBucket < ind.highBucket {
+			aboveVolume = ind.volumes[highBucket+1]
+		}
+
+		if belowVolume >= aboveVolume {
+			lowBucket--
+			areaVolume += belowVolume
+		}
+		if aboveVolume >= belowVolume {
+			highBucket++
+			areaVolume += aboveVolume
+		}
+	}
+
+	return float64(highBucket+1) * ind.bucketSize, float64(lowBucket) * ind.bucketSize
+}
+
+func (ind *VolumeProfile) bucket(price float64) int64 {
+	return int64(math.Floor(price / ind.bucketSize))
+}
+
+func (ind *VolumeProfile) addVolume(bucket int64, volume float64) {
+	if len(ind.volumes) == 0 || bucket < ind.lowBucket {
+		ind.lowBucket = bucket
+	}
+	if len(ind.volumes) == 0 || bucket > ind.highBucket {
+		ind.highBucket = bucket
+	}
+
+	ind.volumes[bucket] += volume
+	ind.totalVolume += volume
+}
+
+func (ind *VolumeProfile) pocBucket() int64 {
+	pocBucket := ind.lowBucket
+	for bucket := ind.lowBucket; bucket <= ind.highBucket; bucket++ {
+		if ind.volumes[bucket] > ind.volumes[pocBucket] {
+			pocBucket = bucket
+		}
+	}
+	return pocBucket
+}
@@ -0,0 +1,117 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math/rand"
+	"time"
+)
+
+var _ = Describe("when creating a volumeprofile", func() {
+	Context("and the profile was given a bucketSize of 0", func() {
+		It("the profile should not be created and return the appropriate error message", func() {
+			profile, err := indicators.NewVolumeProfile(0.0)
+			Expect(profile).To(BeNil())
+			Expect(err.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the profile was created for a stream", func() {
+		It("should have requested to be attached to the stream", func() {
+			stream := newFakeDOHLCVStreamSubscriber()
+			profile, _ := indicators.NewVolumeProfileForStream(stream, 0.5)
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(profile))
+		})
+	})
+})
+
+var _ = Describe("when accumulating a volume profile with DOHLCV source data", func() {
+	var (
+		profile *indicators.VolumeProfile
+		start   = time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	// receives a bar with the high, low and volume
+	receive := func(high float64, low float64, volume float64) {
+		profile.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(start, low, high, low, high, volume), 1)
+	}
+
+	// the volume of the buckets of the profile within the inclusive bounds
+	volumeWithin := func(high float64, low float64) float64 {
+		var volume float64
+		for price := low + 0.25; price < high; price += 0.5 {
+			volume += profile.VolumeAt(price)
+		}
+		return volume
+	}
+
+	BeforeEach(func() {
+		profile, _ = indicators.NewVolumeProfile(0.5)
+	})
+
+	It("should be empty before any ticks are received", func() {
+		high, low := profile.ValueArea(70.0)
+		Expect(profile.TotalVolume()).To(Equal(0.0))
+		Expect(profile.POC()).To(Equal(0.0))
+		Expect(high).To(Equal(0.0))
+		Expect(low).To(Equal(0.0))
+	})
+
+	It("should distribute the volume of a bar across the buckets of its range", func() {
+		receive(101.0, 100.0, 1000.0)
+		Expect(profile.VolumeAt(100.2)).To(BeNumerically("~", 500.0, 1e-9))
+		Expect(profile.VolumeAt(100.7)).To(BeNumerically("~", 500.0, 1e-9))
+		Expect(profile.VolumeAt(101.2)).To(Equal(0.0))
+
+		receive(100.75, 100.25, 400.0)
+		Expect(profile.VolumeAt(100.2)).To(BeNumerically("~", 700.0, 1e-9))
+		Expect(profile.VolumeAt(100.7)).To(BeNumerically("~", 700.0, 1e-9))
+		Expect(profile.TotalVolume()).To(BeNumerically("~", 1400.0, 1e-9))
+	})
+
+	It("should put all of the volume of a bar with no range in the bucket of its close", func() {
+		receive(100.1, 100.1, 300.0)
+		Expect(profile.VolumeAt(100.0)).To(Equal(300.0))
+		Expect(profile.TotalVolume()).To(Equal(300.0))
+	})
+
+	It("should place the point of control in the middle of the highest volume bucket", func() {
+		receive(104.0, 100.0, 800.0)
+		receive(102.5, 102.0, 500.0)
+		receive(101.0, 100.5, 200.0)
+		Expect(profile.POC()).To(Equal(102.25))
+	})
+
+	It("should bound a value area containing at least the requested percent of the volume", func() {
+		random := rand.New(rand.NewSource(5))
+		for i := 0; i < 200; i++ {
+			low := 95.0 + random.NormFloat64()*2.0
+			receive(low+random.Float64()*3.0, low, 100.0+random.Float64()*900.0)
+		}
+
+		for _, pct := range []float64{50.0, 70.0, 90.0} {
+			high, low := profile.ValueArea(pct)
+			Expect(low).To(BeNumerically("<", profile.POC()))
+			Expect(high).To(BeNumerically(">", profile.POC()))
+			Expect(volumeWithin(high, low)).To(BeNumerically(">=", profile.TotalVolume()*pct/100.0))
+		}
+
+		high, low := profile.ValueArea(100.0)
+		Expect(volumeWithin(high, low)).To(BeNumerically("~", profile.TotalVolume(), 1e-6))
+	})
+
+	It("should start a new profile once reset", func() {
+		receive(101.0, 100.0, 1000.0)
+		profile.Reset()
+		receive(110.5, 110.0, 200.0)
+		Expect(profile.TotalVolume()).To(Equal(200.0))
+		Expect(profile.VolumeAt(100.2)).To(Equal(0.0))
+		Expect(profile.POC()).To(Equal(110.25))
+
+		high, low := profile.ValueArea(70.0)
+		Expect(high).To(Equal(110.5))
+		Expect(low).To(Equal(110.0))
+	})
+})
@@ -0,0 +1,223 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionVwapBands func(dataItemVwap float64, dataItemUpperInner float64, dataItemLowerInner float64, dataItemUpperOuter float64, dataItemLowerOuter float64, streamBarIndex int)
+
+// A Volume Weighted Average Price Bands Indicator (VwapBands), no storage, for use in other indicators
+// the session volume weighted average price of the typical price, (high + low + close) / 3, with bands of the
+// inner and outer multipliers of the volume weighted standard deviation of the typical price about it. The sums
+// are accumulated incrementally from the first tick of the session, while no volume has traded in the session
+// the result is the typical price with no deviation
+type VwapBandsWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+	*baseGapReset
+
+	// private variables
+	valueAvailableAction ValueAvailableActionVwapBands
+	innerMultiplier      float64
+	outerMultiplier      float64
+	totalVolume          float64
+	totalPriceVolume     float64
+	totalPriceSqVolume   float64
+}
+
+// NewVwapBandsWithoutStorage creates a Volume Weighted Average Price Bands Indicator (VwapBands) without storage
+func NewVwapBandsWithoutStorage(innerMultiplier float64, outerMultiplier float64, valueAvailableAction ValueAvailableActionVwapBands) (indicator *VwapBandsWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum innerMultiplier for this indicator is 0
+	if innerMultiplier < 0.0 {
+		return nil, errors.New("innerMultiplier is less than the minimum (0)")
+	}
+
+	// check the maximum innerMultiplier
+	if innerMultiplier > math.MaxFloat64 {
+		return nil, errors.New("innerMultiplier is greater than the maximum float64 size")
+	}
+
+	// the minimum outerMultiplier for this indicator is 0
+	if outerMultiplier < 0.0 {
+		return nil, errors.New("outerMultiplier is less than the minimum (0)")
+	}
+
+	// check the maximum outerMultiplier
+	if outerMultiplier > math.MaxFloat64 {
+		return nil, errors.New("outerMultiplier is greater than the maximum float64 size")
+	}
+
+	lookback := 0
+	ind := VwapBandsWithoutStorage{
+		baseIndicator:        newBaseIndicator(lookback),
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		baseGapReset:         newBaseGapReset(),
+		valueAvailableAction: valueAvailableAction,
+		innerMultiplier:      innerMultiplier,
+		outerMultiplier:      outerMultiplier,
+	}
+
+	return &ind, nil
+}
+
+// ResetSession resets the sums, the next tick received is the first of a new session
+func (ind *VwapBandsWithoutStorage) ResetSession() {
+	ind.totalVolume = 0.0
+	ind.totalPriceVolume = 0.0
+	ind.totalPriceSqVolume = 0.0
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *VwapBandsWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	// a gap beyond the maxGap of the policy starts a new session
+	if ind.gapExceeded(tickData) {
+		ind.ResetSession()
+	}
+
+	typicalPrice := (tickData.H() + tickData.L() + tickData.C()) / 3.0
+	ind.totalVolume += tickData.V()
+	ind.totalPriceVolume += typicalPrice * tickData.V()
+	ind.totalPriceSqVolume += typicalPrice * typicalPrice * tickData.V()
+
+	vwap := typicalPrice
+	var stdDev float64 = 0.0
+	if ind.totalVolume > 0.0 {
+		vwap = ind.totalPriceVolume / ind.totalVolume
+
+		// rounding in the sums can leave a flat session with a slightly negative variance
+		variance := ind.totalPriceSqVolume/ind.totalVolume - vwap*vwap
+		if variance > 0.0 {
+			stdDev = math.Sqrt(variance)
+		}
+	}
+
+	vwap = ind.quantize(vwap)
+	upperInner := ind.quantize(vwap + ind.innerMultiplier*stdDev)
+	lowerInner := ind.quantize(vwap - ind.innerMultiplier*stdDev)
+	upperOuter := ind.quantize(vwap + ind.outerMultiplier*stdDev)
+	lowerOuter := ind.quantize(vwap - ind.outerMultiplier*stdDev)
+
+	ind.UpdateMinMax(math.Min(lowerInner, lowerOuter), math.Max(upperInner, upperOuter))
+
+	ind.IncDataLength()
+
+	ind.SetValidFromBar(streamBarIndex)
+
+	// notify of a new result value though the value available action
+	ind.valueAvailableAction(vwap, upperInner, lowerInner, upperOuter, lowerOuter, streamBarIndex)
+}
+
+// A Volume Weighted Average Price Bands Indicator (VwapBands)
+type VwapBands struct {
+	*VwapBandsWithoutStorage
+
+	// public variables
+	Vwap       []float64
+	UpperInner []float64
+	LowerInner []float64
+	UpperOuter []float64
+	LowerOuter []float64
+}
+
+// NewVwapBands creates a Volume Weighted Average Price Bands Indicator (VwapBands) for online usage
+func NewVwapBands(innerMultiplier float64, outerMultiplier float64) (indicator *VwapBands, err error) {
+	ind := VwapBands{}
+	ind.VwapBandsWithoutStorage, err = NewVwapBandsWithoutStorage(innerMultiplier, outerMultiplier,
+		func(dataItemVwap float64, dataItemUpperInner float64, dataItemLowerInner float64, dataItemUpperOuter float64, dataItemLowerOuter float64, streamBarIndex int) {
+			ind.Vwap = append(ind.Vwap, dataItemVwap)
+			ind.UpperInner = append(ind.UpperInner, dataItemUpperInner)
+			ind.LowerInner = append(ind.LowerInner, dataItemLowerInner)
+			ind.UpperOuter = append(ind.UpperOuter, dataItemUpperOuter)
+			ind.LowerOuter = append(ind.LowerOuter, dataItemLowerOuter)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultVwapBands creates a Volume Weighted Average Price Bands Indicator (VwapBands) for online usage with default parameters
+//	- innerMultiplier: 1.0
+//	- outerMultiplier: 2.0
+func NewDefaultVwapBands() (indicator *VwapBands, err error) {
+	innerMultiplier := 1.0
+	outerMultiplier := 2.0
+	return NewVwapBands(innerMultiplier, outerMultiplier)
+}
+
+// NewVwapBandsWithSrcLen creates a Volume Weighted Average Price Bands Indicator (VwapBands) for offline usage
+func NewVwapBandsWithSrcLen(sourceLength uint, innerMultiplier float64, outerMultiplier float64) (indicator *VwapBands, err error) {
+	ind, err := NewVwapBands(innerMultiplier, outerMultiplier)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.allocate(sourceLength - uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultVwapBandsWithSrcLen creates a Volume Weighted Average Price Bands Indicator (VwapBands) for offline usage with default parameters
+func NewDefaultVwapBandsWithSrcLen(sourceLength uint) (indicator *VwapBands, err error) {
+	ind, err := NewDefaultVwapBands()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.allocate(sourceLength - uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewVwapBandsForStream creates a Volume Weighted Average Price Bands Indicator (VwapBands) for online usage with a source data stream
+func NewVwapBandsForStream(priceStream gotrade.DOHLCVStreamSubscriber, innerMultiplier float64, outerMultiplier float64) (indicator *VwapBands, err error) {
+	ind, err := NewVwapBands(innerMultiplier, outerMultiplier)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultVwapBandsForStream creates a Volume Weighted Average Price Bands Indicator (VwapBands) for online usage with a source data stream
+func NewDefaultVwapBandsForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *VwapBands, err error) {
+	ind, err := NewDefaultVwapBands()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewVwapBandsForStreamWithSrcLen creates a Volume Weighted Average Price Bands Indicator (VwapBands) for offline usage with a source data stream
+func NewVwapBandsForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, innerMultiplier float64, outerMultiplier float64) (indicator *VwapBands, err error) {
+	ind, err := NewVwapBandsWithSrcLen(sourceLength, innerMultiplier, outerMultiplier)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultVwapBandsForStreamWithSrcLen creates a Volume Weighted Average Price Bands Indicator (VwapBands) for offline usage with a source data stream
+func NewDefaultVwapBandsForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *VwapBands, err error) {
+	ind, err := NewDefaultVwapBandsWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+func (ind *VwapBands) allocate(capacity uint) {
+	ind.Vwap = make([]float64, 0, capacity)
+	ind.UpperInner = make([]float64, 0, capacity)
+	ind.LowerInner = make([]float64, 0, capacity)
+	ind.UpperOuter = make([]float64, 0, capacity)
+	ind.LowerOuter = make([]float64, 0, capacity)
+}
+
+// WriteCSV writes the VwapBands results as rows after a header of barIndex,vwap,upperInner,lowerInner,upperOuter,
+// lowerOuter, the bar index of each result is its stream bar index plus the startBarOffset
+func (ind *VwapBands) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("vwap", ind.Vwap),
+		floatCSVColumn("upperInner", ind.UpperInner), floatCSVColumn("lowerInner", ind.LowerInner),
+		floatCSVColumn("upperOuter", ind.UpperOuter), floatCSVColumn("lowerOuter", ind.LowerOuter))
+}
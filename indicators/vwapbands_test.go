@@ -0,0 +1,132 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var _ = Describe("when creating a vwapbandswithoutstorage", func() {
+	var (
+		indicator      *indicators.VwapBandsWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemVwap float64, dataItemUpperInner float64, dataItemLowerInner float64, dataItemUpperOuter float64, dataItemLowerOuter float64, streamBarIndex int) {
+		}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewVwapBandsWithoutStorage(1.0, 2.0, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given an innerMultiplier below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewVwapBandsWithoutStorage(-1.0, 2.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given an outerMultiplier below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewVwapBandsWithoutStorage(1.0, -2.0, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+})
+
+var _ = Describe("when calculating volume weighted average price bands (vwapbands) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.VwapBands
+		bars      []gotrade.DOHLCV
+	)
+
+	// a session of bars oscillating about 100.0 by a growing amount, the typical price being the close
+	start := time.Date(2014, 1, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 10; i++ {
+		price := 100.0 + float64(i)*math.Pow(-1.0, float64(i))
+		bars = append(bars, gotrade.NewDOHLCVDataItem(start.Add(time.Duration(i)*time.Minute), price, price, price, price, float64(100*(1+i%3))))
+	}
+
+	// the volume weighted mean and standard deviation of the typical prices of the bars
+	manualBands := func(sessionBars []gotrade.DOHLCV) (vwap float64, stdDev float64) {
+		totalVolume, totalPriceVolume := 0.0, 0.0
+		for _, bar := range sessionBars {
+			totalVolume += bar.V()
+			totalPriceVolume += bar.C() * bar.V()
+		}
+		vwap = totalPriceVolume / totalVolume
+
+		totalDeviationSq := 0.0
+		for _, bar := range sessionBars {
+			totalDeviationSq += bar.V() * (bar.C() - vwap) * (bar.C() - vwap)
+		}
+		return vwap, math.Sqrt(totalDeviationSq / totalVolume)
+	}
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultVwapBands()
+		for i := range bars {
+			indicator.ReceiveDOHLCVTick(bars[i], i+1)
+		}
+	})
+
+	It("should have a result for every bar", func() {
+		Expect(len(indicator.Vwap)).To(Equal(len(bars)))
+		Expect(indicator.ValidFromBar()).To(Equal(1))
+	})
+
+	It("should match the volume weighted mean and standard deviation of the session", func() {
+		for i := range indicator.Vwap {
+			vwap, stdDev := manualBands(bars[:i+1])
+			Expect(indicator.Vwap[i]).To(BeNumerically("~", vwap, 1e-9))
+			Expect(indicator.UpperInner[i]).To(BeNumerically("~", vwap+stdDev, 1e-9))
+			Expect(indicator.LowerInner[i]).To(BeNumerically("~", vwap-stdDev, 1e-9))
+			Expect(indicator.UpperOuter[i]).To(BeNumerically("~", vwap+2.0*stdDev, 1e-9))
+			Expect(indicator.LowerOuter[i]).To(BeNumerically("~", vwap-2.0*stdDev, 1e-9))
+		}
+	})
+
+	It("the bands should widen as the dispersion of the session grows", func() {
+		for i := 2; i < len(indicator.Vwap); i++ {
+			Expect(indicator.UpperOuter[i] - indicator.LowerOuter[i]).To(BeNumerically(">", indicator.UpperOuter[i-1]-indicator.LowerOuter[i-1]))
+		}
+	})
+
+	Context("and a new session is started", func() {
+		BeforeEach(func() {
+			indicator.ResetSession()
+			for i := range bars {
+				indicator.ReceiveDOHLCVTick(bars[i], len(bars)+i+1)
+			}
+		})
+
+		It("the bands should restart from the first bar of the session", func() {
+			sessionStart := len(bars)
+			Expect(len(indicator.Vwap)).To(Equal(2 * len(bars)))
+			Expect(indicator.Vwap[sessionStart]).To(Equal(bars[0].C()))
+			Expect(indicator.UpperOuter[sessionStart]).To(Equal(bars[0].C()))
+			Expect(indicator.LowerOuter[sessionStart]).To(Equal(bars[0].C()))
+			for i := range bars {
+				Expect(indicator.Vwap[sessionStart+i]).To(BeNumerically("~", indicator.Vwap[i], 1e-9))
+				Expect(indicator.UpperOuter[sessionStart+i]).To(BeNumerically("~", indicator.UpperOuter[i], 1e-9))
+			}
+		})
+	})
+})
@@ -0,0 +1,37 @@
+package indicators
+
+import (
+	"math"
+)
+
+type baseWarmupFill struct {
+	warmupFill bool
+}
+
+func newBaseWarmupFill() *baseWarmupFill {
+	return &baseWarmupFill{}
+}
+
+// SetWarmupFill sets whether a NaN is made available for each bar of the lookback period, so that the results
+// line up with the source data, result i being that of source data bar i. The NaNs are made available together,
+// ahead of the first result, they are counted in the Length and the ValidFromBar is that of the first NaN but
+// they do not update the bounds. It should be set before any ticks are received
+func (ind *baseWarmupFill) SetWarmupFill(warmupFill bool) {
+	ind.warmupFill = warmupFill
+}
+
+// fillWarmup makes a NaN available for each bar of the lookback period, and for each withheld result, ahead of
+// the first result, at the streamBarIndex, when the warm-up fill is set, the NaNs being a bar index step apart
+func (ind *baseIndicatorWithFloatBounds) fillWarmup(streamBarIndex int) {
+	if !ind.warmupFill || ind.validFromBar != -1 {
+		return
+	}
+
+	fillBars := ind.lookbackPeriod + ind.withheldResults
+	firstBar := streamBarIndex - ind.barIndexSpan(fillBars)
+	ind.SetValidFromBar(firstBar)
+	for i := 0; i < fillBars; i++ {
+		ind.IncDataLength()
+		ind.notifyValueAvailable(math.NaN(), firstBar+ind.barIndexSpan(i))
+	}
+}
@@ -0,0 +1,201 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+type ValueAvailableActionWaveTrend func(dataItemWt1 float64, dataItemWt2 float64, streamBarIndex int)
+
+// A Wave Trend Oscillator Indicator (WaveTrend), no storage, for use in other indicators
+// the esa, an Ema of the channelLength of the typical price, (high + low + close) / 3, and d, an Ema of the
+// channelLength of the absolute deviation of the typical price from the esa, give the channel index
+// ci = (typical price - esa) / (0.015 * d), with a ci of 0.0 while d is 0. The wt1 line is an Ema of the
+// averageLength of the ci and the wt2 line is an Sma of 4 bars of the wt1, the crosses of the two lines mark the
+// turns in momentum
+type WaveTrendWithoutStorage struct {
+	*baseIndicator
+	*baseFloatBounds
+	*baseQuantizer
+
+	// private variables
+	valueAvailableAction ValueAvailableActionWaveTrend
+	esa                  *EmaWithoutStorage
+	deviation            *EmaWithoutStorage
+	tci                  *EmaWithoutStorage
+	wt2                  *SmaWithoutStorage
+	currentTypicalPrice  float64
+	currentEsa           float64
+	currentWt1           float64
+}
+
+// NewWaveTrendWithoutStorage creates a Wave Trend Oscillator Indicator (WaveTrend) without storage
+func NewWaveTrendWithoutStorage(channelLength int, averageLength int, valueAvailableAction ValueAvailableActionWaveTrend) (indicator *WaveTrendWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum channelLength for this indicator is 2
+	if channelLength < 2 {
+		return nil, errors.New("channelLength is less than the minimum (2)")
+	}
+
+	// check the maximum channelLength
+	if channelLength > MaximumLookbackPeriod {
+		return nil, errors.New("channelLength is greater than the maximum (100000)")
+	}
+
+	// the minimum averageLength for this indicator is 2
+	if averageLength < 2 {
+		return nil, errors.New("averageLength is less than the minimum (2)")
+	}
+
+	// check the maximum averageLength
+	if averageLength > MaximumLookbackPeriod {
+		return nil, errors.New("averageLength is greater than the maximum (100000)")
+	}
+
+	ind := WaveTrendWithoutStorage{
+		baseFloatBounds:      newBaseFloatBounds(),
+		baseQuantizer:        newBaseQuantizer(),
+		valueAvailableAction: valueAvailableAction,
+	}
+
+	ind.wt2, err = NewSmaWithoutStorage(4, func(dataItem float64, streamBarIndex int) {
+		wt1 := ind.quantize(ind.currentWt1)
+		wt2 := ind.quantize(dataItem)
+
+		ind.UpdateMinMax(math.Min(wt1, wt2), math.Max(wt1, wt2))
+
+		ind.IncDataLength()
+
+		ind.SetValidFromBar(streamBarIndex)
+
+		// notify of a new result value though the value available action
+		ind.valueAvailableAction(wt1, wt2, streamBarIndex)
+	})
+
+	ind.tci, err = NewEmaWithoutStorage(averageLength, func(dataItem float64, streamBarIndex int) {
+		ind.currentWt1 = dataItem
+		ind.wt2.ReceiveTick(dataItem, streamBarIndex)
+	})
+
+	ind.deviation, err = NewEmaWithoutStorage(channelLength, func(dataItem float64, streamBarIndex int) {
+		ci := 0.0
+		if dataItem != 0.0 {
+			ci = (ind.currentTypicalPrice - ind.currentEsa) / (0.015 * dataItem)
+		}
+		ind.tci.ReceiveTick(ci, streamBarIndex)
+	})
+
+	ind.esa, err = NewEmaWithoutStorage(channelLength, func(dataItem float64, streamBarIndex int) {
+		ind.currentEsa = dataItem
+		ind.deviation.ReceiveTick(math.Abs(ind.currentTypicalPrice-dataItem), streamBarIndex)
+	})
+
+	lookback := ind.esa.GetLookbackPeriod() + ind.deviation.GetLookbackPeriod() + ind.tci.GetLookbackPeriod() +
+		ind.wt2.GetLookbackPeriod()
+	ind.baseIndicator = newBaseIndicator(lookback)
+
+	return &ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *WaveTrendWithoutStorage) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	ind.currentTypicalPrice = (tickData.H() + tickData.L() + tickData.C()) / 3.0
+	ind.esa.ReceiveTick(ind.currentTypicalPrice, streamBarIndex)
+}
+
+// A Wave Trend Oscillator Indicator (WaveTrend)
+type WaveTrend struct {
+	*WaveTrendWithoutStorage
+
+	// public variables
+	Wt1 []float64
+	Wt2 []float64
+}
+
+// NewWaveTrend creates a Wave Trend Oscillator Indicator (WaveTrend) for online usage
+func NewWaveTrend(channelLength int, averageLength int) (indicator *WaveTrend, err error) {
+	ind := WaveTrend{}
+	ind.WaveTrendWithoutStorage, err = NewWaveTrendWithoutStorage(channelLength, averageLength,
+		func(dataItemWt1 float64, dataItemWt2 float64, streamBarIndex int) {
+			ind.Wt1 = append(ind.Wt1, dataItemWt1)
+			ind.Wt2 = append(ind.Wt2, dataItemWt2)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultWaveTrend creates a Wave Trend Oscillator Indicator (WaveTrend) for online usage with default parameters
+//	- channelLength: 10
+//	- averageLength: 21
+func NewDefaultWaveTrend() (indicator *WaveTrend, err error) {
+	channelLength := 10
+	averageLength := 21
+	return NewWaveTrend(channelLength, averageLength)
+}
+
+// NewWaveTrendWithSrcLen creates a Wave Trend Oscillator Indicator (WaveTrend) for offline usage
+func NewWaveTrendWithSrcLen(sourceLength uint, channelLength int, averageLength int) (indicator *WaveTrend, err error) {
+	ind, err := NewWaveTrend(channelLength, averageLength)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Wt1 = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Wt2 = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultWaveTrendWithSrcLen creates a Wave Trend Oscillator Indicator (WaveTrend) for offline usage with default parameters
+func NewDefaultWaveTrendWithSrcLen(sourceLength uint) (indicator *WaveTrend, err error) {
+	ind, err := NewDefaultWaveTrend()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Wt1 = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+		ind.Wt2 = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewWaveTrendForStream creates a Wave Trend Oscillator Indicator (WaveTrend) for online usage with a source data stream
+func NewWaveTrendForStream(priceStream gotrade.DOHLCVStreamSubscriber, channelLength int, averageLength int) (indicator *WaveTrend, err error) {
+	ind, err := NewWaveTrend(channelLength, averageLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultWaveTrendForStream creates a Wave Trend Oscillator Indicator (WaveTrend) for online usage with a source data stream
+func NewDefaultWaveTrendForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *WaveTrend, err error) {
+	ind, err := NewDefaultWaveTrend()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewWaveTrendForStreamWithSrcLen creates a Wave Trend Oscillator Indicator (WaveTrend) for offline usage with a source data stream
+func NewWaveTrendForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, channelLength int, averageLength int) (indicator *WaveTrend, err error) {
+	ind, err := NewWaveTrendWithSrcLen(sourceLength, channelLength, averageLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultWaveTrendForStreamWithSrcLen creates a Wave Trend Oscillator Indicator (WaveTrend) for offline usage with a source data stream
+func NewDefaultWaveTrendForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *WaveTrend, err error) {
+	ind, err := NewDefaultWaveTrendWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// WriteCSV writes the WaveTrend results as rows after a header of barIndex,wt1,wt2, the bar index of
+// each result is its stream bar index plus the startBarOffset
+func (ind *WaveTrend) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("wt1", ind.Wt1), floatCSVColumn("wt2", ind.Wt2))
+}
@@ -0,0 +1,161 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var _ = Describe("when creating a wavetrendwithoutstorage", func() {
+	var (
+		indicator      *indicators.WaveTrendWithoutStorage
+		indicatorError error
+		fakeAction     = func(dataItemWt1 float64, dataItemWt2 float64, streamBarIndex int) {}
+	)
+
+	Context("and the indicator was not given a value available action", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewWaveTrendWithoutStorage(10, 21, nil)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError).To(Equal(indicators.ErrValueAvailableActionIsNil))
+		})
+	})
+
+	Context("and the indicator was given a channelLength below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewWaveTrendWithoutStorage(1, 21, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given an averageLength below the minimum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewWaveTrendWithoutStorage(10, 1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrBelowMinimum))
+		})
+	})
+
+	Context("and the indicator was given an averageLength above the maximum", func() {
+		BeforeEach(func() {
+			indicator, indicatorError = indicators.NewWaveTrendWithoutStorage(10, indicators.MaximumLookbackPeriod+1, fakeAction)
+		})
+
+		It("the indicator should not be created and return the appropriate error message", func() {
+			Expect(indicator).To(BeNil())
+			Expect(indicatorError.Error()).To(ContainSubstring(indicators.ErrStrAboveMaximum))
+		})
+	})
+})
+
+var _ = Describe("when calculating a wave trend oscillator (wavetrend) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.WaveTrend
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultWaveTrendWithSrcLen(uint(len(sourceDOHLCVData)))
+		for i := range sourceDOHLCVData {
+			indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+		}
+	})
+
+	It("should have a result once the sma of the wt1 is available", func() {
+		Expect(indicator.GetLookbackPeriod()).To(Equal(9 + 9 + 20 + 3))
+		Expect(indicator.ValidFromBar()).To(Equal(42))
+		Expect(indicator.Wt1).To(HaveLen(len(sourceDOHLCVData) - 41))
+		Expect(indicator.Wt2).To(HaveLen(len(sourceDOHLCVData) - 41))
+	})
+
+	It("the wt2 should be the sma of 4 bars of the wt1", func() {
+		for i := 3; i < len(indicator.Wt1); i++ {
+			sma := (indicator.Wt1[i] + indicator.Wt1[i-1] + indicator.Wt1[i-2] + indicator.Wt1[i-3]) / 4.0
+			Expect(indicator.Wt2[i]).To(BeNumerically("~", sma, 0.0000001))
+		}
+	})
+
+	It("should have the bounds of both lines", func() {
+		Expect(indicator.MaxValue()).To(Equal(math.Max(GetFloatDataMax(indicator.Wt1), GetFloatDataMax(indicator.Wt2))))
+		Expect(indicator.MinValue()).To(Equal(math.Min(GetFloatDataMin(indicator.Wt1), GetFloatDataMin(indicator.Wt2))))
+	})
+})
+
+var _ = Describe("when calculating a wave trend oscillator (wavetrend) of a cycle", func() {
+	var (
+		cycle     int = 40
+		indicator *indicators.WaveTrend
+	)
+
+	BeforeEach(func() {
+		indicator, _ = indicators.NewDefaultWaveTrend()
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 6*cycle; i++ {
+			price := 100.0 + 10.0*math.Sin(2.0*math.Pi*float64(i)/float64(cycle))
+			indicator.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price+1.0, price-1.0, price, 1000.0), i+1)
+		}
+	})
+
+	It("the crosses of the wt1 and wt2 should follow the turns of the wt1 momentum", func() {
+		crossesUp, crossesDown := 0, 0
+		for i := 1; i < len(indicator.Wt1); i++ {
+			// the highest and lowest wt1 of the bars leading up to the cross
+			from := i - 4
+			if from < 0 {
+				from = 0
+			}
+			recentMax := GetFloatDataMax(indicator.Wt1[from : i+1])
+			recentMin := GetFloatDataMin(indicator.Wt1[from : i+1])
+
+			if indicator.Wt1[i-1] >= indicator.Wt2[i-1] && indicator.Wt1[i] < indicator.Wt2[i] {
+				crossesDown++
+				Expect(indicator.Wt1[i]).To(BeNumerically("<", recentMax))
+			} else if indicator.Wt1[i-1] <= indicator.Wt2[i-1] && indicator.Wt1[i] > indicator.Wt2[i] {
+				crossesUp++
+				Expect(indicator.Wt1[i]).To(BeNumerically(">", recentMin))
+			}
+		}
+
+		// a cross each way for each cycle once the oscillator has warmed up
+		Expect(crossesDown).To(BeNumerically(">=", 4))
+		Expect(crossesUp).To(BeNumerically(">=", 4))
+		Expect(crossesDown - crossesUp).To(BeNumerically("<=", 1))
+		Expect(crossesUp - crossesDown).To(BeNumerically("<=", 1))
+	})
+})
+
+var _ = Describe("when calculating a wave trend oscillator (wavetrend) with flat source data", func() {
+	It("should be 0 while the deviation is 0", func() {
+		indicator, _ := indicators.NewDefaultWaveTrend()
+		start := time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+		for i := 0; i < 60; i++ {
+			indicator.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), 100.0, 100.0, 100.0, 100.0, 1000.0), i+1)
+		}
+
+		Expect(indicator.Wt1).NotTo(BeEmpty())
+		for i := range indicator.Wt1 {
+			Expect(indicator.Wt1[i]).To(Equal(0.0))
+			Expect(indicator.Wt2[i]).To(Equal(0.0))
+		}
+	})
+})
+
+var _ = Describe("when creating a wavetrend for use with a price stream", func() {
+	It("should have requested to be attached to the stream", func() {
+		stream := newFakeDOHLCVStreamSubscriber()
+		indicator, _ := indicators.NewDefaultWaveTrendForStream(stream)
+		Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+	})
+})
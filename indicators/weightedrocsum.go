@@ -0,0 +1,209 @@
+package indicators
+
+import (
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+)
+
+var (
+	ErrWeightedRocSumHasNoRocWeights = errors.New("A WeightedRocSum requires at least one RocWeight")
+)
+
+// A RocWeight is a Roc of the RocPeriod and the weight of its results in the sum of a WeightedRocSum
+type RocWeight struct {
+	RocPeriod int
+	Weight    float64
+}
+
+// A Weighted Roc Sum Indicator (WeightedRocSum), no storage, for use in other indicators
+// the moving average of the maType and maPeriod of the weighted sum of the Rocs of each of the rocWeights, a
+// generalization of the Coppock Curve, the Wma of 10 of the sum of the Rocs of 14 and 11, from which other long
+// term momentum composites, such as those of the KST, can be built. The sum is available once the Roc of the
+// longest period is valid
+type WeightedRocSumWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	rocs          []*RocWithoutStorage
+	rocWeights    []RocWeight
+	currentValues []float64
+	ma            MovingAverageWithoutStorage
+}
+
+// NewWeightedRocSumWithoutStorage creates a Weighted Roc Sum Indicator (WeightedRocSum) without storage
+func NewWeightedRocSumWithoutStorage(rocWeights []RocWeight, maType MaType, maPeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *WeightedRocSumWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	if len(rocWeights) == 0 {
+		return nil, ErrWeightedRocSumHasNoRocWeights
+	}
+
+	ind := WeightedRocSumWithoutStorage{
+		rocs:          make([]*RocWithoutStorage, len(rocWeights)),
+		rocWeights:    append([]RocWeight{}, rocWeights...),
+		currentValues: make([]float64, len(rocWeights)),
+	}
+
+	ind.ma, err = NewMovingAverageWithoutStorage(maType, maPeriod, func(dataItem float64, streamBarIndex int) {
+		ind.UpdateIndicatorWithNewValue(dataItem, streamBarIndex)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	rocLookback := 0
+	for i := range rocWeights {
+		// each roc updates its own slot of the current values
+		rocIndex := i
+		ind.rocs[i], err = NewRocWithoutStorage(rocWeights[i].RocPeriod, func(dataItem float64, streamBarIndex int) {
+			ind.currentValues[rocIndex] = dataItem
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		if ind.rocs[i].GetLookbackPeriod() > rocLookback {
+			rocLookback = ind.rocs[i].GetLookbackPeriod()
+		}
+	}
+
+	lookback := rocLookback + ind.ma.GetLookbackPeriod()
+	ind.baseIndicatorWithFloatBounds = newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction)
+
+	return &ind, nil
+}
+
+// RocWeights returns the Rocs and their weights, in the order given
+func (ind *WeightedRocSumWithoutStorage) RocWeights() []RocWeight {
+	return append([]RocWeight{}, ind.rocWeights...)
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *WeightedRocSumWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	isValid := true
+	for i := range ind.rocs {
+		ind.rocs[i].ReceiveTick(tickData, streamBarIndex)
+		isValid = isValid && ind.rocs[i].Length() > 0
+	}
+
+	// the sum is available once the roc of the longest period has a value
+	if !isValid {
+		return
+	}
+
+	var sum float64 = 0.0
+	for i := range ind.rocWeights {
+		sum += ind.rocWeights[i].Weight * ind.currentValues[i]
+	}
+
+	ind.ma.ReceiveTick(sum, streamBarIndex)
+}
+
+// A Weighted Roc Sum Indicator (WeightedRocSum)
+type WeightedRocSum struct {
+	*WeightedRocSumWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewWeightedRocSum creates a Weighted Roc Sum Indicator (WeightedRocSum) for online usage
+func NewWeightedRocSum(rocWeights []RocWeight, maType MaType, maPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *WeightedRocSum, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := WeightedRocSum{
+		selectData: selectData,
+	}
+
+	ind.WeightedRocSumWithoutStorage, err = NewWeightedRocSumWithoutStorage(rocWeights, maType, maPeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ind, nil
+}
+
+// NewDefaultWeightedRocSum creates a Weighted Roc Sum Indicator (WeightedRocSum) for online usage with default
+// parameters, those of the Coppock Curve
+//	- rocWeights: {14, 1.0}, {11, 1.0}
+//	- maType: MaTypeWma
+//	- maPeriod: 10
+func NewDefaultWeightedRocSum() (indicator *WeightedRocSum, err error) {
+	rocWeights := []RocWeight{{RocPeriod: 14, Weight: 1.0}, {RocPeriod: 11, Weight: 1.0}}
+	maType := MaTypeWma
+	maPeriod := 10
+	return NewWeightedRocSum(rocWeights, maType, maPeriod, gotrade.UseClosePrice)
+}
+
+// NewWeightedRocSumWithSrcLen creates a Weighted Roc Sum Indicator (WeightedRocSum) for offline usage
+func NewWeightedRocSumWithSrcLen(sourceLength uint, rocWeights []RocWeight, maType MaType, maPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *WeightedRocSum, err error) {
+	ind, err := NewWeightedRocSum(rocWeights, maType, maPeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if err == nil && sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultWeightedRocSumWithSrcLen creates a Weighted Roc Sum Indicator (WeightedRocSum) for offline usage with default parameters
+func NewDefaultWeightedRocSumWithSrcLen(sourceLength uint) (indicator *WeightedRocSum, err error) {
+	ind, err := NewDefaultWeightedRocSum()
+
+	// only initialise the storage if there is enough source data to require it
+	if err == nil && sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewWeightedRocSumForStream creates a Weighted Roc Sum Indicator (WeightedRocSum) for online usage with a source data stream
+func NewWeightedRocSumForStream(priceStream gotrade.DOHLCVStreamSubscriber, rocWeights []RocWeight, maType MaType, maPeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *WeightedRocSum, err error) {
+	ind, err := NewWeightedRocSum(rocWeights, maType, maPeriod, selectData)
+	if err != nil {
+		return nil, err
+	}
+	priceStream.AddTickSubscription(ind)
+	return ind, nil
+}
+
+// NewDefaultWeightedRocSumForStream creates a Weighted Roc Sum Indicator (WeightedRocSum) for online usage with a source data stream
+func NewDefaultWeightedRocSumForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *WeightedRocSum, err error) {
+	ind, err := NewDefaultWeightedRocSum()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *WeightedRocSum) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the WeightedRocSum results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *WeightedRocSum) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the WeightedRocSum results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *WeightedRocSum) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
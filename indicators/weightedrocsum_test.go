@@ -0,0 +1,195 @@
+package indicators_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+)
+
+var _ = Describe("when creating a weightedrocsumwithoutstorage", func() {
+	var (
+		coppockWeights []indicators.RocWeight = []indicators.RocWeight{{RocPeriod: 14, Weight: 1.0}, {RocPeriod: 11, Weight: 1.0}}
+	)
+
+	It("the indicator should not be created without a value available action", func() {
+		indicator, err := indicators.NewWeightedRocSumWithoutStorage(coppockWeights, indicators.MaTypeWma, 10, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrValueAvailableActionIsNil))
+	})
+
+	It("the indicator should not be created without any roc weights", func() {
+		indicator, err := indicators.NewWeightedRocSumWithoutStorage([]indicators.RocWeight{}, indicators.MaTypeWma, 10, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrWeightedRocSumHasNoRocWeights))
+	})
+
+	It("the indicator should not be created with a rocPeriod below the minimum", func() {
+		indicator, _ := indicators.NewWeightedRocSumWithoutStorage([]indicators.RocWeight{{RocPeriod: 0, Weight: 1.0}}, indicators.MaTypeWma, 10, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+	})
+
+	It("the indicator should not be created with a maPeriod below the minimum", func() {
+		indicator, _ := indicators.NewWeightedRocSumWithoutStorage(coppockWeights, indicators.MaTypeWma, 1, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+	})
+
+	It("the indicator should not be created with an unsupported maType", func() {
+		indicator, err := indicators.NewWeightedRocSumWithoutStorage(coppockWeights, indicators.MaType(-1), 10, fakeFloatValAvailable)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrMaTypeNotSupported))
+	})
+
+	It("the indicator should not be created with a nil data selection func", func() {
+		indicator, err := indicators.NewWeightedRocSum(coppockWeights, indicators.MaTypeWma, 10, nil)
+		Expect(indicator).To(BeNil())
+		Expect(err).To(Equal(indicators.ErrDOHLCVDataSelectFuncIsNil))
+	})
+})
+
+var _ = Describe("when calculating a weighted roc sum (weightedrocsum) with DOHLCV source data", func() {
+	var (
+		indicator *indicators.WeightedRocSum
+		inputs    IndicatorWithFloatBoundsSharedSpecInputs
+		stream    *fakeDOHLCVStreamSubscriber
+	)
+
+	// the ma of the maPeriod of the weighted sum of the rocs, each composed from the dedicated indicators
+	composedRocSum := func(rocWeights []indicators.RocWeight, maPeriod int) *indicators.Wma {
+		wma, _ := indicators.NewWma(maPeriod, gotrade.UseClosePrice)
+		rocs := []*indicators.Roc{}
+		longest := 0
+		for i := range rocWeights {
+			roc, _ := indicators.NewRoc(rocWeights[i].RocPeriod, gotrade.UseClosePrice)
+			rocs = append(rocs, roc)
+			if rocWeights[i].RocPeriod > longest {
+				longest = rocWeights[i].RocPeriod
+			}
+		}
+
+		for i := 0; i < len(sourceDOHLCVData); i++ {
+			for j := range rocs {
+				rocs[j].ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		}
+
+		for bar := longest + 1; bar <= len(sourceDOHLCVData); bar++ {
+			sum := 0.0
+			for j := range rocs {
+				sum += rocWeights[j].Weight * rocs[j].ValuesInRange(bar, bar)[0]
+			}
+			wma.ReceiveTick(sum, bar)
+		}
+
+		return wma
+	}
+
+	Context("given the indicator is created via the constructor with defaulted parameters", func() {
+		BeforeEach(func() {
+			indicator, _ = indicators.NewDefaultWeightedRocSum()
+			inputs = NewIndicatorWithFloatBoundsSharedSpecInputs(indicator, len(sourceDOHLCVData), indicator,
+				func() float64 {
+					return GetFloatDataMax(indicator.Data)
+				},
+				func() float64 {
+					return GetFloatDataMin(indicator.Data)
+				})
+		})
+
+		Context("and the indicator has not yet received any ticks", func() {
+			ShouldBeAnInitialisedIndicator(&inputs)
+
+			ShouldNotHaveAnyFloatBoundsSetYet(&inputs)
+		})
+
+		Context("and the indicator has received less ticks than the lookback period", func() {
+
+			BeforeEach(func() {
+				for i := 0; i < indicator.GetLookbackPeriod(); i++ {
+					indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				}
+			})
+
+			ShouldBeAnIndicatorThatHasReceivedFewerTicksThanItsLookbackPeriod(&inputs)
+
+			ShouldNotHaveAnyFloatBoundsSetYet(&inputs)
+		})
+
+		Context("and the indicator has received ticks equal to the lookback period", func() {
+
+			BeforeEach(func() {
+				for i := 0; i <= indicator.GetLookbackPeriod(); i++ {
+					indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				}
+			})
+
+			ShouldBeAnIndicatorThatHasReceivedTicksEqualToItsLookbackPeriod(&inputs)
+
+			ShouldHaveFloatBoundsSetToMinMaxOfResults(&inputs)
+		})
+
+		Context("and the indicator has recieved all of its ticks", func() {
+			BeforeEach(func() {
+				for i := 0; i < len(sourceDOHLCVData); i++ {
+					indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+				}
+			})
+
+			ShouldBeAnIndicatorThatHasReceivedAllOfItsTicks(&inputs)
+
+			ShouldHaveFloatBoundsSetToMinMaxOfResults(&inputs)
+
+			It("should have the lookback period of the Coppock Curve", func() {
+				Expect(indicator.GetLookbackPeriod()).To(Equal(14 + 10 - 1))
+			})
+
+			It("the results should be the Coppock Curve, the wma of 10 of the sum of the rocs of 14 and 11", func() {
+				coppock := composedRocSum([]indicators.RocWeight{{RocPeriod: 14, Weight: 1.0}, {RocPeriod: 11, Weight: 1.0}}, 10)
+				Expect(indicator.ValidFromBar()).To(Equal(coppock.ValidFromBar()))
+				Expect(indicator.Data).To(HaveLen(len(coppock.Data)))
+				for i := range coppock.Data {
+					Expect(indicator.Data[i]).To(BeNumerically("~", coppock.Data[i], 0.0000001))
+				}
+			})
+		})
+	})
+
+	Context("given the indicator is created with KST-like weights", func() {
+		var (
+			rocWeights []indicators.RocWeight = []indicators.RocWeight{{RocPeriod: 10, Weight: 1.0}, {RocPeriod: 15, Weight: 2.0},
+				{RocPeriod: 20, Weight: 3.0}, {RocPeriod: 30, Weight: 4.0}}
+		)
+
+		BeforeEach(func() {
+			indicator, _ = indicators.NewWeightedRocSumWithSrcLen(uint(len(sourceDOHLCVData)), rocWeights, indicators.MaTypeWma, 9, gotrade.UseClosePrice)
+			for i := 0; i < len(sourceDOHLCVData); i++ {
+				indicator.ReceiveDOHLCVTick(sourceDOHLCVData[i], i+1)
+			}
+		})
+
+		It("should have pre-allocated storge for the output data", func() {
+			Expect(cap(indicator.Data)).To(Equal(len(sourceDOHLCVData) - indicator.GetLookbackPeriod()))
+			Expect(len(indicator.Data)).To(Equal(cap(indicator.Data)))
+		})
+
+		It("the results should be the ma of the weighted sum of the rocs", func() {
+			expected := composedRocSum(rocWeights, 9)
+			Expect(indicator.RocWeights()).To(Equal(rocWeights))
+			Expect(indicator.ValidFromBar()).To(Equal(expected.ValidFromBar()))
+			for i := range expected.Data {
+				Expect(indicator.Data[i]).To(BeNumerically("~", expected.Data[i], 0.0000001))
+			}
+		})
+	})
+
+	Context("given the indicator is created via the constructor for use with a price stream", func() {
+		BeforeEach(func() {
+			stream = newFakeDOHLCVStreamSubscriber()
+			indicator, _ = indicators.NewDefaultWeightedRocSumForStream(stream)
+		})
+
+		It("should have requested to be attached to the stream", func() {
+			Expect(stream.lastCallToAddTickSubscriptionArg).To(Equal(indicator))
+		})
+	})
+})
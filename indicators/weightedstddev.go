@@ -0,0 +1,197 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// A Weighted Standard Deviation Indicator (WeightedStdDev), no storage, for use in other indicators
+// the observations are linearly weighted, as in the Wma, with the most recent observation carrying the most weight
+type WeightedStdDevWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodHistory       *list.List
+	periodTotal         float64
+	periodTotalSq       float64
+	periodWeightedSum   float64
+	periodWeightedSumSq float64
+	periodWeightTotal   float64
+	timePeriod          int
+}
+
+// NewWeightedStdDevWithoutStorage creates a Weighted Standard Deviation Indicator (WeightedStdDev) without storage
+func NewWeightedStdDevWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *WeightedStdDevWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := WeightedStdDevWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		periodWeightTotal:            float64(timePeriod*(timePeriod+1)) / 2.0,
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// A Weighted Standard Deviation Indicator (WeightedStdDev)
+type WeightedStdDev struct {
+	*WeightedStdDevWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewWeightedStdDev creates a Weighted Standard Deviation Indicator (WeightedStdDev) for online usage
+func NewWeightedStdDev(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *WeightedStdDev, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := WeightedStdDev{
+		selectData: selectData,
+	}
+
+	ind.WeightedStdDevWithoutStorage, err = NewWeightedStdDevWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultWeightedStdDev creates a Weighted Standard Deviation Indicator (WeightedStdDev) for online usage with default parameters
+//	- timePeriod: 10
+func NewDefaultWeightedStdDev() (indicator *WeightedStdDev, err error) {
+	timePeriod := 10
+	return NewWeightedStdDev(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewWeightedStdDevWithSrcLen creates a Weighted Standard Deviation Indicator (WeightedStdDev) for offline usage
+func NewWeightedStdDevWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *WeightedStdDev, err error) {
+	ind, err := NewWeightedStdDev(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultWeightedStdDevWithSrcLen creates a Weighted Standard Deviation Indicator (WeightedStdDev) for offline usage with default parameters
+func NewDefaultWeightedStdDevWithSrcLen(sourceLength uint) (indicator *WeightedStdDev, err error) {
+	ind, err := NewDefaultWeightedStdDev()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewWeightedStdDevForStream creates a Weighted Standard Deviation Indicator (WeightedStdDev) for online usage with a source data stream
+func NewWeightedStdDevForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *WeightedStdDev, err error) {
+	ind, err := NewWeightedStdDev(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultWeightedStdDevForStream creates a Weighted Standard Deviation Indicator (WeightedStdDev) for online usage with a source data stream
+func NewDefaultWeightedStdDevForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *WeightedStdDev, err error) {
+	ind, err := NewDefaultWeightedStdDev()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewWeightedStdDevForStreamWithSrcLen creates a Weighted Standard Deviation Indicator (WeightedStdDev) for offline usage with a source data stream
+func NewWeightedStdDevForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *WeightedStdDev, err error) {
+	ind, err := NewWeightedStdDevWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultWeightedStdDevForStreamWithSrcLen creates a Weighted Standard Deviation Indicator (WeightedStdDev) for offline usage with a source data stream
+func NewDefaultWeightedStdDevForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *WeightedStdDev, err error) {
+	ind, err := NewDefaultWeightedStdDevWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *WeightedStdDev) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the WeightedStdDev results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *WeightedStdDev) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the WeightedStdDev results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *WeightedStdDev) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
+
+func (ind *WeightedStdDevWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	tickDataSq := tickData * tickData
+
+	if ind.periodHistory.Len() < ind.timePeriod {
+		// while filling the period each new observation takes the next weight
+		weight := float64(ind.periodHistory.Len() + 1)
+		ind.periodWeightedSum += weight * tickData
+		ind.periodWeightedSumSq += weight * tickDataSq
+	} else {
+		// every existing observation loses one unit of weight, the oldest dropping to zero,
+		// and the new observation is given the full weight of the period
+		oldest := ind.periodHistory.Front()
+		oldestValue := oldest.Value.(float64)
+		ind.periodHistory.Remove(oldest)
+
+		ind.periodWeightedSum += float64(ind.timePeriod)*tickData - ind.periodTotal
+		ind.periodWeightedSumSq += float64(ind.timePeriod)*tickDataSq - ind.periodTotalSq
+		ind.periodTotal -= oldestValue
+		ind.periodTotalSq -= oldestValue * oldestValue
+	}
+
+	ind.periodHistory.PushBack(tickData)
+	ind.periodTotal += tickData
+	ind.periodTotalSq += tickDataSq
+
+	if ind.periodHistory.Len() >= ind.timePeriod {
+		mean := ind.periodWeightedSum / ind.periodWeightTotal
+		variance := ind.periodWeightedSumSq/ind.periodWeightTotal - mean*mean
+
+		// guard against a small negative variance from floating point error
+		if variance < 0.0 {
+			variance = 0.0
+		}
+
+		result := math.Sqrt(variance)
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 	"math"
 )
 
@@ -181,3 +182,15 @@ func lowestLowofPeriod(l *list.List) (result float64, err error) {
 
 	return low, err
 }
+
+// ValuesInRange returns the WillR results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *WillR) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the WillR results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *WillR) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"github.com/thetruetrade/gotrade"
+	"io"
 )
 
 // A Weighted Moving Average Indicator (Wma), no storage, for use in other indicators
@@ -174,3 +175,15 @@ func (ind *WmaWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int)
 		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
 	}
 }
+
+// ValuesInRange returns the Wma results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *Wma) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the Wma results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *Wma) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
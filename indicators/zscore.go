@@ -0,0 +1,185 @@
+package indicators
+
+import (
+	"container/list"
+	"errors"
+	"github.com/thetruetrade/gotrade"
+	"io"
+	"math"
+)
+
+// the variance, relative to the square of the mean, below which a period is taken to be flat
+// so that rounding in the running sums does not produce a z-score for a flat period
+const zScoreFlatVarianceTolerance float64 = 0.000000000001
+
+// A Z-Score Indicator (ZScore), no storage, for use in other indicators
+// the number of standard deviations the latest value lies from the mean of the period,
+// a result of 0.0 is given for a period with no deviation
+type ZScoreWithoutStorage struct {
+	*baseIndicatorWithFloatBounds
+
+	// private variables
+	periodHistory *list.List
+	periodTotal   float64
+	periodTotalSq float64
+	timePeriod    int
+}
+
+// NewZScoreWithoutStorage creates a Z-Score Indicator (ZScore) without storage
+func NewZScoreWithoutStorage(timePeriod int, valueAvailableAction ValueAvailableActionFloat) (indicator *ZScoreWithoutStorage, err error) {
+
+	// an indicator without storage MUST have a value available action
+	if valueAvailableAction == nil {
+		return nil, ErrValueAvailableActionIsNil
+	}
+
+	// the minimum timeperiod for this indicator is 2
+	if timePeriod < 2 {
+		return nil, errors.New("timePeriod is less than the minimum (2)")
+	}
+
+	// check the maximum timeperiod
+	if timePeriod > MaximumLookbackPeriod {
+		return nil, errors.New("timePeriod is greater than the maximum (100000)")
+	}
+
+	lookback := timePeriod - 1
+	ind := ZScoreWithoutStorage{
+		baseIndicatorWithFloatBounds: newBaseIndicatorWithFloatBounds(lookback, valueAvailableAction),
+		periodHistory:                list.New(),
+		timePeriod:                   timePeriod,
+	}
+
+	return &ind, nil
+}
+
+// ReceiveTick consumes a source data float price tick
+func (ind *ZScoreWithoutStorage) ReceiveTick(tickData float64, streamBarIndex int) {
+	ind.periodHistory.PushBack(tickData)
+	ind.periodTotal += tickData
+	ind.periodTotalSq += tickData * tickData
+
+	if ind.periodHistory.Len() > ind.timePeriod {
+		var first = ind.periodHistory.Front()
+		var firstValue = first.Value.(float64)
+		ind.periodTotal -= firstValue
+		ind.periodTotalSq -= firstValue * firstValue
+		ind.periodHistory.Remove(first)
+	}
+
+	if ind.periodHistory.Len() == ind.timePeriod {
+		mean := ind.periodTotal / float64(ind.timePeriod)
+		variance := ind.periodTotalSq/float64(ind.timePeriod) - mean*mean
+
+		var result float64 = 0.0
+		if variance > zScoreFlatVarianceTolerance*mean*mean {
+			result = (tickData - mean) / math.Sqrt(variance)
+		}
+
+		ind.UpdateIndicatorWithNewValue(result, streamBarIndex)
+	}
+}
+
+// A Z-Score Indicator (ZScore)
+type ZScore struct {
+	*ZScoreWithoutStorage
+	selectData gotrade.DOHLCVDataSelectionFunc
+
+	// public variables
+	Data []float64
+}
+
+// NewZScore creates a Z-Score Indicator (ZScore) for online usage
+func NewZScore(timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *ZScore, err error) {
+	if selectData == nil {
+		return nil, ErrDOHLCVDataSelectFuncIsNil
+	}
+
+	ind := ZScore{
+		selectData: selectData,
+	}
+
+	ind.ZScoreWithoutStorage, err = NewZScoreWithoutStorage(timePeriod,
+		func(dataItem float64, streamBarIndex int) {
+			ind.Data = append(ind.Data, dataItem)
+		})
+
+	return &ind, err
+}
+
+// NewDefaultZScore creates a Z-Score Indicator (ZScore) for online usage with default parameters
+//	- timePeriod: 20
+func NewDefaultZScore() (indicator *ZScore, err error) {
+	timePeriod := 20
+	return NewZScore(timePeriod, gotrade.UseClosePrice)
+}
+
+// NewZScoreWithSrcLen creates a Z-Score Indicator (ZScore) for offline usage
+func NewZScoreWithSrcLen(sourceLength uint, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *ZScore, err error) {
+	ind, err := NewZScore(timePeriod, selectData)
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewDefaultZScoreWithSrcLen creates a Z-Score Indicator (ZScore) for offline usage with default parameters
+func NewDefaultZScoreWithSrcLen(sourceLength uint) (indicator *ZScore, err error) {
+	ind, err := NewDefaultZScore()
+
+	// only initialise the storage if there is enough source data to require it
+	if sourceLength-uint(ind.GetLookbackPeriod()) > 1 {
+		ind.Data = make([]float64, 0, sourceLength-uint(ind.GetLookbackPeriod()))
+	}
+
+	return ind, err
+}
+
+// NewZScoreForStream creates a Z-Score Indicator (ZScore) for online usage with a source data stream
+func NewZScoreForStream(priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *ZScore, err error) {
+	ind, err := NewZScore(timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultZScoreForStream creates a Z-Score Indicator (ZScore) for online usage with a source data stream
+func NewDefaultZScoreForStream(priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ZScore, err error) {
+	ind, err := NewDefaultZScore()
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewZScoreForStreamWithSrcLen creates a Z-Score Indicator (ZScore) for offline usage with a source data stream
+func NewZScoreForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber, timePeriod int, selectData gotrade.DOHLCVDataSelectionFunc) (indicator *ZScore, err error) {
+	ind, err := NewZScoreWithSrcLen(sourceLength, timePeriod, selectData)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// NewDefaultZScoreForStreamWithSrcLen creates a Z-Score Indicator (ZScore) for offline usage with a source data stream
+func NewDefaultZScoreForStreamWithSrcLen(sourceLength uint, priceStream gotrade.DOHLCVStreamSubscriber) (indicator *ZScore, err error) {
+	ind, err := NewDefaultZScoreWithSrcLen(sourceLength)
+	priceStream.AddTickSubscription(ind)
+	return ind, err
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (ind *ZScore) ReceiveDOHLCVTick(tickData gotrade.DOHLCV, streamBarIndex int) {
+	var selectedData = ind.selectData(tickData)
+	ind.ReceiveTick(selectedData, streamBarIndex)
+}
+
+// ValuesInRange returns the ZScore results for the inclusive bar range fromBar to toBar,
+// clamped to the bars for which results are available
+func (ind *ZScore) ValuesInRange(fromBar int, toBar int) []float64 {
+	return valuesInRange(ind.Data, ind.ValidFromBar(), fromBar, toBar)
+}
+
+// WriteCSV writes the ZScore results as barIndex,value rows after a header, the bar index of each result is
+// its stream bar index plus the startBarOffset
+func (ind *ZScore) WriteCSV(w io.Writer, startBarOffset int) error {
+	return writeCSV(w, startBarOffset, ind.ValidFromBar(), floatCSVColumn("value", ind.Data))
+}
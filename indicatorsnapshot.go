@@ -0,0 +1,74 @@
+package gotrade
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrSnapshotBarIndexIsNotTheCurrentBar = errors.New("Snapshot bar index is not the current bar of the stream")
+)
+
+// ValidFromBarHolder is implemented by the indicators, giving the stream bar index of the first result, -1 before it
+type ValidFromBarHolder interface {
+	ValidFromBar() int
+}
+
+// LatestValueHolder is implemented by the indicators of a single float result, giving the latest result made available
+type LatestValueHolder interface {
+	LatestValue() float64
+}
+
+// An IndicatorSnapshot is the state of a named stream subscriber as of a bar. The value is the latest result made
+// available by the subscriber, a NaN while it is not valid. A subscriber without a single float result, such as
+// the Bollinger Bands or the Macd, has no scalar value, HasValue is false and the value is always a NaN. The
+// lookback period is 0 for a subscriber that does not give one
+type IndicatorSnapshot struct {
+	BarIndex       int
+	Value          float64
+	HasValue       bool
+	IsValid        bool
+	LookbackPeriod int
+}
+
+// SnapshotIndicators returns a frozen copy of the state of each named subscriber, by name, as of the bar barIndex,
+// which must be the bar most recently dispatched by the stream as the values are the latest made available. A
+// subscriber is valid once it has results from a bar at or before barIndex. The subscribers each receive a bar in
+// their own goroutine, so the snapshot must be taken after ReceiveTick returns and before the next bar is received,
+// never from within a subscriber
+func (p *DOHLCVStream) SnapshotIndicators(barIndex int) (map[string]IndicatorSnapshot, error) {
+	if barIndex != p.streamBarIndex {
+		return nil, ErrSnapshotBarIndexIsNotTheCurrentBar
+	}
+
+	snapshots := make(map[string]IndicatorSnapshot)
+
+	for i := range p.subscribers {
+		name := p.subscriberNames[i]
+		if name == "" {
+			continue
+		}
+
+		snapshot := IndicatorSnapshot{BarIndex: barIndex, Value: math.NaN()}
+
+		if holder, ok := p.subscribers[i].(LookbackPeriodHolder); ok {
+			snapshot.LookbackPeriod = holder.GetLookbackPeriod()
+		}
+
+		if holder, ok := p.subscribers[i].(ValidFromBarHolder); ok {
+			validFromBar := holder.ValidFromBar()
+			snapshot.IsValid = validFromBar != -1 && validFromBar <= barIndex
+		}
+
+		if holder, ok := p.subscribers[i].(LatestValueHolder); ok {
+			snapshot.HasValue = true
+			if snapshot.IsValid {
+				snapshot.Value = holder.LatestValue()
+			}
+		}
+
+		snapshots[name] = snapshot
+	}
+
+	return snapshots, nil
+}
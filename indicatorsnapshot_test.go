@@ -0,0 +1,116 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"math"
+	"time"
+)
+
+var _ = Describe("when taking a snapshot of the indicators subscribed to a stream", func() {
+	var (
+		stream    *gotrade.InterDayDOHLCVStream
+		sma       *indicators.Sma
+		rsi       *indicators.Rsi
+		bollinger *indicators.BollingerBands
+		start     time.Time = time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	snapshotAt := func(barIndex int) map[string]gotrade.IndicatorSnapshot {
+		snapshots, err := stream.SnapshotIndicators(barIndex)
+		Expect(err).NotTo(HaveOccurred())
+		return snapshots
+	}
+
+	feed := func(from int, to int) {
+		for i := from; i < to; i++ {
+			price := 100.0 + float64(i%7) + 0.5*float64(i)
+			stream.ReceiveTick(gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, i), price, price+1.0, price-1.0, price, 1000.0))
+		}
+	}
+
+	BeforeEach(func() {
+		stream = gotrade.NewDailyDOHLCVStream()
+		sma, _ = indicators.NewSma(5, gotrade.UseClosePrice)
+		rsi, _ = indicators.NewRsi(14, gotrade.UseClosePrice)
+		bollinger, _ = indicators.NewBollingerBands(5, gotrade.UseClosePrice)
+		unnamed, _ := indicators.NewEma(5, gotrade.UseClosePrice)
+
+		stream.AddNamedTickSubscription("sma", sma)
+		stream.AddNamedTickSubscription("rsi", rsi)
+		stream.AddNamedTickSubscription("bollinger", bollinger)
+		stream.AddTickSubscription(unnamed)
+	})
+
+	It("should only include the named subscribers", func() {
+		snapshots := snapshotAt(0)
+		Expect(snapshots).To(HaveLen(3))
+		Expect(snapshots).To(HaveKey("sma"))
+		Expect(snapshots).To(HaveKey("rsi"))
+		Expect(snapshots).To(HaveKey("bollinger"))
+	})
+
+	It("should give the lookback of each subscriber", func() {
+		snapshots := snapshotAt(0)
+		Expect(snapshots["sma"].LookbackPeriod).To(Equal(4))
+		Expect(snapshots["rsi"].LookbackPeriod).To(Equal(14))
+	})
+
+	It("should reject a bar index other than the current bar of the stream", func() {
+		feed(0, 10)
+		snapshots, err := stream.SnapshotIndicators(9)
+		Expect(snapshots).To(BeNil())
+		Expect(err).To(Equal(gotrade.ErrSnapshotBarIndexIsNotTheCurrentBar))
+	})
+
+	Context("and only the indicator of the shorter lookback is valid", func() {
+		BeforeEach(func() {
+			feed(0, 10)
+		})
+
+		It("should give the latest value of the valid indicator", func() {
+			snapshot := snapshotAt(10)["sma"]
+			Expect(snapshot.BarIndex).To(Equal(10))
+			Expect(snapshot.IsValid).To(BeTrue())
+			Expect(snapshot.Value).To(Equal(sma.Data[len(sma.Data)-1]))
+		})
+
+		It("should give the indicator still in its lookback period as not valid", func() {
+			snapshot := snapshotAt(10)["rsi"]
+			Expect(snapshot.IsValid).To(BeFalse())
+			Expect(math.IsNaN(snapshot.Value)).To(BeTrue())
+		})
+	})
+
+	Context("and enough bars have been received for both indicators", func() {
+		BeforeEach(func() {
+			feed(0, 30)
+		})
+
+		It("should give the latest value of each indicator", func() {
+			snapshots := snapshotAt(30)
+			Expect(snapshots["sma"].IsValid).To(BeTrue())
+			Expect(snapshots["sma"].Value).To(Equal(sma.Data[len(sma.Data)-1]))
+			Expect(snapshots["rsi"].IsValid).To(BeTrue())
+			Expect(snapshots["rsi"].Value).To(Equal(rsi.Data[len(rsi.Data)-1]))
+		})
+
+		It("should give an indicator without a single float result as having no value", func() {
+			snapshot := snapshotAt(30)["bollinger"]
+			Expect(snapshot.IsValid).To(BeTrue())
+			Expect(snapshot.HasValue).To(BeFalse())
+			Expect(math.IsNaN(snapshot.Value)).To(BeTrue())
+			Expect(snapshotAt(30)["sma"].HasValue).To(BeTrue())
+		})
+
+		It("should be a frozen copy unchanged by later bars", func() {
+			snapshots := snapshotAt(30)
+			frozen := snapshots["sma"].Value
+			feed(30, 35)
+			Expect(snapshots["sma"].Value).To(Equal(frozen))
+			Expect(snapshotAt(35)["sma"].Value).NotTo(Equal(frozen))
+		})
+	})
+})
@@ -1 +1,152 @@
-package gotrade
\ No newline at end of file
+package gotrade
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrPnFBoxSizeMustBeGreaterThanZero  = errors.New("Point and figure box size must be greater than 0")
+	ErrPnFReversalMustBeGreaterThanZero = errors.New("Point and figure reversal must be greater than 0")
+	ErrPnFColumnActionIsNil             = errors.New("A PnFColumnAction is required")
+)
+
+// tolerance applied when counting boxes so that a price exactly on a box boundary counts that box
+const pnfBoxTolerance float64 = 0.000000001
+
+type PnFColumnType int
+
+const (
+	NoColumn PnFColumnType = 0
+	XColumn  PnFColumnType = 1
+	OColumn  PnFColumnType = -1
+)
+
+// PnFColumnAction is notified each time a column is started or extended, with the type and box count of
+// the current column and its index, starting from 1 for the first column
+type PnFColumnAction func(columnType PnFColumnType, boxCount int, columnIndex int)
+
+// PointAndFigure transforms DOHLCV price ticks into point and figure columns, ignoring time. A rising X column
+// is extended each time the high moves a full box above the column high, and reverses into a falling O column
+// once the low moves reversal boxes below it, and vice versa for O columns. A column is extended in preference
+// to a reversal when a tick does both.
+type PointAndFigure struct {
+	// private variables
+	boxSize      float64
+	reversal     int
+	columnAction PnFColumnAction
+	hasReference bool
+	columnType   PnFColumnType
+	boxCount     int
+	columnIndex  int
+	columnHigh   float64
+	columnLow    float64
+}
+
+// NewPointAndFigure creates a point and figure transformer
+func NewPointAndFigure(boxSize float64, reversal int, columnAction PnFColumnAction) (*PointAndFigure, error) {
+	if boxSize <= 0.0 {
+		return nil, ErrPnFBoxSizeMustBeGreaterThanZero
+	}
+
+	if reversal <= 0 {
+		return nil, ErrPnFReversalMustBeGreaterThanZero
+	}
+
+	if columnAction == nil {
+		return nil, ErrPnFColumnActionIsNil
+	}
+
+	p := PointAndFigure{boxSize: boxSize, reversal: reversal, columnAction: columnAction}
+	return &p, nil
+}
+
+// NewDefaultPointAndFigure creates a point and figure transformer with default parameters
+//	- reversal: 3
+func NewDefaultPointAndFigure(boxSize float64, columnAction PnFColumnAction) (*PointAndFigure, error) {
+	reversal := 3
+	return NewPointAndFigure(boxSize, reversal, columnAction)
+}
+
+// ColumnType returns the type of the current column, NoColumn until the price has moved a full box
+func (p *PointAndFigure) ColumnType() PnFColumnType {
+	return p.columnType
+}
+
+// BoxCount returns the number of boxes in the current column
+func (p *PointAndFigure) BoxCount() int {
+	return p.boxCount
+}
+
+// ColumnIndex returns the index of the current column, starting from 1 for the first column
+func (p *PointAndFigure) ColumnIndex() int {
+	return p.columnIndex
+}
+
+// ColumnHigh returns the price of the highest box of the current column
+func (p *PointAndFigure) ColumnHigh() float64 {
+	return p.columnHigh
+}
+
+// ColumnLow returns the price of the lowest box of the current column
+func (p *PointAndFigure) ColumnLow() float64 {
+	return p.columnLow
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (p *PointAndFigure) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	// the first tick gives the reference price, aligned to the box size, from which the first column is measured
+	if !p.hasReference {
+		p.hasReference = true
+		p.columnHigh = math.Floor(tickData.C()/p.boxSize+pnfBoxTolerance) * p.boxSize
+		p.columnLow = p.columnHigh
+		return
+	}
+
+	switch p.columnType {
+	case NoColumn:
+		if boxes := p.boxesBetween(tickData.H(), p.columnHigh); boxes >= 1 {
+			p.startColumn(XColumn, boxes, p.columnLow+p.boxSize, p.columnHigh+float64(boxes)*p.boxSize)
+		} else if boxes := p.boxesBetween(p.columnLow, tickData.L()); boxes >= 1 {
+			p.startColumn(OColumn, boxes, p.columnLow-float64(boxes)*p.boxSize, p.columnHigh-p.boxSize)
+		}
+	case XColumn:
+		if boxes := p.boxesBetween(tickData.H(), p.columnHigh); boxes >= 1 {
+			p.columnHigh += float64(boxes) * p.boxSize
+			p.extendColumn(boxes)
+		} else if boxes := p.boxesBetween(p.columnHigh, tickData.L()); boxes >= p.reversal {
+			// the O column starts a box below the top of the X column
+			p.startColumn(OColumn, boxes, p.columnHigh-float64(boxes)*p.boxSize, p.columnHigh-p.boxSize)
+		}
+	case OColumn:
+		if boxes := p.boxesBetween(p.columnLow, tickData.L()); boxes >= 1 {
+			p.columnLow -= float64(boxes) * p.boxSize
+			p.extendColumn(boxes)
+		} else if boxes := p.boxesBetween(tickData.H(), p.columnLow); boxes >= p.reversal {
+			// the X column starts a box above the bottom of the O column
+			p.startColumn(XColumn, boxes, p.columnLow+p.boxSize, p.columnLow+float64(boxes)*p.boxSize)
+		}
+	}
+}
+
+// boxesBetween returns the number of whole boxes from the lower price up to the upper price
+func (p *PointAndFigure) boxesBetween(upper float64, lower float64) int {
+	if upper <= lower {
+		return 0
+	}
+	return int(math.Floor((upper-lower)/p.boxSize + pnfBoxTolerance))
+}
+
+func (p *PointAndFigure) startColumn(columnType PnFColumnType, boxCount int, columnLow float64, columnHigh float64) {
+	p.columnType = columnType
+	p.boxCount = boxCount
+	p.columnIndex++
+	p.columnLow = columnLow
+	p.columnHigh = columnHigh
+	p.columnAction(p.columnType, p.boxCount, p.columnIndex)
+}
+
+func (p *PointAndFigure) extendColumn(boxes int) {
+	p.boxCount += boxes
+	p.columnAction(p.columnType, p.boxCount, p.columnIndex)
+}
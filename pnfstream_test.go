@@ -1 +1,144 @@
-package gotrade_test
\ No newline at end of file
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+type pnfColumnEvent struct {
+	columnType  gotrade.PnFColumnType
+	boxCount    int
+	columnIndex int
+}
+
+var _ = Describe("when creating a point and figure transformer", func() {
+	fakeColumnAction := func(columnType gotrade.PnFColumnType, boxCount int, columnIndex int) {}
+
+	It("should not be created with a box size that is not greater than zero", func() {
+		pnf, err := gotrade.NewPointAndFigure(0.0, 3, fakeColumnAction)
+		Expect(pnf).To(BeNil())
+		Expect(err).To(Equal(gotrade.ErrPnFBoxSizeMustBeGreaterThanZero))
+	})
+
+	It("should not be created with a reversal that is not greater than zero", func() {
+		pnf, err := gotrade.NewPointAndFigure(1.0, 0, fakeColumnAction)
+		Expect(pnf).To(BeNil())
+		Expect(err).To(Equal(gotrade.ErrPnFReversalMustBeGreaterThanZero))
+	})
+
+	It("should not be created without a column action", func() {
+		pnf, err := gotrade.NewPointAndFigure(1.0, 3, nil)
+		Expect(pnf).To(BeNil())
+		Expect(err).To(Equal(gotrade.ErrPnFColumnActionIsNil))
+	})
+})
+
+var _ = Describe("when transforming price ticks into point and figure columns", func() {
+	var (
+		pnf    *gotrade.PointAndFigure
+		events []pnfColumnEvent
+	)
+
+	newTick := func(high float64, low float64) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(time.Time{}, low, high, low, high, 0.0)
+	}
+
+	feedTicks := func(ticks ...gotrade.DOHLCV) {
+		for i, tick := range ticks {
+			pnf.ReceiveDOHLCVTick(tick, i+2)
+		}
+	}
+
+	BeforeEach(func() {
+		events = []pnfColumnEvent{}
+		pnf, _ = gotrade.NewDefaultPointAndFigure(1.0, func(columnType gotrade.PnFColumnType, boxCount int, columnIndex int) {
+			events = append(events, pnfColumnEvent{columnType, boxCount, columnIndex})
+		})
+		pnf.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(time.Time{}, 10.0, 10.0, 10.0, 10.0, 0.0), 1)
+	})
+
+	It("should not have a column until the price has moved a full box", func() {
+		feedTicks(newTick(10.5, 9.5))
+		Expect(pnf.ColumnType()).To(Equal(gotrade.NoColumn))
+		Expect(events).To(BeEmpty())
+	})
+
+	Context("and the price rises steadily", func() {
+		BeforeEach(func() {
+			feedTicks(newTick(11.0, 10.0), newTick(12.5, 11.0), newTick(15.0, 12.5))
+		})
+
+		It("should start and then extend an X column", func() {
+			Expect(events).To(Equal([]pnfColumnEvent{
+				{gotrade.XColumn, 1, 1},
+				{gotrade.XColumn, 2, 1},
+				{gotrade.XColumn, 5, 1},
+			}))
+			Expect(pnf.ColumnType()).To(Equal(gotrade.XColumn))
+			Expect(pnf.BoxCount()).To(Equal(5))
+			Expect(pnf.ColumnHigh()).To(Equal(15.0))
+			Expect(pnf.ColumnLow()).To(Equal(11.0))
+		})
+
+		Context("and the price falls by less than the reversal", func() {
+			BeforeEach(func() {
+				feedTicks(newTick(14.5, 12.5))
+			})
+
+			It("should remain in the X column", func() {
+				Expect(pnf.ColumnType()).To(Equal(gotrade.XColumn))
+				Expect(pnf.ColumnIndex()).To(Equal(1))
+				Expect(events).To(HaveLen(3))
+			})
+		})
+
+		Context("and the price falls by the reversal of boxes", func() {
+			BeforeEach(func() {
+				feedTicks(newTick(14.5, 12.0))
+			})
+
+			It("should start an O column a box below the top of the X column", func() {
+				Expect(events[len(events)-1]).To(Equal(pnfColumnEvent{gotrade.OColumn, 3, 2}))
+				Expect(pnf.ColumnType()).To(Equal(gotrade.OColumn))
+				Expect(pnf.ColumnHigh()).To(Equal(14.0))
+				Expect(pnf.ColumnLow()).To(Equal(12.0))
+			})
+
+			Context("and the price then rises by the reversal of boxes", func() {
+				BeforeEach(func() {
+					feedTicks(newTick(15.0, 12.5))
+				})
+
+				It("should start a new X column a box above the bottom of the O column", func() {
+					Expect(events[len(events)-1]).To(Equal(pnfColumnEvent{gotrade.XColumn, 3, 3}))
+					Expect(pnf.ColumnLow()).To(Equal(13.0))
+					Expect(pnf.ColumnHigh()).To(Equal(15.0))
+				})
+			})
+		})
+
+		Context("and a tick both extends the X column and falls by the reversal", func() {
+			BeforeEach(func() {
+				feedTicks(newTick(16.0, 11.0))
+			})
+
+			It("should extend the X column in preference to the reversal", func() {
+				Expect(events[len(events)-1]).To(Equal(pnfColumnEvent{gotrade.XColumn, 6, 1}))
+			})
+		})
+	})
+
+	Context("and the price falls first", func() {
+		BeforeEach(func() {
+			feedTicks(newTick(10.0, 8.0))
+		})
+
+		It("should start an O column", func() {
+			Expect(events).To(Equal([]pnfColumnEvent{{gotrade.OColumn, 2, 1}}))
+			Expect(pnf.ColumnHigh()).To(Equal(9.0))
+			Expect(pnf.ColumnLow()).To(Equal(8.0))
+		})
+	})
+})
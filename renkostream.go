@@ -0,0 +1,117 @@
+package gotrade
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrRenkoBrickSizeMustBeGreaterThanZero = errors.New("Renko brick size must be greater than 0")
+	ErrRenkoAtrPeriodMustBeGreaterThanZero = errors.New("Renko ATR period must be greater than 0")
+)
+
+// RenkoStream transforms DOHLCV price ticks into Renko bricks, ignoring time. A brick is emitted each time
+// the close moves a full brick from the close of the last brick, a large move emitting several bricks
+// from a single tick. The bricks are dispatched to the stream subscribers as DOHLCV ticks, so any
+// indicator created for a stream can run on Renko bricks. The volume of the source ticks is carried by
+// the next brick emitted.
+type RenkoStream struct {
+	*DOHLCVStream
+
+	// private variables
+	brickSize         float64
+	atrPeriod         int
+	atrCounter        int
+	atrTotal          float64
+	previousClose     float64
+	hasLastBrickClose bool
+	lastBrickClose    float64
+	accumulatedVolume float64
+}
+
+// NewRenkoStream creates a Renko stream with a fixed brick size
+func NewRenkoStream(brickSize float64) (*RenkoStream, error) {
+	if brickSize <= 0.0 {
+		return nil, ErrRenkoBrickSizeMustBeGreaterThanZero
+	}
+
+	s := RenkoStream{DOHLCVStream: newRenkoDOHLCVStream(), brickSize: brickSize}
+	return &s, nil
+}
+
+// NewAtrRenkoStream creates a Renko stream whose brick size is the average true range of the source ticks
+// over atrPeriod, updated with each tick. No bricks are emitted until the first average true range is available
+func NewAtrRenkoStream(atrPeriod int) (*RenkoStream, error) {
+	if atrPeriod <= 0 {
+		return nil, ErrRenkoAtrPeriodMustBeGreaterThanZero
+	}
+
+	s := RenkoStream{DOHLCVStream: newRenkoDOHLCVStream(), atrPeriod: atrPeriod}
+	return &s, nil
+}
+
+func newRenkoDOHLCVStream() *DOHLCVStream {
+	return &DOHLCVStream{streamBarIndex: 0,
+		minValue: math.MaxFloat64,
+		maxValue: math.SmallestNonzeroFloat64}
+}
+
+// BrickSize returns the current brick size, 0 while an ATR based brick size is not yet available
+func (p *RenkoStream) BrickSize() float64 {
+	return p.brickSize
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (p *RenkoStream) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	if p.atrPeriod > 0 {
+		p.updateAtrBrickSize(tickData)
+	}
+	p.previousClose = tickData.C()
+	p.accumulatedVolume += tickData.V()
+
+	// the first tick gives the reference close from which the first brick is measured
+	if !p.hasLastBrickClose {
+		p.hasLastBrickClose = true
+		p.lastBrickClose = tickData.C()
+		return
+	}
+
+	if p.brickSize <= 0.0 {
+		return
+	}
+
+	for tickData.C() >= p.lastBrickClose+p.brickSize {
+		p.emitBrick(tickData, p.lastBrickClose+p.brickSize)
+	}
+
+	for tickData.C() <= p.lastBrickClose-p.brickSize {
+		p.emitBrick(tickData, p.lastBrickClose-p.brickSize)
+	}
+}
+
+func (p *RenkoStream) emitBrick(tickData DOHLCV, brickClose float64) {
+	brickOpen := p.lastBrickClose
+	brick := NewDOHLCVDataItem(tickData.D(), brickOpen, math.Max(brickOpen, brickClose), math.Min(brickOpen, brickClose), brickClose, p.accumulatedVolume)
+
+	p.accumulatedVolume = 0.0
+	p.lastBrickClose = brickClose
+	p.ReceiveTick(brick)
+}
+
+// updateAtrBrickSize applies the tick to the Wilder smoothed average true range used as the brick size
+func (p *RenkoStream) updateAtrBrickSize(tickData DOHLCV) {
+	trueRange := tickData.H() - tickData.L()
+	if p.hasLastBrickClose {
+		trueRange = math.Max(trueRange, math.Max(math.Abs(tickData.H()-p.previousClose), math.Abs(tickData.L()-p.previousClose)))
+	}
+
+	if p.atrCounter < p.atrPeriod {
+		p.atrCounter++
+		p.atrTotal += trueRange
+		if p.atrCounter == p.atrPeriod {
+			p.brickSize = p.atrTotal / float64(p.atrPeriod)
+		}
+	} else {
+		p.brickSize = ((p.brickSize * float64(p.atrPeriod-1)) + trueRange) / float64(p.atrPeriod)
+	}
+}
@@ -0,0 +1,158 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"github.com/thetruetrade/gotrade/indicators"
+	"time"
+)
+
+var _ = Describe("when creating a renko stream", func() {
+	It("should not be created with a brick size that is not greater than zero", func() {
+		stream, err := gotrade.NewRenkoStream(0.0)
+		Expect(stream).To(BeNil())
+		Expect(err).To(Equal(gotrade.ErrRenkoBrickSizeMustBeGreaterThanZero))
+	})
+
+	It("should not be created with an ATR period that is not greater than zero", func() {
+		stream, err := gotrade.NewAtrRenkoStream(0)
+		Expect(stream).To(BeNil())
+		Expect(err).To(Equal(gotrade.ErrRenkoAtrPeriodMustBeGreaterThanZero))
+	})
+})
+
+var _ = Describe("when transforming price ticks into renko bricks", func() {
+	var (
+		stream     *gotrade.RenkoStream
+		subscriber *recordingTickReceiver
+		start      time.Time = time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)
+	)
+
+	newTick := func(day int, close float64, volume float64) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(start.AddDate(0, 0, day), close, close, close, close, volume)
+	}
+
+	BeforeEach(func() {
+		stream, _ = gotrade.NewRenkoStream(1.0)
+		subscriber = &recordingTickReceiver{}
+		stream.AddTickSubscription(subscriber)
+		stream.ReceiveDOHLCVTick(newTick(0, 10.0, 100.0), 1)
+	})
+
+	It("the first tick should only set the reference close", func() {
+		Expect(subscriber.receivedTicks).To(BeEmpty())
+	})
+
+	Context("and a tick moves less than a full brick", func() {
+		BeforeEach(func() {
+			stream.ReceiveDOHLCVTick(newTick(1, 10.9, 50.0), 2)
+		})
+
+		It("should not emit a brick", func() {
+			Expect(subscriber.receivedTicks).To(BeEmpty())
+		})
+	})
+
+	Context("and a single large bar ramps up several bricks", func() {
+		BeforeEach(func() {
+			stream.ReceiveDOHLCVTick(newTick(1, 14.5, 200.0), 2)
+		})
+
+		It("should emit a brick for each full brick move", func() {
+			Expect(subscriber.receivedTicks).To(HaveLen(4))
+			Expect(subscriber.receivedIndexes).To(Equal([]int{1, 2, 3, 4}))
+			for i, brick := range subscriber.receivedTicks {
+				Expect(brick.O()).To(Equal(10.0 + float64(i)))
+				Expect(brick.C()).To(Equal(11.0 + float64(i)))
+				Expect(brick.L()).To(Equal(brick.O()))
+				Expect(brick.H()).To(Equal(brick.C()))
+				Expect(brick.D()).To(Equal(start.AddDate(0, 0, 1)))
+			}
+		})
+
+		It("the volume of the source ticks should be carried by the first brick", func() {
+			Expect(subscriber.receivedTicks[0].V()).To(Equal(300.0))
+			Expect(subscriber.receivedTicks[3].V()).To(Equal(0.0))
+		})
+
+		It("the stream should have stored the bricks", func() {
+			Expect(stream.Data).To(Equal(subscriber.receivedTicks))
+			Expect(stream.MinValue()).To(Equal(10.0))
+			Expect(stream.MaxValue()).To(Equal(14.0))
+		})
+
+		Context("and the price then falls a full brick from the last brick close", func() {
+			BeforeEach(func() {
+				stream.ReceiveDOHLCVTick(newTick(2, 12.9, 10.0), 3)
+			})
+
+			It("should emit a down brick", func() {
+				Expect(subscriber.receivedTicks).To(HaveLen(5))
+				brick := subscriber.receivedTicks[4]
+				Expect(brick.O()).To(Equal(14.0))
+				Expect(brick.C()).To(Equal(13.0))
+				Expect(brick.H()).To(Equal(14.0))
+				Expect(brick.L()).To(Equal(13.0))
+			})
+		})
+	})
+
+	Context("and an indicator is attached to the renko stream", func() {
+		var sma *indicators.Sma
+
+		BeforeEach(func() {
+			sma, _ = indicators.NewSmaForStream(stream, 2, gotrade.UseClosePrice)
+			stream.ReceiveDOHLCVTick(newTick(1, 13.0, 0.0), 2)
+		})
+
+		It("the indicator should have been calculated on the brick closes", func() {
+			Expect(sma.Data).To(Equal([]float64{11.5, 12.5}))
+		})
+	})
+})
+
+var _ = Describe("when transforming price ticks into renko bricks sized by the average true range", func() {
+	var (
+		stream     *gotrade.RenkoStream
+		subscriber *recordingTickReceiver
+	)
+
+	BeforeEach(func() {
+		stream, _ = gotrade.NewAtrRenkoStream(3)
+		subscriber = &recordingTickReceiver{}
+		stream.AddTickSubscription(subscriber)
+	})
+
+	Context("and the average true range is not yet available", func() {
+		BeforeEach(func() {
+			stream.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(time.Time{}, 10.0, 11.0, 9.0, 10.0, 0.0), 1)
+			stream.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(time.Time{}, 10.0, 11.0, 9.0, 20.0, 0.0), 2)
+		})
+
+		It("should not emit any bricks", func() {
+			Expect(stream.BrickSize()).To(Equal(0.0))
+			Expect(subscriber.receivedTicks).To(BeEmpty())
+		})
+	})
+
+	Context("and the average true range is available", func() {
+		BeforeEach(func() {
+			for i := 0; i < 3; i++ {
+				stream.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(time.Time{}, 10.0, 11.0, 9.0, 10.0, 0.0), i+1)
+			}
+			stream.ReceiveDOHLCVTick(gotrade.NewDOHLCVDataItem(time.Time{}, 10.0, 14.5, 9.5, 14.5, 0.0), 4)
+		})
+
+		It("the brick size should follow the average true range", func() {
+			// the range of the first three ticks averages 2.0, then the true range of 5.0 is smoothed in
+			Expect(stream.BrickSize()).To(BeNumerically("~", (2.0*2.0+5.0)/3.0, 0.0000001))
+		})
+
+		It("should emit bricks of the average true range size", func() {
+			Expect(subscriber.receivedTicks).To(HaveLen(1))
+			Expect(subscriber.receivedTicks[0].O()).To(Equal(10.0))
+			Expect(subscriber.receivedTicks[0].C()).To(BeNumerically("~", 13.0, 0.0000001))
+		})
+	})
+})
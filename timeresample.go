@@ -0,0 +1,109 @@
+package gotrade
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrTimeResampleIntervalMustBeGreaterThanZero = errors.New("Time resample interval must be greater than 0")
+)
+
+// A GapFill is the treatment by a TimeResample of a bar interval in which no ticks are received
+type GapFill int
+
+const (
+	// an empty interval emits a bar at the close of the last bar, with no volume
+	GapFillCarryForward GapFill = iota
+	// an empty interval emits no bar
+	GapFillSkip
+)
+
+// TimeResample transforms irregularly spaced DOHLCV price ticks into bars of a fixed time interval. Each tick is
+// assigned by its date to the interval containing it, the intervals being aligned to the zero time, and the bar of an
+// interval is emitted once a tick of a later interval is received, dated at the start of its interval, with the open
+// of the first tick, the highest high, the lowest low, the close of the last tick and the total volume. A tick dated
+// before the interval of the current bar is added to the current bar. Unlike a stream of a fixed count of ticks a bar
+// is emitted for each interval elapsed, an empty interval being filled according to the GapFill. The bars are
+// dispatched to the stream subscribers as DOHLCV ticks, so any indicator created for a stream can run on them
+type TimeResample struct {
+	*DOHLCVStream
+
+	// private variables
+	interval      time.Duration
+	gapFill       GapFill
+	hasBar        bool
+	intervalStart time.Time
+	barOpen       float64
+	barHigh       float64
+	barLow        float64
+	barClose      float64
+	barVolume     float64
+}
+
+// NewTimeResample creates a time resample of bars of the interval, filling the empty intervals according to the gapFill
+func NewTimeResample(interval time.Duration, gapFill GapFill) (*TimeResample, error) {
+	if interval <= 0 {
+		return nil, ErrTimeResampleIntervalMustBeGreaterThanZero
+	}
+
+	s := TimeResample{DOHLCVStream: &DOHLCVStream{streamBarIndex: 0,
+		minValue: math.MaxFloat64,
+		maxValue: math.SmallestNonzeroFloat64},
+		interval: interval,
+		gapFill:  gapFill}
+	return &s, nil
+}
+
+// Interval returns the time interval of the bars
+func (p *TimeResample) Interval() time.Duration {
+	return p.interval
+}
+
+// ReceiveDOHLCVTick consumes a source data DOHLCV price tick
+func (p *TimeResample) ReceiveDOHLCVTick(tickData DOHLCV, streamBarIndex int) {
+	tickIntervalStart := tickData.D().Truncate(p.interval)
+
+	if p.hasBar && tickIntervalStart.After(p.intervalStart) {
+		p.emitBar()
+
+		// the intervals elapsed between the bar and the tick have no ticks
+		for emptyStart := p.intervalStart.Add(p.interval); emptyStart.Before(tickIntervalStart); emptyStart = emptyStart.Add(p.interval) {
+			if p.gapFill == GapFillCarryForward {
+				p.ReceiveTick(NewDOHLCVDataItem(emptyStart, p.barClose, p.barClose, p.barClose, p.barClose, 0.0))
+			}
+		}
+
+		p.hasBar = false
+	}
+
+	if !p.hasBar {
+		p.hasBar = true
+		p.intervalStart = tickIntervalStart
+		p.barOpen = tickData.O()
+		p.barHigh = tickData.H()
+		p.barLow = tickData.L()
+		p.barVolume = 0.0
+	}
+
+	p.barHigh = math.Max(p.barHigh, tickData.H())
+	p.barLow = math.Min(p.barLow, tickData.L())
+	p.barClose = tickData.C()
+	p.barVolume += tickData.V()
+}
+
+// Flush emits the bar of the current interval, as no later tick may be received to complete it, the next tick
+// received starts a new bar
+func (p *TimeResample) Flush() {
+	if !p.hasBar {
+		return
+	}
+
+	p.emitBar()
+	p.hasBar = false
+}
+
+func (p *TimeResample) emitBar() {
+	p.ReceiveTick(NewDOHLCVDataItem(p.intervalStart, p.barOpen, p.barHigh, p.barLow, p.barClose, p.barVolume))
+}
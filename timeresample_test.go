@@ -0,0 +1,108 @@
+package gotrade_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/thetruetrade/gotrade"
+	"time"
+)
+
+var _ = Describe("when creating a time resample", func() {
+	It("should not be created with an interval that is not greater than zero", func() {
+		stream, err := gotrade.NewTimeResample(0, gotrade.GapFillCarryForward)
+		Expect(stream).To(BeNil())
+		Expect(err).To(Equal(gotrade.ErrTimeResampleIntervalMustBeGreaterThanZero))
+	})
+})
+
+var _ = Describe("when resampling irregular price ticks into bars of a time interval", func() {
+	var (
+		stream     *gotrade.TimeResample
+		subscriber *recordingTickReceiver
+		start      time.Time = time.Date(2014, 1, 2, 9, 0, 0, 0, time.UTC)
+		gapFill    gotrade.GapFill
+	)
+
+	newTick := func(offset time.Duration, price float64, volume float64) gotrade.DOHLCV {
+		return gotrade.NewDOHLCVDataItem(start.Add(offset), price, price, price, price, volume)
+	}
+
+	JustBeforeEach(func() {
+		stream, _ = gotrade.NewTimeResample(time.Minute, gapFill)
+		subscriber = &recordingTickReceiver{}
+		stream.AddTickSubscription(subscriber)
+
+		// irregular ticks in the 09:00 and 09:01 intervals, none in 09:02, and one in 09:03
+		ticks := []gotrade.DOHLCV{
+			newTick(3*time.Second, 10.0, 100.0),
+			newTick(17*time.Second, 12.0, 50.0),
+			newTick(41*time.Second, 9.0, 25.0),
+			newTick(59*time.Second, 11.0, 10.0),
+			newTick(time.Minute+30*time.Second, 11.5, 40.0),
+			newTick(3*time.Minute+5*time.Second, 13.0, 60.0),
+		}
+		for i := range ticks {
+			stream.ReceiveDOHLCVTick(ticks[i], i+1)
+		}
+	})
+
+	Context("and empty intervals are carried forward", func() {
+		BeforeEach(func() {
+			gapFill = gotrade.GapFillCarryForward
+		})
+
+		It("should emit a bar for each completed interval", func() {
+			Expect(subscriber.receivedTicks).To(HaveLen(3))
+			Expect(subscriber.receivedIndexes).To(Equal([]int{1, 2, 3}))
+		})
+
+		It("should bucket the ticks of each interval into a bar dated at the start of the interval", func() {
+			first := subscriber.receivedTicks[0]
+			Expect(first.D()).To(Equal(start))
+			Expect(first.O()).To(Equal(10.0))
+			Expect(first.H()).To(Equal(12.0))
+			Expect(first.L()).To(Equal(9.0))
+			Expect(first.C()).To(Equal(11.0))
+			Expect(first.V()).To(Equal(185.0))
+
+			second := subscriber.receivedTicks[1]
+			Expect(second.D()).To(Equal(start.Add(time.Minute)))
+			Expect(second.C()).To(Equal(11.5))
+			Expect(second.V()).To(Equal(40.0))
+		})
+
+		It("should forward fill the empty interval with the last close", func() {
+			filled := subscriber.receivedTicks[2]
+			Expect(filled.D()).To(Equal(start.Add(2 * time.Minute)))
+			Expect(filled.O()).To(Equal(11.5))
+			Expect(filled.H()).To(Equal(11.5))
+			Expect(filled.L()).To(Equal(11.5))
+			Expect(filled.C()).To(Equal(11.5))
+			Expect(filled.V()).To(Equal(0.0))
+		})
+
+		It("should emit the bar of the current interval when flushed", func() {
+			stream.Flush()
+			Expect(subscriber.receivedTicks).To(HaveLen(4))
+			Expect(subscriber.receivedTicks[3].D()).To(Equal(start.Add(3 * time.Minute)))
+			Expect(subscriber.receivedTicks[3].C()).To(Equal(13.0))
+
+			stream.Flush()
+			Expect(subscriber.receivedTicks).To(HaveLen(4))
+		})
+	})
+
+	Context("and empty intervals are skipped", func() {
+		BeforeEach(func() {
+			gapFill = gotrade.GapFillSkip
+		})
+
+		It("should not emit a bar for the empty interval", func() {
+			Expect(subscriber.receivedTicks).To(HaveLen(2))
+			Expect(subscriber.receivedTicks[1].D()).To(Equal(start.Add(time.Minute)))
+
+			stream.Flush()
+			Expect(subscriber.receivedTicks[2].D()).To(Equal(start.Add(3 * time.Minute)))
+		})
+	})
+})